@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -12,20 +20,89 @@ import (
 	"github.com/labstack/gommon/log"
 	_ "github.com/lib/pq"
 	"github.com/robfig/cron/v3"
+	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"github.com/IgorAleksandroff/delivery/cmd"
+	grpcin "github.com/IgorAleksandroff/delivery/internal/adapters/in/grpc"
 	httpin "github.com/IgorAleksandroff/delivery/internal/adapters/in/http"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/kafka"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/grpc/geo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/cityrepo"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/geocacherepo"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/webhookrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/zonerepo"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/connlimit"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
 )
 
+// shutdownTimeout - сколько ждать завершения открытых запросов и потоков при остановке
+const shutdownTimeout = 10 * time.Second
+
 func main() {
+	storage := flag.String("storage", cmd.StoragePostgres, "хранилище: postgres или memory")
+	flag.Parse()
+
 	cfg := getConfigs()
+	cfg.Storage = *storage
+
+	var compositionRoot cmd.CompositionRoot
+	switch cfg.Storage {
+	case cmd.StoragePostgres:
+		compositionRoot = newPostgresCompositionRoot(cfg)
+	case cmd.StorageMemory:
+		compositionRoot = cmd.NewInMemoryCompositionRoot(cfg)
+	default:
+		log.Fatalf("unknown storage: %s", cfg.Storage)
+	}
+
+	// Потоки отслеживания HTTP и gRPC делят один лимит
+	trackingLimiter := connlimit.New(cfg.TrackingMaxConnections)
+
+	startCron(compositionRoot)
+	startKafkaConsumer(compositionRoot)
+	grpcServer, server := startGrpcServer(compositionRoot, cfg, trackingLimiter)
+	e := startWebServer(compositionRoot, cfg, trackingLimiter)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	shutdown(grpcServer, server, e)
+}
+
+// shutdown - health переходит в NOT_SERVING, затем серверы дожидаются открытых запросов, но не дольше shutdownTimeout
+func shutdown(grpcServer *grpc.Server, server *grpcin.Server, e *echo.Echo) {
+	server.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	if err := e.Shutdown(ctx); err != nil {
+		log.Errorf("HTTP server shutdown: %v", err)
+		_ = e.Close()
+	}
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+}
 
+func newPostgresCompositionRoot(cfg cmd.Config) cmd.CompositionRoot {
 	connectionString, err := makeConnectionString(cfg.DbHost, cfg.DbPort, cfg.DbUser, cfg.DbPassword, cfg.DbDbName, cfg.DbSslMode)
 	if err != nil {
 		log.Fatal(err.Error())
@@ -34,38 +111,118 @@ func main() {
 	crateDbIfNotExists(cfg.DbHost, cfg.DbPort, cfg.DbUser, cfg.DbPassword, cfg.DbDbName, cfg.DbSslMode)
 	gormDb := mustGormOpen(connectionString)
 	mustAutoMigrate(gormDb)
+	mustMigrateCityArea(gormDb, cfg)
 
-	compositionRoot := cmd.NewCompositionRoot(gormDb, cfg)
-
-	startCron(compositionRoot)
-	startKafkaConsumer(compositionRoot)
-	startWebServer(compositionRoot, cfg.HttpPort)
+	return cmd.NewCompositionRoot(gormDb, cfg)
 }
 
 func getConfigs() cmd.Config {
+	geoDefaults := geo.DefaultConfig()
+	webhookDefaults := commands.DefaultDeliverWebhooksConfig()
+	consumerDefaults := kafka.DefaultConsumerConfig()
+	slaDefaults := order.DefaultSLAPolicy()
 	config := cmd.Config{
-		HttpPort:                  goDotEnvVariable("HTTP_PORT"),
-		DbHost:                    goDotEnvVariable("DB_HOST"),
-		DbPort:                    goDotEnvVariable("DB_PORT"),
-		DbUser:                    goDotEnvVariable("DB_USER"),
-		DbPassword:                goDotEnvVariable("DB_PASSWORD"),
-		DbDbName:                  goDotEnvVariable("DB_DBNAME"),
-		DbSslMode:                 goDotEnvVariable("DB_SSLMODE"),
-		GeoServiceGrpcHost:        goDotEnvVariable("GEO_SERVICE_GRPC_HOST"),
-		KafkaHost:                 goDotEnvVariable("KAFKA_HOST"),
-		KafkaConsumerGroup:        goDotEnvVariable("KAFKA_CONSUMER_GROUP"),
-		KafkaBasketConfirmedTopic: goDotEnvVariable("KAFKA_BASKET_CONFIRMED_TOPIC"),
+		HttpPort:                     goDotEnvVariable("HTTP_PORT"),
+		GrpcPort:                     goDotEnvString("GRPC_PORT", "5005"),
+		DbHost:                       goDotEnvVariable("DB_HOST"),
+		DbPort:                       goDotEnvVariable("DB_PORT"),
+		DbUser:                       goDotEnvVariable("DB_USER"),
+		DbPassword:                   goDotEnvVariable("DB_PASSWORD"),
+		DbDbName:                     goDotEnvVariable("DB_DBNAME"),
+		DbSslMode:                    goDotEnvVariable("DB_SSLMODE"),
+		GeoServiceGrpcHost:           goDotEnvVariable("GEO_SERVICE_GRPC_HOST"),
+		KafkaHost:                    goDotEnvVariable("KAFKA_HOST"),
+		KafkaConsumerGroup:           goDotEnvString("KAFKA_CONSUMER_GROUP", "delivery"),
+		KafkaBasketConfirmedTopic:    goDotEnvString("KAFKA_BASKET_CONFIRMED_TOPIC", "basket.confirmed"),
+		KafkaBasketConfirmedDlqTopic: basketConfirmedDlqTopic(),
+		KafkaSchemaRegistryUrl:       goDotEnvVariable("KAFKA_SCHEMA_REGISTRY_URL"),
+		KafkaSchemaRegistryTimeout:   goDotEnvDuration("KAFKA_SCHEMA_REGISTRY_TIMEOUT", 5*time.Second),
+		KafkaCourierLocationChangedTopic: goDotEnvString("KAFKA_COURIER_LOCATION_CHANGED_TOPIC",
+			"courier.location.changed"),
+		KafkaConsumerWorkers:        goDotEnvInt("KAFKA_CONSUMER_WORKERS", consumerDefaults.Workers),
+		KafkaCommitInterval:         goDotEnvDuration("KAFKA_COMMIT_INTERVAL", consumerDefaults.CommitInterval),
+		GeoCacheSize:                goDotEnvInt("GEO_CACHE_SIZE", 10000),
+		GeoCacheTTL:                 goDotEnvDuration("GEO_CACHE_TTL", 24*time.Hour),
+		GeoCacheNegativeTTL:         goDotEnvDuration("GEO_CACHE_NEGATIVE_TTL", 5*time.Minute),
+		GeoCachePersistent:          goDotEnvBool("GEO_CACHE_PERSISTENT", false),
+		GeoTimeout:                  goDotEnvDuration("GEO_TIMEOUT", geoDefaults.Timeout),
+		GeoMaxAttempts:              goDotEnvInt("GEO_MAX_ATTEMPTS", geoDefaults.MaxAttempts),
+		GeoBaseBackoff:              goDotEnvDuration("GEO_BASE_BACKOFF", geoDefaults.BaseBackoff),
+		GeoMaxBackoff:               goDotEnvDuration("GEO_MAX_BACKOFF", geoDefaults.MaxBackoff),
+		GeoBreakerThreshold:         goDotEnvInt("GEO_BREAKER_THRESHOLD", geoDefaults.BreakerFailureThreshold),
+		GeoBreakerOpenTimeout:       goDotEnvDuration("GEO_BREAKER_OPEN_TIMEOUT", geoDefaults.BreakerOpenTimeout),
+		GeoFallback:                 goDotEnvVariable("GEO_FALLBACK"),
+		GeoResolveInterval:          goDotEnvDuration("GEO_RESOLVE_INTERVAL", 5*time.Second),
+		TrackingMaxConnections:      goDotEnvInt("TRACKING_MAX_CONNECTIONS", 1000),
+		TrackingHeartbeatInterval:   goDotEnvDuration("TRACKING_HEARTBEAT_INTERVAL", 15*time.Second),
+		WebhookTimeout:              goDotEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second),
+		WebhookMaxAttempts:          goDotEnvInt("WEBHOOK_MAX_ATTEMPTS", webhookDefaults.MaxAttempts),
+		WebhookBaseBackoff:          goDotEnvDuration("WEBHOOK_BASE_BACKOFF", webhookDefaults.BaseBackoff),
+		WebhookMaxBackoff:           goDotEnvDuration("WEBHOOK_MAX_BACKOFF", webhookDefaults.MaxBackoff),
+		WebhookAllowPrivateTargets:  goDotEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+		CityMinX:                    goDotEnvInt("CITY_MIN_X", kernel.DefaultAreaMin),
+		CityMinY:                    goDotEnvInt("CITY_MIN_Y", kernel.DefaultAreaMin),
+		CityMaxX:                    goDotEnvInt("CITY_MAX_X", kernel.DefaultAreaMax),
+		CityMaxY:                    goDotEnvInt("CITY_MAX_Y", kernel.DefaultAreaMax),
+		Coordinates:                 goDotEnvString("COORDINATES", cmd.CoordinatesGrid),
+		CityGeoBounds:               goDotEnvVariable("CITY_GEO_BOUNDS"),
+		RoadGraph:                   goDotEnvVariable("ROAD_GRAPH"),
+		TransportProfiles:           goDotEnvVariable("TRANSPORT_PROFILES"),
+		CrossZoneWait:               goDotEnvDuration("CROSS_ZONE_WAIT", 2*time.Minute),
+		Regions:                     goDotEnvVariable("REGIONS"),
+		DispatchStrategy:            goDotEnvVariable("DISPATCH_STRATEGY"),
+		AssignOrdersInterval:        goDotEnvDuration("ASSIGN_ORDERS_INTERVAL", cmd.DefaultAssignOrdersInterval),
+		MoveCouriersInterval:        goDotEnvDuration("MOVE_COURIERS_INTERVAL", cmd.DefaultMoveCouriersInterval),
+		SLAAssignExpress:            goDotEnvDuration("SLA_ASSIGN_EXPRESS", slaDefaults.Limits(order.TierExpress).Assign),
+		SLAAssignStandard:           goDotEnvDuration("SLA_ASSIGN_STANDARD", slaDefaults.Limits(order.TierStandard).Assign),
+		SLAAssignScheduled:          goDotEnvDuration("SLA_ASSIGN_SCHEDULED", slaDefaults.Limits(order.TierScheduled).Assign),
+		SLADeliverExpress:           goDotEnvDuration("SLA_DELIVER_EXPRESS", slaDefaults.Limits(order.TierExpress).Deliver),
+		SLADeliverStandard:          goDotEnvDuration("SLA_DELIVER_STANDARD", slaDefaults.Limits(order.TierStandard).Deliver),
+		SLADeliverScheduled:         goDotEnvDuration("SLA_DELIVER_SCHEDULED", slaDefaults.Limits(order.TierScheduled).Deliver),
+		SLACheckInterval:            goDotEnvDuration("SLA_CHECK_INTERVAL", 30*time.Second),
+		CourierOfflineAfter:         goDotEnvDuration("COURIER_OFFLINE_AFTER", 2*time.Minute),
+		CourierOfflineCheckInterval: goDotEnvDuration("COURIER_OFFLINE_CHECK_INTERVAL", 15*time.Second),
+		CourierMovement:             goDotEnvString("COURIER_MOVEMENT", cmd.MovementSimulated),
+		LocationSpeedTolerance:      goDotEnvFloat("LOCATION_SPEED_TOLERANCE", 2),
+		ArrivalRadius:               goDotEnvInt("COURIER_ARRIVAL_RADIUS", 30),
+	}
+	if config.GeoFallback == "" {
+		config.GeoFallback = cmd.GeoFallbackNone
 	}
 	return config
 }
 
 func startCron(compositionRoot cmd.CompositionRoot) {
-	c := cron.New()
-	_, err := c.AddFunc("@every 1s", compositionRoot.Jobs.AssignOrdersJob.Run)
+	// Задача, не успевшая закончиться к следующему запуску, пропускает его, а не запускается параллельно
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger)))
+	for _, regionJobs := range compositionRoot.Jobs.RegionJobs {
+		_, err := c.AddFunc("@every "+regionJobs.AssignOrdersInterval.String(), regionJobs.AssignOrdersJob.Run)
+		if err != nil {
+			log.Fatalf("ошибка при добавлении задачи: %v", err)
+		}
+		if regionJobs.MoveCouriersJob == nil {
+			continue
+		}
+		_, err = c.AddFunc("@every "+regionJobs.MoveCouriersInterval.String(), regionJobs.MoveCouriersJob.Run)
+		if err != nil {
+			log.Fatalf("ошибка при добавлении задачи: %v", err)
+		}
+	}
+	_, err := c.AddFunc("@every "+compositionRoot.Jobs.GeoResolveInterval.String(),
+		compositionRoot.Jobs.ResolvePendingGeocodesJob.Run)
 	if err != nil {
 		log.Fatalf("ошибка при добавлении задачи: %v", err)
 	}
-	_, err = c.AddFunc("@every 2s", compositionRoot.Jobs.MoveCouriersJob.Run)
+	_, err = c.AddFunc("@every 1s", compositionRoot.Jobs.DeliverWebhooksJob.Run)
+	if err != nil {
+		log.Fatalf("ошибка при добавлении задачи: %v", err)
+	}
+	_, err = c.AddFunc("@every "+compositionRoot.Jobs.SLACheckInterval.String(), compositionRoot.Jobs.DetectSLABreachesJob.Run)
+	if err != nil {
+		log.Fatalf("ошибка при добавлении задачи: %v", err)
+	}
+	_, err = c.AddFunc("@every "+compositionRoot.Jobs.CourierOfflineCheckInterval.String(),
+		compositionRoot.Jobs.DetectOfflineCouriersJob.Run)
 	if err != nil {
 		log.Fatalf("ошибка при добавлении задачи: %v", err)
 	}
@@ -73,6 +230,10 @@ func startCron(compositionRoot cmd.CompositionRoot) {
 }
 
 func startKafkaConsumer(compositionRoot cmd.CompositionRoot) {
+	if compositionRoot.Consumers.BasketConfirmedConsumer == nil {
+		log.Info("Kafka consumer is disabled")
+		return
+	}
 	go func() {
 		if err := compositionRoot.Consumers.BasketConfirmedConsumer.Consume(); err != nil {
 			log.Fatalf("Kafka consumer error: %v", err)
@@ -80,11 +241,58 @@ func startKafkaConsumer(compositionRoot cmd.CompositionRoot) {
 	}()
 }
 
-func startWebServer(compositionRoot cmd.CompositionRoot, port string) {
+func startGrpcServer(compositionRoot cmd.CompositionRoot, cfg cmd.Config,
+	trackingLimiter *connlimit.Limiter) (*grpc.Server, *grpcin.Server) {
+	realMovement, err := cfg.RealMovement()
+	if err != nil {
+		log.Fatalf("Ошибка инициализации gRPC Server: %v", err)
+	}
+	server, err := grpcin.NewServer(
+		compositionRoot.CommandHandlers.CreateOrderCommandHandler,
+		compositionRoot.CommandHandlers.CancelOrderCommandHandler,
+		compositionRoot.QueryHandlers.GetOrderQueryHandler,
+		compositionRoot.QueryHandlers.GetNotCompletedOrdersQueryHandler,
+		compositionRoot.QueryHandlers.GetAllCouriersQueryHandler,
+		compositionRoot.QueryHandlers.GetCourierQueryHandler,
+		compositionRoot.QueryHandlers.TrackOrderQueryHandler,
+		trackingLimiter,
+		compositionRoot.CommandHandlers.ReportCourierLocationsCommandHandler,
+		realMovement,
+	)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации gRPC Server: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%s", cfg.GrpcPort))
+	if err != nil {
+		log.Fatalf("Ошибка инициализации gRPC Server: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("gRPC server error: %v", err)
+		}
+	}()
+	return grpcServer, server
+}
+
+func startWebServer(compositionRoot cmd.CompositionRoot, cfg cmd.Config, trackingLimiter *connlimit.Limiter) *echo.Echo {
 	handlers, err := httpin.NewServer(
 		compositionRoot.CommandHandlers.CreateOrderCommandHandler,
 		compositionRoot.QueryHandlers.GetAllCouriersQueryHandler,
 		compositionRoot.QueryHandlers.GetNotCompletedOrdersQueryHandler,
+		cfg.Coordinates == cmd.CoordinatesGeo,
+		newCouriers(compositionRoot),
+		newZones(compositionRoot),
+		newWebhooks(compositionRoot),
+		newOrderCancellation(compositionRoot),
+		newOrderSLA(compositionRoot),
+		newOrderReassignment(compositionRoot),
+		newGeoCacheAdmin(compositionRoot),
+		newOrderTracking(compositionRoot, cfg, trackingLimiter),
+		newCourierLocations(compositionRoot),
 	)
 	if err != nil {
 		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
@@ -101,8 +309,105 @@ func startWebServer(compositionRoot cmd.CompositionRoot, port string) {
 	registerSwaggerOpenApi(e)
 	registerSwaggerUi(e)
 	servers.RegisterHandlers(e, handlers)
-	e.Logger.Fatal(e.Start(fmt.Sprintf("0.0.0.0:%s", port)))
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), nil))
+	go func() {
+		if err := e.Start(fmt.Sprintf("0.0.0.0:%s", cfg.HttpPort)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatal(err)
+		}
+	}()
+	return e
+}
+
+func newGeoCacheAdmin(compositionRoot cmd.CompositionRoot) *httpin.GeoCacheAdmin {
+	geoCacheAdmin, err := httpin.NewGeoCacheAdmin(compositionRoot.Clients.GeoCache)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return geoCacheAdmin
+}
+
+func newOrderTracking(compositionRoot cmd.CompositionRoot, cfg cmd.Config,
+	trackingLimiter *connlimit.Limiter) *httpin.OrderTracking {
+	orderTracking, err := httpin.NewOrderTracking(compositionRoot.QueryHandlers.TrackOrderQueryHandler, trackingLimiter,
+		httpin.OrderTrackingConfig{
+			HeartbeatInterval: cfg.TrackingHeartbeatInterval,
+		})
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return orderTracking
+}
+
+func newOrderCancellation(compositionRoot cmd.CompositionRoot) *httpin.OrderCancellation {
+	orderCancellation, err := httpin.NewOrderCancellation(compositionRoot.CommandHandlers.CancelOrderCommandHandler)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return orderCancellation
+}
+
+func newOrderSLA(compositionRoot cmd.CompositionRoot) *httpin.OrderSLA {
+	orderSLA, err := httpin.NewOrderSLA(compositionRoot.QueryHandlers.GetAtRiskOrdersQueryHandler)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return orderSLA
+}
+
+func newOrderReassignment(compositionRoot cmd.CompositionRoot) *httpin.OrderReassignment {
+	orderReassignment, err := httpin.NewOrderReassignment(compositionRoot.CommandHandlers.ReassignOrderCommandHandler)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return orderReassignment
+}
+
+func newCouriers(compositionRoot cmd.CompositionRoot) *httpin.Couriers {
+	couriers, err := httpin.NewCouriers(compositionRoot.QueryHandlers.GetCourierQueryHandler,
+		compositionRoot.CommandHandlers.SetCourierHomeZoneCommandHandler,
+		compositionRoot.CommandHandlers.CourierHeartbeatCommandHandler)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return couriers
+}
+
+func newCourierLocations(compositionRoot cmd.CompositionRoot) *httpin.CourierLocations {
+	if compositionRoot.CommandHandlers.ReportCourierLocationsCommandHandler == nil {
+		return nil
+	}
+	courierLocations, err := httpin.NewCourierLocations(
+		compositionRoot.CommandHandlers.ReportCourierLocationsCommandHandler)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return courierLocations
+}
+
+func newWebhooks(compositionRoot cmd.CompositionRoot) *httpin.Webhooks {
+	webhooks, err := httpin.NewWebhooks(
+		compositionRoot.CommandHandlers.CreateWebhookSubscriptionCommandHandler,
+		compositionRoot.CommandHandlers.DeleteWebhookSubscriptionCommandHandler,
+		compositionRoot.CommandHandlers.SendTestWebhookCommandHandler,
+		compositionRoot.QueryHandlers.GetWebhookSubscriptionsQueryHandler,
+		compositionRoot.QueryHandlers.GetWebhookDeliveriesQueryHandler,
+	)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return webhooks
+}
+
+func newZones(compositionRoot cmd.CompositionRoot) *httpin.Zones {
+	zones, err := httpin.NewZones(
+		compositionRoot.CommandHandlers.CreateZoneCommandHandler,
+		compositionRoot.CommandHandlers.UpdateZoneCommandHandler,
+		compositionRoot.CommandHandlers.DeleteZoneCommandHandler,
+		compositionRoot.QueryHandlers.GetZonesQueryHandler,
+	)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
+	}
+	return zones
 }
 
 func registerSwaggerOpenApi(e *echo.Echo) {
@@ -156,6 +461,68 @@ func goDotEnvVariable(key string) string {
 	return os.Getenv(key)
 }
 
+// basketConfirmedDlqTopic - по умолчанию DLQ называется по исходному топику
+func basketConfirmedDlqTopic() string {
+	return goDotEnvString("KAFKA_BASKET_CONFIRMED_DLQ_TOPIC",
+		goDotEnvString("KAFKA_BASKET_CONFIRMED_TOPIC", "basket.confirmed")+".dlq")
+}
+
+func goDotEnvString(key string, defaultValue string) string {
+	value := goDotEnvVariable(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func goDotEnvInt(key string, defaultValue int) int {
+	value := goDotEnvVariable(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return result
+}
+
+func goDotEnvFloat(key string, defaultValue float64) float64 {
+	value := goDotEnvVariable(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return result
+}
+
+func goDotEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := goDotEnvVariable(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return result
+}
+
+func goDotEnvBool(key string, defaultValue bool) bool {
+	value := goDotEnvVariable(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return result
+}
+
 func makeConnectionString(host string, port string, user string,
 	password string, dbName string, sslMode string) (string, error) {
 	if host == "" {
@@ -213,6 +580,44 @@ func mustAutoMigrate(db *gorm.DB) {
 	if err != nil {
 		log.Fatalf("Ошибка миграции: %v", err)
 	}
+
+	err = db.AutoMigrate(&geocacherepo.GeolocationDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&webhookrepo.SubscriptionDTO{}, &webhookrepo.DeliveryDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&zonerepo.ZoneDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+}
+
+// mustMigrateCityArea - переносит сохранённые координаты каждого региона в его сетку из конфигурации.
+// Точки WGS84 не переносятся
+func mustMigrateCityArea(db *gorm.DB, cfg cmd.Config) {
+	if cfg.Coordinates == cmd.CoordinatesGeo {
+		return
+	}
+	regionSettings, err := cfg.RegionSettings()
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+	areas := make(map[kernel.RegionCode]kernel.Area, len(regionSettings))
+	for _, settings := range regionSettings {
+		area, ok := settings.Region.Bounds().(kernel.Area)
+		if !ok {
+			log.Fatalf("Ошибка миграции: region %s has no grid", settings.Region.Code())
+		}
+		areas[settings.Region.Code()] = area
+	}
+	if err := cityrepo.Migrate(context.Background(), db, areas); err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
 }
 
 func crateDbIfNotExists(host string, port string, user string,
@@ -1,21 +1,37 @@
 package cmd
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	"gorm.io/gorm"
 
 	"github.com/IgorAleksandroff/delivery/internal/adapters/in/jobs"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/in/kafka"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/confluent"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/geocache"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/grpc/geo"
+	kafkaout "github.com/IgorAleksandroff/delivery/internal/adapters/out/kafka"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/geocacherepo"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/webhookrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/zonerepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/webhook"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/services"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/codec"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/eventbus"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
 )
 
@@ -30,33 +46,87 @@ type CompositionRoot struct {
 }
 
 type DomainServices struct {
-	OrderDispatcher *services.Dispatcher
+	Regions *region.Catalog
+	// OrderDispatchers - у каждого региона свой диспетчер со своей стратегией
+	OrderDispatchers map[kernel.RegionCode]*services.Dispatcher
 }
 
 type Repositories struct {
 	UnitOfWork        uow.UnitOfWork
 	OrderRepository   ports.OrderRepository
 	CourierRepository ports.CourierRepository
+
+	WebhookSubscriptionRepository ports.WebhookSubscriptionRepository
+	WebhookDeliveryRepository     ports.WebhookDeliveryRepository
+
+	ZoneRepository ports.ZoneRepository
 }
 
 type CommandHandlers struct {
-	AssignOrdersCommandHandler *commands.AssignOrdersCommandHandler
-	CreateOrderCommandHandler  *commands.CreateOrderCommandHandler
-	MoveCouriersCommandHandler *commands.MoveCouriersCommandHandler
+	AssignOrdersCommandHandlers map[kernel.RegionCode]*commands.AssignOrdersCommandHandler
+	CancelOrderCommandHandler   *commands.CancelOrderCommandHandler
+	ReassignOrderCommandHandler *commands.ReassignOrderCommandHandler
+	CreateOrderCommandHandler   *commands.CreateOrderCommandHandler
+	MoveCouriersCommandHandlers map[kernel.RegionCode]*commands.MoveCouriersCommandHandler
+
+	ResolvePendingGeocodesCommandHandler *commands.ResolvePendingGeocodesCommandHandler
+	DetectSLABreachesCommandHandler      *commands.DetectSLABreachesCommandHandler
+	DetectOfflineCouriersCommandHandler  *commands.DetectOfflineCouriersCommandHandler
+
+	CreateWebhookSubscriptionCommandHandler *commands.CreateWebhookSubscriptionCommandHandler
+	DeleteWebhookSubscriptionCommandHandler *commands.DeleteWebhookSubscriptionCommandHandler
+	DeliverWebhooksCommandHandler           *commands.DeliverWebhooksCommandHandler
+	SendTestWebhookCommandHandler           *commands.SendTestWebhookCommandHandler
+
+	CreateZoneCommandHandler         *commands.CreateZoneCommandHandler
+	UpdateZoneCommandHandler         *commands.UpdateZoneCommandHandler
+	DeleteZoneCommandHandler         *commands.DeleteZoneCommandHandler
+	SetCourierHomeZoneCommandHandler *commands.SetCourierHomeZoneCommandHandler
+	CourierHeartbeatCommandHandler   *commands.CourierHeartbeatCommandHandler
+	// ReportCourierLocationsCommandHandler - nil, если движение курьеров симулируется
+	ReportCourierLocationsCommandHandler *commands.ReportCourierLocationsCommandHandler
 }
 
 type QueryHandlers struct {
-	GetAllCouriersQueryHandler        *queries.GetAllCouriersQueryHandler
-	GetNotCompletedOrdersQueryHandler *queries.GetNotCompletedOrdersQueryHandler
+	GetAllCouriersQueryHandler        queries.GetAllCouriersQueryHandler
+	GetNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler
+	GetOrderQueryHandler              *queries.GetOrderQueryHandler
+	GetCourierQueryHandler            *queries.GetCourierQueryHandler
+	TrackOrderQueryHandler            *queries.TrackOrderQueryHandler
+	GetAtRiskOrdersQueryHandler       *queries.GetAtRiskOrdersQueryHandler
+
+	GetWebhookSubscriptionsQueryHandler *queries.GetWebhookSubscriptionsQueryHandler
+	GetWebhookDeliveriesQueryHandler    *queries.GetWebhookDeliveriesQueryHandler
+
+	GetZonesQueryHandler *queries.GetZonesQueryHandler
 }
 
 type Clients struct {
-	GeoClient ports.GeoClient
+	GeoClient            ports.GeoClient
+	GeoCache             ports.GeoCache
+	DomainEventPublisher ports.DomainEventPublisher
 }
 
 type Jobs struct {
-	AssignOrdersJob cron.Job
-	MoveCouriersJob cron.Job
+	RegionJobs                []RegionJobs
+	ResolvePendingGeocodesJob cron.Job
+	GeoResolveInterval        time.Duration
+	DeliverWebhooksJob        cron.Job
+	DetectSLABreachesJob      cron.Job
+	SLACheckInterval          time.Duration
+
+	DetectOfflineCouriersJob    cron.Job
+	CourierOfflineCheckInterval time.Duration
+}
+
+// RegionJobs - назначение заказов и движение курьеров одного региона, каждое со своим интервалом
+type RegionJobs struct {
+	Region               kernel.RegionCode
+	AssignOrdersJob      cron.Job
+	AssignOrdersInterval time.Duration
+	// MoveCouriersJob - nil, если позиции курьеров присылают их приложения
+	MoveCouriersJob      cron.Job
+	MoveCouriersInterval time.Duration
 }
 
 type Consumers struct {
@@ -64,9 +134,6 @@ type Consumers struct {
 }
 
 func NewCompositionRoot(gormDb *gorm.DB, cfg Config) CompositionRoot {
-	// Domain Services
-	orderDispatcher := services.NewOrderDispatcher()
-
 	// Repositories
 	unitOfWork, err := postgres.NewUnitOfWork(gormDb)
 	if err != nil {
@@ -83,88 +150,626 @@ func NewCompositionRoot(gormDb *gorm.DB, cfg Config) CompositionRoot {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	// Grpc Clients
-	geoClient, err := geo.NewClient(cfg.GeoServiceGrpcHost)
+	webhookSubscriptionRepository, err := webhookrepo.NewSubscriptionRepository(gormDb)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	// Command Handlers
-	createOrderCommandHandler, err := commands.NewCreateOrderCommandHandler(orderRepository, geoClient)
+	webhookDeliveryRepository, err := webhookrepo.NewDeliveryRepository(gormDb)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	assignOrdersCommandHandler, err := commands.NewAssignOrdersCommandHandler(
-		unitOfWork, orderRepository, courierRepository, orderDispatcher)
+	zoneRepository, err := zonerepo.NewRepository(gormDb)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	moveCouriersCommandHandler, err := commands.NewMoveCouriersCommandHandler(
-		unitOfWork, orderRepository, courierRepository)
+	// Query Handlers
+	getAllCouriersQueryHandler, err := queries.NewGetAllCouriersQueryHandler(gormDb)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	// Query Handlers
-	getAllCouriersQueryHandler, err := queries.NewGetAllCouriersQueryHandler(gormDb)
+	getNotCompletedOrdersQueryHandler, err := queries.NewGetNotCompletedOrdersQueryHandler(gormDb)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	getNotCompletedOrdersQueryHandler, err := queries.NewGetNotCompletedOrdersQueryHandler(gormDb)
+	// Grpc Clients
+	geoClient, err := geo.NewClient(cfg.GeoServiceGrpcHost, geo.Config{
+		Timeout:                 cfg.GeoTimeout,
+		MaxAttempts:             cfg.GeoMaxAttempts,
+		BaseBackoff:             cfg.GeoBaseBackoff,
+		MaxBackoff:              cfg.GeoMaxBackoff,
+		BreakerFailureThreshold: cfg.GeoBreakerThreshold,
+		BreakerOpenTimeout:      cfg.GeoBreakerOpenTimeout,
+	})
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	// Jobs
-	assignOrdersJob, err := jobs.NewAssignOrdersJob(assignOrdersCommandHandler)
+	var geoCacheStore geocache.Store
+	if cfg.GeoCachePersistent {
+		geoCacheStore, err = geocacherepo.NewRepository(gormDb)
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+	}
+	geoCache, err := geocache.NewCache(geoClient, geoCacheStore, geoCacheConfig(cfg))
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	moveCouriersJob, err := jobs.NewMoveCouriersJob(moveCouriersCommandHandler)
+	// Kafka Producers
+	messagePublisher, err := confluent.NewPublisher(cfg.KafkaHost)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	// Kafka Consumers
-	basketConfirmedConsumer, err := kafka.NewBasketConfirmedConsumer(cfg.KafkaHost, cfg.KafkaConsumerGroup,
-		cfg.KafkaBasketConfirmedTopic, createOrderCommandHandler)
+	domainEventProducer, err := kafkaout.NewDomainEventProducer(messagePublisher, cfg.KafkaCourierLocationChangedTopic)
 	if err != nil {
 		log.Fatalf("run application error: %s", err)
 	}
 
-	compositionRoot := CompositionRoot{
-		DomainServices: DomainServices{
-			OrderDispatcher: orderDispatcher,
+	compositionRoot := newCompositionRoot(cfg, mustRegionSettings(cfg),
+		Repositories{
+			UnitOfWork:        unitOfWork,
+			OrderRepository:   orderRepository,
+			CourierRepository: courierRepository,
+
+			WebhookSubscriptionRepository: webhookSubscriptionRepository,
+			WebhookDeliveryRepository:     webhookDeliveryRepository,
+
+			ZoneRepository: zoneRepository,
+		},
+		QueryHandlers{
+			GetAllCouriersQueryHandler:        getAllCouriersQueryHandler,
+			GetNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
+		},
+		Clients{
+			GeoClient:            geoCache,
+			GeoCache:             geoCache,
+			DomainEventPublisher: domainEventProducer,
 		},
+	)
+
+	// Kafka Consumers
+	messageConsumer, err := confluent.NewConsumer(cfg.KafkaHost, cfg.KafkaConsumerGroup)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+	compositionRoot.Consumers.BasketConfirmedConsumer = mustBasketConfirmedConsumer(cfg, messageConsumer,
+		messagePublisher, compositionRoot.CommandHandlers.CreateOrderCommandHandler)
+
+	return compositionRoot
+}
+
+// mustBasketConfirmedConsumer - потребитель корзин одинаков для Kafka и брокера в памяти
+func mustBasketConfirmedConsumer(cfg Config, messageConsumer ports.MessageConsumer, deadLetters ports.MessagePublisher,
+	createOrderCommandHandler *commands.CreateOrderCommandHandler) *kafka.BasketConfirmedConsumer {
+	var schemaRegistry codec.SchemaRegistry
+	if cfg.KafkaSchemaRegistryUrl != "" {
+		httpSchemaRegistry, err := codec.NewHTTPSchemaRegistry(cfg.KafkaSchemaRegistryUrl,
+			&http.Client{Timeout: cfg.KafkaSchemaRegistryTimeout})
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+		schemaRegistry = httpSchemaRegistry
+	}
+	basketConfirmedDecoder := codec.NewSelector(
+		codec.NewSchemaRegistryDecoder(schemaRegistry, cfg.KafkaBasketConfirmedTopic+"-value"))
+
+	consumerConfig := kafka.DefaultConsumerConfig()
+	consumerConfig.Workers = cfg.KafkaConsumerWorkers
+	consumerConfig.CommitInterval = cfg.KafkaCommitInterval
+	consumerConfig.DeadLetterTopic = cfg.KafkaBasketConfirmedDlqTopic
+
+	basketConfirmedConsumer, err := kafka.NewBasketConfirmedConsumer(messageConsumer, deadLetters,
+		cfg.KafkaBasketConfirmedTopic, consumerConfig, basketConfirmedDecoder, createOrderCommandHandler)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+	return basketConfirmedConsumer
+}
+
+// NewInMemoryCompositionRoot - собрать приложение без Postgres, Kafka и Geo, для демо и быстрых тестов
+func NewInMemoryCompositionRoot(cfg Config) CompositionRoot {
+	storage := memory.NewStorage()
+	regionSettings := mustRegionSettings(cfg)
+	defaultBounds := regionSettings[0].Region.Bounds()
+
+	// Repositories
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	orderRepository, err := memory.NewOrderRepository(storage)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	courierRepository, err := memory.NewCourierRepository(storage)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	webhookSubscriptionRepository := memory.NewWebhookSubscriptionRepository()
+	webhookDeliveryRepository := memory.NewWebhookDeliveryRepository()
+	zoneRepository := memory.NewZoneRepository()
+
+	for _, settings := range regionSettings {
+		err = seedCouriers(context.Background(), courierRepository, settings.Region, settings.Profiles)
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+	}
 
-		Repositories: Repositories{
+	// Query Handlers
+	getAllCouriersQueryHandler, err := memory.NewGetAllCouriersQueryHandler(storage)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	getNotCompletedOrdersQueryHandler, err := memory.NewGetNotCompletedOrdersQueryHandler(storage)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	// Geo: адреса каждого города попадают в сетку своего региона
+	geoClient := memory.NewGeoClient(nil, defaultBounds)
+	for _, settings := range regionSettings {
+		for _, city := range settings.Region.Cities() {
+			geoClient.SetCityBounds(city, settings.Region.Bounds())
+		}
+	}
+	geoCache, err := geocache.NewCache(geoClient, nil, geoCacheConfig(cfg))
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	// Брокер живёт в памяти процесса, события курьеров доступны только внутри него
+	broker := memory.NewBroker(3)
+	// Как и в Kafka, топик позиций compacted: в нём остаётся последняя позиция каждого курьера
+	broker.Compact(cfg.KafkaCourierLocationChangedTopic)
+	domainEventProducer, err := kafkaout.NewDomainEventProducer(broker.NewPublisher(), cfg.KafkaCourierLocationChangedTopic)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	compositionRoot := newCompositionRoot(cfg, regionSettings,
+		Repositories{
+			UnitOfWork:        unitOfWork,
 			OrderRepository:   orderRepository,
 			CourierRepository: courierRepository,
+
+			WebhookSubscriptionRepository: webhookSubscriptionRepository,
+			WebhookDeliveryRepository:     webhookDeliveryRepository,
+
+			ZoneRepository: zoneRepository,
 		},
-		CommandHandlers: CommandHandlers{
-			AssignOrdersCommandHandler: assignOrdersCommandHandler,
-			CreateOrderCommandHandler:  createOrderCommandHandler,
-			MoveCouriersCommandHandler: moveCouriersCommandHandler,
-		},
-		QueryHandlers: QueryHandlers{
+		QueryHandlers{
 			GetAllCouriersQueryHandler:        getAllCouriersQueryHandler,
 			GetNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
 		},
-		Clients: Clients{
-			GeoClient: geoClient,
+		Clients{
+			GeoClient:            geoCache,
+			GeoCache:             geoCache,
+			DomainEventPublisher: domainEventProducer,
 		},
-		Jobs: Jobs{
-			AssignOrdersJob: assignOrdersJob,
-			MoveCouriersJob: moveCouriersJob,
+	)
+
+	// События корзин читаются из того же брокера в памяти, что и в режиме Kafka
+	compositionRoot.Consumers.BasketConfirmedConsumer = mustBasketConfirmedConsumer(cfg,
+		broker.NewConsumer(cfg.KafkaConsumerGroup), broker.NewPublisher(),
+		compositionRoot.CommandHandlers.CreateOrderCommandHandler)
+
+	return compositionRoot
+}
+
+func geoCacheConfig(cfg Config) geocache.Config {
+	return geocache.Config{
+		Size:        cfg.GeoCacheSize,
+		TTL:         cfg.GeoCacheTTL,
+		NegativeTTL: cfg.GeoCacheNegativeTTL,
+		ServeStale:  cfg.GeoFallback == GeoFallbackCache,
+	}
+}
+
+func newCompositionRoot(cfg Config, regionSettings []RegionSettings, repositories Repositories,
+	queryHandlers QueryHandlers, clients Clients) CompositionRoot {
+	regions := mustRegionCatalog(cfg, regionSettings)
+
+	switch cfg.GeoFallback {
+	case GeoFallbackNone, GeoFallbackCache, GeoFallbackDeferred:
+	default:
+		log.Fatalf("run application error: unknown geo fallback %q", cfg.GeoFallback)
+	}
+
+	// Webhooks
+	webhookSender, err := webhook.NewSender(cfg.WebhookTimeout, cfg.WebhookAllowPrivateTargets)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	// Доставки вебхуков сохраняются в транзакции, изменившей заказ
+	outbox, err := commands.NewEnqueueWebhooksEventHandler(
+		repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	// Events: шина процесса для отслеживания заказов и внешний брокер
+	eventBus := eventbus.New(64)
+	eventPublisher := eventbus.MultiPublisher{eventBus, clients.DomainEventPublisher}
+
+	// Command Handlers
+	createOrderCommandHandler, err := commands.NewCreateOrderCommandHandler(
+		repositories.UnitOfWork, repositories.OrderRepository, clients.GeoClient, regions, eventPublisher, outbox,
+		cfg.GeoFallback == GeoFallbackDeferred)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	resolvePendingGeocodesCommandHandler, err := commands.NewResolvePendingGeocodesCommandHandler(
+		repositories.UnitOfWork, repositories.OrderRepository, clients.GeoClient, regions, eventPublisher, outbox)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	slaPolicy, err := cfg.SLAPolicy()
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+	detectSLABreachesCommandHandler, err := commands.NewDetectSLABreachesCommandHandler(
+		repositories.UnitOfWork, repositories.OrderRepository, eventPublisher, outbox, slaPolicy)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	realMovement, err := cfg.RealMovement()
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+	// Без симуляции курьер, приложение которого ни разу не вышло на связь, не должен получать заказы
+	detectOfflineCouriersCommandHandler, err := commands.NewDetectOfflineCouriersCommandHandler(
+		repositories.UnitOfWork, repositories.CourierRepository, repositories.OrderRepository, eventPublisher,
+		outbox, cfg.CourierOfflineAfter, realMovement)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	// Регионы назначают заказы и двигают курьеров независимо друг от друга
+	orderDispatchers := make(map[kernel.RegionCode]*services.Dispatcher, len(regionSettings))
+	assignOrdersCommandHandlers := make(map[kernel.RegionCode]*commands.AssignOrdersCommandHandler, len(regionSettings))
+	moveCouriersCommandHandlers := make(map[kernel.RegionCode]*commands.MoveCouriersCommandHandler, len(regionSettings))
+	for _, settings := range regionSettings {
+		code := settings.Region.Code()
+		orderDispatchers[code], err = services.NewOrderDispatcherWithStrategy(settings.Router, settings.Profiles,
+			settings.DispatchStrategy)
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+
+		assignOrdersCommandHandlers[code], err = commands.NewAssignOrdersCommandHandler(code,
+			repositories.UnitOfWork, repositories.OrderRepository, repositories.CourierRepository,
+			repositories.ZoneRepository, orderDispatchers[code], eventPublisher, outbox, cfg.CrossZoneWait)
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+
+		moveCouriersCommandHandlers[code], err = commands.NewMoveCouriersCommandHandler(code,
+			repositories.UnitOfWork, repositories.OrderRepository, repositories.CourierRepository,
+			eventPublisher, outbox, settings.Router, settings.Profiles)
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+	}
+
+	cancelOrderCommandHandler, err := commands.NewCancelOrderCommandHandler(
+		repositories.UnitOfWork, repositories.OrderRepository, repositories.CourierRepository,
+		eventPublisher, outbox)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	reassignOrderCommandHandler, err := commands.NewReassignOrderCommandHandler(
+		repositories.UnitOfWork, repositories.OrderRepository, repositories.CourierRepository,
+		eventPublisher, outbox)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	createWebhookSubscriptionCommandHandler, err := commands.NewCreateWebhookSubscriptionCommandHandler(
+		repositories.WebhookSubscriptionRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	deleteWebhookSubscriptionCommandHandler, err := commands.NewDeleteWebhookSubscriptionCommandHandler(
+		repositories.WebhookSubscriptionRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	deliverWebhooksConfig := commands.DefaultDeliverWebhooksConfig()
+	deliverWebhooksConfig.MaxAttempts = cfg.WebhookMaxAttempts
+	deliverWebhooksConfig.BaseBackoff = cfg.WebhookBaseBackoff
+	deliverWebhooksConfig.MaxBackoff = cfg.WebhookMaxBackoff
+	// Запас на чтение подписки и сохранение результата поверх таймаута отправки
+	deliverWebhooksConfig.ClaimTimeout = 2 * cfg.WebhookTimeout
+	deliverWebhooksCommandHandler, err := commands.NewDeliverWebhooksCommandHandler(
+		repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository, webhookSender,
+		deliverWebhooksConfig)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	sendTestWebhookCommandHandler, err := commands.NewSendTestWebhookCommandHandler(
+		repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository, webhookSender)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	createZoneCommandHandler, err := commands.NewCreateZoneCommandHandler(repositories.ZoneRepository, regions)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	updateZoneCommandHandler, err := commands.NewUpdateZoneCommandHandler(repositories.ZoneRepository, regions)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	deleteZoneCommandHandler, err := commands.NewDeleteZoneCommandHandler(repositories.ZoneRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	setCourierHomeZoneCommandHandler, err := commands.NewSetCourierHomeZoneCommandHandler(
+		repositories.CourierRepository, repositories.ZoneRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	courierHeartbeatCommandHandler, err := commands.NewCourierHeartbeatCommandHandler(repositories.CourierRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	var reportCourierLocationsCommandHandler *commands.ReportCourierLocationsCommandHandler
+	if realMovement {
+		// Ход курьера в регионе длится MoveCouriersInterval, по нему проверяется скорость из приложений
+		speedLimits := make(map[kernel.RegionCode]courier.SpeedLimit, len(regionSettings))
+		for _, settings := range regionSettings {
+			speedLimits[settings.Region.Code()], err = courier.NewSpeedLimit(settings.MoveCouriersInterval,
+				cfg.LocationSpeedTolerance)
+			if err != nil {
+				log.Fatalf("run application error: %s", err)
+			}
+		}
+		reportCourierLocationsCommandHandler, err = commands.NewReportCourierLocationsCommandHandler(
+			repositories.UnitOfWork, repositories.CourierRepository, repositories.OrderRepository, eventPublisher,
+			outbox, regions, speedLimits, cfg.ArrivalRadius)
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+	}
+
+	// Query Handlers
+	queryHandlers.GetOrderQueryHandler, err = queries.NewGetOrderQueryHandler(repositories.OrderRepository, slaPolicy)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	queryHandlers.GetAtRiskOrdersQueryHandler, err = queries.NewGetAtRiskOrdersQueryHandler(
+		repositories.OrderRepository, slaPolicy)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	queryHandlers.GetCourierQueryHandler, err = queries.NewGetCourierQueryHandler(
+		repositories.CourierRepository, repositories.OrderRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	trackingRegions := make(map[kernel.RegionCode]queries.TrackingRegion, len(regionSettings))
+	for _, settings := range regionSettings {
+		trackingRegions[settings.Region.Code()] = queries.TrackingRegion{
+			Router:       settings.Router,
+			Profiles:     settings.Profiles,
+			StepInterval: settings.MoveCouriersInterval,
+		}
+	}
+	queryHandlers.TrackOrderQueryHandler, err = queries.NewTrackOrderQueryHandler(
+		repositories.OrderRepository, repositories.CourierRepository, eventBus, trackingRegions)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	queryHandlers.GetWebhookSubscriptionsQueryHandler, err = queries.NewGetWebhookSubscriptionsQueryHandler(
+		repositories.WebhookSubscriptionRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	queryHandlers.GetWebhookDeliveriesQueryHandler, err = queries.NewGetWebhookDeliveriesQueryHandler(
+		repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	queryHandlers.GetZonesQueryHandler, err = queries.NewGetZonesQueryHandler(
+		repositories.ZoneRepository, repositories.OrderRepository)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	// Jobs
+	regionJobs := make([]RegionJobs, 0, len(regionSettings))
+	for _, settings := range regionSettings {
+		code := settings.Region.Code()
+		assignOrdersJob, err := jobs.NewAssignOrdersJob(assignOrdersCommandHandlers[code])
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+
+		var moveCouriersJob cron.Job
+		if !realMovement {
+			moveCouriersJob, err = jobs.NewMoveCouriersJob(moveCouriersCommandHandlers[code])
+			if err != nil {
+				log.Fatalf("run application error: %s", err)
+			}
+		}
+
+		regionJobs = append(regionJobs, RegionJobs{
+			Region:               code,
+			AssignOrdersJob:      assignOrdersJob,
+			AssignOrdersInterval: settings.AssignOrdersInterval,
+			MoveCouriersJob:      moveCouriersJob,
+			MoveCouriersInterval: settings.MoveCouriersInterval,
+		})
+	}
+
+	resolvePendingGeocodesJob, err := jobs.NewResolvePendingGeocodesJob(resolvePendingGeocodesCommandHandler)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	deliverWebhooksJob, err := jobs.NewDeliverWebhooksJob(deliverWebhooksCommandHandler)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	detectSLABreachesJob, err := jobs.NewDetectSLABreachesJob(detectSLABreachesCommandHandler)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	detectOfflineCouriersJob, err := jobs.NewDetectOfflineCouriersJob(detectOfflineCouriersCommandHandler)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+
+	compositionRoot := CompositionRoot{
+		DomainServices: DomainServices{
+			Regions:          regions,
+			OrderDispatchers: orderDispatchers,
+		},
+		Repositories: repositories,
+		CommandHandlers: CommandHandlers{
+			AssignOrdersCommandHandlers: assignOrdersCommandHandlers,
+			CancelOrderCommandHandler:   cancelOrderCommandHandler,
+			ReassignOrderCommandHandler: reassignOrderCommandHandler,
+			CreateOrderCommandHandler:   createOrderCommandHandler,
+			MoveCouriersCommandHandlers: moveCouriersCommandHandlers,
+
+			ResolvePendingGeocodesCommandHandler: resolvePendingGeocodesCommandHandler,
+			DetectSLABreachesCommandHandler:      detectSLABreachesCommandHandler,
+			DetectOfflineCouriersCommandHandler:  detectOfflineCouriersCommandHandler,
+
+			CreateWebhookSubscriptionCommandHandler: createWebhookSubscriptionCommandHandler,
+			DeleteWebhookSubscriptionCommandHandler: deleteWebhookSubscriptionCommandHandler,
+			DeliverWebhooksCommandHandler:           deliverWebhooksCommandHandler,
+			SendTestWebhookCommandHandler:           sendTestWebhookCommandHandler,
+
+			CreateZoneCommandHandler:         createZoneCommandHandler,
+			UpdateZoneCommandHandler:         updateZoneCommandHandler,
+			DeleteZoneCommandHandler:         deleteZoneCommandHandler,
+			SetCourierHomeZoneCommandHandler: setCourierHomeZoneCommandHandler,
+			CourierHeartbeatCommandHandler:   courierHeartbeatCommandHandler,
+
+			ReportCourierLocationsCommandHandler: reportCourierLocationsCommandHandler,
 		},
-		Consumers: Consumers{
-			BasketConfirmedConsumer: basketConfirmedConsumer,
+		QueryHandlers: queryHandlers,
+		Clients:       clients,
+		Jobs: Jobs{
+			RegionJobs:                regionJobs,
+			ResolvePendingGeocodesJob: resolvePendingGeocodesJob,
+			GeoResolveInterval:        cfg.GeoResolveInterval,
+			DeliverWebhooksJob:        deliverWebhooksJob,
+			DetectSLABreachesJob:      detectSLABreachesJob,
+			SLACheckInterval:          cfg.SLACheckInterval,
+
+			DetectOfflineCouriersJob:    detectOfflineCouriersJob,
+			CourierOfflineCheckInterval: cfg.CourierOfflineCheckInterval,
 		},
 	}
 
 	return compositionRoot
 }
+
+func mustRegionSettings(cfg Config) []RegionSettings {
+	settings, err := cfg.RegionSettings()
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+	return settings
+}
+
+// mustRegionCatalog - без файла Regions любой адрес попадает в единственный регион по умолчанию
+func mustRegionCatalog(cfg Config, regionSettings []RegionSettings) *region.Catalog {
+	if cfg.Regions == "" {
+		catalog, err := region.NewSingleRegionCatalog(regionSettings[0].Region.Bounds())
+		if err != nil {
+			log.Fatalf("run application error: %s", err)
+		}
+		return catalog
+	}
+	regions := make([]*region.Region, 0, len(regionSettings))
+	for _, settings := range regionSettings {
+		regions = append(regions, settings.Region)
+	}
+	catalog, err := region.NewCatalog(regions...)
+	if err != nil {
+		log.Fatalf("run application error: %s", err)
+	}
+	return catalog
+}
+
+// seedCouriers - добавить демо-курьеров региона, как в README для Postgres. На маленькой сетке курьеры сдвигаются
+// к её краю, в координатах WGS84 клетки сетки по умолчанию растягиваются на весь прямоугольник города.
+// Скорость транспорта берётся из профиля, если он есть
+func seedCouriers(ctx context.Context, courierRepository ports.CourierRepository, seedRegion *region.Region,
+	profiles *courier.Profiles) error {
+	bounds := seedRegion.Bounds()
+	seeds := []struct {
+		name           string
+		transportName  string
+		transportSpeed int
+		profile        string
+		x, y           int
+	}{
+		{name: "Пеший", transportName: "Пешком", transportSpeed: 1, profile: courier.ProfileWalking, x: 1, y: 3},
+		{name: "Вело", transportName: "Велосипед", transportSpeed: 2, profile: courier.ProfileBicycle, x: 4, y: 5},
+		{name: "Авто", transportName: "Машина", transportSpeed: 3, profile: courier.ProfileCar, x: 7, y: 9},
+	}
+
+	for _, seed := range seeds {
+		location, err := kernel.NewLocation(seed.x, seed.y)
+		if err != nil {
+			return err
+		}
+		if geoBounds, ok := bounds.(kernel.GeoBounds); ok {
+			location = geoBounds.LocationAt(float64(seed.x)/kernel.DefaultAreaMax, float64(seed.y)/kernel.DefaultAreaMax)
+		}
+		transport, err := courier.NewTransport(seed.transportName, seed.transportSpeed)
+		if profile, ok := profiles.Get(seed.profile); ok {
+			transport, err = profile.NewTransport(seed.transportName)
+		}
+		if err != nil {
+			return err
+		}
+		courierAggregate, err := courier.NewCourierInRegion(seedRegion.Code(), seed.name, transport, bounds.Clamp(location))
+		if err != nil {
+			return err
+		}
+		err = courierRepository.Add(ctx, courierAggregate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
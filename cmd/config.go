@@ -1,15 +1,167 @@
 package cmd
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Поведение при недоступности Geo
+const (
+	GeoFallbackNone     = "none"
+	GeoFallbackCache    = "cache"
+	GeoFallbackDeferred = "deferred"
+)
+
+// Вид координат курьеров и заказов
+const (
+	CoordinatesGrid = "grid"
+	CoordinatesGeo  = "geo"
+)
+
+// Откуда берутся позиции курьеров
+const (
+	// MovementSimulated - курьеры ходят к заказам сами, по заданию MoveCouriersJob
+	MovementSimulated = "simulated"
+	// MovementReal - позиции присылают приложения курьеров
+	MovementReal = "real"
+)
+
+// Интервалы заданий региона по умолчанию. Курьеры делают шаг с интервалом MoveCouriersInterval, по нему же считается ETA
+const (
+	DefaultAssignOrdersInterval = time.Second
+	DefaultMoveCouriersInterval = 2 * time.Second
+)
+
 type Config struct {
-	HttpPort                  string
-	DbHost                    string
-	DbPort                    string
-	DbUser                    string
-	DbPassword                string
-	DbDbName                  string
-	DbSslMode                 string
-	GeoServiceGrpcHost        string
-	KafkaHost                 string
-	KafkaConsumerGroup        string
-	KafkaBasketConfirmedTopic string
+	Storage                          string
+	HttpPort                         string
+	GrpcPort                         string
+	DbHost                           string
+	DbPort                           string
+	DbUser                           string
+	DbPassword                       string
+	DbDbName                         string
+	DbSslMode                        string
+	GeoServiceGrpcHost               string
+	KafkaHost                        string
+	KafkaConsumerGroup               string
+	KafkaBasketConfirmedTopic        string
+	KafkaBasketConfirmedDlqTopic     string
+	KafkaCourierLocationChangedTopic string
+	KafkaSchemaRegistryUrl           string
+	KafkaSchemaRegistryTimeout       time.Duration
+	KafkaConsumerWorkers             int
+	KafkaCommitInterval              time.Duration
+	GeoCacheSize                     int
+	GeoCacheTTL                      time.Duration
+	GeoCacheNegativeTTL              time.Duration
+	GeoCachePersistent               bool
+	GeoTimeout                       time.Duration
+	GeoMaxAttempts                   int
+	GeoBaseBackoff                   time.Duration
+	GeoMaxBackoff                    time.Duration
+	GeoBreakerThreshold              int
+	GeoBreakerOpenTimeout            time.Duration
+	GeoFallback                      string
+	GeoResolveInterval               time.Duration
+	TrackingMaxConnections           int
+	TrackingHeartbeatInterval        time.Duration
+	WebhookTimeout                   time.Duration
+	WebhookMaxAttempts               int
+	WebhookBaseBackoff               time.Duration
+	WebhookMaxBackoff                time.Duration
+	WebhookAllowPrivateTargets       bool
+	CityMinX                         int
+	CityMinY                         int
+	CityMaxX                         int
+	CityMaxY                         int
+	Coordinates                      string
+	CityGeoBounds                    string
+	RoadGraph                        string
+	TransportProfiles                string
+	CrossZoneWait                    time.Duration
+	Regions                          string
+	DispatchStrategy                 string
+	AssignOrdersInterval             time.Duration
+	MoveCouriersInterval             time.Duration
+	SLAAssignExpress                 time.Duration
+	SLAAssignStandard                time.Duration
+	SLAAssignScheduled               time.Duration
+	SLADeliverExpress                time.Duration
+	SLADeliverStandard               time.Duration
+	SLADeliverScheduled              time.Duration
+	SLACheckInterval                 time.Duration
+	CourierOfflineAfter              time.Duration
+	CourierOfflineCheckInterval      time.Duration
+	CourierMovement                  string
+	LocationSpeedTolerance           float64
+	ArrivalRadius                    int
+}
+
+// CityArea - сетка города, в которую должны попадать курьеры и заказы
+func (c Config) CityArea() (kernel.Area, error) {
+	return kernel.NewArea(c.CityMinX, c.CityMinY, c.CityMaxX, c.CityMaxY)
+}
+
+// CityBounds - сетка CityArea или, в координатах WGS84, прямоугольник CityGeoBounds
+func (c Config) CityBounds() (kernel.Bounds, error) {
+	switch c.Coordinates {
+	case CoordinatesGrid, "":
+		return c.CityArea()
+	case CoordinatesGeo:
+		if c.CityGeoBounds == "" {
+			return kernel.DefaultGeoBounds(), nil
+		}
+		return ParseGeoBounds(c.CityGeoBounds)
+	default:
+		return nil, fmt.Errorf("unknown coordinates: %s", c.Coordinates)
+	}
+}
+
+// RealMovement - позиции курьеров приходят из их приложений, симуляция движения выключена
+func (c Config) RealMovement() (bool, error) {
+	switch c.CourierMovement {
+	case MovementSimulated, "":
+		return false, nil
+	case MovementReal:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown courier movement: %s", c.CourierMovement)
+	}
+}
+
+// SLAPolicy - сроки назначения и доставки заказов по уровням
+func (c Config) SLAPolicy() (order.SLAPolicy, error) {
+	return order.NewSLAPolicy(
+		order.SLALimits{Assign: c.SLAAssignExpress, Deliver: c.SLADeliverExpress},
+		order.SLALimits{Assign: c.SLAAssignStandard, Deliver: c.SLADeliverStandard},
+		order.SLALimits{Assign: c.SLAAssignScheduled, Deliver: c.SLADeliverScheduled},
+	)
+}
+
+// ParseGeoBounds - прямоугольник WGS84 из строки south,west,north,east
+func ParseGeoBounds(value string) (kernel.GeoBounds, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return kernel.GeoBounds{}, fmt.Errorf("geo bounds must be south,west,north,east, got %q", value)
+	}
+	var coordinates [4]float64
+	for i, part := range parts {
+		coordinate, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return kernel.GeoBounds{}, fmt.Errorf("geo bounds %q: %w", value, err)
+		}
+		coordinates[i] = coordinate
+	}
+	return kernel.NewGeoBounds(coordinates[0], coordinates[1], coordinates[2], coordinates[3])
 }
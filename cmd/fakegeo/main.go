@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/labstack/gommon/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/IgorAleksandroff/delivery/cmd"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/fakegeo"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	pb "github.com/IgorAleksandroff/delivery/pkg/clients/geo/geosrv/geopb"
+)
+
+func main() {
+	addr := flag.String("addr", ":5004", "адрес gRPC сервера")
+	streetsPath := flag.String("streets", "", "JSON словарь улиц {\"Бажная\": {\"x\": 1, \"y\": 2}}")
+	unknownNotFound := flag.Bool("unknown-not-found", false, "отвечать NotFound на улицы не из словаря")
+	latency := flag.Duration("latency", 0, "задержка перед каждым ответом")
+	errorRate := flag.Float64("error-rate", 0, "доля запросов от 0 до 1, завершающихся ошибкой")
+	errorCode := flag.Uint("error-code", uint(codes.Unavailable), "gRPC код внедряемой ошибки")
+	minX := flag.Int("min-x", kernel.DefaultAreaMin, "левая граница сетки города")
+	minY := flag.Int("min-y", kernel.DefaultAreaMin, "нижняя граница сетки города")
+	maxX := flag.Int("max-x", kernel.DefaultAreaMax, "правая граница сетки города")
+	maxY := flag.Int("max-y", kernel.DefaultAreaMax, "верхняя граница сетки города")
+	geoBounds := flag.String("geo-bounds", "", "отвечать координатами WGS84 внутри south,west,north,east вместо клеток сетки")
+	flag.Parse()
+
+	var bounds kernel.Bounds
+	var err error
+	if *geoBounds != "" {
+		bounds, err = cmd.ParseGeoBounds(*geoBounds)
+	} else {
+		bounds, err = kernel.NewArea(*minX, *minY, *maxX, *maxY)
+	}
+	if err != nil {
+		log.Fatalf("city bounds: %v", err)
+	}
+
+	var streets map[string]kernel.Location
+	if *streetsPath != "" {
+		streets, err = fakegeo.LoadStreets(*streetsPath, bounds)
+		if err != nil {
+			log.Fatalf("load streets: %v", err)
+		}
+	}
+
+	server, err := fakegeo.NewServer(fakegeo.Config{
+		Streets:         streets,
+		UnknownNotFound: *unknownNotFound,
+		Bounds:          bounds,
+		Latency:         *latency,
+		ErrorRate:       *errorRate,
+		ErrorCode:       codes.Code(*errorCode),
+	})
+	if err != nil {
+		log.Fatalf("create fake geo: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen %s: %v", *addr, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterGeoServer(grpcServer, server)
+	reflection.Register(grpcServer)
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		grpcServer.GracefulStop()
+	}()
+
+	log.Infof("fake geo listening on %s, streets in dictionary: %d", listener.Addr(), len(streets))
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
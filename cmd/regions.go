@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/roadgraph"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/transportprofiles"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/services"
+)
+
+// RegionSettings - регион и его собственные настройки назначения заказов и движения курьеров
+type RegionSettings struct {
+	Region               *region.Region
+	DispatchStrategy     services.Strategy
+	AssignOrdersInterval time.Duration
+	MoveCouriersInterval time.Duration
+	// Router и Profiles - дороги и профили транспорта в координатах региона
+	Router   routing.Router
+	Profiles *courier.Profiles
+}
+
+type regionsFileDTO struct {
+	Regions []regionDTO `json:"regions"`
+}
+
+type regionDTO struct {
+	Code   string   `json:"code"`
+	Cities []string `json:"cities"`
+	// Area - сетка региона minX, minY, maxX, maxY, GeoBounds - прямоугольник WGS84 south, west, north, east.
+	// Без них регион занимает сетку из CITY_* или CITY_GEO_BOUNDS
+	Area      *[4]int     `json:"area"`
+	GeoBounds *[4]float64 `json:"geoBounds"`
+	// Пустые поля берутся из DISPATCH_STRATEGY, ASSIGN_ORDERS_INTERVAL и MOVE_COURIERS_INTERVAL
+	DispatchStrategy     string `json:"dispatchStrategy"`
+	AssignOrdersInterval string `json:"assignOrdersInterval"`
+	MoveCouriersInterval string `json:"moveCouriersInterval"`
+	// RoadGraph и TransportProfiles - файлы в координатах региона. Без них курьеры региона ходят по прямой,
+	// а транспорт едет по любой дороге со своей скоростью
+	RoadGraph         string `json:"roadGraph"`
+	TransportProfiles string `json:"transportProfiles"`
+}
+
+// RegionSettings - регионы из файла Regions, без него - один регион по умолчанию на сетке города
+func (c Config) RegionSettings() ([]RegionSettings, error) {
+	defaults, err := c.defaultRegionSettings()
+	if err != nil {
+		return nil, err
+	}
+	if c.Regions == "" {
+		defaults.Router, err = loadRouter(c.RoadGraph, defaults.Region.Bounds())
+		if err != nil {
+			return nil, err
+		}
+		defaults.Profiles, err = loadTransportProfiles(c.TransportProfiles, defaults.Region.Bounds())
+		if err != nil {
+			return nil, err
+		}
+		return []RegionSettings{defaults}, nil
+	}
+	if c.RoadGraph != "" || c.TransportProfiles != "" {
+		return nil, fmt.Errorf("with regions file road graph and transport profiles are set per region: " +
+			"roadGraph, transportProfiles")
+	}
+
+	file, err := os.Open(c.Regions)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	settings, err := ReadRegionSettings(file, c.Coordinates, defaults)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", c.Regions, err)
+	}
+	return settings, nil
+}
+
+func (c Config) defaultRegionSettings() (RegionSettings, error) {
+	bounds, err := c.CityBounds()
+	if err != nil {
+		return RegionSettings{}, err
+	}
+	defaultRegion, err := region.NewRegion(kernel.DefaultRegion(), bounds, nil)
+	if err != nil {
+		return RegionSettings{}, err
+	}
+	strategy, err := services.ParseStrategy(c.DispatchStrategy)
+	if err != nil {
+		return RegionSettings{}, err
+	}
+	settings := RegionSettings{
+		Region:               defaultRegion,
+		DispatchStrategy:     strategy,
+		AssignOrdersInterval: c.AssignOrdersInterval,
+		MoveCouriersInterval: c.MoveCouriersInterval,
+	}
+	if settings.AssignOrdersInterval <= 0 || settings.MoveCouriersInterval <= 0 {
+		return RegionSettings{}, fmt.Errorf("assign orders and move couriers intervals must be positive")
+	}
+	return settings, nil
+}
+
+// ReadRegionSettings - прочитать регионы из JSON:
+//
+//	{"regions": [
+//	  {"code": "msk", "cities": ["Москва"], "area": [1, 1, 10, 10], "dispatchStrategy": "fastest"},
+//	  {"code": "kzn", "cities": ["Казань"], "area": [1, 1, 20, 20], "moveCouriersInterval": "1s"}
+//	]}
+//
+// Первый регион - регион по умолчанию для новых зон. Заказ с незнакомым городом не принимается.
+// Незаданные поля региона берутся из defaults
+func ReadRegionSettings(r io.Reader, coordinates string, defaults RegionSettings) ([]RegionSettings, error) {
+	var file regionsFileDTO
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+	if len(file.Regions) == 0 {
+		return nil, fmt.Errorf("no regions")
+	}
+
+	settings := make([]RegionSettings, 0, len(file.Regions))
+	regions := make([]*region.Region, 0, len(file.Regions))
+	for _, dto := range file.Regions {
+		regionSettings, err := toRegionSettings(dto, coordinates, defaults)
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", dto.Code, err)
+		}
+		settings = append(settings, regionSettings)
+		regions = append(regions, regionSettings.Region)
+	}
+
+	// Каталог проверяет, что коды и города регионов не повторяются
+	if _, err := region.NewCatalog(regions...); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func toRegionSettings(dto regionDTO, coordinates string, defaults RegionSettings) (RegionSettings, error) {
+	code, err := kernel.NewRegionCode(dto.Code)
+	if err != nil {
+		return RegionSettings{}, err
+	}
+
+	bounds := defaults.Region.Bounds()
+	switch {
+	case dto.Area != nil && dto.GeoBounds != nil:
+		return RegionSettings{}, fmt.Errorf("area and geoBounds are mutually exclusive")
+	case dto.Area != nil:
+		if coordinates == CoordinatesGeo {
+			return RegionSettings{}, fmt.Errorf("area needs %s coordinates, use geoBounds", CoordinatesGrid)
+		}
+		bounds, err = kernel.NewArea(dto.Area[0], dto.Area[1], dto.Area[2], dto.Area[3])
+	case dto.GeoBounds != nil:
+		if coordinates != CoordinatesGeo {
+			return RegionSettings{}, fmt.Errorf("geoBounds needs %s coordinates, use area", CoordinatesGeo)
+		}
+		bounds, err = kernel.NewGeoBounds(dto.GeoBounds[0], dto.GeoBounds[1], dto.GeoBounds[2], dto.GeoBounds[3])
+	}
+	if err != nil {
+		return RegionSettings{}, err
+	}
+
+	settings := defaults
+	settings.Region, err = region.NewRegion(code, bounds, dto.Cities)
+	if err != nil {
+		return RegionSettings{}, err
+	}
+	if dto.DispatchStrategy != "" {
+		settings.DispatchStrategy, err = services.ParseStrategy(dto.DispatchStrategy)
+		if err != nil {
+			return RegionSettings{}, err
+		}
+	}
+	if settings.AssignOrdersInterval, err = parseInterval(dto.AssignOrdersInterval, defaults.AssignOrdersInterval); err != nil {
+		return RegionSettings{}, fmt.Errorf("assignOrdersInterval: %w", err)
+	}
+	if settings.MoveCouriersInterval, err = parseInterval(dto.MoveCouriersInterval, defaults.MoveCouriersInterval); err != nil {
+		return RegionSettings{}, fmt.Errorf("moveCouriersInterval: %w", err)
+	}
+	if settings.Router, err = loadRouter(dto.RoadGraph, bounds); err != nil {
+		return RegionSettings{}, fmt.Errorf("roadGraph: %w", err)
+	}
+	if settings.Profiles, err = loadTransportProfiles(dto.TransportProfiles, bounds); err != nil {
+		return RegionSettings{}, fmt.Errorf("transportProfiles: %w", err)
+	}
+	return settings, nil
+}
+
+// loadRouter - дороги из файла, без него курьеры ходят по прямой
+func loadRouter(path string, bounds kernel.Bounds) (routing.Router, error) {
+	if path == "" {
+		return routing.Direct{}, nil
+	}
+	graph, err := roadgraph.Load(path, bounds)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("road graph %s: %d intersections", path, graph.Nodes())
+	return graph, nil
+}
+
+// loadTransportProfiles - профили транспорта из файла, без него транспорт едет по любой дороге со своей скоростью
+func loadTransportProfiles(path string, bounds kernel.Bounds) (*courier.Profiles, error) {
+	if path == "" {
+		return courier.NewProfiles()
+	}
+	return transportprofiles.Load(path, bounds)
+}
+
+func parseInterval(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", value)
+	}
+	return interval, nil
+}
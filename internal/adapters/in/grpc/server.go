@@ -0,0 +1,580 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/connlimit"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	pb "github.com/IgorAleksandroff/delivery/pkg/servers/deliverysrv/deliverypb"
+)
+
+var _ pb.DeliveryServer = &Server{}
+
+// Server - gRPC API поверх тех же обработчиков команд и запросов, что и HTTP
+type Server struct {
+	pb.UnimplementedDeliveryServer
+
+	createOrderCommandHandler         *commands.CreateOrderCommandHandler
+	cancelOrderCommandHandler         *commands.CancelOrderCommandHandler
+	getOrderQueryHandler              *queries.GetOrderQueryHandler
+	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler
+	getAllCouriersQueryHandler        queries.GetAllCouriersQueryHandler
+	getCourierQueryHandler            *queries.GetCourierQueryHandler
+	trackOrderQueryHandler            *queries.TrackOrderQueryHandler
+
+	// trackingLimiter - лимит потоков отслеживания на все заказы, общий с HTTP
+	trackingLimiter *connlimit.Limiter
+	health          *health.Server
+
+	// reportCourierLocationsCommandHandler - nil, если движение курьеров симулируется, тогда ReportLocations
+	// отвечает FailedPrecondition
+	reportCourierLocationsCommandHandler *commands.ReportCourierLocationsCommandHandler
+}
+
+// NewServer - обработчик позиций курьеров обязателен при realMovement и не задаётся при симуляции движения
+func NewServer(
+	createOrderCommandHandler *commands.CreateOrderCommandHandler,
+	cancelOrderCommandHandler *commands.CancelOrderCommandHandler,
+	getOrderQueryHandler *queries.GetOrderQueryHandler,
+	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler,
+	getAllCouriersQueryHandler queries.GetAllCouriersQueryHandler,
+	getCourierQueryHandler *queries.GetCourierQueryHandler,
+	trackOrderQueryHandler *queries.TrackOrderQueryHandler,
+	trackingLimiter *connlimit.Limiter,
+	reportCourierLocationsCommandHandler *commands.ReportCourierLocationsCommandHandler,
+	realMovement bool,
+) (*Server, error) {
+	if createOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
+	}
+	if cancelOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("cancelOrderCommandHandler")
+	}
+	if getOrderQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getOrderQueryHandler")
+	}
+	if getNotCompletedOrdersQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getNotCompletedOrdersQueryHandler")
+	}
+	if getAllCouriersQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getAllCouriersQueryHandler")
+	}
+	if getCourierQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getCourierQueryHandler")
+	}
+	if trackOrderQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("trackOrderQueryHandler")
+	}
+	if trackingLimiter == nil {
+		return nil, errs.NewValueIsRequiredError("trackingLimiter")
+	}
+	if realMovement && reportCourierLocationsCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("reportCourierLocationsCommandHandler")
+	}
+	if !realMovement && reportCourierLocationsCommandHandler != nil {
+		return nil, errs.NewValueIsInvalidError("reportCourierLocationsCommandHandler")
+	}
+
+	return &Server{
+		createOrderCommandHandler:         createOrderCommandHandler,
+		cancelOrderCommandHandler:         cancelOrderCommandHandler,
+		getOrderQueryHandler:              getOrderQueryHandler,
+		getNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
+		getAllCouriersQueryHandler:        getAllCouriersQueryHandler,
+		getCourierQueryHandler:            getCourierQueryHandler,
+		trackOrderQueryHandler:            trackOrderQueryHandler,
+		trackingLimiter:                   trackingLimiter,
+		health:                            health.NewServer(),
+
+		reportCourierLocationsCommandHandler: reportCourierLocationsCommandHandler,
+	}, nil
+}
+
+// Register - зарегистрировать сервис вместе с health checking и reflection
+func (s *Server) Register(grpcServer *grpc.Server) {
+	pb.RegisterDeliveryServer(grpcServer, s)
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(pb.Delivery_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, s.health)
+
+	reflection.Register(grpcServer)
+}
+
+// Shutdown - перевести health в NOT_SERVING перед остановкой, чтобы балансировщик перестал слать запросы
+func (s *Server) Shutdown() {
+	s.health.Shutdown()
+}
+
+func (s *Server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderReply, error) {
+	orderID, err := parseID(req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	a := req.GetAddress()
+	address, err := kernel.NewAddress(a.GetCountry(), a.GetCity(), a.GetStreet(), a.GetHouse(), a.GetApartment())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	window, err := fromDeliveryWindow(req.GetDeliveryWindow())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	command, err := commands.NewCreateOrderCommandWithTier(orderID, address, fromOrderTier(req.GetTier()), window)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.createOrderCommandHandler.Handle(ctx, command)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreateOrderReply{OrderId: orderID.String()}, nil
+}
+
+func (s *Server) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	orderID, err := parseID(req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	query, err := queries.NewGetOrderQuery(orderID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := s.getOrderQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := &pb.Order{
+		Id:             response.ID.String(),
+		Status:         toOrderStatus(response.Status),
+		CourierId:      idOrEmpty(response.CourierID),
+		Region:         response.Region,
+		Tier:           toOrderTier(response.Tier),
+		SlaDeadline:    timestamppb.New(response.SLADeadline),
+		CreatedAt:      timestamppb.New(response.CreatedAt),
+		AssignedAt:     toTimestamp(response.AssignedAt),
+		PickedUpAt:     toTimestamp(response.PickedUpAt),
+		CompletedAt:    toTimestamp(response.CompletedAt),
+		DeliveryWindow: toDeliveryWindow(response.Window),
+		Address: &pb.Address{
+			Country:   response.Address.Country,
+			City:      response.Address.City,
+			Street:    response.Address.Street,
+			House:     response.Address.House,
+			Apartment: response.Address.Apartment,
+		},
+	}
+	if response.Location != nil {
+		result.Location = toLocation(*response.Location)
+	}
+	return result, nil
+}
+
+func (s *Server) ListActiveOrders(ctx context.Context, req *pb.ListActiveOrdersRequest) (*pb.ListActiveOrdersReply, error) {
+	sort, err := queries.ParseSort(req.GetSort(), queries.OrderSortFields, queries.DefaultOrderSort)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := queries.NewPage(int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	region, err := parseRegion(req.GetRegion())
+	if err != nil {
+		return nil, err
+	}
+	query, err := queries.NewGetNotCompletedOrdersQuery(queries.OrdersFilter{Region: region}, sort, page)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := s.getNotCompletedOrdersQueryHandler.Handle(query)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := &pb.ListActiveOrdersReply{
+		Orders:        make([]*pb.Order, 0, len(response.Orders)),
+		NextPageToken: response.NextCursor,
+	}
+	for _, o := range response.Orders {
+		reply.Orders = append(reply.Orders, &pb.Order{
+			Id:             o.ID.String(),
+			Status:         toOrderStatus(o.Status),
+			CourierId:      idOrEmpty(o.CourierID),
+			Location:       toLocation(o.Location),
+			Region:         o.Region,
+			Tier:           toOrderTier(o.Tier),
+			DeliveryWindow: toDeliveryWindow(o.Window),
+		})
+	}
+	return reply, nil
+}
+
+func (s *Server) ListCouriers(ctx context.Context, req *pb.ListCouriersRequest) (*pb.ListCouriersReply, error) {
+	sort, err := queries.ParseSort(req.GetSort(), queries.CourierSortFields, queries.DefaultCourierSort)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := queries.NewPage(int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	region, err := parseRegion(req.GetRegion())
+	if err != nil {
+		return nil, err
+	}
+	query, err := queries.NewGetAllCouriersQuery(queries.CouriersFilter{Region: region}, sort, page)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := s.getAllCouriersQueryHandler.Handle(query)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := &pb.ListCouriersReply{
+		Couriers:      make([]*pb.Courier, 0, len(response.Couriers)),
+		NextPageToken: response.NextCursor,
+	}
+	for _, c := range response.Couriers {
+		reply.Couriers = append(reply.Couriers, toCourier(c))
+	}
+	return reply, nil
+}
+
+func (s *Server) GetCourier(ctx context.Context, req *pb.GetCourierRequest) (*pb.Courier, error) {
+	courierID, err := parseID(req.GetCourierId())
+	if err != nil {
+		return nil, err
+	}
+	query, err := queries.NewGetCourierQuery(courierID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := s.getCourierQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toCourier(response), nil
+}
+
+func (s *Server) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderReply, error) {
+	orderID, err := parseID(req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	command, err := commands.NewCancelOrderCommand(orderID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.cancelOrderCommandHandler.Handle(ctx, command)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CancelOrderReply{}, nil
+}
+
+// WatchOrder - поток завершается с OK после доставки или отмены заказа.
+// При исчерпании общего с HTTP лимита потоков отслеживания отвечает ResourceExhausted.
+// Если сервер отключил отстающего клиента раньше, поток завершается с Unavailable и клиенту стоит переподключиться
+func (s *Server) WatchOrder(req *pb.WatchOrderRequest, stream grpc.ServerStreamingServer[pb.OrderUpdate]) error {
+	orderID, err := parseID(req.GetOrderId())
+	if err != nil {
+		return err
+	}
+	query, err := queries.NewTrackOrderQuery(orderID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !s.trackingLimiter.Acquire() {
+		return status.Error(codes.ResourceExhausted, "too many tracking connections")
+	}
+	defer s.trackingLimiter.Release()
+
+	ctx := stream.Context()
+	updates, err := s.trackOrderQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	final := false
+	for update := range updates {
+		err = stream.Send(toOrderUpdate(update))
+		if err != nil {
+			return err
+		}
+		final = update.Status.IsFinal()
+	}
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if !final {
+		return status.Error(codes.Unavailable, "order tracking interrupted, reconnect")
+	}
+	return nil
+}
+
+// ReportLocations - позиции применяются по мере поступления, каждое сообщение отдельно.
+// Отклонённые позиции и сообщения с ошибкой не прерывают поток, номер в ответе - порядковый номер сообщения
+func (s *Server) ReportLocations(stream grpc.ClientStreamingServer[pb.CourierLocationReport, pb.ReportLocationsReply]) error {
+	if s.reportCourierLocationsCommandHandler == nil {
+		return status.Error(codes.FailedPrecondition, "courier movement is simulated, locations are not accepted")
+	}
+
+	ctx := stream.Context()
+	reply := &pb.ReportLocationsReply{}
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(reply)
+		}
+		if err != nil {
+			return err
+		}
+		command, err := toReportCourierLocationsCommand(req)
+		if err != nil {
+			reply.Rejected = append(reply.Rejected, &pb.RejectedLocation{Index: index, Reason: err.Error()})
+			continue
+		}
+
+		response, err := s.reportCourierLocationsCommandHandler.Handle(ctx, command)
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			reply.Rejected = append(reply.Rejected, &pb.RejectedLocation{Index: index, Reason: err.Error()})
+			continue
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+		reply.Accepted += int32(response.Accepted)
+		for _, rejected := range response.Rejected {
+			reply.Rejected = append(reply.Rejected, &pb.RejectedLocation{Index: index, Reason: rejected.Reason.Error()})
+		}
+		for _, orderID := range response.CompletedOrders {
+			reply.CompletedOrderIds = append(reply.CompletedOrderIds, orderID.String())
+		}
+	}
+}
+
+// toReportCourierLocationsCommand - ошибка означает, что сообщение некорректно и его позиция отклоняется
+func toReportCourierLocationsCommand(req *pb.CourierLocationReport) (commands.ReportCourierLocationsCommand, error) {
+	courierID, err := uuid.Parse(req.GetCourierId())
+	if err != nil {
+		return commands.ReportCourierLocationsCommand{}, fmt.Errorf("invalid courier id %q: %w", req.GetCourierId(), err)
+	}
+	location, err := fromLocation(req.GetLocation())
+	if err != nil {
+		return commands.ReportCourierLocationsCommand{}, err
+	}
+	if req.GetReportedAt() == nil {
+		return commands.ReportCourierLocationsCommand{}, errs.NewValueIsRequiredError("reportedAt")
+	}
+	return commands.NewReportCourierLocationsCommand(courierID, []commands.LocationReport{
+		{Location: location, ReportedAt: req.GetReportedAt().AsTime()},
+	})
+}
+
+func toOrderUpdate(update queries.OrderTrackingResponse) *pb.OrderUpdate {
+	result := &pb.OrderUpdate{
+		OrderId:    update.OrderID.String(),
+		Status:     toOrderStatus(string(update.Status)),
+		CourierId:  idOrEmpty(update.CourierID),
+		OccurredAt: timestamppb.New(update.OccurredAt),
+	}
+	if update.CourierLocation != nil {
+		result.CourierLocation = toLocation(*update.CourierLocation)
+	}
+	if update.ETA != nil {
+		eta := int32(update.ETA.Seconds())
+		result.EtaSeconds = &eta
+	}
+	return result
+}
+
+var orderStatuses = map[order.Status]pb.OrderStatus{
+	order.StatusCreated:        pb.OrderStatus_ORDER_STATUS_CREATED,
+	order.StatusAssigned:       pb.OrderStatus_ORDER_STATUS_ASSIGNED,
+	order.StatusCompleted:      pb.OrderStatus_ORDER_STATUS_COMPLETED,
+	order.StatusCancelled:      pb.OrderStatus_ORDER_STATUS_CANCELLED,
+	order.StatusPendingGeocode: pb.OrderStatus_ORDER_STATUS_PENDING_GEOCODE,
+}
+
+func toOrderStatus(s string) pb.OrderStatus {
+	return orderStatuses[order.Status(s)]
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toDeliveryWindow(window *queries.DeliveryWindowResponse) *pb.DeliveryWindow {
+	if window == nil {
+		return nil
+	}
+	return &pb.DeliveryWindow{From: timestamppb.New(window.From), To: timestamppb.New(window.To)}
+}
+
+// fromDeliveryWindow - незаданный интервал - пустой
+func fromDeliveryWindow(window *pb.DeliveryWindow) (order.DeliveryWindow, error) {
+	if window == nil {
+		return order.DeliveryWindow{}, nil
+	}
+	var from, to time.Time
+	if window.GetFrom() != nil {
+		from = window.GetFrom().AsTime()
+	}
+	if window.GetTo() != nil {
+		to = window.GetTo().AsTime()
+	}
+	return order.NewDeliveryWindow(from, to)
+}
+
+var orderTiers = map[order.Tier]pb.OrderTier{
+	order.TierStandard:  pb.OrderTier_ORDER_TIER_STANDARD,
+	order.TierExpress:   pb.OrderTier_ORDER_TIER_EXPRESS,
+	order.TierScheduled: pb.OrderTier_ORDER_TIER_SCHEDULED,
+}
+
+func toOrderTier(t string) pb.OrderTier {
+	return orderTiers[order.Tier(t)]
+}
+
+// fromOrderTier - неизвестный и незаданный уровень - обычная доставка
+func fromOrderTier(t pb.OrderTier) order.Tier {
+	for tier, value := range orderTiers {
+		if value == t {
+			return tier
+		}
+	}
+	return order.TierStandard
+}
+
+var courierStatuses = map[courier.Status]pb.CourierStatus{
+	courier.StatusFree:    pb.CourierStatus_COURIER_STATUS_FREE,
+	courier.StatusBusy:    pb.CourierStatus_COURIER_STATUS_BUSY,
+	courier.StatusOffline: pb.CourierStatus_COURIER_STATUS_OFFLINE,
+}
+
+func toCourier(response queries.CourierResponse) *pb.Courier {
+	result := &pb.Courier{
+		Id:        response.ID.String(),
+		Name:      response.Name,
+		Location:  toLocation(response.Location),
+		Status:    courierStatuses[courier.Status(response.Status)],
+		Transport: &pb.Transport{Name: response.Transport.Name, Speed: int32(response.Transport.Speed)},
+		Orders:    make([]*pb.AssignedOrder, 0, len(response.Orders)),
+		Region:    response.Region,
+	}
+	for _, o := range response.Orders {
+		result.Orders = append(result.Orders, &pb.AssignedOrder{
+			Id:       o.ID.String(),
+			Location: toLocation(o.Location),
+			Distance: int32(o.Distance),
+		})
+	}
+	if response.LastAssignedAt != nil {
+		result.LastAssignedAt = timestamppb.New(*response.LastAssignedAt)
+	}
+	if response.LastSeenAt != nil {
+		result.LastSeenAt = timestamppb.New(*response.LastSeenAt)
+	}
+	return result
+}
+
+func toLocation(location queries.LocationResponse) *pb.Location {
+	if location.Lat != nil && location.Lon != nil {
+		return &pb.Location{Wgs84: &pb.Wgs84{Latitude: *location.Lat, Longitude: *location.Lon}}
+	}
+	return &pb.Location{X: int32(location.X), Y: int32(location.Y)}
+}
+
+// fromLocation - клетка сетки или, если заданы координаты WGS84, точка на карте
+func fromLocation(location *pb.Location) (kernel.Location, error) {
+	if location == nil {
+		return kernel.Location{}, errs.NewValueIsRequiredError("location")
+	}
+	if wgs84 := location.GetWgs84(); wgs84 != nil {
+		return kernel.NewGeoLocation(wgs84.GetLatitude(), wgs84.GetLongitude())
+	}
+	return kernel.NewLocation(int(location.GetX()), int(location.GetY()))
+}
+
+func idOrEmpty(ID *uuid.UUID) string {
+	if ID == nil {
+		return ""
+	}
+	return ID.String()
+}
+
+func parseID(raw string) (uuid.UUID, error) {
+	ID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid id %q: %v", raw, err)
+	}
+	return ID, nil
+}
+
+// parseRegion - пустой код не ограничивает выборку
+func parseRegion(raw string) (kernel.RegionCode, error) {
+	if raw == "" {
+		return kernel.RegionCode{}, nil
+	}
+	region, err := kernel.NewRegionCode(raw)
+	if err != nil {
+		return kernel.RegionCode{}, status.Errorf(codes.InvalidArgument, "invalid region %q: %v", raw, err)
+	}
+	return region, nil
+}
+
+// toStatus - перевести ошибку приложения в gRPC код
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, commands.OrderAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, order.ErrOrderCompleted), errors.Is(err, order.ErrOrderCancelled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ports.ErrGeolocationNotFound), errors.Is(err, kernel.ErrLocationOutOfArea),
+		errors.Is(err, region.ErrUnknownRegion):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ports.ErrGeoServiceUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, errs.ErrValueIsRequired), errors.Is(err, errs.ErrValueIsInvalid),
+		errors.Is(err, errs.ErrValueIsOutOfRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,375 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/connlimit"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/eventbus"
+	pb "github.com/IgorAleksandroff/delivery/pkg/servers/deliverysrv/deliverypb"
+)
+
+func setupServerTest(t *testing.T) *grpc.ClientConn {
+	conn, _ := setupServerTestWithStorage(t)
+	return conn
+}
+
+func setupServerTestWithStorage(t *testing.T) (*grpc.ClientConn, *memory.Storage) {
+	conn, storage, _ := setupServerTestWithLimiter(t, connlimit.New(16))
+	return conn, storage
+}
+
+func setupServerTestWithLimiter(t *testing.T, trackingLimiter *connlimit.Limiter) (*grpc.ClientConn, *memory.Storage, *Server) {
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	bus := eventbus.New(16)
+	outbox, err := commands.NewEnqueueWebhooksEventHandler(memory.NewWebhookSubscriptionRepository(),
+		memory.NewWebhookDeliveryRepository())
+	require.NoError(t, err)
+
+	regions, err := region.NewSingleRegionCatalog(kernel.DefaultArea())
+	require.NoError(t, err)
+	createOrder, err := commands.NewCreateOrderCommandHandler(unitOfWork, orderRepository,
+		memory.NewGeoClient(nil, kernel.DefaultArea()), regions, bus, outbox, false)
+	require.NoError(t, err)
+	cancelOrder, err := commands.NewCancelOrderCommandHandler(unitOfWork, orderRepository, courierRepository, bus, outbox)
+	require.NoError(t, err)
+	getOrder, err := queries.NewGetOrderQueryHandler(orderRepository, order.DefaultSLAPolicy())
+	require.NoError(t, err)
+	getNotCompletedOrders, err := memory.NewGetNotCompletedOrdersQueryHandler(storage)
+	require.NoError(t, err)
+	getAllCouriers, err := memory.NewGetAllCouriersQueryHandler(storage)
+	require.NoError(t, err)
+	getCourier, err := queries.NewGetCourierQueryHandler(courierRepository, orderRepository)
+	require.NoError(t, err)
+	trackOrder, err := queries.NewTrackOrderQueryHandler(orderRepository, courierRepository, bus,
+		map[kernel.RegionCode]queries.TrackingRegion{
+			kernel.DefaultRegion(): {Router: routing.Direct{}, Profiles: &courier.Profiles{}, StepInterval: 2 * time.Second},
+		})
+	require.NoError(t, err)
+
+	speedLimit, err := courier.NewSpeedLimit(time.Second, 1)
+	require.NoError(t, err)
+	reportLocations, err := commands.NewReportCourierLocationsCommandHandler(unitOfWork, courierRepository,
+		orderRepository, bus, outbox, regions, map[kernel.RegionCode]courier.SpeedLimit{kernel.DefaultRegion(): speedLimit}, 0)
+	require.NoError(t, err)
+
+	server, err := NewServer(createOrder, cancelOrder, getOrder, getNotCompletedOrders, getAllCouriers, getCourier,
+		trackOrder, trackingLimiter, reportLocations, true)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, storage, server
+}
+
+func createOrder(t *testing.T, client pb.DeliveryClient) string {
+	orderID := uuid.New().String()
+	reply, err := client.CreateOrder(context.Background(), &pb.CreateOrderRequest{
+		OrderId: orderID,
+		Address: &pb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, orderID, reply.GetOrderId())
+	return orderID
+}
+
+func Test_ServerShouldCreateExpressOrder(t *testing.T) {
+	ctx := context.Background()
+	client := pb.NewDeliveryClient(setupServerTest(t))
+	orderID := uuid.New().String()
+	_, err := client.CreateOrder(ctx, &pb.CreateOrderRequest{
+		OrderId: orderID,
+		Address: &pb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+		Tier:    pb.OrderTier_ORDER_TIER_EXPRESS,
+	})
+	require.NoError(t, err)
+
+	got, err := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+	assert.Equal(t, pb.OrderTier_ORDER_TIER_EXPRESS, got.GetTier())
+
+	active, err := client.ListActiveOrders(ctx, &pb.ListActiveOrdersRequest{})
+	require.NoError(t, err)
+	require.Len(t, active.GetOrders(), 1)
+	assert.Equal(t, pb.OrderTier_ORDER_TIER_EXPRESS, active.GetOrders()[0].GetTier())
+}
+
+func Test_ServerShouldCreateAndReadOrders(t *testing.T) {
+	ctx := context.Background()
+	client := pb.NewDeliveryClient(setupServerTest(t))
+	orderID := createOrder(t, client)
+
+	got, err := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_CREATED, got.GetStatus())
+	assert.Equal(t, "Бажная", got.GetAddress().GetStreet())
+	assert.NotNil(t, got.GetLocation())
+	assert.Empty(t, got.GetCourierId())
+	assert.Equal(t, pb.OrderTier_ORDER_TIER_STANDARD, got.GetTier())
+	assert.True(t, got.GetSlaDeadline().AsTime().After(time.Now()))
+	assert.NotNil(t, got.GetCreatedAt())
+	assert.Nil(t, got.GetAssignedAt())
+
+	active, err := client.ListActiveOrders(ctx, &pb.ListActiveOrdersRequest{})
+	require.NoError(t, err)
+	require.Len(t, active.GetOrders(), 1)
+	assert.Equal(t, orderID, active.GetOrders()[0].GetId())
+	assert.Empty(t, active.GetNextPageToken())
+
+	// Постраничное чтение: второй заказ на следующей странице
+	secondID := createOrder(t, client)
+	first, err := client.ListActiveOrders(ctx, &pb.ListActiveOrdersRequest{PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, first.GetOrders(), 1)
+	assert.Equal(t, orderID, first.GetOrders()[0].GetId())
+	require.NotEmpty(t, first.GetNextPageToken())
+	second, err := client.ListActiveOrders(ctx, &pb.ListActiveOrdersRequest{PageSize: 1, PageToken: first.GetNextPageToken()})
+	require.NoError(t, err)
+	require.Len(t, second.GetOrders(), 1)
+	assert.Equal(t, secondID, second.GetOrders()[0].GetId())
+	assert.Empty(t, second.GetNextPageToken())
+	_, err = client.ListActiveOrders(ctx, &pb.ListActiveOrdersRequest{PageToken: "broken"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Повтор с тем же id и неизвестный заказ
+	_, err = client.CreateOrder(ctx, &pb.CreateOrderRequest{
+		OrderId: orderID,
+		Address: &pb.Address{Street: "Бажная"},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: uuid.New().String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "not-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_ServerWatchOrderShouldEndAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := pb.NewDeliveryClient(setupServerTest(t))
+	orderID := createOrder(t, client)
+
+	stream, err := client.WatchOrder(ctx, &pb.WatchOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+	snapshot, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_CREATED, snapshot.GetStatus())
+
+	_, err = client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_CANCELLED, update.GetStatus())
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: orderID})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func Test_ServerWatchOrderShouldShareTrackingLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	trackingLimiter := connlimit.New(1)
+	conn, _, _ := setupServerTestWithLimiter(t, trackingLimiter)
+	client := pb.NewDeliveryClient(conn)
+	orderID := createOrder(t, client)
+
+	// Единственный слот занят потоком HTTP
+	require.True(t, trackingLimiter.Acquire())
+	stream, err := client.WatchOrder(ctx, &pb.WatchOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	trackingLimiter.Release()
+	stream, err = client.WatchOrder(ctx, &pb.WatchOrderRequest{OrderId: orderID})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, 1, trackingLimiter.Open())
+}
+
+func Test_ServerShouldReportNotServingAfterShutdown(t *testing.T) {
+	conn, _, server := setupServerTestWithLimiter(t, connlimit.New(16))
+
+	server.Shutdown()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{Service: pb.Delivery_ServiceDesc.ServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+func Test_ServerShouldReportHealth(t *testing.T) {
+	conn := setupServerTest(t)
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{Service: pb.Delivery_ServiceDesc.ServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func Test_ServerShouldReturnCourierWithAssignedOrders(t *testing.T) {
+	ctx := context.Background()
+	conn, storage := setupServerTestWithStorage(t)
+	client := pb.NewDeliveryClient(conn)
+
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	busy := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, busy.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, busy))
+	free := courier.MustNewCourier("Пеший", "Пешком", 1, kernel.MustNewLocation(5, 5))
+	require.NoError(t, courierRepository.Add(ctx, free))
+	assigned := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""),
+		kernel.MustNewLocation(4, 5))
+	require.NoError(t, assigned.AssignToCourier(busy.ID()))
+	require.NoError(t, orderRepository.Add(ctx, assigned))
+
+	got, err := client.GetCourier(ctx, &pb.GetCourierRequest{CourierId: busy.ID().String()})
+	require.NoError(t, err)
+	assert.Equal(t, pb.CourierStatus_COURIER_STATUS_BUSY, got.GetStatus())
+	assert.Equal(t, "Велосипед", got.GetTransport().GetName())
+	assert.Equal(t, int32(2), got.GetTransport().GetSpeed())
+	require.Len(t, got.GetOrders(), 1)
+	assert.Equal(t, assigned.ID().String(), got.GetOrders()[0].GetId())
+	assert.Equal(t, int32(7), got.GetOrders()[0].GetDistance())
+	assert.NotNil(t, got.GetLastAssignedAt())
+
+	list, err := client.ListCouriers(ctx, &pb.ListCouriersRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetCouriers(), 2)
+	assert.Len(t, list.GetCouriers()[0].GetOrders(), 1)
+	assert.Equal(t, pb.CourierStatus_COURIER_STATUS_FREE, list.GetCouriers()[1].GetStatus())
+	assert.Empty(t, list.GetCouriers()[1].GetOrders())
+	assert.Nil(t, list.GetCouriers()[1].GetLastAssignedAt())
+
+	_, err = client.GetCourier(ctx, &pb.GetCourierRequest{CourierId: uuid.New().String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_ServerShouldAcceptCourierLocationStream(t *testing.T) {
+	ctx := context.Background()
+	conn, storage := setupServerTestWithStorage(t)
+	client := pb.NewDeliveryClient(conn)
+
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	now := time.Now().Add(-time.Minute)
+	bike := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	bike.Heartbeat(now)
+	require.NoError(t, bike.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, bike))
+	assigned := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""),
+		kernel.MustNewLocation(2, 2))
+	require.NoError(t, assigned.AssignToCourier(bike.ID()))
+	require.NoError(t, orderRepository.Add(ctx, assigned))
+
+	stream, err := client.ReportLocations(ctx)
+	require.NoError(t, err)
+	for i, location := range []*pb.Location{{X: 10, Y: 10}, {X: 2, Y: 2}} {
+		require.NoError(t, stream.Send(&pb.CourierLocationReport{
+			CourierId:  bike.ID().String(),
+			Location:   location,
+			ReportedAt: timestamppb.New(now.Add(time.Duration(i+1) * time.Second)),
+		}))
+	}
+	reply, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), reply.GetAccepted())
+	require.Len(t, reply.GetRejected(), 1)
+	assert.Equal(t, int32(0), reply.GetRejected()[0].GetIndex())
+	assert.Equal(t, []string{assigned.ID().String()}, reply.GetCompletedOrderIds())
+
+	got, err := client.GetCourier(ctx, &pb.GetCourierRequest{CourierId: bike.ID().String()})
+	require.NoError(t, err)
+	assert.Equal(t, pb.CourierStatus_COURIER_STATUS_FREE, got.GetStatus())
+	assert.Equal(t, int32(2), got.GetLocation().GetX())
+}
+
+func Test_ServerLocationStreamShouldRejectMalformedMessagesAndContinue(t *testing.T) {
+	ctx := context.Background()
+	conn, storage := setupServerTestWithStorage(t)
+	client := pb.NewDeliveryClient(conn)
+
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	now := time.Now().Add(-time.Minute)
+	bike := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	bike.Heartbeat(now)
+	require.NoError(t, courierRepository.Add(ctx, bike))
+
+	stream, err := client.ReportLocations(ctx)
+	require.NoError(t, err)
+	reports := []*pb.CourierLocationReport{
+		{CourierId: "not-a-uuid", Location: &pb.Location{X: 2, Y: 1}, ReportedAt: timestamppb.New(now)},
+		{CourierId: uuid.New().String(), Location: &pb.Location{X: 2, Y: 1}, ReportedAt: timestamppb.New(now)},
+		{CourierId: bike.ID().String(), ReportedAt: timestamppb.New(now)},
+		{CourierId: bike.ID().String(), Location: &pb.Location{X: 2, Y: 1},
+			ReportedAt: timestamppb.New(now.Add(time.Second))},
+		{CourierId: bike.ID().String(), Location: &pb.Location{X: 3, Y: 1},
+			ReportedAt: timestamppb.New(now.Add(2 * time.Second))},
+	}
+	for _, report := range reports {
+		require.NoError(t, stream.Send(report))
+	}
+	reply, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), reply.GetAccepted())
+	require.Len(t, reply.GetRejected(), 3)
+	for i, rejected := range reply.GetRejected() {
+		assert.Equal(t, int32(i), rejected.GetIndex())
+		assert.NotEmpty(t, rejected.GetReason())
+	}
+
+	got, err := client.GetCourier(ctx, &pb.GetCourierRequest{CourierId: bike.ID().String()})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), got.GetLocation().GetX())
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// CourierLocations - приём позиций из приложений курьеров
+type CourierLocations struct {
+	reportCourierLocationsCommandHandler *commands.ReportCourierLocationsCommandHandler
+}
+
+func NewCourierLocations(
+	reportCourierLocationsCommandHandler *commands.ReportCourierLocationsCommandHandler,
+) (*CourierLocations, error) {
+	if reportCourierLocationsCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("reportCourierLocationsCommandHandler")
+	}
+	return &CourierLocations{reportCourierLocationsCommandHandler: reportCourierLocationsCommandHandler}, nil
+}
+
+// ReportCourierLocations - позиции принимаются только при реальном движении курьеров
+func (s *Server) ReportCourierLocations(c echo.Context, courierID uuid.UUID) error {
+	if s.courierLocations == nil {
+		return c.JSON(http.StatusNotFound,
+			problems.NewNotFound("courier locations are accepted only with COURIER_MOVEMENT=real"))
+	}
+	return s.courierLocations.ReportCourierLocations(c, courierID)
+}
+
+func (cl *CourierLocations) ReportCourierLocations(c echo.Context, courierID uuid.UUID) error {
+	var request servers.ReportCourierLocationsJSONRequestBody
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	reports := make([]commands.LocationReport, 0, len(request.Locations))
+	for _, report := range request.Locations {
+		location, err := toKernelLocation(servers.Location{X: report.X, Y: report.Y, Lat: report.Lat, Lon: report.Lon})
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+		}
+		reports = append(reports, commands.LocationReport{Location: location, ReportedAt: report.ReportedAt})
+	}
+	command, err := commands.NewReportCourierLocationsCommand(courierID, reports)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	result, err := cl.reportCourierLocationsCommandHandler.Handle(c.Request().Context(), command)
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	case err != nil:
+		return err
+	}
+
+	response := servers.CourierLocationsResult{
+		Accepted:        result.Accepted,
+		Rejected:        make([]servers.RejectedCourierLocation, 0, len(result.Rejected)),
+		CompletedOrders: make([]uuid.UUID, 0, len(result.CompletedOrders)),
+	}
+	for _, rejected := range result.Rejected {
+		response.Rejected = append(response.Rejected,
+			servers.RejectedCourierLocation{Index: rejected.Index, Reason: rejected.Reason.Error()})
+	}
+	response.CompletedOrders = append(response.CompletedOrders, result.CompletedOrders...)
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// Couriers - карточка курьера, его домашняя зона и сигналы о том, что курьер на связи
+type Couriers struct {
+	getCourierQueryHandler           *queries.GetCourierQueryHandler
+	setCourierHomeZoneCommandHandler *commands.SetCourierHomeZoneCommandHandler
+	courierHeartbeatCommandHandler   *commands.CourierHeartbeatCommandHandler
+}
+
+func NewCouriers(getCourierQueryHandler *queries.GetCourierQueryHandler,
+	setCourierHomeZoneCommandHandler *commands.SetCourierHomeZoneCommandHandler,
+	courierHeartbeatCommandHandler *commands.CourierHeartbeatCommandHandler) (*Couriers, error) {
+	if getCourierQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getCourierQueryHandler")
+	}
+	if setCourierHomeZoneCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("setCourierHomeZoneCommandHandler")
+	}
+	if courierHeartbeatCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("courierHeartbeatCommandHandler")
+	}
+	return &Couriers{
+		getCourierQueryHandler:           getCourierQueryHandler,
+		setCourierHomeZoneCommandHandler: setCourierHomeZoneCommandHandler,
+		courierHeartbeatCommandHandler:   courierHeartbeatCommandHandler,
+	}, nil
+}
+
+func (h *Couriers) GetCourier(c echo.Context, courierID uuid.UUID) error {
+	query, err := queries.NewGetCourierQuery(courierID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	response, err := h.getCourierQueryHandler.Handle(c.Request().Context(), query)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, toCourier(response, time.Now()))
+}
+
+func (h *Couriers) SetCourierHomeZone(c echo.Context, courierID uuid.UUID) error {
+	var request servers.SetCourierHomeZoneJSONRequestBody
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	command, err := commands.NewSetCourierHomeZoneCommand(courierID, request.ZoneId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = h.setCourierHomeZoneCommandHandler.Handle(c.Request().Context(), command)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CourierHeartbeat - курьер на связи; недоступный курьер снова получает заказы
+func (h *Couriers) CourierHeartbeat(c echo.Context, courierID uuid.UUID) error {
+	command, err := commands.NewCourierHeartbeatCommand(courierID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = h.courierHeartbeatCommandHandler.Handle(c.Request().Context(), command)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toCourier(response queries.CourierResponse, now time.Time) servers.Courier {
+	result := servers.Courier{
+		Id:             response.ID,
+		Name:           response.Name,
+		Region:         response.Region,
+		Location:       toLocation(response.Location),
+		Status:         response.Status,
+		Transport:      servers.CourierTransport{Name: response.Transport.Name, Speed: response.Transport.Speed},
+		Orders:         make([]servers.CourierOrder, 0, len(response.Orders)),
+		LastAssignedAt: response.LastAssignedAt,
+		HomeZoneId:     response.HomeZoneID,
+		LastSeenAt:     response.LastSeenAt,
+	}
+	for _, o := range response.Orders {
+		result.Orders = append(result.Orders, servers.CourierOrder{
+			Id:       o.ID,
+			Location: toLocation(o.Location),
+			Distance: o.Distance,
+		})
+	}
+	if response.LastAssignedAt != nil {
+		seconds := int64(now.Sub(*response.LastAssignedAt) / time.Second)
+		result.SecondsSinceLastAssignment = &seconds
+	}
+	return result
+}
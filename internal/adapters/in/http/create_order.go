@@ -2,16 +2,36 @@ package http
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
 )
 
-func (s *Server) CreateOrder(c echo.Context) error {
-	createOrderCommand, err := commands.NewCreateOrderCommand(uuid.New(), "Бажная")
+func (s *Server) CreateOrder(c echo.Context, params servers.CreateOrderParams) error {
+	// Город выбирает регион заказа, когда регионы заданы файлом
+	address, err := kernel.NewAddress("", stringValue(params.City), "Бажная", "", "")
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	tier, err := order.ParseTier(stringValue(params.Tier))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	window, err := parseDeliveryWindow(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	createOrderCommand, err := commands.NewCreateOrderCommandWithTier(uuid.New(), address, tier, window)
 	if err != nil {
 		return problems.NewBadRequest(err.Error())
 	}
@@ -23,3 +43,18 @@ func (s *Server) CreateOrder(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, nil)
 }
+
+// parseDeliveryWindow - без window_from и window_to интервала нет
+func parseDeliveryWindow(params servers.CreateOrderParams) (order.DeliveryWindow, error) {
+	if params.WindowFrom == nil && params.WindowTo == nil {
+		return order.DeliveryWindow{}, nil
+	}
+	var from, to time.Time
+	if params.WindowFrom != nil {
+		from = *params.WindowFrom
+	}
+	if params.WindowTo != nil {
+		to = *params.WindowTo
+	}
+	return order.NewDeliveryWindow(from, to)
+}
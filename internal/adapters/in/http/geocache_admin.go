@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// GeoCacheAdmin - служебные эндпоинты кэша геокодирования
+type GeoCacheAdmin struct {
+	geoCache ports.GeoCache
+}
+
+func NewGeoCacheAdmin(geoCache ports.GeoCache) (*GeoCacheAdmin, error) {
+	if geoCache == nil {
+		return nil, errs.NewValueIsRequiredError("geoCache")
+	}
+	return &GeoCacheAdmin{geoCache: geoCache}, nil
+}
+
+func (a *GeoCacheAdmin) GetGeoCacheStats(c echo.Context) error {
+	stats := a.geoCache.Stats()
+	return c.JSON(http.StatusOK, servers.GeoCacheStats{
+		Hits:           stats.Hits,
+		NegativeHits:   stats.NegativeHits,
+		PersistentHits: stats.PersistentHits,
+		Misses:         stats.Misses,
+		StaleHits:      stats.StaleHits,
+		Evictions:      stats.Evictions,
+		Size:           stats.Size,
+	})
+}
+
+// InvalidateGeoCacheAddress - уточнить адрес можно query-параметрами country, city и house
+func (a *GeoCacheAdmin) InvalidateGeoCacheAddress(c echo.Context, street string,
+	params servers.InvalidateGeoCacheAddressParams) error {
+	address, err := kernel.NewAddress(stringValue(params.Country), stringValue(params.City), street,
+		stringValue(params.House), "")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = a.geoCache.Invalidate(c.Request().Context(), address)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *GeoCacheAdmin) InvalidateGeoCache(c echo.Context) error {
+	err := a.geoCache.InvalidateAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
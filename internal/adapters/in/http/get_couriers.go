@@ -3,19 +3,21 @@ package http
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
 )
 
-func (s *Server) GetCouriers(c echo.Context) error {
-	query, err := queries.NewGetAllCouriersQuery()
+func (s *Server) GetCouriers(c echo.Context, params servers.GetCouriersParams) error {
+	query, err := newGetAllCouriersQuery(params, s.geoCoordinates)
 	if err != nil {
-		return problems.NewBadRequest(err.Error())
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
 	}
 
 	response, err := s.getAllCouriersQueryHandler.Handle(query)
@@ -23,21 +25,49 @@ func (s *Server) GetCouriers(c echo.Context) error {
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
 		}
+		return err
 	}
 
-	var couriers []servers.Courier
+	now := time.Now()
+	page := servers.CouriersPage{
+		Couriers:   make([]servers.Courier, 0, len(response.Couriers)),
+		NextCursor: nextCursor(response.NextCursor),
+	}
 	for _, courier := range response.Couriers {
-		location := servers.Location{
-			X: courier.Location.X,
-			Y: courier.Location.Y,
-		}
+		page.Couriers = append(page.Couriers, toCourier(courier, now))
+	}
+	return c.JSON(http.StatusOK, page)
+}
 
-		var courier = servers.Courier{
-			Id:       courier.ID,
-			Name:     courier.Name,
-			Location: location,
-		}
-		couriers = append(couriers, courier)
+func newGetAllCouriersQuery(params servers.GetCouriersParams, geoCoordinates bool) (queries.GetAllCouriersQuery, error) {
+	sort, err := queries.ParseSort(stringValue(params.Sort), queries.CourierSortFields, queries.DefaultCourierSort)
+	if err != nil {
+		return queries.GetAllCouriersQuery{}, err
+	}
+	page, err := newPage(params.Limit, params.Cursor)
+	if err != nil {
+		return queries.GetAllCouriersQuery{}, err
+	}
+	box, err := parseBoundingBox(stringValue(params.Bbox), geoCoordinates)
+	if err != nil {
+		return queries.GetAllCouriersQuery{}, err
+	}
+	created, err := queries.NewTimeRange(params.CreatedFrom, params.CreatedTo)
+	if err != nil {
+		return queries.GetAllCouriersQuery{}, err
+	}
+
+	region, err := parseRegion(stringValue(params.Region))
+	if err != nil {
+		return queries.GetAllCouriersQuery{}, err
+	}
+
+	filter := queries.CouriersFilter{
+		Status:    courier.Status(stringValue(params.Status)),
+		Region:    region,
+		Transport: stringValue(params.Transport),
+		Box:       box,
+		Created:   created,
 	}
-	return c.JSON(http.StatusOK, couriers)
+	return queries.NewGetAllCouriersQuery(filter, sort, page)
 }
@@ -8,14 +8,15 @@ import (
 
 	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
 )
 
-func (s *Server) GetOrders(c echo.Context) error {
-	query, err := queries.NewGetNotCompletedOrdersQuery()
+func (s *Server) GetOrders(c echo.Context, params servers.GetOrdersParams) error {
+	query, err := newGetNotCompletedOrdersQuery(params, s.geoCoordinates)
 	if err != nil {
-		return problems.NewBadRequest(err.Error())
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
 	}
 
 	response, err := s.getNotCompletedOrdersQueryHandler.Handle(query)
@@ -23,20 +24,60 @@ func (s *Server) GetOrders(c echo.Context) error {
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
 		}
+		return err
 	}
 
-	var orders []servers.Order
-	for _, courier := range response.Orders {
-		location := servers.Location{
-			X: courier.Location.X,
-			Y: courier.Location.Y,
-		}
+	page := servers.OrdersPage{
+		Orders:     make([]servers.Order, 0, len(response.Orders)),
+		NextCursor: nextCursor(response.NextCursor),
+	}
+	for _, item := range response.Orders {
+		page.Orders = append(page.Orders, servers.Order{
+			Id:             item.ID,
+			Location:       toLocation(item.Location),
+			Region:         item.Region,
+			Tier:           item.Tier,
+			DeliveryWindow: toDeliveryWindow(item.Window),
+		})
+	}
+	return c.JSON(http.StatusOK, page)
+}
 
-		var courier = servers.Order{
-			Id:       courier.ID,
-			Location: location,
-		}
-		orders = append(orders, courier)
+func toDeliveryWindow(window *queries.DeliveryWindowResponse) *servers.DeliveryWindow {
+	if window == nil {
+		return nil
+	}
+	return &servers.DeliveryWindow{From: window.From, To: window.To}
+}
+
+func newGetNotCompletedOrdersQuery(params servers.GetOrdersParams, geoCoordinates bool) (queries.GetNotCompletedOrdersQuery, error) {
+	sort, err := queries.ParseSort(stringValue(params.Sort), queries.OrderSortFields, queries.DefaultOrderSort)
+	if err != nil {
+		return queries.GetNotCompletedOrdersQuery{}, err
+	}
+	page, err := newPage(params.Limit, params.Cursor)
+	if err != nil {
+		return queries.GetNotCompletedOrdersQuery{}, err
+	}
+	box, err := parseBoundingBox(stringValue(params.Bbox), geoCoordinates)
+	if err != nil {
+		return queries.GetNotCompletedOrdersQuery{}, err
+	}
+	created, err := queries.NewTimeRange(params.CreatedFrom, params.CreatedTo)
+	if err != nil {
+		return queries.GetNotCompletedOrdersQuery{}, err
+	}
+
+	region, err := parseRegion(stringValue(params.Region))
+	if err != nil {
+		return queries.GetNotCompletedOrdersQuery{}, err
+	}
+
+	filter := queries.OrdersFilter{
+		Status:  order.Status(stringValue(params.Status)),
+		Region:  region,
+		Box:     box,
+		Created: created,
 	}
-	return c.JSON(http.StatusOK, orders)
+	return queries.NewGetNotCompletedOrdersQuery(filter, sort, page)
 }
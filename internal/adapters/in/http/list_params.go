@@ -0,0 +1,88 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+// newPage - limit не задан - страница по умолчанию
+func newPage(limit *int, cursor *string) (queries.Page, error) {
+	size := 0
+	if limit != nil {
+		if *limit <= 0 {
+			return queries.Page{}, queries.ErrInvalidPageSize
+		}
+		size = *limit
+	}
+	return queries.NewPage(size, stringValue(cursor))
+}
+
+// parseBoundingBox - bbox=minX,minY,maxX,maxY для клеток или, если сервис работает в координатах WGS84,
+// bbox=south,west,north,east в градусах
+func parseBoundingBox(raw string, geoCoordinates bool) (*queries.BoundingBox, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, queries.ErrInvalidFilter
+	}
+
+	var box queries.BoundingBox
+	if geoCoordinates {
+		var values [4]float64
+		for i, part := range parts {
+			value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, queries.ErrInvalidFilter
+			}
+			values[i] = value
+		}
+		geoBox, err := queries.NewGeoBoundingBox(values[0], values[1], values[2], values[3])
+		if err != nil {
+			return nil, err
+		}
+		box = geoBox
+	} else {
+		var values [4]int
+		for i, part := range parts {
+			value, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, queries.ErrInvalidFilter
+			}
+			values[i] = value
+		}
+		gridBox, err := queries.NewBoundingBox(values[0], values[1], values[2], values[3])
+		if err != nil {
+			return nil, err
+		}
+		box = gridBox
+	}
+	return &box, nil
+}
+
+// parseRegion - код региона, пустая строка - все регионы или регион по умолчанию
+func parseRegion(raw string) (kernel.RegionCode, error) {
+	if raw == "" {
+		return kernel.RegionCode{}, nil
+	}
+	return kernel.NewRegionCode(raw)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// nextCursor - nil на последней странице
+func nextCursor(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+	return &cursor
+}
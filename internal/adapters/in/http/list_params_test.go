@@ -0,0 +1,61 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func Test_ParseBoundingBoxShouldFollowCoordinates(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            string
+		geoCoordinates bool
+		inside         kernel.Location
+		outside        kernel.Location
+	}{
+		{
+			name:    "grid",
+			raw:     "1,1,5,5",
+			inside:  kernel.MustNewLocation(3, 3),
+			outside: kernel.MustNewLocation(6, 3),
+		},
+		{
+			name:           "geo whole degrees",
+			raw:            "55,37,56,38",
+			geoCoordinates: true,
+			inside:         kernel.MustNewGeoLocation(55.75, 37.6),
+			outside:        kernel.MustNewGeoLocation(54.9, 37.6),
+		},
+		{
+			name:           "geo",
+			raw:            "55.70, 37.50, 55.80, 37.70",
+			geoCoordinates: true,
+			inside:         kernel.MustNewGeoLocation(55.75, 37.6),
+			outside:        kernel.MustNewGeoLocation(55.75, 37.8),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box, err := parseBoundingBox(tt.raw, tt.geoCoordinates)
+			require.NoError(t, err)
+			require.NotNil(t, box)
+			assert.True(t, box.Contains(tt.inside))
+			assert.False(t, box.Contains(tt.outside))
+		})
+	}
+
+	box, err := parseBoundingBox("", false)
+	require.NoError(t, err)
+	assert.Nil(t, box)
+
+	// В клетках дробные границы не принимаются
+	_, err = parseBoundingBox("1.5,1,5,5", false)
+	assert.ErrorIs(t, err, queries.ErrInvalidFilter)
+	_, err = parseBoundingBox("1,1,5", true)
+	assert.ErrorIs(t, err, queries.ErrInvalidFilter)
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// toLocation - клетка сетки или, в координатах WGS84, широта и долгота
+func toLocation(location queries.LocationResponse) servers.Location {
+	return servers.Location{
+		X:   location.X,
+		Y:   location.Y,
+		Lat: location.Lat,
+		Lon: location.Lon,
+	}
+}
+
+// toKernelLocation - клетка сетки или, если заданы lat и lon, точка WGS84
+func toKernelLocation(location servers.Location) (kernel.Location, error) {
+	if location.Lat != nil && location.Lon != nil {
+		return kernel.NewGeoLocation(*location.Lat, *location.Lon)
+	}
+	return kernel.NewLocation(location.X, location.Y)
+}
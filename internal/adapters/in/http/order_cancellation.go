@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// OrderCancellation - отмена заказа
+type OrderCancellation struct {
+	cancelOrderCommandHandler *commands.CancelOrderCommandHandler
+}
+
+func NewOrderCancellation(cancelOrderCommandHandler *commands.CancelOrderCommandHandler) (*OrderCancellation, error) {
+	if cancelOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("cancelOrderCommandHandler")
+	}
+	return &OrderCancellation{cancelOrderCommandHandler: cancelOrderCommandHandler}, nil
+}
+
+func (o *OrderCancellation) CancelOrder(c echo.Context, orderID uuid.UUID) error {
+	command, err := commands.NewCancelOrderCommand(orderID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = o.cancelOrderCommandHandler.Handle(c.Request().Context(), command)
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	case errors.Is(err, order.ErrOrderCompleted), errors.Is(err, order.ErrOrderCancelled):
+		return c.JSON(http.StatusConflict, problems.NewConflict("order-not-cancellable", err.Error()))
+	case err != nil:
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
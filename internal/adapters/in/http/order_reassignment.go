@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// OrderReassignment - ручная передача заказа другому курьеру
+type OrderReassignment struct {
+	reassignOrderCommandHandler *commands.ReassignOrderCommandHandler
+}
+
+func NewOrderReassignment(reassignOrderCommandHandler *commands.ReassignOrderCommandHandler) (*OrderReassignment, error) {
+	if reassignOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("reassignOrderCommandHandler")
+	}
+	return &OrderReassignment{reassignOrderCommandHandler: reassignOrderCommandHandler}, nil
+}
+
+func (o *OrderReassignment) ReassignOrder(c echo.Context, orderID uuid.UUID) error {
+	var request servers.ReassignOrderJSONRequestBody
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	command, err := commands.NewReassignOrderCommand(orderID, request.CourierId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = o.reassignOrderCommandHandler.Handle(c.Request().Context(), command)
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	case errors.Is(err, courier.ErrCourierAlreadyBusy), errors.Is(err, courier.ErrCourierOffline),
+		errors.Is(err, commands.CourierInAnotherRegion):
+		return c.JSON(http.StatusConflict, problems.NewConflict("courier-not-available", err.Error()))
+	case errors.Is(err, order.ErrOrderCompleted), errors.Is(err, order.ErrOrderCancelled),
+		errors.Is(err, order.ErrOrderNotGeocoded):
+		return c.JSON(http.StatusConflict, problems.NewConflict("order-not-reassignable", err.Error()))
+	case err != nil:
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
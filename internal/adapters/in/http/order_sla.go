@@ -0,0 +1,66 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// OrderSLA - заказы под угрозой нарушения сроков
+type OrderSLA struct {
+	getAtRiskOrdersQueryHandler *queries.GetAtRiskOrdersQueryHandler
+}
+
+func NewOrderSLA(getAtRiskOrdersQueryHandler *queries.GetAtRiskOrdersQueryHandler) (*OrderSLA, error) {
+	if getAtRiskOrdersQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getAtRiskOrdersQueryHandler")
+	}
+	return &OrderSLA{getAtRiskOrdersQueryHandler: getAtRiskOrdersQueryHandler}, nil
+}
+
+// GetAtRiskOrders - ?within=15m задаёт запас до срока, ?region=msk - регион
+func (o *OrderSLA) GetAtRiskOrders(c echo.Context, params servers.GetAtRiskOrdersParams) error {
+	var within time.Duration
+	if raw := stringValue(params.Within); raw != "" {
+		var err error
+		within, err = time.ParseDuration(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, problems.NewBadRequest(fmt.Sprintf("within: %v", err)))
+		}
+	}
+	region, err := parseRegion(stringValue(params.Region))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	query, err := queries.NewGetAtRiskOrdersQuery(region, within)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	response, err := o.getAtRiskOrdersQueryHandler.Handle(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	orders := make([]servers.AtRiskOrder, 0, len(response))
+	for _, atRisk := range response {
+		orders = append(orders, servers.AtRiskOrder{
+			Id:               atRisk.ID,
+			Region:           atRisk.Region,
+			Tier:             atRisk.Tier,
+			Status:           atRisk.Status,
+			CourierId:        atRisk.CourierID,
+			Stage:            atRisk.Stage,
+			Deadline:         atRisk.Deadline,
+			RemainingSeconds: int64(atRisk.Remaining / time.Second),
+		})
+	}
+	return c.JSON(http.StatusOK, orders)
+}
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/connlimit"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// OrderTracking - отслеживание заказа через SSE и WebSocket
+type OrderTracking struct {
+	trackOrderQueryHandler *queries.TrackOrderQueryHandler
+	// limiter - лимит потоков отслеживания на все заказы, общий с gRPC
+	limiter *connlimit.Limiter
+	cfg     OrderTrackingConfig
+}
+
+type OrderTrackingConfig struct {
+	HeartbeatInterval time.Duration
+}
+
+const (
+	trackingMessageType  = "tracking"
+	heartbeatMessageType = "heartbeat"
+)
+
+func NewOrderTracking(trackOrderQueryHandler *queries.TrackOrderQueryHandler, limiter *connlimit.Limiter,
+	cfg OrderTrackingConfig) (*OrderTracking, error) {
+	if trackOrderQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("trackOrderQueryHandler")
+	}
+	if limiter == nil {
+		return nil, errs.NewValueIsRequiredError("limiter")
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		return nil, errs.NewValueIsInvalidError("heartbeatInterval")
+	}
+	return &OrderTracking{trackOrderQueryHandler: trackOrderQueryHandler, limiter: limiter, cfg: cfg}, nil
+}
+
+// TrackOrder - Server-Sent Events: событие tracking на каждое изменение, комментарий-heartbeat в паузах
+func (t *OrderTracking) TrackOrder(c echo.Context, orderID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	updates, release, err := t.subscribe(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Запрещаем буферизацию ответа в nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	ticker := time.NewTicker(t.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_, err := fmt.Fprintf(w, ": heartbeat\n\n")
+			if err != nil {
+				return nil
+			}
+			w.Flush()
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(toOrderTrackingUpdate(update))
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", trackingMessageType, data)
+			if err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
+
+// TrackOrderWebSocket - те же обновления JSON-сообщениями, heartbeat отдельным сообщением
+func (t *OrderTracking) TrackOrderWebSocket(c echo.Context, orderID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	updates, release, err := t.subscribe(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Origin не проверяем, CORS открыт для всех
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		// Клиент ничего не присылает, читаем только чтобы заметить закрытие соединения
+		go func() {
+			defer cancel()
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+		}()
+
+		ticker := time.NewTicker(t.cfg.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			var err error
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				err = websocket.JSON.Send(ws, servers.TrackingHeartbeat{Type: heartbeatMessageType, At: now.UTC()})
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				err = websocket.JSON.Send(ws, toOrderTrackingUpdate(update))
+			}
+			if err != nil {
+				return
+			}
+		}
+	}}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// subscribe - занять соединение из лимита и подписаться на заказ.
+// Ошибки возвращаются как echo.HTTPError с problem details, чтобы ответить до начала потока
+func (t *OrderTracking) subscribe(ctx context.Context, orderID uuid.UUID) (<-chan queries.OrderTrackingResponse, func(), error) {
+	query, err := queries.NewTrackOrderQuery(orderID)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	if !t.limiter.Acquire() {
+		return nil, nil, echo.NewHTTPError(http.StatusServiceUnavailable,
+			problems.NewServiceUnavailable("too many tracking connections"))
+	}
+	release := t.limiter.Release
+
+	updates, err := t.trackOrderQueryHandler.Handle(ctx, query)
+	if err != nil {
+		release()
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return nil, nil, echo.NewHTTPError(http.StatusNotFound, problems.NewNotFound(err.Error()))
+		}
+		return nil, nil, err
+	}
+	return updates, release, nil
+}
+
+func toOrderTrackingUpdate(response queries.OrderTrackingResponse) servers.OrderTrackingUpdate {
+	update := servers.OrderTrackingUpdate{
+		Type:       trackingMessageType,
+		OrderId:    response.OrderID,
+		Status:     string(response.Status),
+		CourierId:  response.CourierID,
+		OccurredAt: response.OccurredAt,
+	}
+	if response.CourierLocation != nil {
+		location := toLocation(*response.CourierLocation)
+		update.CourierLocation = &location
+	}
+	if response.ETA != nil {
+		seconds := int(response.ETA.Round(time.Second) / time.Second)
+		update.EtaSeconds = &seconds
+	}
+	return update
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/connlimit"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/eventbus"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+func setupOrderTrackingTest(t *testing.T, limiter *connlimit.Limiter, cfg OrderTrackingConfig) (*httptest.Server, uuid.UUID) {
+	storage := memory.NewStorage()
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""),
+		kernel.MustNewLocation(1, 4))
+	require.NoError(t, orderRepository.Add(context.Background(), orderAggregate))
+
+	trackHandler, err := queries.NewTrackOrderQueryHandler(orderRepository, courierRepository, eventbus.New(16),
+		map[kernel.RegionCode]queries.TrackingRegion{
+			kernel.DefaultRegion(): {Router: routing.Direct{}, Profiles: &courier.Profiles{}, StepInterval: 2 * time.Second},
+		})
+	require.NoError(t, err)
+	orderTracking, err := NewOrderTracking(trackHandler, limiter, cfg)
+	require.NoError(t, err)
+
+	e := echo.New()
+	servers.RegisterHandlers(e, &Server{OrderTracking: orderTracking})
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server, orderAggregate.ID()
+}
+
+func Test_OrderTrackingSSEShouldStreamSnapshotAndHeartbeats(t *testing.T) {
+	server, orderID := setupOrderTrackingTest(t, connlimit.New(1), OrderTrackingConfig{HeartbeatInterval: 20 * time.Millisecond})
+
+	resp, err := http.Get(server.URL + "/api/v1/orders/" + orderID.String() + "/track")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+	reader := bufio.NewReader(resp.Body)
+	readLine := func() string {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		return strings.TrimSuffix(line, "\n")
+	}
+
+	assert.Equal(t, "event: tracking", readLine())
+	var update servers.OrderTrackingUpdate
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(readLine(), "data: ")), &update))
+	assert.Equal(t, orderID, update.OrderId)
+	assert.Equal(t, string(order.StatusCreated), update.Status)
+	assert.Equal(t, "", readLine())
+
+	assert.Equal(t, ": heartbeat", readLine())
+}
+
+func Test_OrderTrackingWebSocketShouldStreamSnapshotAndHeartbeats(t *testing.T) {
+	server, orderID := setupOrderTrackingTest(t, connlimit.New(1), OrderTrackingConfig{HeartbeatInterval: 20 * time.Millisecond})
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/orders/" + orderID.String() + "/track/ws"
+	ws, err := websocket.Dial(wsURL, "", server.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	var update servers.OrderTrackingUpdate
+	require.NoError(t, websocket.JSON.Receive(ws, &update))
+	assert.Equal(t, trackingMessageType, update.Type)
+	assert.Equal(t, orderID, update.OrderId)
+
+	var message servers.TrackingHeartbeat
+	require.NoError(t, websocket.JSON.Receive(ws, &message))
+	assert.Equal(t, heartbeatMessageType, message.Type)
+}
+
+func Test_OrderTrackingShouldRejectUnknownOrderAndLimitConnections(t *testing.T) {
+	limiter := connlimit.New(1)
+	server, orderID := setupOrderTrackingTest(t, limiter, OrderTrackingConfig{HeartbeatInterval: time.Second})
+
+	resp, err := http.Get(server.URL + "/api/v1/orders/" + uuid.NewString() + "/track")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/api/v1/orders/not-a-uuid/track")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	first, err := http.Get(server.URL + "/api/v1/orders/" + orderID.String() + "/track")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, first.StatusCode)
+
+	second, err := http.Get(server.URL + "/api/v1/orders/" + orderID.String() + "/track")
+	require.NoError(t, err)
+	second.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, second.StatusCode)
+
+	// После отключения клиента место освобождается
+	first.Body.Close()
+	require.Eventually(t, func() bool { return limiter.Open() == 0 }, time.Second, 10*time.Millisecond)
+}
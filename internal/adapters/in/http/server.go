@@ -4,20 +4,49 @@ import (
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
 )
 
+var _ servers.ServerInterface = &Server{}
+
+// Server - обработчики OpenAPI контракта, остальные операции контракта реализуют встроенные группы
 type Server struct {
+	*Couriers
+	*Zones
+	*Webhooks
+	*OrderCancellation
+	*OrderSLA
+	*OrderReassignment
+	*GeoCacheAdmin
+	*OrderTracking
+
+	// courierLocations - nil, если движение курьеров симулируется
+	courierLocations *CourierLocations
+
 	createOrderCommandHandler *commands.CreateOrderCommandHandler
 
-	getAllCouriersQueryHandler        *queries.GetAllCouriersQueryHandler
-	getNotCompletedOrdersQueryHandler *queries.GetNotCompletedOrdersQueryHandler
+	getAllCouriersQueryHandler        queries.GetAllCouriersQueryHandler
+	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler
+
+	// geoCoordinates - сервис работает в координатах WGS84, фильтр bbox задаётся в градусах
+	geoCoordinates bool
 }
 
 func NewServer(
 	createOrderCommandHandler *commands.CreateOrderCommandHandler,
 
-	getAllCouriersQueryHandler *queries.GetAllCouriersQueryHandler,
-	getNotCompletedOrdersQueryHandler *queries.GetNotCompletedOrdersQueryHandler,
+	getAllCouriersQueryHandler queries.GetAllCouriersQueryHandler,
+	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler,
+	geoCoordinates bool,
+	couriers *Couriers,
+	zones *Zones,
+	webhooks *Webhooks,
+	orderCancellation *OrderCancellation,
+	orderSLA *OrderSLA,
+	orderReassignment *OrderReassignment,
+	geoCacheAdmin *GeoCacheAdmin,
+	orderTracking *OrderTracking,
+	courierLocations *CourierLocations,
 ) (*Server, error) {
 	if createOrderCommandHandler == nil {
 		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
@@ -28,10 +57,45 @@ func NewServer(
 	if getNotCompletedOrdersQueryHandler == nil {
 		return nil, errs.NewValueIsRequiredError("getNotCompletedOrdersQueryHandler")
 	}
+	if couriers == nil {
+		return nil, errs.NewValueIsRequiredError("couriers")
+	}
+	if zones == nil {
+		return nil, errs.NewValueIsRequiredError("zones")
+	}
+	if webhooks == nil {
+		return nil, errs.NewValueIsRequiredError("webhooks")
+	}
+	if orderCancellation == nil {
+		return nil, errs.NewValueIsRequiredError("orderCancellation")
+	}
+	if orderSLA == nil {
+		return nil, errs.NewValueIsRequiredError("orderSLA")
+	}
+	if orderReassignment == nil {
+		return nil, errs.NewValueIsRequiredError("orderReassignment")
+	}
+	if geoCacheAdmin == nil {
+		return nil, errs.NewValueIsRequiredError("geoCacheAdmin")
+	}
+	if orderTracking == nil {
+		return nil, errs.NewValueIsRequiredError("orderTracking")
+	}
 	return &Server{
+		Couriers:          couriers,
+		Zones:             zones,
+		Webhooks:          webhooks,
+		OrderCancellation: orderCancellation,
+		OrderSLA:          orderSLA,
+		OrderReassignment: orderReassignment,
+		GeoCacheAdmin:     geoCacheAdmin,
+		OrderTracking:     orderTracking,
+		courierLocations:  courierLocations,
+
 		createOrderCommandHandler: createOrderCommandHandler,
 
 		getAllCouriersQueryHandler:        getAllCouriersQueryHandler,
 		getNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
+		geoCoordinates:                    geoCoordinates,
 	}, nil
 }
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// Webhooks - управление подписками на вебхуки
+type Webhooks struct {
+	createSubscriptionCommandHandler *commands.CreateWebhookSubscriptionCommandHandler
+	deleteSubscriptionCommandHandler *commands.DeleteWebhookSubscriptionCommandHandler
+	sendTestWebhookCommandHandler    *commands.SendTestWebhookCommandHandler
+	getSubscriptionsQueryHandler     *queries.GetWebhookSubscriptionsQueryHandler
+	getDeliveriesQueryHandler        *queries.GetWebhookDeliveriesQueryHandler
+}
+
+func NewWebhooks(
+	createSubscriptionCommandHandler *commands.CreateWebhookSubscriptionCommandHandler,
+	deleteSubscriptionCommandHandler *commands.DeleteWebhookSubscriptionCommandHandler,
+	sendTestWebhookCommandHandler *commands.SendTestWebhookCommandHandler,
+	getSubscriptionsQueryHandler *queries.GetWebhookSubscriptionsQueryHandler,
+	getDeliveriesQueryHandler *queries.GetWebhookDeliveriesQueryHandler,
+) (*Webhooks, error) {
+	if createSubscriptionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createSubscriptionCommandHandler")
+	}
+	if deleteSubscriptionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deleteSubscriptionCommandHandler")
+	}
+	if sendTestWebhookCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("sendTestWebhookCommandHandler")
+	}
+	if getSubscriptionsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getSubscriptionsQueryHandler")
+	}
+	if getDeliveriesQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getDeliveriesQueryHandler")
+	}
+	return &Webhooks{
+		createSubscriptionCommandHandler: createSubscriptionCommandHandler,
+		deleteSubscriptionCommandHandler: deleteSubscriptionCommandHandler,
+		sendTestWebhookCommandHandler:    sendTestWebhookCommandHandler,
+		getSubscriptionsQueryHandler:     getSubscriptionsQueryHandler,
+		getDeliveriesQueryHandler:        getDeliveriesQueryHandler,
+	}, nil
+}
+
+func (w *Webhooks) CreateWebhookSubscription(c echo.Context) error {
+	var request servers.CreateWebhookSubscriptionJSONRequestBody
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	eventTypes := make([]webhook.EventType, 0, len(request.EventTypes))
+	for _, eventType := range request.EventTypes {
+		eventTypes = append(eventTypes, webhook.EventType(eventType))
+	}
+	subscriptionID := uuid.New()
+	command, err := commands.NewCreateWebhookSubscriptionCommand(subscriptionID, request.Url, request.Secret, eventTypes)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = w.createSubscriptionCommandHandler.Handle(c.Request().Context(), command)
+	if isInvalidSubscription(err) {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, servers.WebhookSubscriptionCreated{Id: subscriptionID})
+}
+
+func (w *Webhooks) GetWebhookSubscriptions(c echo.Context) error {
+	query, err := queries.NewGetWebhookSubscriptionsQuery()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	response, err := w.getSubscriptionsQueryHandler.Handle(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make([]servers.WebhookSubscription, 0, len(response.Subscriptions))
+	for _, subscription := range response.Subscriptions {
+		subscriptions = append(subscriptions, servers.WebhookSubscription{
+			Id:         subscription.ID,
+			Url:        subscription.URL,
+			EventTypes: subscription.EventTypes,
+			CreatedAt:  subscription.CreatedAt,
+		})
+	}
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+func (w *Webhooks) DeleteWebhookSubscription(c echo.Context, subscriptionID uuid.UUID) error {
+	command, err := commands.NewDeleteWebhookSubscriptionCommand(subscriptionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = w.deleteSubscriptionCommandHandler.Handle(c.Request().Context(), command)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (w *Webhooks) GetWebhookDeliveries(c echo.Context, subscriptionID uuid.UUID) error {
+	query, err := queries.NewGetWebhookDeliveriesQuery(subscriptionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	response, err := w.getDeliveriesQueryHandler.Handle(c.Request().Context(), query)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]servers.WebhookDelivery, 0, len(response.Deliveries))
+	for _, delivery := range response.Deliveries {
+		deliveries = append(deliveries, toWebhookDelivery(delivery))
+	}
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// SendTestWebhook - отправить проверочное событие и вернуть результат попытки. Неудачная доставка не ошибка запроса
+func (w *Webhooks) SendTestWebhook(c echo.Context, subscriptionID uuid.UUID) error {
+	command, err := commands.NewSendTestWebhookCommand(subscriptionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	delivery, err := w.sendTestWebhookCommandHandler.Handle(c.Request().Context(), command)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toWebhookDelivery(queries.NewWebhookDeliveryResponse(delivery)))
+}
+
+func toWebhookDelivery(delivery queries.WebhookDeliveryResponse) servers.WebhookDelivery {
+	result := servers.WebhookDelivery{
+		Id:            delivery.ID,
+		EventId:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+	if delivery.ResponseCode != 0 {
+		result.ResponseCode = &delivery.ResponseCode
+	}
+	if delivery.LastError != "" {
+		result.LastError = &delivery.LastError
+	}
+	return result
+}
+
+func isInvalidSubscription(err error) bool {
+	return errors.Is(err, webhook.ErrInvalidURL) ||
+		errors.Is(err, webhook.ErrInvalidSecret) ||
+		errors.Is(err, webhook.ErrInvalidEventTypes)
+}
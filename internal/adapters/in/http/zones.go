@@ -0,0 +1,226 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/http/problems"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	servers "github.com/IgorAleksandroff/delivery/pkg/servers"
+)
+
+// Zones - управление зонами доставки
+type Zones struct {
+	createZoneCommandHandler *commands.CreateZoneCommandHandler
+	updateZoneCommandHandler *commands.UpdateZoneCommandHandler
+	deleteZoneCommandHandler *commands.DeleteZoneCommandHandler
+	getZonesQueryHandler     *queries.GetZonesQueryHandler
+}
+
+func NewZones(
+	createZoneCommandHandler *commands.CreateZoneCommandHandler,
+	updateZoneCommandHandler *commands.UpdateZoneCommandHandler,
+	deleteZoneCommandHandler *commands.DeleteZoneCommandHandler,
+	getZonesQueryHandler *queries.GetZonesQueryHandler,
+) (*Zones, error) {
+	if createZoneCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createZoneCommandHandler")
+	}
+	if updateZoneCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("updateZoneCommandHandler")
+	}
+	if deleteZoneCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deleteZoneCommandHandler")
+	}
+	if getZonesQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getZonesQueryHandler")
+	}
+	return &Zones{
+		createZoneCommandHandler: createZoneCommandHandler,
+		updateZoneCommandHandler: updateZoneCommandHandler,
+		deleteZoneCommandHandler: deleteZoneCommandHandler,
+		getZonesQueryHandler:     getZonesQueryHandler,
+	}, nil
+}
+
+func (h *Zones) CreateZone(c echo.Context) error {
+	var request servers.CreateZoneJSONRequestBody
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	shape, err := toZoneShape(request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	regionCode, err := parseRegion(stringValue(request.Region))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	zoneID := uuid.New()
+	command, err := commands.NewCreateZoneCommand(zoneID, regionCode, request.Name, shape)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = h.createZoneCommandHandler.Handle(c.Request().Context(), command)
+	if isInvalidZone(err) {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, servers.ZoneCreated{Id: zoneID})
+}
+
+func (h *Zones) GetZones(c echo.Context, params servers.GetZonesParams) error {
+	regionCode, err := parseRegion(stringValue(params.Region))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	query, err := queries.NewGetZonesQuery(regionCode)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	response, err := h.getZonesQueryHandler.Handle(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	zones := make([]servers.Zone, 0, len(response.Zones))
+	for _, z := range response.Zones {
+		zones = append(zones, toZone(z))
+	}
+	return c.JSON(http.StatusOK, zones)
+}
+
+func (h *Zones) GetZone(c echo.Context, zoneID uuid.UUID) error {
+	query, err := queries.NewGetZoneQuery(zoneID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	response, err := h.getZonesQueryHandler.Handle(c.Request().Context(), query)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, toZone(response.Zones[0]))
+}
+
+func (h *Zones) UpdateZone(c echo.Context, zoneID uuid.UUID) error {
+	var request servers.UpdateZoneJSONRequestBody
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	shape, err := toZoneShape(request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	command, err := commands.NewUpdateZoneCommand(zoneID, request.Name, shape)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = h.updateZoneCommandHandler.Handle(c.Request().Context(), command)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if isInvalidZone(err) {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Zones) DeleteZone(c echo.Context, zoneID uuid.UUID) error {
+	command, err := commands.NewDeleteZoneCommand(zoneID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, problems.NewBadRequest(err.Error()))
+	}
+
+	err = h.deleteZoneCommandHandler.Handle(c.Request().Context(), command)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
+	}
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// toZoneShape - ровно одна из границ: cells или polygon
+func toZoneShape(request servers.ZoneRequest) (zone.Shape, error) {
+	var polygon []servers.Location
+	if request.Polygon != nil {
+		polygon = *request.Polygon
+	}
+	if (request.Cells == nil) == (len(polygon) == 0) {
+		return zone.Shape{}, zone.ErrInvalidShape
+	}
+	if request.Cells != nil {
+		cells, err := kernel.NewArea(request.Cells.MinX, request.Cells.MinY, request.Cells.MaxX, request.Cells.MaxY)
+		if err != nil {
+			return zone.Shape{}, err
+		}
+		return zone.NewCellsShape(cells)
+	}
+
+	vertices := make([]kernel.Location, 0, len(polygon))
+	for _, vertex := range polygon {
+		location, err := toKernelLocation(vertex)
+		if err != nil {
+			return zone.Shape{}, err
+		}
+		vertices = append(vertices, location)
+	}
+	return zone.NewPolygonShape(vertices)
+}
+
+func toZone(response queries.ZoneResponse) servers.Zone {
+	result := servers.Zone{
+		Id:         response.ID,
+		Region:     response.Region,
+		Name:       response.Name,
+		QueueDepth: response.QueueDepth,
+		CreatedAt:  response.CreatedAt,
+	}
+	if response.Cells != nil {
+		result.Cells = &servers.ZoneCells{
+			MinX: response.Cells.MinX, MinY: response.Cells.MinY, MaxX: response.Cells.MaxX, MaxY: response.Cells.MaxY,
+		}
+	}
+	if len(response.Polygon) > 0 {
+		polygon := make([]servers.Location, 0, len(response.Polygon))
+		for _, vertex := range response.Polygon {
+			polygon = append(polygon, toLocation(vertex))
+		}
+		result.Polygon = &polygon
+	}
+	return result
+}
+
+func isInvalidZone(err error) bool {
+	return errors.Is(err, zone.ErrInvalidZoneName) ||
+		errors.Is(err, region.ErrUnknownRegion) ||
+		errors.Is(err, zone.ErrInvalidShape) ||
+		errors.Is(err, kernel.ErrLocationOutOfArea)
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ cron.Job = &DeliverWebhooksJob{}
+
+type DeliverWebhooksJob struct {
+	deliverWebhooksCommandHandler *commands.DeliverWebhooksCommandHandler
+}
+
+func NewDeliverWebhooksJob(
+	deliverWebhooksCommandHandler *commands.DeliverWebhooksCommandHandler) (*DeliverWebhooksJob, error) {
+	if deliverWebhooksCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deliverWebhooksCommandHandler")
+	}
+
+	return &DeliverWebhooksJob{
+		deliverWebhooksCommandHandler: deliverWebhooksCommandHandler}, nil
+}
+
+func (j *DeliverWebhooksJob) Run() {
+	ctx := context.Background()
+	command, err := commands.NewDeliverWebhooksCommand()
+	if err != nil {
+		log.Error(err)
+	}
+	err = j.deliverWebhooksCommandHandler.Handle(ctx, command)
+	if err != nil {
+		log.Error(err)
+	}
+}
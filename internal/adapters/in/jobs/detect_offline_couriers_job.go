@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ cron.Job = &DetectOfflineCouriersJob{}
+
+type DetectOfflineCouriersJob struct {
+	detectOfflineCouriersCommandHandler *commands.DetectOfflineCouriersCommandHandler
+}
+
+func NewDetectOfflineCouriersJob(
+	detectOfflineCouriersCommandHandler *commands.DetectOfflineCouriersCommandHandler) (*DetectOfflineCouriersJob, error) {
+	if detectOfflineCouriersCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("detectOfflineCouriersCommandHandler")
+	}
+
+	return &DetectOfflineCouriersJob{
+		detectOfflineCouriersCommandHandler: detectOfflineCouriersCommandHandler}, nil
+}
+
+func (j *DetectOfflineCouriersJob) Run() {
+	ctx := context.Background()
+	command, err := commands.NewDetectOfflineCouriersCommand()
+	if err != nil {
+		log.Error(err)
+	}
+	err = j.detectOfflineCouriersCommandHandler.Handle(ctx, command)
+	if err != nil {
+		log.Error(err)
+	}
+}
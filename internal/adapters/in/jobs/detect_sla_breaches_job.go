@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ cron.Job = &DetectSLABreachesJob{}
+
+type DetectSLABreachesJob struct {
+	detectSLABreachesCommandHandler *commands.DetectSLABreachesCommandHandler
+}
+
+func NewDetectSLABreachesJob(
+	detectSLABreachesCommandHandler *commands.DetectSLABreachesCommandHandler) (*DetectSLABreachesJob, error) {
+	if detectSLABreachesCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("detectSLABreachesCommandHandler")
+	}
+
+	return &DetectSLABreachesJob{
+		detectSLABreachesCommandHandler: detectSLABreachesCommandHandler}, nil
+}
+
+func (j *DetectSLABreachesJob) Run() {
+	ctx := context.Background()
+	command, err := commands.NewDetectSLABreachesCommand()
+	if err != nil {
+		log.Error(err)
+	}
+	err = j.detectSLABreachesCommandHandler.Handle(ctx, command)
+	if err != nil {
+		log.Error(err)
+	}
+}
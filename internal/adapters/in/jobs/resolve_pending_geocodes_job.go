@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ cron.Job = &ResolvePendingGeocodesJob{}
+
+type ResolvePendingGeocodesJob struct {
+	resolvePendingGeocodesCommandHandler *commands.ResolvePendingGeocodesCommandHandler
+}
+
+func NewResolvePendingGeocodesJob(
+	resolvePendingGeocodesCommandHandler *commands.ResolvePendingGeocodesCommandHandler) (*ResolvePendingGeocodesJob, error) {
+	if resolvePendingGeocodesCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("resolvePendingGeocodesCommandHandler")
+	}
+
+	return &ResolvePendingGeocodesJob{
+		resolvePendingGeocodesCommandHandler: resolvePendingGeocodesCommandHandler}, nil
+}
+
+func (j *ResolvePendingGeocodesJob) Run() {
+	ctx := context.Background()
+	command, err := commands.NewResolvePendingGeocodesCommand()
+	if err != nil {
+		log.Error(err)
+	}
+	err = j.resolvePendingGeocodesCommandHandler.Handle(ctx, command)
+	if err != nil {
+		log.Error(err)
+	}
+}
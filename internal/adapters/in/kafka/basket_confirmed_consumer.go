@@ -2,103 +2,372 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/in/kafka/pool"
 	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/codec"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 	"github.com/IgorAleksandroff/delivery/pkg/clients/queues/queues/basketconfirmedpb"
-	"github.com/confluentinc/confluent-kafka-go/kafka"
-	"github.com/google/uuid"
-	"github.com/labstack/gommon/log"
-	"time"
 )
 
+// Заголовки сообщения в DLQ
+const (
+	HeaderDeadLetterError     = "x-error"
+	HeaderDeadLetterTopic     = "x-original-topic"
+	HeaderDeadLetterPartition = "x-original-partition"
+	HeaderDeadLetterOffset    = "x-original-offset"
+	HeaderDeadLetterAttempts  = "x-attempts"
+)
+
+// ConsumerConfig - параллельная обработка: сообщения одной корзины идут к одному воркеру по порядку
+type ConsumerConfig struct {
+	Workers        int
+	QueueSize      int
+	HandleTimeout  time.Duration
+	CommitInterval time.Duration
+	// RevokeTimeout - сколько ждать обработки отзываемых при ребалансе партиций
+	RevokeTimeout time.Duration
+
+	// MaxAttempts - попыток обработать сообщение, прежде чем отправить его в DeadLetterTopic
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	DeadLetterTopic string
+}
+
+func DefaultConsumerConfig() ConsumerConfig {
+	return ConsumerConfig{
+		Workers:        8,
+		QueueSize:      64,
+		HandleTimeout:  5 * time.Second,
+		CommitInterval: time.Second,
+		RevokeTimeout:  30 * time.Second,
+		MaxAttempts:    3,
+		RetryBackoff:   200 * time.Millisecond,
+	}
+}
+
 type BasketConfirmedConsumer struct {
 	topic                     string
-	consumer                  *kafka.Consumer
+	consumer                  ports.MessageConsumer
+	deadLetters               ports.MessagePublisher
+	decoder                   *codec.Selector
 	createOrderCommandHandler *commands.CreateOrderCommandHandler
+
+	cfg     ConsumerConfig
+	workers *pool.Pool
+	offsets *pool.OffsetTracker
+
+	// ctx - отменяется в Close, прерывает декодирование, которое ждёт внешние сервисы
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stop    chan struct{}
+	stopped chan struct{}
 }
 
-func NewBasketConfirmedConsumer(host string, group string, topic string,
+func NewBasketConfirmedConsumer(consumer ports.MessageConsumer, deadLetters ports.MessagePublisher, topic string,
+	cfg ConsumerConfig, decoder *codec.Selector,
 	handler *commands.CreateOrderCommandHandler) (*BasketConfirmedConsumer, error) {
-	if host == "" {
-		return nil, errs.NewValueIsRequiredError("host")
+	if consumer == nil {
+		return nil, errs.NewValueIsRequiredError("consumer")
 	}
-	if group == "" {
-		return nil, errs.NewValueIsRequiredError("group")
+	if deadLetters == nil {
+		return nil, errs.NewValueIsRequiredError("deadLetters")
 	}
 	if topic == "" {
 		return nil, errs.NewValueIsRequiredError("topic")
 	}
+	if decoder == nil {
+		return nil, errs.NewValueIsRequiredError("decoder")
+	}
 	if handler == nil {
 		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
 	}
+	if cfg.HandleTimeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("handleTimeout")
+	}
+	if cfg.CommitInterval <= 0 {
+		return nil, errs.NewValueIsInvalidError("commitInterval")
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, errs.NewValueIsInvalidError("maxAttempts")
+	}
+	if cfg.DeadLetterTopic == "" {
+		return nil, errs.NewValueIsRequiredError("deadLetterTopic")
+	}
 
-	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers":  host,
-		"group.id":           group,
-		"enable.auto.commit": false,
-		"auto.offset.reset":  "earliest",
-	})
+	workers, err := pool.New(cfg.Workers, cfg.QueueSize)
 	if err != nil {
-		log.Fatalf("Failed to create consumer: %v", err)
+		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	return &BasketConfirmedConsumer{
 		topic:                     topic,
 		consumer:                  consumer,
+		deadLetters:               deadLetters,
+		decoder:                   decoder,
 		createOrderCommandHandler: handler,
-	}, err
+		cfg:                       cfg,
+		workers:                   workers,
+		offsets:                   pool.NewOffsetTracker(),
+		ctx:                       ctx,
+		cancel:                    cancel,
+		stop:                      make(chan struct{}),
+		stopped:                   make(chan struct{}),
+	}, nil
 }
 
+// Close - остановить чтение, дообработать принятые сообщения и зафиксировать offset
 func (c *BasketConfirmedConsumer) Close() error {
+	c.cancel()
+	close(c.stop)
+	<-c.stopped
 	return c.consumer.Close()
 }
 
 func (c *BasketConfirmedConsumer) Consume() error {
-	err := c.consumer.Subscribe(c.topic, nil)
+	defer close(c.stopped)
+
+	err := c.consumer.Subscribe(c.topic, c.revoke)
 	if err != nil {
-		log.Fatalf("Failed to subscribe to topic: %s", err)
+		return err
 	}
 
+	lastCommit := time.Now()
 	for {
+		select {
+		case <-c.stop:
+			c.workers.Close()
+			c.commit()
+			return nil
+		default:
+		}
+
 		c.consume()
+
+		if time.Since(lastCommit) >= c.cfg.CommitInterval {
+			c.commit()
+			lastCommit = time.Now()
+		}
 	}
 }
 
 func (c *BasketConfirmedConsumer) consume() {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	msg, err := c.consumer.Poll(100 * time.Millisecond)
+	if err != nil {
+		log.Printf("Consumer error: %v", err)
+		return
+	}
+	if msg == nil {
+		return
+	}
+
+	c.offsets.Start(msg.Partition, msg.Offset)
+
+	decodeCtx, cancel := context.WithTimeout(c.ctx, c.cfg.HandleTimeout)
 	defer cancel()
+	createOrderCommand, err := newCreateOrderCommand(decodeCtx, c.decoder, msg.Headers[codec.HeaderContentType],
+		msg.Value)
+	if err != nil && c.ctx.Err() != nil {
+		// Остановка: сообщение не обработано и offset не фиксируется, его прочитают после перезапуска
+		log.Printf("Message %s/%d/%d is left unprocessed on shutdown: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		return
+	}
+	if err != nil {
+		// Битое сообщение не исправится повторной обработкой, сразу в DLQ
+		log.Printf("Invalid message %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		c.deadLetter(msg, err, 0)
+		c.offsets.Done(msg.Partition, msg.Offset)
+		return
+	}
 
-	msg, err := c.consumer.ReadMessage(-1)
+	// Порядок важен только внутри корзины
+	key := createOrderCommand.OrderID().String()
+	err = c.workers.Submit(context.Background(), key, func() {
+		defer c.offsets.Done(msg.Partition, msg.Offset)
+		c.handle(msg, createOrderCommand)
+	})
 	if err != nil {
-		fmt.Printf("Consumer error: %v (%v)\n", err, msg)
+		log.Printf("Failed to submit message %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		c.offsets.Done(msg.Partition, msg.Offset)
+	}
+}
+
+// handle - обработать с повторами, после исчерпания попыток отправить в DLQ
+func (c *BasketConfirmedConsumer) handle(msg *ports.Message, createOrderCommand commands.CreateOrderCommand) {
+	var err error
+	for attempt := 1; attempt <= c.cfg.MaxAttempts; attempt++ {
+		err = c.handleOnce(createOrderCommand)
+		if err == nil || errors.Is(err, commands.OrderAlreadyExists) {
+			// Повторная доставка того же события - не ошибка
+			return
+		}
+		log.Printf("Failed to handle createOrder command, attempt %d/%d: %v", attempt, c.cfg.MaxAttempts, err)
+		if attempt < c.cfg.MaxAttempts {
+			time.Sleep(c.cfg.RetryBackoff * time.Duration(attempt))
+		}
 	}
+	c.deadLetter(msg, err, c.cfg.MaxAttempts)
+}
+
+func (c *BasketConfirmedConsumer) handleOnce(createOrderCommand commands.CreateOrderCommand) error {
+	// Время на обработку отсчитывается от начала попытки, а не от чтения
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.HandleTimeout)
+	defer cancel()
+	return c.createOrderCommandHandler.Handle(ctx, createOrderCommand)
+}
 
-	// Обрабатываем сообщение
-	fmt.Printf("Received: %s => %s\n", msg.TopicPartition, string(msg.Value))
+func (c *BasketConfirmedConsumer) deadLetter(msg *ports.Message, cause error, attempts int) {
+	headers := make(map[string]string, len(msg.Headers)+5)
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[HeaderDeadLetterError] = cause.Error()
+	headers[HeaderDeadLetterTopic] = msg.Topic
+	headers[HeaderDeadLetterPartition] = strconv.Itoa(int(msg.Partition))
+	headers[HeaderDeadLetterOffset] = strconv.FormatInt(msg.Offset, 10)
+	headers[HeaderDeadLetterAttempts] = strconv.Itoa(attempts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.HandleTimeout)
+	defer cancel()
+	err := c.deadLetters.Publish(ctx, ports.Message{
+		Topic:   c.cfg.DeadLetterTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		// Сообщение потеряно для повторной обработки, остаётся только лог
+		log.Errorf("Failed to publish message %s/%d/%d to %s: %v",
+			msg.Topic, msg.Partition, msg.Offset, c.cfg.DeadLetterTopic, err)
+	}
+}
+
+// commit - зафиксировать offset до самого раннего необработанного сообщения каждой партиции
+func (c *BasketConfirmedConsumer) commit() {
+	commits := c.offsets.Committable()
+	if len(commits) == 0 {
+		return
+	}
+
+	offsets := make(map[int32]int64, len(commits))
+	for _, commit := range commits {
+		offsets[commit.Partition] = commit.Offset
+	}
+
+	err := c.consumer.Commit(c.topic, offsets)
+	if err != nil {
+		log.Printf("Commit failed: %v", err)
+		return
+	}
+	c.offsets.MarkCommitted(commits)
+}
+
+// revoke - перед отзывом партиций дообработать их сообщения и зафиксировать offset,
+// иначе новый владелец партиции обработает их повторно
+func (c *BasketConfirmedConsumer) revoke(partitions []int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.RevokeTimeout)
+	defer cancel()
+	err := c.offsets.WaitIdle(ctx, partitions)
+	if err != nil {
+		log.Printf("Revoked partitions %v are still in progress: %v", partitions, err)
+	}
+
+	c.commit()
+	c.offsets.Forget(partitions)
+}
+
+// newCreateOrderCommand - декодировать и строго проверить событие, затем собрать команду
+func newCreateOrderCommand(ctx context.Context, decoder *codec.Selector, contentType string,
+	value []byte) (commands.CreateOrderCommand, error) {
 	var event basketconfirmedpb.BasketConfirmedIntegrationEvent
-	err = json.Unmarshal(msg.Value, &event)
+	err := decoder.Decode(ctx, contentType, value, &event)
 	if err != nil {
-		log.Printf("Failed to unmarshal message: %v", err)
+		return commands.CreateOrderCommand{}, fmt.Errorf("decode: %w", err)
 	}
 
-	// Отправляем команду
-	createOrderCommand, err := commands.NewCreateOrderCommand(
-		createOrderID(event.BasketId), event.GetAddress().GetStreet())
+	err = validateBasketConfirmed(&event)
 	if err != nil {
-		log.Printf("Failed to create changeStocks command: %v", err)
+		return commands.CreateOrderCommand{}, err
 	}
-	err = c.createOrderCommandHandler.Handle(ctx, createOrderCommand)
+
+	eventAddress := event.GetAddress()
+	address, err := kernel.NewAddress(eventAddress.GetCountry(), eventAddress.GetCity(),
+		eventAddress.GetStreet(), eventAddress.GetHouse(), eventAddress.GetApartment())
 	if err != nil {
-		log.Printf("Failed to handle createOrder command: %v", err)
+		return commands.CreateOrderCommand{}, err
 	}
 
-	// Подтверждаем обработку сообщения
-	_, err = c.consumer.CommitMessage(msg)
+	tier, err := basketTier(&event)
 	if err != nil {
-		log.Printf("Commit failed: %v", err)
+		return commands.CreateOrderCommand{}, err
+	}
+
+	// Интервал корзины нужен только доставке к интервалу
+	var window order.DeliveryWindow
+	if tier == order.TierScheduled {
+		window, err = deliveryWindow(event.GetDeliveryPeriod(), time.Now())
+		if err != nil {
+			return commands.CreateOrderCommand{}, err
+		}
+	}
+
+	return commands.NewCreateOrderCommandWithTier(createOrderID(event.GetBasketId()), address, tier, window)
+}
+
+// basketTier - уровень из события, без него корзина с интервалом доставки едет к интервалу
+func basketTier(event *basketconfirmedpb.BasketConfirmedIntegrationEvent) (order.Tier, error) {
+	if event.GetDeliveryTier() != "" {
+		return order.ParseTier(event.GetDeliveryTier())
+	}
+	period := event.GetDeliveryPeriod()
+	if period.GetFrom() != 0 || period.GetTo() != 0 {
+		return order.TierScheduled, nil
+	}
+	return order.TierStandard, nil
+}
+
+// deliveryWindow - часы from..to по местному времени в ближайший день, когда интервал ещё не закончился
+func deliveryWindow(period *basketconfirmedpb.DeliveryPeriod, now time.Time) (order.DeliveryWindow, error) {
+	if period == nil {
+		return order.DeliveryWindow{}, errs.NewValueIsRequiredError("deliveryPeriod")
+	}
+	from, to := int(period.GetFrom()), int(period.GetTo())
+	if from < 0 || to > 24 || from >= to {
+		return order.DeliveryWindow{}, errs.NewValueIsInvalidError("deliveryPeriod")
+	}
+
+	now = now.Local()
+	year, month, day := now.Date()
+	end := time.Date(year, month, day, to, 0, 0, 0, now.Location())
+	if !end.After(now) {
+		day++
+		end = time.Date(year, month, day, to, 0, 0, 0, now.Location())
+	}
+	return order.NewDeliveryWindow(time.Date(year, month, day, from, 0, 0, 0, now.Location()), end)
+}
+
+func validateBasketConfirmed(event *basketconfirmedpb.BasketConfirmedIntegrationEvent) error {
+	var errList []error
+	if _, err := uuid.Parse(event.GetBasketId()); err != nil {
+		errList = append(errList, errs.NewValueIsInvalidError("basketId"))
+	}
+	if event.GetAddress() == nil {
+		errList = append(errList, errs.NewValueIsRequiredError("address"))
+	} else if event.GetAddress().GetStreet() == "" {
+		errList = append(errList, errs.NewValueIsRequiredError("address.street"))
 	}
+	return errors.Join(errList...)
 }
 
 func createOrderID(basketID string) uuid.UUID {
@@ -0,0 +1,264 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/codec"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/eventbus"
+	"github.com/IgorAleksandroff/delivery/pkg/clients/queues/queues/basketconfirmedpb"
+)
+
+func Test_NewCreateOrderCommandShouldValidateEvent(t *testing.T) {
+	decoder := codec.NewSelector(codec.NewSchemaRegistryDecoder(nil, ""))
+
+	testCases := []struct {
+		name         string
+		event        *basketconfirmedpb.BasketConfirmedIntegrationEvent
+		expectedTier order.Tier
+		expectError  bool
+	}{
+		{
+			name: "Valid event",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId: "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:  &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+			},
+			expectedTier: order.TierStandard,
+		},
+		{
+			name: "Express tier",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId:     "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:      &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+				DeliveryTier: "Express",
+			},
+			expectedTier: order.TierExpress,
+		},
+		{
+			name: "Delivery period without tier is scheduled",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId:       "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:        &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+				DeliveryPeriod: &basketconfirmedpb.DeliveryPeriod{From: 9, To: 12},
+			},
+			expectedTier: order.TierScheduled,
+		},
+		{
+			name: "Scheduled tier without delivery period",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId:     "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:      &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+				DeliveryTier: "scheduled",
+			},
+			expectError: true,
+		},
+		{
+			name: "Empty delivery period",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId:       "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:        &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+				DeliveryPeriod: &basketconfirmedpb.DeliveryPeriod{From: 12, To: 9},
+			},
+			expectError: true,
+		},
+		{
+			name: "Unknown tier",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId:     "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:      &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+				DeliveryTier: "overnight",
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid basket id",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId: "not-a-uuid",
+				Address:  &basketconfirmedpb.Address{Street: "Бажная"},
+			},
+			expectError: true,
+		},
+		{
+			name:        "Missing address",
+			event:       &basketconfirmedpb.BasketConfirmedIntegrationEvent{BasketId: "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f"},
+			expectError: true,
+		},
+		{
+			name: "Missing street",
+			event: &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+				BasketId: "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+				Address:  &basketconfirmedpb.Address{City: "Москва"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := proto.Marshal(tc.event)
+			require.NoError(t, err)
+
+			command, err := newCreateOrderCommand(context.Background(), decoder, codec.ContentTypeProtobuf, value)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Россия, Москва, Бажная, 1", command.Address().String())
+			assert.Equal(t, tc.expectedTier, command.Tier())
+			assert.Equal(t, tc.expectedTier == order.TierScheduled, !command.DeliveryWindow().IsEmpty())
+		})
+	}
+}
+
+func Test_DeliveryWindowShouldStartAtNextOccurrenceOfPeriod(t *testing.T) {
+	period := &basketconfirmedpb.DeliveryPeriod{From: 9, To: 12}
+	morning := time.Date(2025, 1, 1, 10, 30, 0, 0, time.Local)
+
+	// Интервал уже идёт - сегодня
+	window, err := deliveryWindow(period, morning)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 9, 0, 0, 0, time.Local), window.From().Local())
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local), window.To().Local())
+
+	// Интервал закончился - завтра
+	window, err = deliveryWindow(period, morning.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 2, 9, 0, 0, 0, time.Local), window.From().Local())
+	assert.Equal(t, time.Date(2025, 1, 2, 12, 0, 0, 0, time.Local), window.To().Local())
+}
+
+type unavailableGeoClient struct{}
+
+func (s *unavailableGeoClient) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	return kernel.Location{}, ports.ErrGeoServiceUnavailable
+}
+
+const (
+	testTopic           = "basket.confirmed"
+	testDeadLetterTopic = "basket.confirmed.dlq"
+)
+
+func setupConsumerTest(t *testing.T, geoClient ports.GeoClient) (*memory.Broker, *memory.OrderRepository) {
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	regions, err := region.NewSingleRegionCatalog(kernel.DefaultArea())
+	require.NoError(t, err)
+	outbox, err := commands.NewEnqueueWebhooksEventHandler(memory.NewWebhookSubscriptionRepository(),
+		memory.NewWebhookDeliveryRepository())
+	require.NoError(t, err)
+	handler, err := commands.NewCreateOrderCommandHandler(unitOfWork, orderRepository, geoClient, regions,
+		eventbus.New(16), outbox, false)
+	require.NoError(t, err)
+
+	cfg := DefaultConsumerConfig()
+	cfg.Workers = 2
+	cfg.CommitInterval = 10 * time.Millisecond
+	cfg.RetryBackoff = time.Millisecond
+	cfg.DeadLetterTopic = testDeadLetterTopic
+
+	broker := memory.NewBroker(2)
+	consumer, err := NewBasketConfirmedConsumer(broker.NewConsumer("delivery"), broker.NewPublisher(),
+		testTopic, cfg, codec.NewSelector(codec.NewSchemaRegistryDecoder(nil, "")), handler)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- consumer.Consume() }()
+	t.Cleanup(func() {
+		require.NoError(t, consumer.Close())
+		require.NoError(t, <-done)
+	})
+	return broker, orderRepository
+}
+
+func publishBasketConfirmed(t *testing.T, broker *memory.Broker, basketID string, value []byte) {
+	err := broker.NewPublisher().Publish(context.Background(), ports.Message{
+		Topic:   testTopic,
+		Key:     []byte(basketID),
+		Value:   value,
+		Headers: map[string]string{codec.HeaderContentType: codec.ContentTypeProtobuf},
+	})
+	require.NoError(t, err)
+}
+
+func basketConfirmed(t *testing.T, basketID string) []byte {
+	value, err := proto.Marshal(&basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: basketID,
+		Address:  &basketconfirmedpb.Address{Country: "Россия", City: "Москва", Street: "Бажная", House: "1"},
+	})
+	require.NoError(t, err)
+	return value
+}
+
+func committed(broker *memory.Broker) int64 {
+	var total int64
+	for partition := range int32(2) {
+		if offset := broker.Committed("delivery", testTopic, partition); offset > 0 {
+			total += offset
+		}
+	}
+	return total
+}
+
+func Test_ConsumerShouldCreateOrdersAndCommit(t *testing.T) {
+	broker, orderRepository := setupConsumerTest(t, memory.NewGeoClient(nil, kernel.DefaultArea()))
+
+	basketIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, basketID := range basketIDs {
+		publishBasketConfirmed(t, broker, basketID.String(), basketConfirmed(t, basketID.String()))
+	}
+	// Повторная доставка не считается ошибкой
+	publishBasketConfirmed(t, broker, basketIDs[0].String(), basketConfirmed(t, basketIDs[0].String()))
+
+	require.Eventually(t, func() bool { return committed(broker) == 4 }, time.Second, 10*time.Millisecond)
+	for _, basketID := range basketIDs {
+		_, err := orderRepository.Get(context.Background(), basketID)
+		assert.NoError(t, err)
+	}
+	assert.Empty(t, broker.Messages(testDeadLetterTopic))
+}
+
+func Test_ConsumerShouldSendFailedMessageToDeadLetterTopic(t *testing.T) {
+	broker, _ := setupConsumerTest(t, &unavailableGeoClient{})
+
+	basketID := uuid.New().String()
+	publishBasketConfirmed(t, broker, basketID, basketConfirmed(t, basketID))
+
+	require.Eventually(t, func() bool { return committed(broker) == 1 }, time.Second, 10*time.Millisecond)
+	deadLetters := broker.Messages(testDeadLetterTopic)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, basketID, string(deadLetters[0].Key))
+	assert.Equal(t, basketConfirmed(t, basketID), deadLetters[0].Value)
+	assert.Equal(t, codec.ContentTypeProtobuf, deadLetters[0].Headers[codec.HeaderContentType])
+	assert.Equal(t, testTopic, deadLetters[0].Headers[HeaderDeadLetterTopic])
+	assert.Equal(t, "0", deadLetters[0].Headers[HeaderDeadLetterOffset])
+	assert.Equal(t, "3", deadLetters[0].Headers[HeaderDeadLetterAttempts])
+	assert.Contains(t, deadLetters[0].Headers[HeaderDeadLetterError], ports.ErrGeoServiceUnavailable.Error())
+}
+
+func Test_ConsumerShouldSendInvalidMessageToDeadLetterTopicWithoutRetries(t *testing.T) {
+	broker, _ := setupConsumerTest(t, memory.NewGeoClient(nil, kernel.DefaultArea()))
+
+	publishBasketConfirmed(t, broker, "broken", []byte("not a protobuf"))
+
+	require.Eventually(t, func() bool { return committed(broker) == 1 }, time.Second, 10*time.Millisecond)
+	deadLetters := broker.Messages(testDeadLetterTopic)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "0", deadLetters[0].Headers[HeaderDeadLetterAttempts])
+}
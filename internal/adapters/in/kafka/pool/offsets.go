@@ -0,0 +1,138 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// Commit - offset, который можно зафиксировать: следующий к чтению после рестарта
+type Commit struct {
+	Partition int32
+	Offset    int64
+}
+
+// OffsetTracker - следит за сообщениями в обработке и считает, до какого
+// offset партиция обработана целиком. Сообщения завершаются в любом порядке,
+// а фиксировать можно только offset до самого раннего незавершённого.
+type OffsetTracker struct {
+	mu         sync.Mutex
+	idle       *sync.Cond
+	partitions map[int32]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	pending   map[int64]struct{}
+	next      int64
+	committed int64
+}
+
+func NewOffsetTracker() *OffsetTracker {
+	t := &OffsetTracker{partitions: make(map[int32]*partitionOffsets)}
+	t.idle = sync.NewCond(&t.mu)
+	return t
+}
+
+// Start - сообщение прочитано и ушло в обработку
+func (t *OffsetTracker) Start(partition int32, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.partitions[partition]
+	if !ok {
+		state = &partitionOffsets{pending: make(map[int64]struct{}), committed: -1}
+		t.partitions[partition] = state
+	}
+	state.pending[offset] = struct{}{}
+	if offset+1 > state.next {
+		state.next = offset + 1
+	}
+}
+
+// Done - обработка сообщения завершена, успешно или нет
+func (t *OffsetTracker) Done(partition int32, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.partitions[partition]
+	if !ok {
+		return
+	}
+	delete(state.pending, offset)
+	if len(state.pending) == 0 {
+		t.idle.Broadcast()
+	}
+}
+
+// Committable - offset по партициям, продвинувшимся с прошлого MarkCommitted
+func (t *OffsetTracker) Committable() []Commit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var commits []Commit
+	for partition, state := range t.partitions {
+		offset := state.committable()
+		if offset > state.committed {
+			commits = append(commits, Commit{Partition: partition, Offset: offset})
+		}
+	}
+	return commits
+}
+
+func (t *OffsetTracker) MarkCommitted(commits []Commit) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, commit := range commits {
+		if state, ok := t.partitions[commit.Partition]; ok && commit.Offset > state.committed {
+			state.committed = commit.Offset
+		}
+	}
+}
+
+// WaitIdle - дождаться завершения всех сообщений партиций, например перед их отзывом при ребалансе
+func (t *OffsetTracker) WaitIdle(ctx context.Context, partitions []int32) error {
+	stop := context.AfterFunc(ctx, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.idle.Broadcast()
+	})
+	defer stop()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for !t.isIdle(partitions) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		t.idle.Wait()
+	}
+	return nil
+}
+
+// Forget - партиции отозваны, их состояние больше не нужно
+func (t *OffsetTracker) Forget(partitions []int32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, partition := range partitions {
+		delete(t.partitions, partition)
+	}
+}
+
+func (t *OffsetTracker) isIdle(partitions []int32) bool {
+	for _, partition := range partitions {
+		if state, ok := t.partitions[partition]; ok && len(state.pending) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *partitionOffsets) committable() int64 {
+	offset := s.next
+	for pending := range s.pending {
+		if pending < offset {
+			offset = pending
+		}
+	}
+	return offset
+}
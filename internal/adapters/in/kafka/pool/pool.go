@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var ErrPoolClosed = errors.New("pool is closed")
+
+// Pool - воркеры с очередью на каждого. Задачи с одним ключом попадают
+// к одному воркеру и выполняются строго в порядке отправки.
+type Pool struct {
+	queues []chan func()
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func New(workers int, queueSize int) (*Pool, error) {
+	if workers < 1 {
+		return nil, errs.NewValueIsInvalidError("workers")
+	}
+	if queueSize < 0 {
+		return nil, errs.NewValueIsInvalidError("queueSize")
+	}
+
+	p := &Pool{queues: make([]chan func(), workers)}
+	for i := range p.queues {
+		p.queues[i] = make(chan func(), queueSize)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p, nil
+}
+
+// Submit - поставить задачу в очередь воркера ключа, блокируется, пока очередь полна
+func (p *Pool) Submit(ctx context.Context, key string, task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.queues[p.worker(key)] <- task:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close - дождаться выполнения всех принятых задач и остановить воркеры
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *Pool) worker(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+func (p *Pool) work(queue chan func()) {
+	defer p.wg.Done()
+	for task := range queue {
+		task()
+	}
+}
@@ -0,0 +1,149 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PoolShouldKeepOrderPerKey(t *testing.T) {
+	p, err := New(4, 8)
+	require.NoError(t, err)
+
+	const keys, perKey = 10, 100
+	var mu sync.Mutex
+	processed := make(map[string][]int)
+	for i := range perKey {
+		for k := range keys {
+			key := fmt.Sprintf("basket-%d", k)
+			require.NoError(t, p.Submit(context.Background(), key, func() {
+				mu.Lock()
+				defer mu.Unlock()
+				processed[key] = append(processed[key], i)
+			}))
+		}
+	}
+	p.Close()
+
+	require.Len(t, processed, keys)
+	for key, sequence := range processed {
+		require.Len(t, sequence, perKey, key)
+		for i, value := range sequence {
+			assert.Equal(t, i, value, key)
+		}
+	}
+}
+
+func Test_PoolShouldRejectAfterClose(t *testing.T) {
+	p, err := New(1, 0)
+	require.NoError(t, err)
+	p.Close()
+
+	assert.ErrorIs(t, p.Submit(context.Background(), "key", func() {}), ErrPoolClosed)
+}
+
+func Test_PoolSubmitShouldRespectContext(t *testing.T) {
+	p, err := New(1, 0)
+	require.NoError(t, err)
+	defer p.Close()
+
+	// Единственный воркер занят, очереди нет
+	release := make(chan struct{})
+	require.NoError(t, p.Submit(context.Background(), "key", func() { <-release }))
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.Submit(ctx, "key", func() {}), context.DeadlineExceeded)
+}
+
+func Test_OffsetTrackerShouldCommitUpToLowestPending(t *testing.T) {
+	tracker := NewOffsetTracker()
+	for offset := int64(10); offset < 15; offset++ {
+		tracker.Start(0, offset)
+	}
+	tracker.Start(1, 3)
+
+	// 10 ещё в обработке, поэтому 11-12 фиксировать нельзя
+	tracker.Done(0, 11)
+	tracker.Done(0, 12)
+	assert.ElementsMatch(t, []Commit{{Partition: 0, Offset: 10}, {Partition: 1, Offset: 3}}, tracker.Committable())
+	tracker.MarkCommitted(tracker.Committable())
+	assert.Empty(t, tracker.Committable())
+
+	tracker.Done(0, 10)
+	assert.Equal(t, []Commit{{Partition: 0, Offset: 13}}, tracker.Committable())
+
+	tracker.Done(0, 13)
+	tracker.Done(0, 14)
+	tracker.Done(1, 3)
+	commits := tracker.Committable()
+	assert.ElementsMatch(t, []Commit{{Partition: 0, Offset: 15}, {Partition: 1, Offset: 4}}, commits)
+	tracker.MarkCommitted(commits)
+
+	tracker.Forget([]int32{0, 1})
+	assert.Empty(t, tracker.Committable())
+}
+
+func Test_OffsetTrackerWaitIdle(t *testing.T) {
+	tracker := NewOffsetTracker()
+	tracker.Start(0, 1)
+	tracker.Start(1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, tracker.WaitIdle(ctx, []int32{0}), context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		tracker.Done(0, 1)
+	}()
+	// Партиция 1 не отзывается, её сообщение ждать не нужно
+	require.NoError(t, tracker.WaitIdle(context.Background(), []int32{0}))
+}
+
+// Обработка с задержкой, как у команды с походом в Geo и базу
+func benchmarkPool(b *testing.B, workers int) {
+	p, err := New(workers, 64)
+	require.NoError(b, err)
+	tracker := NewOffsetTracker()
+
+	b.ResetTimer()
+	for i := range b.N {
+		offset := int64(i)
+		tracker.Start(0, offset)
+		err := p.Submit(context.Background(), fmt.Sprintf("basket-%d", i%1000), func() {
+			defer tracker.Done(0, offset)
+			time.Sleep(100 * time.Microsecond)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	p.Close()
+	b.StopTimer()
+
+	if commits := tracker.Committable(); len(commits) != 1 || commits[0].Offset != int64(b.N) {
+		b.Fatalf("unexpected commits %v", commits)
+	}
+}
+
+func BenchmarkPool_1Worker(b *testing.B)   { benchmarkPool(b, 1) }
+func BenchmarkPool_8Workers(b *testing.B)  { benchmarkPool(b, 8) }
+func BenchmarkPool_32Workers(b *testing.B) { benchmarkPool(b, 32) }
+
+func BenchmarkOffsetTracker(b *testing.B) {
+	tracker := NewOffsetTracker()
+	for i := range b.N {
+		tracker.Start(int32(i%4), int64(i))
+		tracker.Done(int32(i%4), int64(i))
+		if i%100 == 0 {
+			tracker.MarkCommitted(tracker.Committable())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package confluent
+
+import (
+	"errors"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.MessageConsumer = &Consumer{}
+
+type Consumer struct {
+	consumer *kafka.Consumer
+}
+
+func NewConsumer(host string, group string) (*Consumer, error) {
+	if host == "" {
+		return nil, errs.NewValueIsRequiredError("host")
+	}
+	if group == "" {
+		return nil, errs.NewValueIsRequiredError("group")
+	}
+
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  host,
+		"group.id":           group,
+		"enable.auto.commit": false,
+		"auto.offset.reset":  "earliest",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Consumer{consumer: consumer}, nil
+}
+
+func (c *Consumer) Subscribe(topic string, onRevoke func(partitions []int32)) error {
+	return c.consumer.Subscribe(topic, func(_ *kafka.Consumer, event kafka.Event) error {
+		revoked, ok := event.(kafka.RevokedPartitions)
+		if !ok || onRevoke == nil {
+			return nil
+		}
+		partitions := make([]int32, len(revoked.Partitions))
+		for i, topicPartition := range revoked.Partitions {
+			partitions[i] = topicPartition.Partition
+		}
+		onRevoke(partitions)
+		return nil
+	})
+}
+
+func (c *Consumer) Poll(timeout time.Duration) (*ports.Message, error) {
+	msg, err := c.consumer.ReadMessage(timeout)
+	if err != nil {
+		var kafkaErr kafka.Error
+		if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+
+	var topic string
+	if msg.TopicPartition.Topic != nil {
+		topic = *msg.TopicPartition.Topic
+	}
+
+	return &ports.Message{
+		Topic:     topic,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+	}, nil
+}
+
+func (c *Consumer) Commit(topic string, offsets map[int32]int64) error {
+	topicPartitions := make([]kafka.TopicPartition, 0, len(offsets))
+	for partition, offset := range offsets {
+		topicPartitions = append(topicPartitions, kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: partition,
+			Offset:    kafka.Offset(offset),
+		})
+	}
+
+	_, err := c.consumer.CommitOffsets(topicPartitions)
+	return err
+}
+
+func (c *Consumer) Close() error {
+	return c.consumer.Close()
+}
@@ -0,0 +1,71 @@
+package confluent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.MessagePublisher = &Publisher{}
+
+const flushTimeoutMs = 5000
+
+type Publisher struct {
+	producer *kafka.Producer
+}
+
+func NewPublisher(host string) (*Publisher, error) {
+	if host == "" {
+		return nil, errs.NewValueIsRequiredError("host")
+	}
+
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers":  host,
+		"enable.idempotence": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{producer: producer}, nil
+}
+
+// Publish - отправить сообщение и дождаться подтверждения брокера
+func (p *Publisher) Publish(ctx context.Context, message ports.Message) error {
+	headers := make([]kafka.Header, 0, len(message.Headers))
+	for key, value := range message.Headers {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	delivery := make(chan kafka.Event, 1)
+	err := p.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &message.Topic, Partition: kafka.PartitionAny},
+		Key:            message.Key,
+		Value:          message.Value,
+		Headers:        headers,
+	}, delivery)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case event := <-delivery:
+		msg, ok := event.(*kafka.Message)
+		if !ok {
+			return fmt.Errorf("unexpected delivery event %v", event)
+		}
+		return msg.TopicPartition.Error
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Publisher) Close() error {
+	p.producer.Flush(flushTimeoutMs)
+	p.producer.Close()
+	return nil
+}
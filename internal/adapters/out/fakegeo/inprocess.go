@@ -0,0 +1,46 @@
+package fakegeo
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/IgorAleksandroff/delivery/pkg/clients/geo/geosrv/geopb"
+)
+
+const bufSize = 1024 * 1024
+
+// InProcess - сервер, запущенный в памяти процесса поверх bufconn, для тестов без сети
+type InProcess struct {
+	listener   *bufconn.Listener
+	grpcServer *grpc.Server
+}
+
+func StartInProcess(server *Server) *InProcess {
+	listener := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer()
+	pb.RegisterGeoServer(grpcServer, server)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	return &InProcess{listener: listener, grpcServer: grpcServer}
+}
+
+// Target - адрес для grpc.NewClient, используется вместе с DialOption
+func (p *InProcess) Target() string {
+	return "passthrough:///bufnet"
+}
+
+func (p *InProcess) DialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return p.listener.DialContext(ctx)
+	})
+}
+
+func (p *InProcess) Stop() {
+	p.grpcServer.Stop()
+}
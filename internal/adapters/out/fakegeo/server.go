@@ -0,0 +1,212 @@
+package fakegeo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	pb "github.com/IgorAleksandroff/delivery/pkg/clients/geo/geosrv/geopb"
+)
+
+var _ pb.GeoServer = &Server{}
+
+type Config struct {
+	// Streets - словарь улица -> координаты в любом городе, остальные адреса вычисляются по хэшу
+	Streets map[string]kernel.Location
+	// UnknownNotFound - отвечать NotFound на улицы не из словаря вместо хэша
+	UnknownNotFound bool
+	// Bounds - границы, в которые попадают вычисленные по хэшу координаты, по умолчанию kernel.DefaultArea.
+	// С kernel.GeoBounds сервер отвечает координатами WGS84
+	Bounds kernel.Bounds
+
+	// Latency - задержка перед каждым ответом
+	Latency time.Duration
+	// ErrorRate - доля запросов от 0 до 1, на которые сервер ответит ErrorCode
+	ErrorRate float64
+	ErrorCode codes.Code
+}
+
+// Server - локальная замена геосервиса для разработки и тестов
+type Server struct {
+	pb.UnimplementedGeoServer
+
+	geoClient       *memory.GeoClient
+	bounds          kernel.Bounds
+	unknownNotFound bool
+
+	mu        sync.Mutex
+	latency   time.Duration
+	errorRate float64
+	errorCode codes.Code
+	random    *rand.Rand
+
+	calls atomic.Int64
+}
+
+func NewServer(cfg Config) (*Server, error) {
+	if cfg.ErrorRate < 0 || cfg.ErrorRate > 1 {
+		return nil, fmt.Errorf("error rate must be between 0 and 1, got %v", cfg.ErrorRate)
+	}
+	if cfg.Latency < 0 {
+		return nil, fmt.Errorf("latency must not be negative, got %v", cfg.Latency)
+	}
+	if cfg.ErrorCode == codes.OK {
+		cfg.ErrorCode = codes.Unavailable
+	}
+	if cfg.Bounds == nil || cfg.Bounds.IsEmpty() {
+		cfg.Bounds = kernel.DefaultArea()
+	}
+
+	locations := make(map[kernel.Address]kernel.Location, len(cfg.Streets))
+	for street, location := range cfg.Streets {
+		address, err := kernel.NewStreetAddress(street)
+		if err != nil {
+			return nil, fmt.Errorf("street %q: %w", street, err)
+		}
+		locations[address] = location
+	}
+
+	return &Server{
+		geoClient:       memory.NewGeoClient(locations, cfg.Bounds),
+		bounds:          cfg.Bounds,
+		unknownNotFound: cfg.UnknownNotFound,
+		latency:         cfg.Latency,
+		errorRate:       cfg.ErrorRate,
+		errorCode:       cfg.ErrorCode,
+		random:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+func (s *Server) GetGeolocation(ctx context.Context, req *pb.GetGeolocationRequest) (*pb.GetGeolocationReply, error) {
+	s.calls.Add(1)
+
+	latency, fail, errorCode := s.faults()
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+	}
+	if fail {
+		return nil, status.Error(errorCode, "injected failure")
+	}
+
+	address, err := requestAddress(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	location, ok := s.geoClient.Lookup(address)
+	if !ok {
+		if s.unknownNotFound {
+			return nil, status.Errorf(codes.NotFound, "address %q not found", address)
+		}
+		location, err = memory.HashLocation(address, s.bounds)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &pb.GetGeolocationReply{Location: toLocation(location)}, nil
+}
+
+func toLocation(location kernel.Location) *pb.Location {
+	if location.IsGeo() {
+		return &pb.Location{Wgs84: &pb.Wgs84{Latitude: location.Lat(), Longitude: location.Lon()}}
+	}
+	return &pb.Location{X: int32(location.X()), Y: int32(location.Y())}
+}
+
+// requestAddress - клиенты первой версии контракта присылают только Street
+func requestAddress(req *pb.GetGeolocationRequest) (kernel.Address, error) {
+	if address := req.GetAddress(); address != nil {
+		return kernel.NewAddress(address.GetCountry(), address.GetCity(), address.GetStreet(),
+			address.GetHouse(), address.GetApartment())
+	}
+	return kernel.NewStreetAddress(req.GetStreet())
+}
+
+// SetGeolocation - добавить или заменить адрес в словаре на лету
+func (s *Server) SetGeolocation(address kernel.Address, location kernel.Location) {
+	s.geoClient.SetGeolocation(address, location)
+}
+
+// SetFaults - поменять задержку и долю ошибок на лету
+func (s *Server) SetFaults(latency time.Duration, errorRate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+	s.errorRate = errorRate
+}
+
+// Calls - сколько запросов получил сервер
+func (s *Server) Calls() int64 {
+	return s.calls.Load()
+}
+
+func (s *Server) faults() (time.Duration, bool, codes.Code) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fail := s.errorRate > 0 && s.random.Float64() < s.errorRate
+	return s.latency, fail, s.errorCode
+}
+
+type locationJSON struct {
+	X   int      `json:"x"`
+	Y   int      `json:"y"`
+	Lat *float64 `json:"lat"`
+	Lon *float64 `json:"lon"`
+}
+
+func (l locationJSON) location() (kernel.Location, error) {
+	if l.Lat != nil && l.Lon != nil {
+		return kernel.NewGeoLocation(*l.Lat, *l.Lon)
+	}
+	return kernel.NewLocation(l.X, l.Y)
+}
+
+// LoadStreets - прочитать словарь улиц из JSON файла вида {"Бажная": {"x": 1, "y": 2}}
+// или {"Бажная": {"lat": 55.75, "lon": 37.62}}, все улицы должны попадать в bounds
+func LoadStreets(path string, bounds kernel.Bounds) (map[string]kernel.Location, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]locationJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	streets := make(map[string]kernel.Location, len(raw))
+	var errs []error
+	for street, coordinates := range raw {
+		location, err := coordinates.location()
+		if err == nil {
+			err = bounds.Validate(location)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("street %q: %w", street, err))
+			continue
+		}
+		streets[street] = location
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return streets, nil
+}
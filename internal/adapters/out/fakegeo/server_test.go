@@ -0,0 +1,104 @@
+package fakegeo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	pb "github.com/IgorAleksandroff/delivery/pkg/clients/geo/geosrv/geopb"
+)
+
+func Test_ServerShouldAnswerFromDictionaryAndHash(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewServer(Config{Streets: map[string]kernel.Location{"Бажная": kernel.MustNewLocation(1, 2)}})
+	require.NoError(t, err)
+
+	reply, err := server.GetGeolocation(ctx, &pb.GetGeolocationRequest{Street: "бажная"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), reply.GetLocation().GetX())
+	assert.Equal(t, int32(2), reply.GetLocation().GetY())
+
+	// Неизвестная улица всегда получает одни и те же координаты
+	expected, err := memory.HashLocation(kernel.MustNewAddress("", "", "Несуществующая", "", ""), kernel.DefaultArea())
+	require.NoError(t, err)
+	for range 2 {
+		reply, err = server.GetGeolocation(ctx, &pb.GetGeolocationRequest{Street: "Несуществующая"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(expected.X()), reply.GetLocation().GetX())
+		assert.Equal(t, int32(expected.Y()), reply.GetLocation().GetY())
+	}
+	assert.Equal(t, int64(3), server.Calls())
+}
+
+func Test_ServerShouldPreferFullAddress(t *testing.T) {
+	server, err := NewServer(Config{})
+	require.NoError(t, err)
+
+	address := kernel.MustNewAddress("Россия", "Москва", "Несуществующая", "1", "")
+	expected, err := memory.HashLocation(address, kernel.DefaultArea())
+	require.NoError(t, err)
+
+	reply, err := server.GetGeolocation(context.Background(), &pb.GetGeolocationRequest{
+		Street:  "Несуществующая",
+		Address: &pb.Address{Country: "Россия", City: "Москва", Street: "Несуществующая", House: "1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(expected.X()), reply.GetLocation().GetX())
+	assert.Equal(t, int32(expected.Y()), reply.GetLocation().GetY())
+
+	_, err = server.GetGeolocation(context.Background(), &pb.GetGeolocationRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_ServerShouldReturnNotFoundForUnknownStreets(t *testing.T) {
+	server, err := NewServer(Config{UnknownNotFound: true})
+	require.NoError(t, err)
+
+	_, err = server.GetGeolocation(context.Background(), &pb.GetGeolocationRequest{Street: "Несуществующая"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_ServerShouldInjectErrors(t *testing.T) {
+	server, err := NewServer(Config{ErrorRate: 1})
+	require.NoError(t, err)
+
+	_, err = server.GetGeolocation(context.Background(), &pb.GetGeolocationRequest{Street: "Бажная"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	server.SetFaults(0, 0)
+	_, err = server.GetGeolocation(context.Background(), &pb.GetGeolocationRequest{Street: "Бажная"})
+	assert.NoError(t, err)
+
+	_, err = NewServer(Config{ErrorRate: 2})
+	assert.Error(t, err)
+}
+
+func Test_LoadStreets(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "streets.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"Бажная": {"x": 1, "y": 2}}`), 0o600))
+	streets, err := LoadStreets(path, kernel.DefaultArea())
+	require.NoError(t, err)
+	assert.True(t, streets["Бажная"].Equals(kernel.MustNewLocation(1, 2)))
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"Бажная": {"x": 0, "y": 20}}`), 0o600))
+	_, err = LoadStreets(invalid, kernel.DefaultArea())
+	assert.ErrorIs(t, err, kernel.ErrLocationOutOfArea)
+	// На сетке побольше те же координаты допустимы
+	_, err = LoadStreets(invalid, kernel.MustNewArea(0, 0, 20, 20))
+	assert.NoError(t, err)
+
+	// Словарь из репозитория должен оставаться валидным
+	_, err = LoadStreets("../../../../deps/services/fakegeo/streets.json", kernel.DefaultArea())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,186 @@
+package geocache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var (
+	_ ports.GeoClient = &Cache{}
+	_ ports.GeoCache  = &Cache{}
+)
+
+// Entry - закэшированный результат геокодирования.
+// NotFound означает негативную запись: Geo не знает такую улицу.
+type Entry struct {
+	Key       string
+	Location  kernel.Location
+	NotFound  bool
+	ExpiresAt time.Time
+}
+
+func (e Entry) isExpired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// Store - постоянное хранилище второго уровня, переживающее рестарт сервиса
+type Store interface {
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	Put(ctx context.Context, entry Entry) error
+	Delete(ctx context.Context, key string) error
+	DeleteAll(ctx context.Context) error
+}
+
+type Config struct {
+	Size        int
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	// ServeStale - отдавать просроченную запись, если Geo недоступен
+	ServeStale bool
+}
+
+// Cache - кэширующий декоратор ports.GeoClient: LRU в памяти процесса и опциональный Store
+type Cache struct {
+	next  ports.GeoClient
+	store Store
+	lru   *lru
+
+	ttl         time.Duration
+	negativeTTL time.Duration
+	serveStale  bool
+	now         func() time.Time
+
+	hits           atomic.Int64
+	negativeHits   atomic.Int64
+	persistentHits atomic.Int64
+	misses         atomic.Int64
+	staleHits      atomic.Int64
+	evictions      atomic.Int64
+}
+
+func NewCache(next ports.GeoClient, store Store, cfg Config) (*Cache, error) {
+	if next == nil {
+		return nil, errs.NewValueIsRequiredError("next")
+	}
+	if cfg.Size <= 0 {
+		return nil, errs.NewValueIsInvalidError("size")
+	}
+	if cfg.TTL <= 0 {
+		return nil, errs.NewValueIsInvalidError("ttl")
+	}
+	if cfg.NegativeTTL < 0 {
+		return nil, errs.NewValueIsInvalidError("negativeTTL")
+	}
+
+	return &Cache{
+		next:        next,
+		store:       store,
+		lru:         newLRU(cfg.Size),
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+		serveStale:  cfg.ServeStale,
+		now:         time.Now,
+	}, nil
+}
+
+func (c *Cache) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	key := address.GeocodingKey()
+
+	// Первый уровень - память процесса
+	entry, ok := c.lru.get(key)
+	if ok && !entry.isExpired(c.now()) {
+		return c.hit(entry, &c.hits)
+	}
+	stale, hasStale := entry, ok
+
+	// Второй уровень - постоянное хранилище
+	if c.store != nil {
+		entry, ok, err := c.store.Get(ctx, key)
+		if err != nil {
+			log.Printf("geocache: read from store failed: %v", err)
+		}
+		if ok && !entry.isExpired(c.now()) {
+			c.putToMemory(entry)
+			return c.hit(entry, &c.persistentHits)
+		}
+		if ok && !hasStale {
+			stale, hasStale = entry, true
+		}
+	}
+
+	// Промах - идём в Geo
+	c.misses.Add(1)
+	location, err := c.next.GetGeolocation(ctx, address)
+	if err != nil {
+		if errors.Is(err, ports.ErrGeolocationNotFound) && c.negativeTTL > 0 {
+			c.put(ctx, Entry{Key: key, NotFound: true, ExpiresAt: c.now().Add(c.negativeTTL)})
+		}
+		// Geo недоступен - лучше устаревшая координата, чем ошибка
+		if errors.Is(err, ports.ErrGeoServiceUnavailable) && c.serveStale && hasStale && !stale.NotFound {
+			c.staleHits.Add(1)
+			return stale.Location, nil
+		}
+		return kernel.Location{}, err
+	}
+
+	c.put(ctx, Entry{Key: key, Location: location, ExpiresAt: c.now().Add(c.ttl)})
+	return location, nil
+}
+
+func (c *Cache) Invalidate(ctx context.Context, address kernel.Address) error {
+	key := address.GeocodingKey()
+	c.lru.delete(key)
+	if c.store != nil {
+		return c.store.Delete(ctx, key)
+	}
+	return nil
+}
+
+func (c *Cache) InvalidateAll(ctx context.Context) error {
+	c.lru.clear()
+	if c.store != nil {
+		return c.store.DeleteAll(ctx)
+	}
+	return nil
+}
+
+func (c *Cache) Stats() ports.GeoCacheStats {
+	return ports.GeoCacheStats{
+		Hits:           c.hits.Load(),
+		NegativeHits:   c.negativeHits.Load(),
+		PersistentHits: c.persistentHits.Load(),
+		Misses:         c.misses.Load(),
+		StaleHits:      c.staleHits.Load(),
+		Evictions:      c.evictions.Load(),
+		Size:           c.lru.len(),
+	}
+}
+
+func (c *Cache) hit(entry Entry, counter *atomic.Int64) (kernel.Location, error) {
+	if entry.NotFound {
+		c.negativeHits.Add(1)
+		return kernel.Location{}, ports.ErrGeolocationNotFound
+	}
+	counter.Add(1)
+	return entry.Location, nil
+}
+
+func (c *Cache) put(ctx context.Context, entry Entry) {
+	c.putToMemory(entry)
+	if c.store != nil {
+		if err := c.store.Put(ctx, entry); err != nil {
+			log.Printf("geocache: write to store failed: %v", err)
+		}
+	}
+}
+
+func (c *Cache) putToMemory(entry Entry) {
+	c.evictions.Add(int64(c.lru.put(entry)))
+}
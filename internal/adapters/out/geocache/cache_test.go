@@ -0,0 +1,234 @@
+package geocache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+)
+
+type stubGeoClient struct {
+	locations map[string]kernel.Location
+	calls     int
+}
+
+func (s *stubGeoClient) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	s.calls++
+	location, ok := s.locations[address.Street()]
+	if !ok {
+		return kernel.Location{}, ports.ErrGeolocationNotFound
+	}
+	return location, nil
+}
+
+type stubStore struct {
+	entries map[string]Entry
+}
+
+func (s *stubStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	entry, ok := s.entries[key]
+	return entry, ok, nil
+}
+
+func (s *stubStore) Put(ctx context.Context, entry Entry) error {
+	s.entries[entry.Key] = entry
+	return nil
+}
+
+func (s *stubStore) Delete(ctx context.Context, key string) error {
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *stubStore) DeleteAll(ctx context.Context) error {
+	s.entries = make(map[string]Entry)
+	return nil
+}
+
+func streetAddress(street string) kernel.Address {
+	return kernel.MustNewAddress("", "", street, "", "")
+}
+
+func setupTest(t *testing.T, store Store, size int) (*Cache, *stubGeoClient, *time.Time) {
+	geoClient := &stubGeoClient{locations: map[string]kernel.Location{
+		"Бажная":     kernel.MustNewLocation(1, 2),
+		"Тенистая":   kernel.MustNewLocation(3, 4),
+		"Айтишная":   kernel.MustNewLocation(5, 6),
+		"Эльфийская": kernel.MustNewLocation(7, 8),
+	}}
+
+	cache, err := NewCache(geoClient, store, Config{Size: size, TTL: time.Hour, NegativeTTL: time.Minute})
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, geoClient, &now
+}
+
+func Test_CacheShouldServeRepeatedStreetsFromMemory(t *testing.T) {
+	ctx := context.Background()
+	cache, geoClient, _ := setupTest(t, nil, 10)
+
+	for range 3 {
+		location, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+		require.NoError(t, err)
+		assert.True(t, location.Equals(kernel.MustNewLocation(1, 2)))
+	}
+	// Ключ не зависит от регистра, лишних пробелов и квартиры
+	_, err := cache.GetGeolocation(ctx, streetAddress("  бажная "))
+	require.NoError(t, err)
+	_, err = cache.GetGeolocation(ctx, kernel.MustNewAddress("", "", "Бажная", "", "15"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, geoClient.calls)
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(4), stats.Hits)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func Test_CacheShouldExpireEntriesAfterTTL(t *testing.T) {
+	ctx := context.Background()
+	cache, geoClient, now := setupTest(t, nil, 10)
+
+	_, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+
+	*now = now.Add(time.Hour)
+	_, err = cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, geoClient.calls)
+	assert.Equal(t, int64(2), cache.Stats().Misses)
+}
+
+func Test_CacheShouldCacheUnknownStreets(t *testing.T) {
+	ctx := context.Background()
+	cache, geoClient, now := setupTest(t, nil, 10)
+
+	_, err := cache.GetGeolocation(ctx, streetAddress("Несуществующая"))
+	assert.ErrorIs(t, err, ports.ErrGeolocationNotFound)
+	_, err = cache.GetGeolocation(ctx, streetAddress("Несуществующая"))
+	assert.ErrorIs(t, err, ports.ErrGeolocationNotFound)
+	assert.Equal(t, 1, geoClient.calls)
+	assert.Equal(t, int64(1), cache.Stats().NegativeHits)
+
+	// Негативная запись живёт меньше положительной
+	*now = now.Add(time.Minute)
+	_, err = cache.GetGeolocation(ctx, streetAddress("Несуществующая"))
+	assert.ErrorIs(t, err, ports.ErrGeolocationNotFound)
+	assert.Equal(t, 2, geoClient.calls)
+}
+
+func Test_CacheShouldEvictLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache, geoClient, _ := setupTest(t, nil, 2)
+
+	for _, street := range []string{"Бажная", "Тенистая", "Бажная", "Айтишная"} {
+		_, err := cache.GetGeolocation(ctx, streetAddress(street))
+		require.NoError(t, err)
+	}
+	// Тенистая вытеснена, Бажная осталась
+	_, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, geoClient.calls)
+	_, err = cache.GetGeolocation(ctx, streetAddress("Тенистая"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, geoClient.calls)
+
+	assert.Equal(t, int64(2), cache.Stats().Evictions)
+	assert.Equal(t, 2, cache.Stats().Size)
+}
+
+func Test_CacheShouldReadThroughPersistentStore(t *testing.T) {
+	ctx := context.Background()
+	store := &stubStore{entries: make(map[string]Entry)}
+	cache, geoClient, _ := setupTest(t, store, 10)
+
+	_, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+	require.Contains(t, store.entries, streetAddress("Бажная").GeocodingKey())
+
+	// Новый процесс с пустой памятью читает запись из хранилища
+	restarted, _, _ := setupTest(t, store, 10)
+	restarted.next = geoClient
+	location, err := restarted.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+	assert.True(t, location.Equals(kernel.MustNewLocation(1, 2)))
+	assert.Equal(t, 1, geoClient.calls)
+	assert.Equal(t, int64(1), restarted.Stats().PersistentHits)
+}
+
+func Test_CacheShouldInvalidateEntries(t *testing.T) {
+	ctx := context.Background()
+	store := &stubStore{entries: make(map[string]Entry)}
+	cache, geoClient, _ := setupTest(t, store, 10)
+
+	_, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+	_, err = cache.GetGeolocation(ctx, streetAddress("Тенистая"))
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Invalidate(ctx, streetAddress("Бажная")))
+	assert.NotContains(t, store.entries, streetAddress("Бажная").GeocodingKey())
+	_, err = cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, geoClient.calls)
+
+	require.NoError(t, cache.InvalidateAll(ctx))
+	assert.Empty(t, store.entries)
+	assert.Equal(t, 0, cache.Stats().Size)
+}
+
+func Test_CacheShouldServeStaleEntryWhenGeoUnavailable(t *testing.T) {
+	ctx := context.Background()
+	cache, geoClient, now := setupTest(t, nil, 10)
+	cache.serveStale = true
+
+	_, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+
+	// Запись просрочена, а Geo лежит
+	*now = now.Add(2 * time.Hour)
+	next := &unavailableGeoClient{}
+	cache.next = next
+	location, err := cache.GetGeolocation(ctx, streetAddress("Бажная"))
+	require.NoError(t, err)
+	assert.True(t, location.Equals(kernel.MustNewLocation(1, 2)))
+	assert.Equal(t, int64(1), cache.Stats().StaleHits)
+
+	// Без устаревшей записи ошибка пробрасывается
+	_, err = cache.GetGeolocation(ctx, streetAddress("Тенистая"))
+	assert.ErrorIs(t, err, ports.ErrGeoServiceUnavailable)
+	assert.Equal(t, 1, geoClient.calls)
+	assert.Equal(t, 2, next.calls)
+}
+
+type unavailableGeoClient struct {
+	calls int
+}
+
+func (s *unavailableGeoClient) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	s.calls++
+	return kernel.Location{}, ports.ErrGeoServiceUnavailable
+}
+
+func Test_CacheShouldSeparateAddressesOnSameStreet(t *testing.T) {
+	ctx := context.Background()
+	cache, geoClient, _ := setupTest(t, nil, 10)
+
+	_, err := cache.GetGeolocation(ctx, kernel.MustNewAddress("Россия", "Москва", "Бажная", "1", ""))
+	require.NoError(t, err)
+	_, err = cache.GetGeolocation(ctx, kernel.MustNewAddress("Россия", "Казань", "Бажная", "1", ""))
+	require.NoError(t, err)
+	_, err = cache.GetGeolocation(ctx, kernel.MustNewAddress("Россия", "Москва", "Бажная", "40", ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, geoClient.calls)
+	assert.Equal(t, 3, cache.Stats().Size)
+}
@@ -0,0 +1,82 @@
+package geocache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru - потокобезопасный LRU-список записей кэша фиксированного размера
+type lru struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (l *lru) get(key string) (Entry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.items[key]
+	if !ok {
+		return Entry{}, false
+	}
+	l.order.MoveToFront(element)
+	return element.Value.(Entry), true
+}
+
+// put - сохранить запись и вернуть количество вытесненных записей
+func (l *lru) put(entry Entry) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.items[entry.Key]; ok {
+		element.Value = entry
+		l.order.MoveToFront(element)
+		return 0
+	}
+
+	l.items[entry.Key] = l.order.PushFront(entry)
+
+	evicted := 0
+	for l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(Entry).Key)
+		evicted++
+	}
+	return evicted
+}
+
+func (l *lru) delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.items[key]; ok {
+		l.order.Remove(element)
+		delete(l.items, key)
+	}
+}
+
+func (l *lru) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.items = make(map[string]*list.Element, l.capacity)
+	l.order.Init()
+}
+
+func (l *lru) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.order.Len()
+}
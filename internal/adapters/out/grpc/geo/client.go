@@ -2,34 +2,71 @@ package geo
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"math/rand"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/circuitbreaker"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 	pb "github.com/IgorAleksandroff/delivery/pkg/clients/geo/geosrv/geopb"
 )
 
 var _ ports.GeoClient = &Client{}
 
+type Config struct {
+	// Timeout - таймаут одной попытки, общий срок задаёт контекст вызывающего
+	Timeout     time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	BreakerFailureThreshold int
+	BreakerOpenTimeout      time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:                 2 * time.Second,
+		MaxAttempts:             3,
+		BaseBackoff:             100 * time.Millisecond,
+		MaxBackoff:              2 * time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      30 * time.Second,
+	}
+}
+
 type Client struct {
 	conn     *grpc.ClientConn
 	pbClient pb.GeoClient
-	timeout  time.Duration
+	cfg      Config
+	breaker  *circuitbreaker.Breaker
+	sleep    func(ctx context.Context, d time.Duration) error
 }
 
-func NewClient(host string) (*Client, error) {
+// NewClient - opts дополняют стандартные параметры соединения, например подменяют транспорт в тестах
+func NewClient(host string, cfg Config, opts ...grpc.DialOption) (*Client, error) {
 	if host == "" {
 		return nil, errs.NewValueIsRequiredError("host")
 	}
+	if cfg.Timeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("timeout")
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, errs.NewValueIsInvalidError("maxAttempts")
+	}
 
-	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(host, opts...)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	pbClient := pb.NewGeoClient(conn)
@@ -37,7 +74,9 @@ func NewClient(host string) (*Client, error) {
 	return &Client{
 		conn:     conn,
 		pbClient: pbClient,
-		timeout:  5 * time.Second,
+		cfg:      cfg,
+		breaker:  circuitbreaker.New(cfg.BreakerFailureThreshold, cfg.BreakerOpenTimeout),
+		sleep:    sleep,
 	}, nil
 }
 
@@ -45,24 +84,111 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-func (c *Client) GetGeolocation(ctx context.Context, street string) (kernel.Location, error) {
-	// Формируем запрос
+func (c *Client) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return kernel.Location{}, fmt.Errorf("%w: %v", ports.ErrGeoServiceUnavailable, err)
+	}
+
+	// Формируем запрос, Street дублируем для Geo первой версии
 	req := &pb.GetGeolocationRequest{
-		Street: street,
+		Street: address.Street(),
+		Address: &pb.Address{
+			Country:   address.Country(),
+			City:      address.City(),
+			Street:    address.Street(),
+			House:     address.House(),
+			Apartment: address.Apartment(),
+		},
+	}
+
+	// Делаем запрос с повторами на временных ошибках
+	var resp *pb.GetGeolocationReply
+	var err error
+	for attempt := 1; ; attempt++ {
+		resp, err = c.call(ctx, req)
+		if err == nil || !isRetryable(ctx, err) {
+			break
+		}
+		if attempt >= c.cfg.MaxAttempts {
+			c.breaker.Failure()
+			return kernel.Location{}, fmt.Errorf("%w: %v", ports.ErrGeoServiceUnavailable, err)
+		}
+		if sleepErr := c.sleep(ctx, c.backoff(attempt)); sleepErr != nil {
+			break
+		}
+	}
+
+	// Вызывающий перестал ждать, ответ Geo ничего не говорит о его состоянии
+	if ctx.Err() != nil {
+		c.breaker.Release()
+		return kernel.Location{}, fmt.Errorf("%w: %v", ctx.Err(), err)
 	}
 
-	// Делаем запрос
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel()
-	resp, err := c.pbClient.GetGeolocation(ctx, req)
 	if err != nil {
-		return kernel.Location{}, err
+		switch status.Code(err) {
+		case codes.NotFound:
+			c.breaker.Success()
+			return kernel.Location{}, fmt.Errorf("%w: %s", ports.ErrGeolocationNotFound, address)
+		case codes.Unavailable, codes.DeadlineExceeded:
+			c.breaker.Failure()
+			return kernel.Location{}, fmt.Errorf("%w: %v", ports.ErrGeoServiceUnavailable, err)
+		default:
+			// Geo ответил, значит сервис доступен
+			c.breaker.Success()
+			return kernel.Location{}, err
+		}
 	}
+	c.breaker.Success()
 
-	// Создаем и возвращаем VO Geo
-	location, err := kernel.NewLocation(int(resp.Location.X), int(resp.Location.Y))
+	// Создаем и возвращаем VO Geo, Geo с реальной картой отвечает координатами WGS84
+	if wgs84 := resp.GetLocation().GetWgs84(); wgs84 != nil {
+		return kernel.NewGeoLocation(wgs84.GetLatitude(), wgs84.GetLongitude())
+	}
+	location, err := kernel.NewLocation(int(resp.GetLocation().GetX()), int(resp.GetLocation().GetY()))
 	if err != nil {
 		return kernel.Location{}, err
 	}
 	return location, nil
 }
+
+func (c *Client) call(ctx context.Context, req *pb.GetGeolocationRequest) (*pb.GetGeolocationReply, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+	return c.pbClient.GetGeolocation(ctx, req)
+}
+
+// backoff - экспоненциальная задержка перед попыткой attempt+1 со случайным разбросом
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.cfg.BaseBackoff << (attempt - 1)
+	if delay <= 0 || delay > c.cfg.MaxBackoff {
+		delay = c.cfg.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// isRetryable - повторяем только временную недоступность Geo, пока жив контекст вызывающего
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return errors.Is(err, context.DeadlineExceeded)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
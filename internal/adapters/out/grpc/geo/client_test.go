@@ -0,0 +1,201 @@
+package geo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/fakegeo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/circuitbreaker"
+	pb "github.com/IgorAleksandroff/delivery/pkg/clients/geo/geosrv/geopb"
+)
+
+type stubPbClient struct {
+	errs  []error
+	calls int
+}
+
+func (s *stubPbClient) GetGeolocation(ctx context.Context, in *pb.GetGeolocationRequest,
+	opts ...grpc.CallOption) (*pb.GetGeolocationReply, error) {
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pb.GetGeolocationReply{Location: &pb.Location{X: 3, Y: 4}}, nil
+}
+
+func setupTest(pbClient pb.GeoClient) *Client {
+	cfg := DefaultConfig()
+	cfg.BreakerFailureThreshold = 2
+	return &Client{
+		pbClient: pbClient,
+		cfg:      cfg,
+		breaker:  circuitbreaker.New(cfg.BreakerFailureThreshold, time.Minute),
+		sleep:    func(ctx context.Context, d time.Duration) error { return nil },
+	}
+}
+
+func Test_ClientShouldRetryTransientErrors(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "geo is down")
+	pbClient := &stubPbClient{errs: []error{unavailable, unavailable}}
+	client := setupTest(pbClient)
+
+	location, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Бажная", "", ""))
+	require.NoError(t, err)
+	assert.Equal(t, 3, location.X())
+	assert.Equal(t, 3, pbClient.calls)
+	assert.Equal(t, circuitbreaker.StateClosed, client.breaker.State())
+}
+
+func Test_ClientShouldNotRetryNotFound(t *testing.T) {
+	pbClient := &stubPbClient{errs: []error{status.Error(codes.NotFound, "unknown street")}}
+	client := setupTest(pbClient)
+
+	_, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Несуществующая", "", ""))
+	assert.ErrorIs(t, err, ports.ErrGeolocationNotFound)
+	assert.Equal(t, 1, pbClient.calls)
+}
+
+func Test_ClientShouldOpenBreakerAfterFailures(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "geo is down")
+	pbClient := &stubPbClient{errs: []error{unavailable, unavailable, unavailable, unavailable, unavailable, unavailable}}
+	client := setupTest(pbClient)
+
+	for range 2 {
+		_, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Бажная", "", ""))
+		assert.ErrorIs(t, err, ports.ErrGeoServiceUnavailable)
+	}
+	assert.Equal(t, 6, pbClient.calls)
+	assert.Equal(t, circuitbreaker.StateOpen, client.breaker.State())
+
+	// Разомкнутый предохранитель не пускает запросы в Geo
+	_, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Бажная", "", ""))
+	assert.ErrorIs(t, err, ports.ErrGeoServiceUnavailable)
+	assert.Equal(t, 6, pbClient.calls)
+}
+
+func Test_ClientShouldStopRetryingWhenCallerContextDone(t *testing.T) {
+	pbClient := &stubPbClient{errs: []error{status.Error(codes.Unavailable, "geo is down")}}
+	client := setupTest(pbClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetGeolocation(ctx, kernel.MustNewAddress("", "", "Бажная", "", ""))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, pbClient.calls)
+}
+
+func Test_ClientShouldNotTouchBreakerWhenCallerGivesUp(t *testing.T) {
+	deadlineExceeded := status.Error(codes.DeadlineExceeded, "context deadline exceeded")
+	canceled := status.Error(codes.Canceled, "context canceled")
+	pbClient := &stubPbClient{errs: []error{deadlineExceeded, deadlineExceeded, canceled}}
+	client := setupTest(pbClient)
+	address := kernel.MustNewAddress("", "", "Бажная", "", "")
+
+	// Истёкший срок вызывающего не считается отказом Geo
+	for range 2 {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		_, err := client.GetGeolocation(ctx, address)
+		cancel()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	assert.Equal(t, circuitbreaker.StateClosed, client.breaker.State())
+
+	// Отмена вызывающим не считается и успехом: счётчик отказов не сбрасывается
+	client.breaker.Failure()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetGeolocation(ctx, address)
+	assert.ErrorIs(t, err, context.Canceled)
+	client.breaker.Failure()
+	assert.Equal(t, circuitbreaker.StateOpen, client.breaker.State())
+	assert.Equal(t, 3, pbClient.calls)
+}
+
+func setupFakeGeo(t *testing.T, cfg fakegeo.Config) (*Client, *fakegeo.Server) {
+	server, err := fakegeo.NewServer(cfg)
+	require.NoError(t, err)
+	inProcess := fakegeo.StartInProcess(server)
+	t.Cleanup(inProcess.Stop)
+
+	clientCfg := DefaultConfig()
+	clientCfg.Timeout = 100 * time.Millisecond
+	clientCfg.BaseBackoff = time.Millisecond
+	clientCfg.MaxBackoff = time.Millisecond
+	client, err := NewClient(inProcess.Target(), clientCfg, inProcess.DialOption())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, server
+}
+
+func Test_ClientShouldGetGeolocationFromFakeGeo(t *testing.T) {
+	client, server := setupFakeGeo(t, fakegeo.Config{
+		Streets:         map[string]kernel.Location{"Бажная": kernel.MustNewLocation(1, 2)},
+		UnknownNotFound: true,
+	})
+
+	location, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Бажная", "", ""))
+	require.NoError(t, err)
+	assert.True(t, location.Equals(kernel.MustNewLocation(1, 2)))
+
+	_, err = client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Несуществующая", "", ""))
+	assert.ErrorIs(t, err, ports.ErrGeolocationNotFound)
+	assert.Equal(t, int64(2), server.Calls())
+}
+
+func Test_ClientShouldTreatSlowFakeGeoAsUnavailable(t *testing.T) {
+	client, server := setupFakeGeo(t, fakegeo.Config{Latency: time.Second})
+
+	_, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Бажная", "", ""))
+	assert.ErrorIs(t, err, ports.ErrGeoServiceUnavailable)
+	assert.Equal(t, int64(client.cfg.MaxAttempts), server.Calls())
+}
+
+func Test_ClientShouldSendFullAddress(t *testing.T) {
+	client, _ := setupFakeGeo(t, fakegeo.Config{})
+
+	// Fake Geo хэширует адрес целиком, значит город и дом дошли до сервера
+	for _, address := range []kernel.Address{
+		kernel.MustNewAddress("Россия", "Москва", "Несуществующая", "1", ""),
+		kernel.MustNewAddress("Россия", "Казань", "Несуществующая", "1", ""),
+	} {
+		location, err := client.GetGeolocation(context.Background(), address)
+		require.NoError(t, err)
+		expected, err := memory.HashLocation(address, kernel.DefaultArea())
+		require.NoError(t, err)
+		assert.True(t, location.Equals(expected), "address %v", address)
+	}
+}
+
+func Test_ClientShouldReturnWgs84Coordinates(t *testing.T) {
+	bounds := kernel.DefaultGeoBounds()
+	client, _ := setupFakeGeo(t, fakegeo.Config{
+		Streets: map[string]kernel.Location{"Бажная": kernel.MustNewGeoLocation(55.7539, 37.6208)},
+		Bounds:  bounds,
+	})
+
+	location, err := client.GetGeolocation(context.Background(), kernel.MustNewAddress("", "", "Бажная", "", ""))
+	require.NoError(t, err)
+	assert.True(t, location.Equals(kernel.MustNewGeoLocation(55.7539, 37.6208)))
+
+	address := kernel.MustNewAddress("Россия", "Москва", "Несуществующая", "1", "")
+	location, err = client.GetGeolocation(context.Background(), address)
+	require.NoError(t, err)
+	expected, err := memory.HashLocation(address, bounds)
+	require.NoError(t, err)
+	assert.True(t, location.Equals(expected))
+	assert.True(t, bounds.Contains(location))
+}
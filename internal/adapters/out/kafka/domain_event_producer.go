@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/codec"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/pkg/clients/queues/queues/courierlocationchangedpb"
+)
+
+var _ ports.DomainEventPublisher = &DomainEventProducer{}
+
+// DomainEventProducer - переводит доменные события в интеграционные и отправляет их в Kafka
+type DomainEventProducer struct {
+	publisher                   ports.MessagePublisher
+	courierLocationChangedTopic string
+}
+
+// NewDomainEventProducer - courierLocationChangedTopic должен быть compacted:
+// ключ сообщения - id курьера, поэтому в топике остаётся последняя позиция каждого курьера
+func NewDomainEventProducer(publisher ports.MessagePublisher, courierLocationChangedTopic string) (*DomainEventProducer, error) {
+	if publisher == nil {
+		return nil, errs.NewValueIsRequiredError("publisher")
+	}
+	if courierLocationChangedTopic == "" {
+		return nil, errs.NewValueIsRequiredError("courierLocationChangedTopic")
+	}
+
+	return &DomainEventProducer{
+		publisher:                   publisher,
+		courierLocationChangedTopic: courierLocationChangedTopic,
+	}, nil
+}
+
+func (p *DomainEventProducer) Publish(ctx context.Context, events ...ddd.DomainEvent) error {
+	var errList []error
+	for _, event := range events {
+		message, ok, err := p.toMessage(event)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		if !ok {
+			// Событие не интересно внешним потребителям
+			continue
+		}
+
+		err = p.publisher.Publish(ctx, message)
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return errors.Join(errList...)
+}
+
+func (p *DomainEventProducer) toMessage(event ddd.DomainEvent) (ports.Message, bool, error) {
+	switch e := event.(type) {
+	case courier.LocationChangedDomainEvent:
+		value, err := proto.Marshal(&courierlocationchangedpb.CourierLocationChangedIntegrationEvent{
+			EventId:    e.EventID().String(),
+			CourierId:  e.CourierID().String(),
+			Location:   toLocation(e.Location()),
+			OccurredAt: timestamppb.New(e.OccurredAt()),
+		})
+		if err != nil {
+			return ports.Message{}, false, err
+		}
+		return ports.Message{
+			Topic:   p.courierLocationChangedTopic,
+			Key:     []byte(e.CourierID().String()),
+			Value:   value,
+			Headers: map[string]string{codec.HeaderContentType: codec.ContentTypeProtobuf},
+		}, true, nil
+	default:
+		return ports.Message{}, false, nil
+	}
+}
+
+func toLocation(location kernel.Location) *courierlocationchangedpb.Location {
+	if location.IsGeo() {
+		return &courierlocationchangedpb.Location{Wgs84: &courierlocationchangedpb.Wgs84{
+			Latitude:  location.Lat(),
+			Longitude: location.Lon(),
+		}}
+	}
+	return &courierlocationchangedpb.Location{X: int32(location.X()), Y: int32(location.Y())}
+}
@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/pkg/clients/queues/queues/courierlocationchangedpb"
+)
+
+const testTopic = "courier.location.changed"
+
+type unknownDomainEvent struct {
+	courier.LocationChangedDomainEvent
+}
+
+func Test_DomainEventProducerShouldPublishCourierLocationKeyedByCourier(t *testing.T) {
+	broker := memory.NewBroker(3)
+	producer, err := NewDomainEventProducer(broker.NewPublisher(), testTopic)
+	require.NoError(t, err)
+
+	courierID := uuid.New()
+	first := courier.NewLocationChangedDomainEvent(courierID, kernel.MustNewLocation(1, 2))
+	second := courier.NewLocationChangedDomainEvent(courierID, kernel.MustNewLocation(1, 3))
+	other := courier.NewLocationChangedDomainEvent(uuid.New(), kernel.MustNewLocation(5, 5))
+	require.NoError(t, producer.Publish(context.Background(), first, second, other, unknownDomainEvent{}))
+
+	messages := broker.Messages(testTopic)
+	require.Len(t, messages, 3)
+
+	var last *courierlocationchangedpb.CourierLocationChangedIntegrationEvent
+	for _, msg := range messages {
+		if string(msg.Key) != courierID.String() {
+			continue
+		}
+		last = &courierlocationchangedpb.CourierLocationChangedIntegrationEvent{}
+		require.NoError(t, proto.Unmarshal(msg.Value, last))
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, second.EventID().String(), last.GetEventId())
+	assert.Equal(t, courierID.String(), last.GetCourierId())
+	assert.Equal(t, int32(1), last.GetLocation().GetX())
+	assert.Equal(t, int32(3), last.GetLocation().GetY())
+	assert.True(t, second.OccurredAt().Equal(last.GetOccurredAt().AsTime()))
+}
@@ -0,0 +1,445 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+)
+
+var (
+	_ ports.MessageConsumer  = &BrokerConsumer{}
+	_ ports.MessagePublisher = &BrokerPublisher{}
+)
+
+var (
+	ErrConsumerClosed    = errors.New("consumer is closed")
+	ErrAlreadySubscribed = errors.New("consumer is already subscribed")
+	ErrNotSubscribed     = errors.New("consumer is not subscribed")
+)
+
+// Broker - брокер сообщений в памяти процесса: топики с партициями,
+// offset и группы потребителей с распределением партиций между участниками.
+// Сообщения ниже offset, зафиксированного всеми читающими топик группами, удаляются.
+// Топики без групп хранят всё, для них есть компакция по ключу
+type Broker struct {
+	mu         sync.Mutex
+	partitions int
+	topics     map[string]*topicLog
+	groups     map[string]*consumerGroup
+	// notify закрывается и пересоздаётся при каждой публикации
+	notify chan struct{}
+}
+
+type topicLog struct {
+	partitions []*partitionLog
+	// compacted - в партиции остаётся только последнее сообщение каждого ключа
+	compacted bool
+}
+
+type partitionLog struct {
+	// messages - по возрастанию offset, после удаления и компакции offset идут с пропусками
+	messages []ports.Message
+	next     int64
+}
+
+type consumerGroup struct {
+	committed map[string]map[int32]int64
+	members   []*BrokerConsumer
+	// revoking - партиции, прежний владелец которых ещё не зафиксировал offset.
+	// Новый владелец начнёт их читать только после этого, как при ребалансе в Kafka.
+	revoking map[int32]bool
+}
+
+func NewBroker(partitions int) *Broker {
+	if partitions < 1 {
+		partitions = 1
+	}
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string]*topicLog),
+		groups:     make(map[string]*consumerGroup),
+		notify:     make(chan struct{}),
+	}
+}
+
+func (b *Broker) NewPublisher() *BrokerPublisher {
+	return &BrokerPublisher{broker: b}
+}
+
+func (b *Broker) NewConsumer(group string) *BrokerConsumer {
+	return &BrokerConsumer{broker: b, group: group, positions: make(map[int32]int64)}
+}
+
+// Compact - включить компакцию топика, как cleanup.policy=compact в Kafka:
+// новое сообщение удаляет из партиции предыдущее с тем же ключом
+func (b *Broker) Compact(topic string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log := b.topic(topic)
+	log.compacted = true
+	for _, partition := range log.partitions {
+		latest := make(map[string]int64, len(partition.messages))
+		for _, message := range partition.messages {
+			latest[string(message.Key)] = message.Offset
+		}
+		partition.messages = slices.DeleteFunc(partition.messages, func(message ports.Message) bool {
+			return latest[string(message.Key)] != message.Offset
+		})
+	}
+}
+
+// Messages - хранимые сообщения топика по порядку партиций, для проверок в тестах
+func (b *Broker) Messages(topic string) []ports.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []ports.Message
+	if log, ok := b.topics[topic]; ok {
+		for _, partition := range log.partitions {
+			messages = append(messages, partition.messages...)
+		}
+	}
+	return messages
+}
+
+// Committed - зафиксированный группой offset партиции, -1 если фиксаций не было
+func (b *Broker) Committed(group string, topic string, partition int32) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if g, ok := b.groups[group]; ok {
+		if offset, ok := g.committed[topic][partition]; ok {
+			return offset
+		}
+	}
+	return -1
+}
+
+func (b *Broker) topic(name string) *topicLog {
+	log, ok := b.topics[name]
+	if !ok {
+		log = &topicLog{partitions: make([]*partitionLog, b.partitions)}
+		for i := range log.partitions {
+			log.partitions[i] = &partitionLog{}
+		}
+		b.topics[name] = log
+	}
+	return log
+}
+
+// retain - удалить сообщения партиции, которые прочитаны и зафиксированы всеми группами топика.
+// Группа, ещё не фиксировавшая offset партиции, держит её с начала
+func (b *Broker) retain(topic string, partition int32) {
+	oldest, readers := int64(-1), 0
+	for _, group := range b.groups {
+		committed, ok := group.committed[topic]
+		if !ok && !group.reads(topic) {
+			continue
+		}
+		readers++
+		offset := committed[partition]
+		if oldest < 0 || offset < oldest {
+			oldest = offset
+		}
+	}
+	if readers == 0 {
+		return
+	}
+
+	log := b.topic(topic).partitions[partition]
+	kept := sort.Search(len(log.messages), func(i int) bool { return log.messages[i].Offset >= oldest })
+	log.messages = slices.Delete(log.messages, 0, kept)
+}
+
+// reads - в группе есть участник, подписанный на топик
+func (g *consumerGroup) reads(topic string) bool {
+	for _, member := range g.members {
+		if member.topic == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// rebalance - раздать партиции топика участникам группы по кругу в порядке вступления
+func (b *Broker) rebalance(group *consumerGroup, topic string) {
+	partitionCount := int32(len(b.topic(topic).partitions))
+	assignments := make(map[*BrokerConsumer][]int32, len(group.members))
+	for partition := int32(0); partition < partitionCount; partition++ {
+		if len(group.members) == 0 {
+			break
+		}
+		member := group.members[int(partition)%len(group.members)]
+		assignments[member] = append(assignments[member], partition)
+	}
+
+	for _, member := range group.members {
+		member.reassign(group, assignments[member])
+	}
+}
+
+type BrokerPublisher struct {
+	broker *Broker
+}
+
+// Publish - партиция выбирается по хэшу ключа, как в Kafka
+func (p *BrokerPublisher) Publish(ctx context.Context, message ports.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b := p.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log := b.topic(message.Topic)
+	h := fnv.New32a()
+	_, _ = h.Write(message.Key)
+	partition := int32(h.Sum32() % uint32(len(log.partitions)))
+
+	target := log.partitions[partition]
+	if log.compacted {
+		target.messages = slices.DeleteFunc(target.messages, func(previous ports.Message) bool {
+			return string(previous.Key) == string(message.Key)
+		})
+	}
+	message.Partition = partition
+	message.Offset = target.next
+	message.Headers = cloneHeaders(message.Headers)
+	target.messages = append(target.messages, message)
+	target.next++
+
+	close(b.notify)
+	b.notify = make(chan struct{})
+	return nil
+}
+
+func (p *BrokerPublisher) Close() error {
+	return nil
+}
+
+type BrokerConsumer struct {
+	broker *Broker
+	group  string
+
+	// Поля ниже защищены broker.mu
+	topic     string
+	onRevoke  func(partitions []int32)
+	assigned  []int32
+	revoked   []int32
+	positions map[int32]int64
+	next      int
+	closed    bool
+}
+
+func (c *BrokerConsumer) Subscribe(topic string, onRevoke func(partitions []int32)) error {
+	b := c.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c.closed {
+		return ErrConsumerClosed
+	}
+	if c.topic != "" {
+		return ErrAlreadySubscribed
+	}
+	c.topic = topic
+	c.onRevoke = onRevoke
+
+	group, ok := b.groups[c.group]
+	if !ok {
+		group = &consumerGroup{committed: make(map[string]map[int32]int64), revoking: make(map[int32]bool)}
+		b.groups[c.group] = group
+	}
+	group.members = append(group.members, c)
+	b.rebalance(group, topic)
+	return nil
+}
+
+func (c *BrokerConsumer) Poll(timeout time.Duration) (*ports.Message, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		c.handleRevoked()
+
+		b := c.broker
+		b.mu.Lock()
+		if c.closed {
+			b.mu.Unlock()
+			return nil, ErrConsumerClosed
+		}
+		if c.topic == "" {
+			b.mu.Unlock()
+			return nil, ErrNotSubscribed
+		}
+		if len(c.revoked) > 0 {
+			b.mu.Unlock()
+			continue
+		}
+		if message, ok := c.nextMessage(); ok {
+			b.mu.Unlock()
+			return message, nil
+		}
+		notify := b.notify
+		b.mu.Unlock()
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-notify:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+func (c *BrokerConsumer) Commit(topic string, offsets map[int32]int64) error {
+	b := c.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	group, ok := b.groups[c.group]
+	if !ok {
+		return ErrNotSubscribed
+	}
+	committed, ok := group.committed[topic]
+	if !ok {
+		committed = make(map[int32]int64)
+		group.committed[topic] = committed
+	}
+	for partition, offset := range offsets {
+		committed[partition] = offset
+		b.retain(topic, partition)
+	}
+	return nil
+}
+
+// Close - отдать свои партиции через onRevoke и выйти из группы, партиции достанутся оставшимся участникам
+func (c *BrokerConsumer) Close() error {
+	b := c.broker
+	b.mu.Lock()
+	if c.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	group, ok := b.groups[c.group]
+	if ok {
+		for _, partition := range c.assigned {
+			group.revoking[partition] = true
+		}
+	}
+	c.revoked = append(c.revoked, c.assigned...)
+	c.assigned = nil
+	b.mu.Unlock()
+
+	c.handleRevoked()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !ok || c.topic == "" {
+		return nil
+	}
+	for i, member := range group.members {
+		if member == c {
+			group.members = append(group.members[:i], group.members[i+1:]...)
+			break
+		}
+	}
+	b.rebalance(group, c.topic)
+	return nil
+}
+
+// reassign - новое распределение партиций, отобранные партиции отзываются на следующем Poll
+func (c *BrokerConsumer) reassign(group *consumerGroup, partitions []int32) {
+	keep := make(map[int32]bool, len(partitions))
+	for _, partition := range partitions {
+		keep[partition] = true
+	}
+	for _, partition := range c.assigned {
+		if !keep[partition] {
+			c.revoked = append(c.revoked, partition)
+			group.revoking[partition] = true
+			delete(c.positions, partition)
+		}
+	}
+
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	c.assigned = partitions
+}
+
+// handleRevoked - вызвать onRevoke вне блокировки брокера, чтобы из него можно было сделать Commit
+func (c *BrokerConsumer) handleRevoked() {
+	b := c.broker
+	b.mu.Lock()
+	revoked, onRevoke := c.revoked, c.onRevoke
+	c.revoked = nil
+	b.mu.Unlock()
+
+	if len(revoked) == 0 {
+		return
+	}
+	if onRevoke != nil {
+		onRevoke(revoked)
+	}
+
+	b.mu.Lock()
+	if group, ok := b.groups[c.group]; ok {
+		for _, partition := range revoked {
+			delete(group.revoking, partition)
+		}
+	}
+	// Будим новых владельцев партиций
+	close(b.notify)
+	b.notify = make(chan struct{})
+	b.mu.Unlock()
+}
+
+// nextMessage - читаем партиции по кругу, чтобы одна не забивала остальные
+func (c *BrokerConsumer) nextMessage() (*ports.Message, bool) {
+	partitions := c.broker.topic(c.topic).partitions
+	group := c.broker.groups[c.group]
+	for i := 0; i < len(c.assigned); i++ {
+		partition := c.assigned[(c.next+i)%len(c.assigned)]
+		if group.revoking[partition] {
+			continue
+		}
+		position, ok := c.positions[partition]
+		if !ok {
+			// Начинаем с зафиксированного группой offset
+			position = group.committed[c.topic][partition]
+		}
+		c.positions[partition] = position
+		// Удалённые и вытесненные компакцией offset пропускаем
+		messages := partitions[partition].messages
+		index := sort.Search(len(messages), func(i int) bool { return messages[i].Offset >= position })
+		if index < len(messages) {
+			message := messages[index]
+			c.positions[partition] = message.Offset + 1
+			c.next = (c.next + i + 1) % len(c.assigned)
+			message.Headers = cloneHeaders(message.Headers)
+			return &message, true
+		}
+	}
+	return nil, false
+}
+
+func cloneHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(headers))
+	for key, value := range headers {
+		clone[key] = value
+	}
+	return clone
+}
@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+)
+
+const testTopic = "basket.confirmed"
+
+func publish(t *testing.T, broker *Broker, key string, value string) {
+	err := broker.NewPublisher().Publish(context.Background(), ports.Message{
+		Topic:   testTopic,
+		Key:     []byte(key),
+		Value:   []byte(value),
+		Headers: map[string]string{"content-type": "application/json"},
+	})
+	require.NoError(t, err)
+}
+
+func pollAll(t *testing.T, consumer *BrokerConsumer) []*ports.Message {
+	var messages []*ports.Message
+	for {
+		msg, err := consumer.Poll(10 * time.Millisecond)
+		require.NoError(t, err)
+		if msg == nil {
+			return messages
+		}
+		messages = append(messages, msg)
+	}
+}
+
+func Test_BrokerShouldKeepOrderWithinKey(t *testing.T) {
+	broker := NewBroker(3)
+	for i := range 5 {
+		publish(t, broker, "basket-1", fmt.Sprint(i))
+	}
+
+	messages := broker.Messages(testTopic)
+	require.Len(t, messages, 5)
+	for i, msg := range messages {
+		assert.Equal(t, messages[0].Partition, msg.Partition)
+		assert.Equal(t, int64(i), msg.Offset)
+		assert.Equal(t, fmt.Sprint(i), string(msg.Value))
+		assert.Equal(t, "application/json", msg.Headers["content-type"])
+	}
+}
+
+func Test_BrokerConsumerShouldResumeFromCommittedOffset(t *testing.T) {
+	broker := NewBroker(1)
+	for i := range 3 {
+		publish(t, broker, "basket-1", fmt.Sprint(i))
+	}
+
+	consumer := broker.NewConsumer("delivery")
+	require.NoError(t, consumer.Subscribe(testTopic, nil))
+	messages := pollAll(t, consumer)
+	require.Len(t, messages, 3)
+	// Другая группа подписана до фиксации и держит сообщения с начала
+	other := broker.NewConsumer("analytics")
+	require.NoError(t, other.Subscribe(testTopic, nil))
+
+	// Фиксируем только первое сообщение, остальные придут повторно
+	require.NoError(t, consumer.Commit(testTopic, map[int32]int64{0: 1}))
+	assert.Equal(t, int64(1), broker.Committed("delivery", testTopic, 0))
+	require.NoError(t, consumer.Close())
+
+	restarted := broker.NewConsumer("delivery")
+	require.NoError(t, restarted.Subscribe(testTopic, nil))
+	messages = pollAll(t, restarted)
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(1), messages[0].Offset)
+
+	// У другой группы своя позиция
+	assert.Len(t, pollAll(t, other), 3)
+	assert.Equal(t, int64(-1), broker.Committed("analytics", testTopic, 0))
+}
+
+func Test_BrokerShouldSplitPartitionsBetweenGroupMembers(t *testing.T) {
+	broker := NewBroker(4)
+	for i := range 20 {
+		publish(t, broker, fmt.Sprintf("basket-%d", i), fmt.Sprint(i))
+	}
+
+	first := broker.NewConsumer("delivery")
+	second := broker.NewConsumer("delivery")
+	require.NoError(t, first.Subscribe(testTopic, nil))
+	require.NoError(t, second.Subscribe(testTopic, nil))
+
+	firstMessages := pollAll(t, first)
+	secondMessages := pollAll(t, second)
+	assert.Len(t, append(firstMessages, secondMessages...), 20)
+
+	partitions := make(map[int32]bool)
+	for _, msg := range firstMessages {
+		partitions[msg.Partition] = true
+	}
+	for _, msg := range secondMessages {
+		assert.False(t, partitions[msg.Partition], "partition %d is read by both members", msg.Partition)
+	}
+}
+
+func Test_BrokerShouldRevokePartitionsOnRebalance(t *testing.T) {
+	broker := NewBroker(2)
+	for i := range 10 {
+		publish(t, broker, fmt.Sprintf("basket-%d", i), fmt.Sprint(i))
+	}
+
+	var revoked []int32
+	read := make(map[int32]int64)
+	first := broker.NewConsumer("delivery")
+	require.NoError(t, first.Subscribe(testTopic, func(partitions []int32) {
+		revoked = append(revoked, partitions...)
+		// Перед отдачей партиции фиксируем всё прочитанное
+		offsets := make(map[int32]int64)
+		for _, partition := range partitions {
+			offsets[partition] = read[partition]
+		}
+		require.NoError(t, first.Commit(testTopic, offsets))
+	}))
+	for _, msg := range pollAll(t, first) {
+		read[msg.Partition] = msg.Offset + 1
+	}
+	require.Len(t, read, 2)
+
+	second := broker.NewConsumer("delivery")
+	require.NoError(t, second.Subscribe(testTopic, nil))
+
+	// Отзыв срабатывает на следующем чтении старого владельца
+	assert.Empty(t, pollAll(t, first))
+	assert.Len(t, revoked, 1)
+	// Новый владелец продолжает с зафиксированного offset
+	assert.Empty(t, pollAll(t, second))
+}
+
+func Test_BrokerConsumerShouldFailAfterClose(t *testing.T) {
+	broker := NewBroker(1)
+	consumer := broker.NewConsumer("delivery")
+	require.NoError(t, consumer.Subscribe(testTopic, nil))
+	assert.ErrorIs(t, consumer.Subscribe(testTopic, nil), ErrAlreadySubscribed)
+
+	require.NoError(t, consumer.Close())
+	_, err := consumer.Poll(time.Millisecond)
+	assert.ErrorIs(t, err, ErrConsumerClosed)
+}
+
+func Test_BrokerShouldDropMessagesCommittedByAllGroups(t *testing.T) {
+	broker := NewBroker(1)
+	for i := range 4 {
+		publish(t, broker, "basket-1", fmt.Sprint(i))
+	}
+
+	delivery := broker.NewConsumer("delivery")
+	require.NoError(t, delivery.Subscribe(testTopic, nil))
+	analytics := broker.NewConsumer("analytics")
+	require.NoError(t, analytics.Subscribe(testTopic, nil))
+
+	require.NoError(t, delivery.Commit(testTopic, map[int32]int64{0: 3}))
+	assert.Len(t, broker.Messages(testTopic), 4)
+	require.NoError(t, analytics.Commit(testTopic, map[int32]int64{0: 2}))
+	messages := broker.Messages(testTopic)
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(2), messages[0].Offset)
+
+	// Новая группа читает с самого старого хранимого сообщения
+	late := broker.NewConsumer("late")
+	require.NoError(t, late.Subscribe(testTopic, nil))
+	read := pollAll(t, late)
+	require.Len(t, read, 2)
+	assert.Equal(t, int64(2), read[0].Offset)
+
+	publish(t, broker, "basket-1", "4")
+	assert.Equal(t, int64(4), broker.Messages(testTopic)[2].Offset)
+}
+
+func Test_BrokerShouldKeepLatestMessagePerKeyInCompactedTopic(t *testing.T) {
+	broker := NewBroker(2)
+	publish(t, broker, "courier-1", "1")
+	publish(t, broker, "courier-2", "1")
+	broker.Compact(testTopic)
+	for i := 2; i <= 5; i++ {
+		publish(t, broker, "courier-1", fmt.Sprint(i))
+		publish(t, broker, "courier-2", fmt.Sprint(i))
+	}
+
+	messages := broker.Messages(testTopic)
+	require.Len(t, messages, 2)
+	for _, msg := range messages {
+		assert.Equal(t, "5", string(msg.Value))
+		assert.Equal(t, int64(4), msg.Offset)
+	}
+
+	// Потребитель пропускает вытесненные offset
+	consumer := broker.NewConsumer("delivery")
+	require.NoError(t, consumer.Subscribe(testTopic, nil))
+	assert.Len(t, pollAll(t, consumer), 2)
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+)
+
+func cloneOrder(aggregate *order.Order) *order.Order {
+	var courierID = aggregate.AssignedCourier()
+	if courierID != nil {
+		id := *courierID
+		courierID = &id
+	}
+	return order.RestoreOrder(aggregate.ID(), aggregate.Region(), aggregate.Tier(), aggregate.DeliveryWindow(), courierID,
+		aggregate.Address(), aggregate.Location(), aggregate.Status(), aggregate.Timestamps(), aggregate.UnassignReason())
+}
+
+func cloneCourier(aggregate *courier.Courier) *courier.Courier {
+	transport := courier.RestoreTransport(
+		aggregate.Transport().ID(), aggregate.Transport().Name(), aggregate.Transport().Speed(),
+		aggregate.Transport().Profile())
+	var lastAssignedAt = aggregate.LastAssignedAt()
+	if lastAssignedAt != nil {
+		assignedAt := *lastAssignedAt
+		lastAssignedAt = &assignedAt
+	}
+	var homeZoneID = aggregate.HomeZoneID()
+	if homeZoneID != nil {
+		id := *homeZoneID
+		homeZoneID = &id
+	}
+	var lastSeenAt = aggregate.LastSeenAt()
+	if lastSeenAt != nil {
+		seenAt := *lastSeenAt
+		lastSeenAt = &seenAt
+	}
+	return courier.RestoreCourier(aggregate.ID(), aggregate.Region(), aggregate.Name(), transport, aggregate.Location(),
+		aggregate.Status(), aggregate.CreatedAt(), lastAssignedAt, homeZoneID, lastSeenAt)
+}
+
+func cloneZone(aggregate *zone.Zone) *zone.Zone {
+	return zone.RestoreZone(aggregate.ID(), aggregate.Region(), aggregate.Name(), aggregate.Shape(), aggregate.CreatedAt())
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.CourierRepository = &CourierRepository{}
+
+type CourierRepository struct {
+	storage *Storage
+}
+
+func NewCourierRepository(storage *Storage) (*CourierRepository, error) {
+	if storage == nil {
+		return nil, errs.NewValueIsRequiredError("storage")
+	}
+
+	return &CourierRepository{
+		storage: storage,
+	}, nil
+}
+
+func (r *CourierRepository) Add(ctx context.Context, aggregate *courier.Courier) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+	return r.storage.putCourier(ctx, aggregate, true)
+}
+
+func (r *CourierRepository) Update(ctx context.Context, aggregate *courier.Courier) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+	return r.storage.putCourier(ctx, aggregate, false)
+}
+
+func (r *CourierRepository) Get(ctx context.Context, ID uuid.UUID) (*courier.Courier, error) {
+	aggregate, ok := r.storage.getCourier(ctx, ID)
+	if !ok {
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return aggregate, nil
+}
+
+func (r *CourierRepository) GetAllInFreeStatus(ctx context.Context, region kernel.RegionCode) ([]*courier.Courier, error) {
+	aggregates := make([]*courier.Courier, 0)
+	for _, aggregate := range r.storage.listCouriers(ctx) {
+		if aggregate.IsFree() && aggregate.Region() == region {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
+
+func (r *CourierRepository) GetAllStale(ctx context.Context, seenBefore time.Time) ([]*courier.Courier, error) {
+	aggregates := make([]*courier.Courier, 0)
+	for _, aggregate := range r.storage.listCouriers(ctx) {
+		lastSeenAt := aggregate.LastSeenAt()
+		if !aggregate.IsOffline() && lastSeenAt != nil && lastSeenAt.Before(seenBefore) {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
+
+func (r *CourierRepository) GetAllNeverSeen(ctx context.Context, createdBefore time.Time) ([]*courier.Courier, error) {
+	aggregates := make([]*courier.Courier, 0)
+	for _, aggregate := range r.storage.listCouriers(ctx) {
+		if !aggregate.IsOffline() && aggregate.LastSeenAt() == nil && aggregate.CreatedAt().Before(createdBefore) {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+)
+
+var _ ports.GeoClient = &GeoClient{}
+
+// GeoClient - геосервис без сети: известные адреса берутся из словаря,
+// для остальных координаты детерминированно вычисляются по хэшу адреса.
+// Словарь хранится по Address.GeocodingKey, адрес из одной улицы подходит к этой улице в любом городе
+type GeoClient struct {
+	mu        sync.RWMutex
+	locations map[string]kernel.Location
+	bounds    kernel.Bounds
+	// cities - границы городов, адреса которых попадают не в общие bounds, а в сетку своего региона
+	cities map[string]kernel.Bounds
+}
+
+// NewGeoClient - bounds задаёт вид координат: клетки для kernel.Area, WGS84 для kernel.GeoBounds
+func NewGeoClient(locations map[kernel.Address]kernel.Location, bounds kernel.Bounds) *GeoClient {
+	client := &GeoClient{
+		locations: make(map[string]kernel.Location, len(locations)),
+		bounds:    bounds,
+		cities:    make(map[string]kernel.Bounds),
+	}
+	for address, location := range locations {
+		client.SetGeolocation(address, location)
+	}
+	return client
+}
+
+func (c *GeoClient) SetGeolocation(address kernel.Address, location kernel.Location) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.locations[address.GeocodingKey()] = location
+}
+
+// SetCityBounds - адреса города city вычисляются внутри bounds
+func (c *GeoClient) SetCityBounds(city string, bounds kernel.Bounds) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cities[normalizeCity(city)] = bounds
+}
+
+func (c *GeoClient) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	if err := ctx.Err(); err != nil {
+		return kernel.Location{}, err
+	}
+
+	if location, ok := c.Lookup(address); ok {
+		return location, nil
+	}
+
+	return HashLocation(address, c.boundsOf(address.City()))
+}
+
+func (c *GeoClient) boundsOf(city string) kernel.Bounds {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if bounds, ok := c.cities[normalizeCity(city)]; ok {
+		return bounds
+	}
+	return c.bounds
+}
+
+// Lookup - найти адрес только в словаре, без вычисления по хэшу. Сначала ищется адрес целиком,
+// затем улица без города
+func (c *GeoClient) Lookup(address kernel.Address) (kernel.Location, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if location, ok := c.locations[address.GeocodingKey()]; ok {
+		return location, true
+	}
+	street, err := kernel.NewStreetAddress(address.Street())
+	if err != nil {
+		return kernel.Location{}, false
+	}
+	location, ok := c.locations[street.GeocodingKey()]
+	return location, ok
+}
+
+// HashLocation - детерминированно вычислить координаты адреса внутри границ города
+func HashLocation(address kernel.Address, bounds kernel.Bounds) (kernel.Location, error) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(address.GeocodingKey()))
+	sum := h.Sum32()
+
+	switch b := bounds.(type) {
+	case kernel.Area:
+		width, height := uint32(b.Width()), uint32(b.Height())
+		x := int(sum%width) + b.Min().X()
+		y := int((sum/width)%height) + b.Min().Y()
+		return b.NewLocation(x, y)
+	case kernel.GeoBounds:
+		// Младшие и старшие 16 бит хэша - доли долготы и широты
+		east := float64(sum&0xffff) / 0xffff
+		north := float64(sum>>16) / 0xffff
+		return b.LocationAt(east, north), nil
+	default:
+		return kernel.Location{}, fmt.Errorf("unsupported bounds %T", bounds)
+	}
+}
+
+func normalizeCity(city string) string {
+	return strings.ToLower(strings.Join(strings.Fields(city), " "))
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func Test_GeoClientShouldNotMixSameStreetsOfDifferentCities(t *testing.T) {
+	ctx := context.Background()
+	moscow := kernel.MustNewAddress("Россия", "Москва", "Ленина", "1", "")
+	kazan := kernel.MustNewAddress("Россия", "Казань", "Ленина", "1", "")
+	client := NewGeoClient(map[kernel.Address]kernel.Location{
+		moscow: kernel.MustNewLocation(2, 3),
+		kernel.MustNewAddress("", "", "Бажная", "", ""): kernel.MustNewLocation(8, 9),
+	}, kernel.DefaultArea())
+
+	location, err := client.GetGeolocation(ctx, kernel.MustNewAddress("россия", " Москва ", "ленина", "1", "кв. 5"))
+	require.NoError(t, err)
+	assert.True(t, location.Equals(kernel.MustNewLocation(2, 3)))
+
+	_, ok := client.Lookup(kazan)
+	assert.False(t, ok)
+	expected, err := HashLocation(kazan, kernel.DefaultArea())
+	require.NoError(t, err)
+	location, err = client.GetGeolocation(ctx, kazan)
+	require.NoError(t, err)
+	assert.True(t, location.Equals(expected))
+
+	// Улица без города подходит к адресу в любом городе
+	location, err = client.GetGeolocation(ctx, kernel.MustNewAddress("Россия", "Казань", "Бажная", "7", ""))
+	require.NoError(t, err)
+	assert.True(t, location.Equals(kernel.MustNewLocation(8, 9)))
+}
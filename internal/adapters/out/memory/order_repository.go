@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.OrderRepository = &OrderRepository{}
+
+type OrderRepository struct {
+	storage *Storage
+}
+
+func NewOrderRepository(storage *Storage) (*OrderRepository, error) {
+	if storage == nil {
+		return nil, errs.NewValueIsRequiredError("storage")
+	}
+
+	return &OrderRepository{
+		storage: storage,
+	}, nil
+}
+
+func (r *OrderRepository) Add(ctx context.Context, aggregate *order.Order) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+	return r.storage.putOrder(ctx, aggregate, true)
+}
+
+func (r *OrderRepository) Update(ctx context.Context, aggregate *order.Order) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+	return r.storage.putOrder(ctx, aggregate, false)
+}
+
+func (r *OrderRepository) Get(ctx context.Context, ID uuid.UUID) (*order.Order, error) {
+	aggregate, ok := r.storage.getOrder(ctx, ID)
+	if !ok {
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return aggregate, nil
+}
+
+func (r *OrderRepository) GetFirstInCreatedStatus(ctx context.Context) (*order.Order, error) {
+	var first *order.Order
+	for _, aggregate := range r.storage.listOrders(ctx) {
+		if aggregate.Status() == order.StatusCreated && (first == nil || order.CompareForDispatch(aggregate, first) < 0) {
+			first = aggregate
+		}
+	}
+	if first == nil {
+		return nil, errs.NewObjectNotFoundError("Created order", nil)
+	}
+	return first, nil
+}
+
+func (r *OrderRepository) GetAllInCreatedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error) {
+	aggregates := make([]*order.Order, 0)
+	for _, aggregate := range r.storage.listOrders(ctx) {
+		if aggregate.Status() == order.StatusCreated && aggregate.Region() == region {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	slices.SortFunc(aggregates, order.CompareForDispatch)
+	return aggregates, nil
+}
+
+func (r *OrderRepository) GetAllInAssignedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error) {
+	aggregates := make([]*order.Order, 0)
+	for _, aggregate := range r.storage.listOrders(ctx) {
+		if aggregate.Status() == order.StatusAssigned && aggregate.Region() == region {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
+
+func (r *OrderRepository) GetAllAssignedToCourier(ctx context.Context, courierID uuid.UUID) ([]*order.Order, error) {
+	aggregates := make([]*order.Order, 0)
+	for _, aggregate := range r.storage.listOrders(ctx) {
+		if aggregate.IsAssigned() && *aggregate.AssignedCourier() == courierID {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
+
+func (r *OrderRepository) GetAllNotFinal(ctx context.Context) ([]*order.Order, error) {
+	aggregates := make([]*order.Order, 0)
+	for _, aggregate := range r.storage.listOrders(ctx) {
+		if !aggregate.Status().IsFinal() {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
+
+func (r *OrderRepository) GetAllInPendingGeocodeStatus(ctx context.Context) ([]*order.Order, error) {
+	aggregates := make([]*order.Order, 0)
+	for _, aggregate := range r.storage.listOrders(ctx) {
+		if aggregate.IsPendingGeocode() {
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates, nil
+}
@@ -0,0 +1,212 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var (
+	_ queries.GetAllCouriersQueryHandler        = &GetAllCouriersQueryHandler{}
+	_ queries.GetNotCompletedOrdersQueryHandler = &GetNotCompletedOrdersQueryHandler{}
+)
+
+type GetAllCouriersQueryHandler struct {
+	storage *Storage
+}
+
+func NewGetAllCouriersQueryHandler(storage *Storage) (*GetAllCouriersQueryHandler, error) {
+	if storage == nil {
+		return nil, errs.NewValueIsRequiredError("storage")
+	}
+	return &GetAllCouriersQueryHandler{storage: storage}, nil
+}
+
+func (q *GetAllCouriersQueryHandler) Handle(query queries.GetAllCouriersQuery) (queries.GetAllCouriersResponse, error) {
+	if query.IsEmpty() {
+		return queries.GetAllCouriersResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	filter := query.Filter()
+	matched := make([]*courier.Courier, 0)
+	for _, aggregate := range q.storage.listCouriers(context.Background()) {
+		if filter.Status != "" && aggregate.Status() != filter.Status {
+			continue
+		}
+		if !filter.Region.IsEmpty() && !aggregate.Region().Equals(filter.Region) {
+			continue
+		}
+		if filter.Transport != "" && !strings.EqualFold(aggregate.Transport().Name(), filter.Transport) {
+			continue
+		}
+		if filter.Box != nil && !filter.Box.Contains(aggregate.Location()) {
+			continue
+		}
+		if !filter.Created.Contains(aggregate.CreatedAt()) {
+			continue
+		}
+		matched = append(matched, aggregate)
+	}
+
+	keyOf := func(aggregate *courier.Courier) sortKey {
+		return sortKey{id: aggregate.ID(), name: aggregate.Name(), createdAt: aggregate.CreatedAt()}
+	}
+	aggregates, last, err := paginate(matched, query.Sort(), query.Page(), keyOf)
+	if err != nil {
+		return queries.GetAllCouriersResponse{}, err
+	}
+
+	var response queries.GetAllCouriersResponse
+	if last != nil {
+		response.NextCursor = query.NextCursor(last.ID(), last.Name(), last.CreatedAt())
+	}
+	if len(aggregates) == 0 {
+		return response, nil
+	}
+
+	assigned := make(map[uuid.UUID][]*order.Order)
+	for _, aggregate := range q.storage.listOrders(context.Background()) {
+		if aggregate.IsAssigned() {
+			courierID := *aggregate.AssignedCourier()
+			assigned[courierID] = append(assigned[courierID], aggregate)
+		}
+	}
+	for _, aggregate := range aggregates {
+		response.Couriers = append(response.Couriers, queries.NewCourierResponse(aggregate, assigned[aggregate.ID()]))
+	}
+	return response, nil
+}
+
+type GetNotCompletedOrdersQueryHandler struct {
+	storage *Storage
+}
+
+func NewGetNotCompletedOrdersQueryHandler(storage *Storage) (*GetNotCompletedOrdersQueryHandler, error) {
+	if storage == nil {
+		return nil, errs.NewValueIsRequiredError("storage")
+	}
+	return &GetNotCompletedOrdersQueryHandler{storage: storage}, nil
+}
+
+func (q *GetNotCompletedOrdersQueryHandler) Handle(
+	query queries.GetNotCompletedOrdersQuery) (queries.GetNotCompletedOrdersResponse, error) {
+	if query.IsEmpty() {
+		return queries.GetNotCompletedOrdersResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	filter := query.Filter()
+	matched := make([]*order.Order, 0)
+	for _, aggregate := range q.storage.listOrders(context.Background()) {
+		if !slices.Contains(queries.ActiveOrderStatuses, aggregate.Status()) {
+			continue
+		}
+		if filter.Status != "" && aggregate.Status() != filter.Status {
+			continue
+		}
+		if !filter.Region.IsEmpty() && !aggregate.Region().Equals(filter.Region) {
+			continue
+		}
+		if filter.Box != nil && !filter.Box.Contains(aggregate.Location()) {
+			continue
+		}
+		if !filter.Created.Contains(aggregate.CreatedAt()) {
+			continue
+		}
+		matched = append(matched, aggregate)
+	}
+
+	keyOf := func(aggregate *order.Order) sortKey {
+		return sortKey{id: aggregate.ID(), createdAt: aggregate.CreatedAt()}
+	}
+	aggregates, last, err := paginate(matched, query.Sort(), query.Page(), keyOf)
+	if err != nil {
+		return queries.GetNotCompletedOrdersResponse{}, err
+	}
+
+	var response queries.GetNotCompletedOrdersResponse
+	if last != nil {
+		response.NextCursor = query.NextCursor(last.ID(), last.CreatedAt())
+	}
+	for _, aggregate := range aggregates {
+		response.Orders = append(response.Orders, queries.OrderResponse{
+			ID:        aggregate.ID(),
+			CourierID: aggregate.AssignedCourier(),
+			Region:    aggregate.Region().String(),
+			Tier:      string(aggregate.Tier()),
+			Window:    queries.NewDeliveryWindowResponse(aggregate.DeliveryWindow()),
+			Status:    string(aggregate.Status()),
+			Location:  queries.NewLocationResponse(aggregate.Location()),
+		})
+	}
+	return response, nil
+}
+
+type sortKey struct {
+	id        uuid.UUID
+	name      string
+	createdAt time.Time
+}
+
+// compare - тот же порядок, что ORDER BY <поле>, id в Postgres
+func (k sortKey) compare(other sortKey, field string) int {
+	var result int
+	switch field {
+	case queries.SortByName:
+		result = strings.Compare(k.name, other.name)
+	default:
+		result = k.createdAt.Compare(other.createdAt)
+	}
+	if result != 0 {
+		return result
+	}
+	return bytes.Compare(k.id[:], other.id[:])
+}
+
+// paginate - отсортировать, пропустить всё до курсора и вернуть страницу.
+// last - последний элемент страницы, если за ним есть ещё элементы
+func paginate[T any](items []T, sort queries.Sort, page queries.Page, keyOf func(T) sortKey) ([]T, T, error) {
+	var none T
+	direction := 1
+	if sort.Desc {
+		direction = -1
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		return direction * keyOf(a).compare(keyOf(b), sort.Field)
+	})
+
+	if page.After != nil {
+		after := sortKey{id: page.After.ID}
+		switch sort.Field {
+		case queries.SortByName:
+			after.name = page.After.Key
+		default:
+			createdAt, err := queries.ParseTimeKey(page.After.Key)
+			if err != nil {
+				return nil, none, err
+			}
+			after.createdAt = createdAt
+		}
+		start := len(items)
+		for i, item := range items {
+			if direction*keyOf(item).compare(after, sort.Field) > 0 {
+				start = i
+				break
+			}
+		}
+		items = items[start:]
+	}
+
+	if len(items) > page.Size {
+		return items[:page.Size], items[page.Size-1], nil
+	}
+	return items, none, nil
+}
@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+var baseTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func storeCourier(storage *Storage, name string, transport string, x int, status courier.Status, minutes int) {
+	storage.storeCourier(courier.RestoreCourier(uuid.New(), kernel.DefaultRegion(), name, courier.RestoreTransport(uuid.New(), transport, 1, ""),
+		kernel.MustNewLocation(x, 1), status, baseTime.Add(time.Duration(minutes)*time.Minute), nil, nil, nil))
+}
+
+func listCouriers(t *testing.T, handler *GetAllCouriersQueryHandler, filter queries.CouriersFilter, sort string,
+	size int) []string {
+	parsedSort, err := queries.ParseSort(sort, queries.CourierSortFields, queries.DefaultCourierSort)
+	require.NoError(t, err)
+
+	var names []string
+	cursor := ""
+	for {
+		page, err := queries.NewPage(size, cursor)
+		require.NoError(t, err)
+		query, err := queries.NewGetAllCouriersQuery(filter, parsedSort, page)
+		require.NoError(t, err)
+		response, err := handler.Handle(query)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(response.Couriers), size)
+		for _, c := range response.Couriers {
+			names = append(names, c.Name)
+		}
+		if response.NextCursor == "" {
+			return names
+		}
+		cursor = response.NextCursor
+	}
+}
+
+func Test_GetAllCouriersShouldWalkPagesInSortOrder(t *testing.T) {
+	storage := NewStorage()
+	// Одинаковое время создания у двух курьеров: порядок между ними задаёт id
+	for i, name := range []string{"c", "a", "e", "b", "d"} {
+		storeCourier(storage, name, "Пешком", i+1, courier.StatusFree, i/2)
+	}
+	handler, err := NewGetAllCouriersQueryHandler(storage)
+	require.NoError(t, err)
+
+	byCreated := listCouriers(t, handler, queries.CouriersFilter{}, "created_at", 2)
+	require.Len(t, byCreated, 5)
+	assert.ElementsMatch(t, []string{"c", "a"}, byCreated[:2])
+	assert.ElementsMatch(t, []string{"e", "b"}, byCreated[2:4])
+	assert.Equal(t, "d", byCreated[4])
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, listCouriers(t, handler, queries.CouriersFilter{}, "name", 2))
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, listCouriers(t, handler, queries.CouriersFilter{}, "-name", 3))
+}
+
+func Test_GetAllCouriersShouldApplyFilters(t *testing.T) {
+	storage := NewStorage()
+	storeCourier(storage, "walker", "Пешком", 1, courier.StatusFree, 0)
+	storeCourier(storage, "cyclist", "Велосипед", 5, courier.StatusBusy, 10)
+	storeCourier(storage, "driver", "Машина", 9, courier.StatusFree, 20)
+	handler, err := NewGetAllCouriersQueryHandler(storage)
+	require.NoError(t, err)
+
+	box, err := queries.NewBoundingBox(4, 1, 10, 1)
+	require.NoError(t, err)
+	from := baseTime.Add(10 * time.Minute)
+	created, err := queries.NewTimeRange(&from, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		filter   queries.CouriersFilter
+		expected []string
+	}{
+		{queries.CouriersFilter{Status: courier.StatusFree}, []string{"walker", "driver"}},
+		{queries.CouriersFilter{Transport: "велосипед"}, []string{"cyclist"}},
+		{queries.CouriersFilter{Box: &box}, []string{"cyclist", "driver"}},
+		{queries.CouriersFilter{Created: created}, []string{"cyclist", "driver"}},
+		{queries.CouriersFilter{Status: courier.StatusFree, Box: &box}, []string{"driver"}},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			assert.Equal(t, tt.expected, listCouriers(t, handler, tt.filter, "", 10))
+		})
+	}
+}
+
+func Test_GetAllCouriersShouldFilterGeoLocationsByGeoBoundingBox(t *testing.T) {
+	storage := NewStorage()
+	storeCourier(storage, "grid", "Пешком", 1, courier.StatusFree, 0)
+	for name, lat := range map[string]float64{"center": 55.75, "north": 55.95} {
+		storage.storeCourier(courier.RestoreCourier(uuid.New(), kernel.DefaultRegion(), name,
+			courier.RestoreTransport(uuid.New(), "Пешком", 1, ""), kernel.MustNewGeoLocation(lat, 37.6),
+			courier.StatusFree, baseTime, nil, nil, nil))
+	}
+	handler, err := NewGetAllCouriersQueryHandler(storage)
+	require.NoError(t, err)
+
+	geoBox, err := queries.NewGeoBoundingBox(55.7, 37.5, 55.8, 37.7)
+	require.NoError(t, err)
+	gridBox, err := queries.NewBoundingBox(1, 1, 10, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"center"}, listCouriers(t, handler, queries.CouriersFilter{Box: &geoBox}, "name", 10))
+	assert.Equal(t, []string{"grid"}, listCouriers(t, handler, queries.CouriersFilter{Box: &gridBox}, "name", 10))
+}
+
+func Test_GetNotCompletedOrdersShouldPaginateActiveOrders(t *testing.T) {
+	storage := NewStorage()
+	var active []uuid.UUID
+	for i := range 5 {
+		aggregate := order.RestoreOrder(uuid.New(), kernel.DefaultRegion(), order.TierStandard, order.DeliveryWindow{},
+			nil, kernel.MustNewAddress("", "", "Бажная", "", ""),
+			kernel.MustNewLocation(i+1, 1), order.StatusCreated,
+			order.Timestamps{CreatedAt: baseTime.Add(time.Duration(i) * time.Minute)}, "")
+		if i == 2 {
+			require.NoError(t, aggregate.Cancel())
+		} else {
+			active = append(active, aggregate.ID())
+		}
+		storage.storeOrder(aggregate)
+	}
+	handler, err := NewGetNotCompletedOrdersQueryHandler(storage)
+	require.NoError(t, err)
+
+	sort, err := queries.ParseSort("-created_at", queries.OrderSortFields, queries.DefaultOrderSort)
+	require.NoError(t, err)
+	page, err := queries.NewPage(3, "")
+	require.NoError(t, err)
+	query, err := queries.NewGetNotCompletedOrdersQuery(queries.OrdersFilter{}, sort, page)
+	require.NoError(t, err)
+	first, err := handler.Handle(query)
+	require.NoError(t, err)
+	require.Len(t, first.Orders, 3)
+	require.NotEmpty(t, first.NextCursor)
+
+	page, err = queries.NewPage(3, first.NextCursor)
+	require.NoError(t, err)
+	query, err = queries.NewGetNotCompletedOrdersQuery(queries.OrdersFilter{}, sort, page)
+	require.NoError(t, err)
+	second, err := handler.Handle(query)
+	require.NoError(t, err)
+	require.Len(t, second.Orders, 1)
+	assert.Empty(t, second.NextCursor)
+
+	var ids []uuid.UUID
+	for _, o := range append(first.Orders, second.Orders...) {
+		ids = append(ids, o.ID)
+	}
+	assert.Equal(t, []uuid.UUID{active[3], active[2], active[1], active[0]}, ids)
+
+	// Курсор выдан для другой сортировки
+	page, err = queries.NewPage(3, first.NextCursor)
+	require.NoError(t, err)
+	_, err = queries.NewGetNotCompletedOrdersQuery(queries.OrdersFilter{}, queries.DefaultOrderSort, page)
+	assert.ErrorIs(t, err, queries.ErrInvalidCursor)
+}
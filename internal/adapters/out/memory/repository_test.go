@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports/portstest"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+func Test_OrderRepositoryContract(t *testing.T) {
+	portstest.RunOrderRepositoryContract(t, func(t *testing.T) (uow.UnitOfWork, ports.OrderRepository) {
+		storage := NewStorage()
+		unitOfWork, err := NewUnitOfWork(storage)
+		require.NoError(t, err)
+		orderRepository, err := NewOrderRepository(storage)
+		require.NoError(t, err)
+		return unitOfWork, orderRepository
+	})
+}
+
+func Test_CourierRepositoryContract(t *testing.T) {
+	portstest.RunCourierRepositoryContract(t, func(t *testing.T) (uow.UnitOfWork, ports.CourierRepository) {
+		storage := NewStorage()
+		unitOfWork, err := NewUnitOfWork(storage)
+		require.NoError(t, err)
+		courierRepository, err := NewCourierRepository(storage)
+		require.NoError(t, err)
+		return unitOfWork, courierRepository
+	})
+}
+
+func Test_ZoneRepositoryContract(t *testing.T) {
+	portstest.RunZoneRepositoryContract(t, func(t *testing.T) ports.ZoneRepository {
+		return NewZoneRepository()
+	})
+}
@@ -0,0 +1,348 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+var (
+	ErrObjectAlreadyExists = errors.New("object already exists")
+	// ErrConcurrentModification - агрегат изменили после того, как транзакция его прочитала
+	ErrConcurrentModification = errors.New("object was modified by another transaction")
+)
+
+type txKey struct{}
+
+// Storage - общее хранилище агрегатов для in-memory адаптеров.
+// Агрегаты хранятся копиями, поэтому изменения попадают в хранилище только через Add/Update.
+type Storage struct {
+	mu       sync.RWMutex
+	seq      uint64
+	orders   map[uuid.UUID]orderRecord
+	couriers map[uuid.UUID]courierRecord
+}
+
+// orderRecord - version растёт с каждым сохранением агрегата
+type orderRecord struct {
+	seq       uint64
+	version   uint64
+	aggregate *order.Order
+}
+
+type courierRecord struct {
+	seq       uint64
+	version   uint64
+	aggregate *courier.Courier
+}
+
+// transaction - изменения, накопленные внутри UnitOfWork до Commit.
+// orderVersions, courierVersions - версии агрегатов на момент первого чтения в транзакции, 0 - агрегата не было
+type transaction struct {
+	mu              sync.Mutex
+	orders          map[uuid.UUID]*order.Order
+	couriers        map[uuid.UUID]*courier.Courier
+	orderVersions   map[uuid.UUID]uint64
+	courierVersions map[uuid.UUID]uint64
+	ordered         []uuid.UUID
+	// onCommit - изменения хранилищ вне Storage, которые применяются только вместе с транзакцией
+	onCommit []func()
+	done     bool
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		orders:   make(map[uuid.UUID]orderRecord),
+		couriers: make(map[uuid.UUID]courierRecord),
+	}
+}
+
+func newTransaction() *transaction {
+	return &transaction{
+		orders:          make(map[uuid.UUID]*order.Order),
+		couriers:        make(map[uuid.UUID]*courier.Courier),
+		orderVersions:   make(map[uuid.UUID]uint64),
+		courierVersions: make(map[uuid.UUID]uint64),
+	}
+}
+
+func getTxFromContext(ctx context.Context) *transaction {
+	tx, ok := ctx.Value(txKey{}).(*transaction)
+	if ok {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		if !tx.done {
+			return tx
+		}
+	}
+	return nil
+}
+
+func (s *Storage) getOrder(ctx context.Context, ID uuid.UUID) (*order.Order, bool) {
+	tx := getTxFromContext(ctx)
+	if tx != nil {
+		tx.mu.Lock()
+		aggregate, ok := tx.orders[ID]
+		tx.mu.Unlock()
+		if ok {
+			return cloneOrder(aggregate), true
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.orders[ID]
+	if !ok {
+		return nil, false
+	}
+	if tx != nil {
+		tx.mu.Lock()
+		rememberVersion(tx.orderVersions, ID, record.version)
+		tx.mu.Unlock()
+	}
+	return cloneOrder(record.aggregate), true
+}
+
+// putOrder - сохранить копию агрегата; при isNew агрегат не должен существовать
+func (s *Storage) putOrder(ctx context.Context, aggregate *order.Order, isNew bool) error {
+	if tx := getTxFromContext(ctx); tx != nil {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		if isNew {
+			_, staged := tx.orders[aggregate.ID()]
+			s.mu.RLock()
+			_, stored := s.orders[aggregate.ID()]
+			s.mu.RUnlock()
+			if staged || stored {
+				return ErrObjectAlreadyExists
+			}
+			rememberVersion(tx.orderVersions, aggregate.ID(), 0)
+		}
+		tx.orders[aggregate.ID()] = cloneOrder(aggregate)
+		tx.ordered = append(tx.ordered, aggregate.ID())
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, stored := s.orders[aggregate.ID()]; isNew && stored {
+		return ErrObjectAlreadyExists
+	}
+	s.storeOrder(cloneOrder(aggregate))
+	return nil
+}
+
+// listOrders - вернуть копии всех заказов в порядке добавления с учётом незакоммиченных изменений
+func (s *Storage) listOrders(ctx context.Context) []*order.Order {
+	s.mu.RLock()
+	records := make([]orderRecord, 0, len(s.orders))
+	for _, record := range s.orders {
+		records = append(records, record)
+	}
+	s.mu.RUnlock()
+	sort.Slice(records, func(i, j int) bool { return records[i].seq < records[j].seq })
+
+	aggregates := make([]*order.Order, 0, len(records))
+	for _, record := range records {
+		aggregates = append(aggregates, record.aggregate)
+	}
+
+	if tx := getTxFromContext(ctx); tx != nil {
+		tx.mu.Lock()
+		for _, record := range records {
+			rememberVersion(tx.orderVersions, record.aggregate.ID(), record.version)
+		}
+		aggregates = mergeStaged(aggregates, tx.orders, tx.ordered, (*order.Order).ID)
+		tx.mu.Unlock()
+	}
+
+	result := make([]*order.Order, len(aggregates))
+	for i, aggregate := range aggregates {
+		result[i] = cloneOrder(aggregate)
+	}
+	return result
+}
+
+func (s *Storage) getCourier(ctx context.Context, ID uuid.UUID) (*courier.Courier, bool) {
+	tx := getTxFromContext(ctx)
+	if tx != nil {
+		tx.mu.Lock()
+		aggregate, ok := tx.couriers[ID]
+		tx.mu.Unlock()
+		if ok {
+			return cloneCourier(aggregate), true
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.couriers[ID]
+	if !ok {
+		return nil, false
+	}
+	if tx != nil {
+		tx.mu.Lock()
+		rememberVersion(tx.courierVersions, ID, record.version)
+		tx.mu.Unlock()
+	}
+	return cloneCourier(record.aggregate), true
+}
+
+// putCourier - сохранить копию агрегата; при isNew агрегат не должен существовать
+func (s *Storage) putCourier(ctx context.Context, aggregate *courier.Courier, isNew bool) error {
+	if tx := getTxFromContext(ctx); tx != nil {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		if isNew {
+			_, staged := tx.couriers[aggregate.ID()]
+			s.mu.RLock()
+			_, stored := s.couriers[aggregate.ID()]
+			s.mu.RUnlock()
+			if staged || stored {
+				return ErrObjectAlreadyExists
+			}
+			rememberVersion(tx.courierVersions, aggregate.ID(), 0)
+		}
+		tx.couriers[aggregate.ID()] = cloneCourier(aggregate)
+		tx.ordered = append(tx.ordered, aggregate.ID())
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, stored := s.couriers[aggregate.ID()]; isNew && stored {
+		return ErrObjectAlreadyExists
+	}
+	s.storeCourier(cloneCourier(aggregate))
+	return nil
+}
+
+// listCouriers - вернуть копии всех курьеров в порядке добавления с учётом незакоммиченных изменений
+func (s *Storage) listCouriers(ctx context.Context) []*courier.Courier {
+	s.mu.RLock()
+	records := make([]courierRecord, 0, len(s.couriers))
+	for _, record := range s.couriers {
+		records = append(records, record)
+	}
+	s.mu.RUnlock()
+	sort.Slice(records, func(i, j int) bool { return records[i].seq < records[j].seq })
+
+	aggregates := make([]*courier.Courier, 0, len(records))
+	for _, record := range records {
+		aggregates = append(aggregates, record.aggregate)
+	}
+
+	if tx := getTxFromContext(ctx); tx != nil {
+		tx.mu.Lock()
+		for _, record := range records {
+			rememberVersion(tx.courierVersions, record.aggregate.ID(), record.version)
+		}
+		aggregates = mergeStaged(aggregates, tx.couriers, tx.ordered, (*courier.Courier).ID)
+		tx.mu.Unlock()
+	}
+
+	result := make([]*courier.Courier, len(aggregates))
+	for i, aggregate := range aggregates {
+		result[i] = cloneCourier(aggregate)
+	}
+	return result
+}
+
+// commit - применить изменения транзакции к хранилищу. Если прочитанный транзакцией агрегат
+// с тех пор сохранили, не применяется ничего и возвращается ErrConcurrentModification
+func (s *Storage) commit(tx *transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ID := range tx.orders {
+		if version, ok := tx.orderVersions[ID]; ok && version != s.orders[ID].version {
+			return fmt.Errorf("%w: order %s", ErrConcurrentModification, ID)
+		}
+	}
+	for ID := range tx.couriers {
+		if version, ok := tx.courierVersions[ID]; ok && version != s.couriers[ID].version {
+			return fmt.Errorf("%w: courier %s", ErrConcurrentModification, ID)
+		}
+	}
+
+	for _, ID := range tx.ordered {
+		if aggregate, ok := tx.orders[ID]; ok {
+			s.storeOrder(aggregate)
+		}
+		if aggregate, ok := tx.couriers[ID]; ok {
+			s.storeCourier(aggregate)
+		}
+	}
+	for _, apply := range tx.onCommit {
+		apply()
+	}
+	return nil
+}
+
+func (s *Storage) storeOrder(aggregate *order.Order) {
+	record, ok := s.orders[aggregate.ID()]
+	if !ok {
+		s.seq++
+		record.seq = s.seq
+	}
+	record.version++
+	record.aggregate = aggregate
+	s.orders[aggregate.ID()] = record
+}
+
+func (s *Storage) storeCourier(aggregate *courier.Courier) {
+	record, ok := s.couriers[aggregate.ID()]
+	if !ok {
+		s.seq++
+		record.seq = s.seq
+	}
+	record.version++
+	record.aggregate = aggregate
+	s.couriers[aggregate.ID()] = record
+}
+
+// applyOnCommit - выполнить apply при Commit транзакции из ctx или сразу, если транзакции нет
+func applyOnCommit(ctx context.Context, apply func()) {
+	if tx := getTxFromContext(ctx); tx != nil {
+		tx.mu.Lock()
+		tx.onCommit = append(tx.onCommit, apply)
+		tx.mu.Unlock()
+		return
+	}
+	apply()
+}
+
+// rememberVersion - запомнить версию только при первом чтении, более поздние чтения её не обновляют
+func rememberVersion(versions map[uuid.UUID]uint64, ID uuid.UUID, version uint64) {
+	if _, ok := versions[ID]; !ok {
+		versions[ID] = version
+	}
+}
+
+// mergeStaged - заменить сохранённые агрегаты изменёнными в транзакции, новые добавить в конец
+func mergeStaged[T any](stored []T, staged map[uuid.UUID]T, ordered []uuid.UUID, id func(T) uuid.UUID) []T {
+	seen := make(map[uuid.UUID]bool, len(stored))
+	for i, aggregate := range stored {
+		ID := id(aggregate)
+		seen[ID] = true
+		if changed, ok := staged[ID]; ok {
+			stored[i] = changed
+		}
+	}
+	for _, ID := range ordered {
+		changed, ok := staged[ID]
+		if !ok || seen[ID] {
+			continue
+		}
+		seen[ID] = true
+		stored = append(stored, changed)
+	}
+	return stored
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+var _ uow.UnitOfWork = &UnitOfWork{}
+
+type UnitOfWork struct {
+	storage *Storage
+}
+
+func NewUnitOfWork(storage *Storage) (*UnitOfWork, error) {
+	if storage == nil {
+		return nil, errs.NewValueIsRequiredError("storage")
+	}
+	return &UnitOfWork{storage: storage}, nil
+}
+
+func (u *UnitOfWork) Begin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txKey{}, newTransaction())
+}
+
+func (u *UnitOfWork) Commit(ctx context.Context) error {
+	tx := getTxFromContext(ctx)
+	if tx == nil {
+		return nil
+	}
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.done = true
+	return u.storage.commit(tx)
+}
+
+func (u *UnitOfWork) Rollback(ctx context.Context) error {
+	tx := getTxFromContext(ctx)
+	if tx == nil {
+		return nil
+	}
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.done = true
+	return nil
+}
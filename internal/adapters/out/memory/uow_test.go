@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+func setupTest(t *testing.T) (*UnitOfWork, *OrderRepository, *CourierRepository) {
+	storage := NewStorage()
+
+	unitOfWork, err := NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	return unitOfWork, orderRepository, courierRepository
+}
+
+func Test_UnitOfWorkShouldDiscardChangesOnRollback(t *testing.T) {
+	ctx := context.Background()
+	unitOfWork, orderRepository, courierRepository := setupTest(t)
+
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(5, 5))
+	require.NoError(t, orderRepository.Add(ctx, orderAggregate))
+	courierAggregate := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, courierRepository.Add(ctx, courierAggregate))
+
+	// Меняем агрегаты внутри транзакции
+	txCtx := unitOfWork.Begin(ctx)
+	require.NoError(t, orderAggregate.AssignToCourier(courierAggregate.ID()))
+	require.NoError(t, courierAggregate.SetBusy())
+	require.NoError(t, orderRepository.Update(txCtx, orderAggregate))
+	require.NoError(t, courierRepository.Update(txCtx, courierAggregate))
+
+	// Внутри транзакции изменения видны, снаружи - нет
+	orderInTx, err := orderRepository.Get(txCtx, orderAggregate.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusAssigned, orderInTx.Status())
+	orderOutsideTx, err := orderRepository.Get(ctx, orderAggregate.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, orderOutsideTx.Status())
+
+	require.NoError(t, unitOfWork.Rollback(txCtx))
+
+	orderAfterRollback, err := orderRepository.Get(ctx, orderAggregate.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, orderAfterRollback.Status())
+	assert.Nil(t, orderAfterRollback.AssignedCourier())
+	courierAfterRollback, err := courierRepository.Get(ctx, courierAggregate.ID())
+	require.NoError(t, err)
+	assert.True(t, courierAfterRollback.IsFree())
+}
+
+func Test_UnitOfWorkShouldApplyChangesOnCommit(t *testing.T) {
+	ctx := context.Background()
+	unitOfWork, orderRepository, _ := setupTest(t)
+
+	txCtx := unitOfWork.Begin(ctx)
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(5, 5))
+	require.NoError(t, orderRepository.Add(txCtx, orderAggregate))
+
+	_, err := orderRepository.Get(ctx, orderAggregate.ID())
+	assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+
+	require.NoError(t, unitOfWork.Commit(txCtx))
+	// Rollback после Commit ничего не отменяет
+	require.NoError(t, unitOfWork.Rollback(txCtx))
+
+	orderAfterCommit, err := orderRepository.Get(ctx, orderAggregate.ID())
+	require.NoError(t, err)
+	require.NotNil(t, orderAfterCommit)
+	assert.Equal(t, orderAggregate.ID(), orderAfterCommit.ID())
+}
+
+func Test_RepositoryShouldNotLeakAggregateChanges(t *testing.T) {
+	ctx := context.Background()
+	_, orderRepository, _ := setupTest(t)
+
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(5, 5))
+	require.NoError(t, orderRepository.Add(ctx, orderAggregate))
+
+	// Изменение без Update не должно попасть в хранилище
+	require.NoError(t, orderAggregate.AssignToCourier(uuid.New()))
+
+	stored, err := orderRepository.Get(ctx, orderAggregate.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, stored.Status())
+	assert.ErrorIs(t, orderRepository.Add(ctx, orderAggregate), ErrObjectAlreadyExists)
+}
+
+func Test_UnitOfWorkShouldRejectCommitOverConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	unitOfWork, orderRepository, courierRepository := setupTest(t)
+
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(5, 5))
+	require.NoError(t, orderRepository.Add(ctx, orderAggregate))
+	courierAggregate := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, courierRepository.Add(ctx, courierAggregate))
+
+	// Транзакция выбирает свободного курьера списком
+	txCtx := unitOfWork.Begin(ctx)
+	freeCouriers, err := courierRepository.GetAllInFreeStatus(txCtx, kernel.DefaultRegion())
+	require.NoError(t, err)
+	require.Len(t, freeCouriers, 1)
+	orderInTx, err := orderRepository.Get(txCtx, orderAggregate.ID())
+	require.NoError(t, err)
+
+	// Тем временем курьера занимают без транзакции
+	require.NoError(t, courierAggregate.SetBusy())
+	require.NoError(t, courierRepository.Update(ctx, courierAggregate))
+
+	require.NoError(t, orderInTx.AssignToCourier(freeCouriers[0].ID()))
+	require.NoError(t, freeCouriers[0].SetBusy())
+	require.NoError(t, orderRepository.Update(txCtx, orderInTx))
+	require.NoError(t, courierRepository.Update(txCtx, freeCouriers[0]))
+	assert.ErrorIs(t, unitOfWork.Commit(txCtx), ErrConcurrentModification)
+
+	// Не применилось ничего, в том числе заказ, который никто не трогал
+	stored, err := orderRepository.Get(ctx, orderAggregate.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, stored.Status())
+}
+
+func Test_UnitOfWorkShouldAddWebhookDeliveriesOnCommit(t *testing.T) {
+	ctx := context.Background()
+	unitOfWork, _, _ := setupTest(t)
+	deliveries := NewWebhookDeliveryRepository()
+	subscriptionID := uuid.New()
+	newDelivery := func() *webhook.Delivery {
+		delivery, err := webhook.NewDelivery(subscriptionID, uuid.New(), webhook.EventOrderCreated, []byte("{}"), time.Now())
+		require.NoError(t, err)
+		return delivery
+	}
+	stored := func() []*webhook.Delivery {
+		got, err := deliveries.GetAllBySubscription(ctx, subscriptionID, 0)
+		require.NoError(t, err)
+		return got
+	}
+
+	txCtx := unitOfWork.Begin(ctx)
+	require.NoError(t, deliveries.Add(txCtx, newDelivery()))
+	require.NoError(t, unitOfWork.Rollback(txCtx))
+	assert.Empty(t, stored())
+
+	txCtx = unitOfWork.Begin(ctx)
+	require.NoError(t, deliveries.Add(txCtx, newDelivery()))
+	assert.Empty(t, stored())
+	require.NoError(t, unitOfWork.Commit(txCtx))
+	assert.Len(t, stored(), 1)
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var (
+	_ ports.WebhookSubscriptionRepository = &WebhookSubscriptionRepository{}
+	_ ports.WebhookDeliveryRepository     = &WebhookDeliveryRepository{}
+)
+
+// WebhookSubscriptionRepository - подписки не участвуют в UnitOfWork, поэтому хранятся отдельно от Storage
+type WebhookSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[uuid.UUID]*webhook.Subscription
+}
+
+func NewWebhookSubscriptionRepository() *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		subscriptions: make(map[uuid.UUID]*webhook.Subscription),
+	}
+}
+
+func (r *WebhookSubscriptionRepository) Add(ctx context.Context, subscription *webhook.Subscription) error {
+	if subscription == nil {
+		return errs.NewValueIsRequiredError("subscription")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscriptions[subscription.ID()]; ok {
+		return ErrObjectAlreadyExists
+	}
+	r.subscriptions[subscription.ID()] = subscription
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscriptions[ID]; !ok {
+		return errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	delete(r.subscriptions, ID)
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) Get(ctx context.Context, ID uuid.UUID) (*webhook.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	subscription, ok := r.subscriptions[ID]
+	if !ok {
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return subscription, nil
+}
+
+func (r *WebhookSubscriptionRepository) GetAll(ctx context.Context) ([]*webhook.Subscription, error) {
+	r.mu.RLock()
+	subscriptions := make([]*webhook.Subscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt().Before(subscriptions[j].CreatedAt())
+	})
+	return subscriptions, nil
+}
+
+// WebhookDeliveryRepository - доставки, добавленные в UnitOfWork, появляются только после Commit
+type WebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[uuid.UUID]webhook.Delivery
+}
+
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		deliveries: make(map[uuid.UUID]webhook.Delivery),
+	}
+}
+
+func (r *WebhookDeliveryRepository) Add(ctx context.Context, deliveries ...*webhook.Delivery) error {
+	r.mu.RLock()
+	for _, delivery := range deliveries {
+		if delivery == nil {
+			r.mu.RUnlock()
+			return errs.NewValueIsRequiredError("delivery")
+		}
+		if _, ok := r.deliveries[delivery.ID()]; ok {
+			r.mu.RUnlock()
+			return ErrObjectAlreadyExists
+		}
+	}
+	r.mu.RUnlock()
+
+	staged := make([]webhook.Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		staged = append(staged, *delivery)
+	}
+	applyOnCommit(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for _, delivery := range staged {
+			r.deliveries[delivery.ID()] = delivery
+		}
+	})
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *webhook.Delivery) error {
+	if delivery == nil {
+		return errs.NewValueIsRequiredError("delivery")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deliveries[delivery.ID()]; !ok {
+		return errs.NewObjectNotFoundError(delivery.ID().String(), delivery.ID())
+	}
+	r.deliveries[delivery.ID()] = *delivery
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, claimUntil time.Time,
+	limit int) ([]*webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := make([]*webhook.Delivery, 0)
+	for _, delivery := range r.deliveries {
+		if !delivery.IsFinished() && !delivery.NextAttemptAt().After(now) {
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttemptAt().Before(deliveries[j].NextAttemptAt())
+	})
+	deliveries = truncate(deliveries, limit)
+	for _, delivery := range deliveries {
+		err := delivery.Claim(claimUntil)
+		if err != nil {
+			return nil, err
+		}
+		r.deliveries[delivery.ID()] = *delivery
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) GetAllBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*webhook.Delivery, error) {
+	deliveries := r.filter(func(d *webhook.Delivery) bool {
+		return d.SubscriptionID() == subscriptionID
+	})
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt().After(deliveries[j].CreatedAt())
+	})
+	return truncate(deliveries, limit), nil
+}
+
+// filter - вернуть копии доставок, чтобы изменения попадали в репозиторий только через Update
+func (r *WebhookDeliveryRepository) filter(match func(d *webhook.Delivery) bool) []*webhook.Delivery {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	deliveries := make([]*webhook.Delivery, 0)
+	for _, delivery := range r.deliveries {
+		if match(&delivery) {
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	return deliveries
+}
+
+func truncate(deliveries []*webhook.Delivery, limit int) []*webhook.Delivery {
+	if limit > 0 && len(deliveries) > limit {
+		return deliveries[:limit]
+	}
+	return deliveries
+}
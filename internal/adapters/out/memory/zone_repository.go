@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.ZoneRepository = &ZoneRepository{}
+
+// ZoneRepository - зоны не участвуют в UnitOfWork, поэтому хранятся отдельно от Storage
+type ZoneRepository struct {
+	mu    sync.RWMutex
+	zones map[uuid.UUID]*zone.Zone
+}
+
+func NewZoneRepository() *ZoneRepository {
+	return &ZoneRepository{
+		zones: make(map[uuid.UUID]*zone.Zone),
+	}
+}
+
+func (r *ZoneRepository) Add(ctx context.Context, aggregate *zone.Zone) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.zones[aggregate.ID()]; ok {
+		return ErrObjectAlreadyExists
+	}
+	r.zones[aggregate.ID()] = cloneZone(aggregate)
+	return nil
+}
+
+func (r *ZoneRepository) Update(ctx context.Context, aggregate *zone.Zone) error {
+	if aggregate == nil {
+		return errs.NewValueIsRequiredError("aggregate")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.zones[aggregate.ID()]; !ok {
+		return errs.NewObjectNotFoundError(aggregate.ID().String(), aggregate.ID())
+	}
+	r.zones[aggregate.ID()] = cloneZone(aggregate)
+	return nil
+}
+
+func (r *ZoneRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.zones[ID]; !ok {
+		return errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	delete(r.zones, ID)
+	return nil
+}
+
+func (r *ZoneRepository) Get(ctx context.Context, ID uuid.UUID) (*zone.Zone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	aggregate, ok := r.zones[ID]
+	if !ok {
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return cloneZone(aggregate), nil
+}
+
+func (r *ZoneRepository) GetAll(ctx context.Context) ([]*zone.Zone, error) {
+	r.mu.RLock()
+	zones := make([]*zone.Zone, 0, len(r.zones))
+	for _, aggregate := range r.zones {
+		zones = append(zones, cloneZone(aggregate))
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].CreatedAt().Equal(zones[j].CreatedAt()) {
+			return zones[i].ID().String() < zones[j].ID().String()
+		}
+		return zones[i].CreatedAt().Before(zones[j].CreatedAt())
+	})
+	return zones, nil
+}
@@ -0,0 +1,35 @@
+package cityrepo
+
+import (
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+// AreaDTO - сетка региона, по строке на регион
+type AreaDTO struct {
+	ID     int    `gorm:"primaryKey"`
+	Region string `gorm:"type:varchar(32);not null;default:'default';uniqueIndex"`
+	MinX   int
+	MinY   int
+	MaxX   int
+	MaxY   int
+}
+
+// TableName - вернуть имя таблицы для сетки города
+func (AreaDTO) TableName() string {
+	return "city_area"
+}
+
+func DomainToDTO(ID int, region kernel.RegionCode, area kernel.Area) AreaDTO {
+	return AreaDTO{
+		ID:     ID,
+		Region: region.String(),
+		MinX:   area.Min().X(),
+		MinY:   area.Min().Y(),
+		MaxX:   area.Max().X(),
+		MaxY:   area.Max().Y(),
+	}
+}
+
+func DtoToDomain(dto AreaDTO) (kernel.Area, error) {
+	return kernel.NewArea(dto.MinX, dto.MinY, dto.MaxX, dto.MaxY)
+}
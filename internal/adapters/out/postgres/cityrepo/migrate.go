@@ -0,0 +1,139 @@
+package cityrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/geocacherepo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var ErrActiveOrdersOutOfArea = errors.New("active orders are out of area")
+
+// Точки WGS84 к сетке не относятся и не переносятся
+const outside = "location_lat IS NULL AND (location_x < ? OR location_x > ? OR location_y < ? OR location_y > ?)"
+
+// Migrate - приводит сохранённые данные каждого региона к его сетке из areas.
+// Регион без записи о сетке считается заполненным на DefaultArea. При смене сетки курьеры региона переносятся
+// на ближайшую клетку, а незавершённые заказы региона вне сетки останавливают миграцию:
+// их адрес придётся поправить вручную. Геокоды общие для всех регионов и забываются, только если не попадают
+// ни в одну сетку
+func Migrate(ctx context.Context, db *gorm.DB, areas map[kernel.RegionCode]kernel.Area) error {
+	if db == nil {
+		return errs.NewValueIsRequiredError("db")
+	}
+	if len(areas) == 0 {
+		return errs.NewValueIsRequiredError("areas")
+	}
+	for region, area := range areas {
+		if region.IsEmpty() || area.IsEmpty() {
+			return errs.NewValueIsRequiredError("area")
+		}
+	}
+	if err := db.AutoMigrate(&AreaDTO{}); err != nil {
+		return err
+	}
+
+	regions := make([]kernel.RegionCode, 0, len(areas))
+	for region := range areas {
+		regions = append(regions, region)
+	}
+	slices.SortFunc(regions, func(a, b kernel.RegionCode) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		changed := false
+		for _, region := range regions {
+			migrated, err := migrateRegion(tx, region, areas[region])
+			if err != nil {
+				return err
+			}
+			changed = changed || migrated
+		}
+		if !changed {
+			return nil
+		}
+
+		geolocations := tx.Where("NOT not_found")
+		for _, region := range regions {
+			geolocations = geolocations.Where(outside, bounds(areas[region])...)
+		}
+		geolocations = geolocations.Delete(&geocacherepo.GeolocationDTO{})
+		if geolocations.Error != nil {
+			return geolocations.Error
+		}
+		log.Printf("city areas migrated: %d geocodes dropped", geolocations.RowsAffected)
+		return nil
+	})
+}
+
+// migrateRegion - перенести курьеров региона в сетку area. false - сетка региона не менялась
+func migrateRegion(tx *gorm.DB, region kernel.RegionCode, area kernel.Area) (bool, error) {
+	previous := kernel.DefaultArea()
+	var dtos []AreaDTO
+	if err := tx.Where("region = ?", region.String()).Limit(1).Find(&dtos).Error; err != nil {
+		return false, err
+	}
+	ID := 0
+	if len(dtos) > 0 {
+		stored, err := DtoToDomain(dtos[0])
+		if err != nil {
+			return false, err
+		}
+		previous, ID = stored, dtos[0].ID
+	}
+	if previous.Equals(area) && len(dtos) > 0 {
+		return false, nil
+	}
+
+	var activeOrders int64
+	err := tx.Model(&orderrepo.OrderDTO{}).
+		Where("region = ? AND status IN ?", region.String(), []order.Status{order.StatusCreated, order.StatusAssigned}).
+		Where(outside, bounds(area)...).
+		Count(&activeOrders).Error
+	if err != nil {
+		return false, err
+	}
+	if activeOrders > 0 {
+		return false, fmt.Errorf("%w: %d orders of region %s are not in %s",
+			ErrActiveOrdersOutOfArea, activeOrders, region, area)
+	}
+
+	couriers := tx.Model(&courierrepo.CourierDTO{}).Where("region = ?", region.String()).
+		Where(outside, bounds(area)...).Updates(map[string]any{
+		"location_x": gorm.Expr("LEAST(GREATEST(location_x, ?), ?)", area.Min().X(), area.Max().X()),
+		"location_y": gorm.Expr("LEAST(GREATEST(location_y, ?), ?)", area.Min().Y(), area.Max().Y()),
+	})
+	if couriers.Error != nil {
+		return false, couriers.Error
+	}
+
+	// Новую строку нумеруем сами: первая сетка сохранялась с явным id и последовательность могла отстать
+	if ID == 0 {
+		if err := tx.Model(&AreaDTO{}).Select("COALESCE(MAX(id), 0) + 1").Scan(&ID).Error; err != nil {
+			return false, err
+		}
+	}
+	dto := DomainToDTO(ID, region, area)
+	if err := tx.Save(&dto).Error; err != nil {
+		return false, err
+	}
+	log.Printf("city area of region %s migrated from %s to %s: %d couriers moved",
+		region, previous, area, couriers.RowsAffected)
+	return true, nil
+}
+
+func bounds(area kernel.Area) []any {
+	return []any{area.Min().X(), area.Max().X(), area.Min().Y(), area.Max().Y()}
+}
@@ -1,6 +1,8 @@
 package courierrepo
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
@@ -9,22 +11,33 @@ import (
 
 type CourierDTO struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Region    string    `gorm:"type:varchar(32);not null;default:'default';index"`
 	Name      string
 	Transport TransportDTO   `gorm:"foreignKey:CourierID;constraint:OnDelete:CASCADE;"`
 	Location  LocationDTO    `gorm:"embedded;embeddedPrefix:location_"`
 	Status    courier.Status `gorm:"type:varchar(20)"`
+	CreatedAt time.Time      `gorm:"not null;default:now();index"`
+
+	LastAssignedAt *time.Time
+	HomeZoneID     *uuid.UUID `gorm:"type:uuid;index"`
+	// LastSeenAt - последний сигнал от курьера, nil - на связь не выходил
+	LastSeenAt *time.Time `gorm:"index"`
 }
 
 type TransportDTO struct {
 	ID        uuid.UUID `gorm:"primaryKey"`
 	Name      string
 	Speed     int
+	Profile   string    `gorm:"type:varchar(20);not null;default:''"`
 	CourierID uuid.UUID `gorm:"type:uuid;index"`
 }
 
 type LocationDTO struct {
 	X int
 	Y int
+	// Lat, Lon - заполнены только для координат WGS84
+	Lat *float64
+	Lon *float64
 }
 
 // TableName - вернуть имя таблицы для курьеров
@@ -40,25 +53,46 @@ func (TransportDTO) TableName() string {
 func DomainToDTO(aggregate *courier.Courier) CourierDTO {
 	var courierDTO CourierDTO
 	courierDTO.ID = aggregate.ID()
+	courierDTO.Region = aggregate.Region().String()
 	courierDTO.Name = aggregate.Name()
 	courierDTO.Transport = TransportDTO{
 		ID:        aggregate.Transport().ID(),
 		Name:      aggregate.Transport().Name(),
 		Speed:     aggregate.Transport().Speed(),
+		Profile:   aggregate.Transport().Profile(),
 		CourierID: aggregate.ID(),
 	}
-	courierDTO.Location = LocationDTO{
-		X: aggregate.Location().X(),
-		Y: aggregate.Location().Y(),
-	}
+	courierDTO.Location = locationToDTO(aggregate.Location())
 	courierDTO.Status = aggregate.Status()
+	courierDTO.CreatedAt = aggregate.CreatedAt()
+	courierDTO.LastAssignedAt = aggregate.LastAssignedAt()
+	courierDTO.HomeZoneID = aggregate.HomeZoneID()
+	courierDTO.LastSeenAt = aggregate.LastSeenAt()
 	return courierDTO
 }
 
 func DtoToDomain(dto CourierDTO) *courier.Courier {
 	var aggregate *courier.Courier
-	transport := courier.RestoreTransport(dto.Transport.ID, dto.Transport.Name, dto.Transport.Speed)
-	location, _ := kernel.NewLocation(dto.Location.X, dto.Location.Y)
-	aggregate = courier.RestoreCourier(dto.ID, dto.Name, transport, location, dto.Status)
+	transport := courier.RestoreTransport(dto.Transport.ID, dto.Transport.Name, dto.Transport.Speed,
+		dto.Transport.Profile)
+	location, _ := dtoToLocation(dto.Location)
+	region, _ := kernel.NewRegionCode(dto.Region)
+	aggregate = courier.RestoreCourier(dto.ID, region, dto.Name, transport, location, dto.Status, dto.CreatedAt,
+		dto.LastAssignedAt, dto.HomeZoneID, dto.LastSeenAt)
 	return aggregate
 }
+
+func locationToDTO(location kernel.Location) LocationDTO {
+	if location.IsGeo() {
+		lat, lon := location.Lat(), location.Lon()
+		return LocationDTO{Lat: &lat, Lon: &lon}
+	}
+	return LocationDTO{X: location.X(), Y: location.Y()}
+}
+
+func dtoToLocation(dto LocationDTO) (kernel.Location, error) {
+	if dto.Lat != nil && dto.Lon != nil {
+		return kernel.NewGeoLocation(*dto.Lat, *dto.Lon)
+	}
+	return kernel.NewLocation(dto.X, dto.Y)
+}
@@ -3,12 +3,14 @@ package courierrepo
 import (
 	"context"
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
@@ -67,6 +69,9 @@ func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*courier.Courier, e
 	result := tx.
 		Preload(clause.Associations).
 		Find(&dto, ID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 	if result.RowsAffected == 0 {
 		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
 	}
@@ -75,7 +80,7 @@ func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*courier.Courier, e
 	return aggregate, nil
 }
 
-func (r *Repository) GetAllInFreeStatus(ctx context.Context) ([]*courier.Courier, error) {
+func (r *Repository) GetAllInFreeStatus(ctx context.Context, region kernel.RegionCode) ([]*courier.Courier, error) {
 	var dtos []CourierDTO
 
 	tx := postgres.GetTxFromContext(ctx)
@@ -84,13 +89,56 @@ func (r *Repository) GetAllInFreeStatus(ctx context.Context) ([]*courier.Courier
 	}
 	result := tx.
 		Preload(clause.Associations).
-		Where("status = ?", courier.StatusFree).
+		Where("status = ? AND region = ?", courier.StatusFree, region.String()).
 		Find(&dtos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	if result.RowsAffected == 0 {
-		return nil, errs.NewObjectNotFoundError("Free couriers", nil)
+
+	aggregates := make([]*courier.Courier, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllStale(ctx context.Context, seenBefore time.Time) ([]*courier.Courier, error) {
+	var dtos []CourierDTO
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.
+		Preload(clause.Associations).
+		Where("status <> ? AND last_seen_at < ?", courier.StatusOffline, seenBefore).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	aggregates := make([]*courier.Courier, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllNeverSeen(ctx context.Context, createdBefore time.Time) ([]*courier.Courier, error) {
+	var dtos []CourierDTO
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.
+		Preload(clause.Associations).
+		Where("status <> ? AND last_seen_at IS NULL AND created_at < ?", courier.StatusOffline, createdBefore).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
 	aggregates := make([]*courier.Courier, len(dtos))
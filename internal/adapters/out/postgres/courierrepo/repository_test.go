@@ -9,9 +9,13 @@ import (
 	postgresgorm "gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports/portstest"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/testutil"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
 )
 
 func setupTest(t *testing.T) (context.Context, *gorm.DB, error) {
@@ -65,3 +69,19 @@ func Test_CourierRepositoryShouldCanAddCourier(t *testing.T) {
 	require.Equal(t, courierAggregate.ID(), courierFromDb.ID)
 	require.Equal(t, courierAggregate.Status(), courierFromDb.Status)
 }
+
+func Test_CourierRepositoryContract(t *testing.T) {
+	_, db, err := setupTest(t)
+	require.NoError(t, err)
+
+	portstest.RunCourierRepositoryContract(t, func(t *testing.T) (uow.UnitOfWork, ports.CourierRepository) {
+		// Каждый сценарий начинается с пустых таблиц
+		require.NoError(t, db.Exec("TRUNCATE TABLE couriers, transports").Error)
+
+		unitOfWork, err := postgres.NewUnitOfWork(db)
+		require.NoError(t, err)
+		courierRepository, err := NewRepository(db)
+		require.NoError(t, err)
+		return unitOfWork, courierRepository
+	})
+}
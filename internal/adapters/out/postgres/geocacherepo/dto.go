@@ -0,0 +1,65 @@
+package geocacherepo
+
+import (
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/geocache"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+type GeolocationDTO struct {
+	Key       string      `gorm:"primaryKey"`
+	Location  LocationDTO `gorm:"embedded;embeddedPrefix:location_"`
+	NotFound  bool
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+type LocationDTO struct {
+	X int
+	Y int
+	// Lat, Lon - заполнены только для координат WGS84
+	Lat *float64
+	Lon *float64
+}
+
+// TableName - вернуть имя таблицы для кэша геокодирования
+func (GeolocationDTO) TableName() string {
+	return "geolocations"
+}
+
+func EntryToDTO(entry geocache.Entry) GeolocationDTO {
+	return GeolocationDTO{
+		Key:       entry.Key,
+		Location:  locationToDTO(entry.Location),
+		NotFound:  entry.NotFound,
+		ExpiresAt: entry.ExpiresAt,
+	}
+}
+
+func DtoToEntry(dto GeolocationDTO) geocache.Entry {
+	var location kernel.Location
+	if !dto.NotFound {
+		location, _ = dtoToLocation(dto.Location)
+	}
+	return geocache.Entry{
+		Key:       dto.Key,
+		Location:  location,
+		NotFound:  dto.NotFound,
+		ExpiresAt: dto.ExpiresAt,
+	}
+}
+
+func locationToDTO(location kernel.Location) LocationDTO {
+	if location.IsGeo() {
+		lat, lon := location.Lat(), location.Lon()
+		return LocationDTO{Lat: &lat, Lon: &lon}
+	}
+	return LocationDTO{X: location.X(), Y: location.Y()}
+}
+
+func dtoToLocation(dto LocationDTO) (kernel.Location, error) {
+	if dto.Lat != nil && dto.Lon != nil {
+		return kernel.NewGeoLocation(*dto.Lat, *dto.Lon)
+	}
+	return kernel.NewLocation(dto.X, dto.Y)
+}
@@ -0,0 +1,54 @@
+package geocacherepo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/geocache"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ geocache.Store = &Repository{}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) (*Repository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &Repository{
+		db: db,
+	}, nil
+}
+
+func (r *Repository) Get(ctx context.Context, key string) (geocache.Entry, bool, error) {
+	var dtos []GeolocationDTO
+	result := r.db.WithContext(ctx).Where("key = ?", key).Limit(1).Find(&dtos)
+	if result.Error != nil {
+		return geocache.Entry{}, false, result.Error
+	}
+	if len(dtos) == 0 {
+		return geocache.Entry{}, false, nil
+	}
+	return DtoToEntry(dtos[0]), true, nil
+}
+
+func (r *Repository) Put(ctx context.Context, entry geocache.Entry) error {
+	dto := EntryToDTO(entry)
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&dto).Error
+}
+
+func (r *Repository) Delete(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Delete(&GeolocationDTO{}, "key = ?", key).Error
+}
+
+func (r *Repository) DeleteAll(ctx context.Context) error {
+	return r.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&GeolocationDTO{}).Error
+}
@@ -1,6 +1,8 @@
 package orderrepo
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
@@ -9,14 +11,41 @@ import (
 
 type OrderDTO struct {
 	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	Region    string       `gorm:"type:varchar(32);not null;default:'default';index"`
+	Tier      order.Tier   `gorm:"type:varchar(20);not null;default:'standard'"`
 	CourierID *uuid.UUID   `gorm:"type:uuid;index"`
+	Address   AddressDTO   `gorm:"embedded;embeddedPrefix:address_"`
 	Location  LocationDTO  `gorm:"embedded;embeddedPrefix:location_"`
 	Status    order.Status `gorm:"type:varchar(20)"`
+	CreatedAt time.Time    `gorm:"not null;default:now();index"`
+	// Моменты назначения, выезда курьера и доставки, nil - ещё не было
+	AssignedAt  *time.Time
+	PickedUpAt  *time.Time
+	CompletedAt *time.Time
+	// Когда замечены нарушения сроков назначения и доставки, каждое отмечается один раз
+	AssignSLABreachedAt  *time.Time
+	DeliverSLABreachedAt *time.Time
+	// UnassignReason - почему заказ последний раз сняли с курьера
+	UnassignReason order.UnassignReason `gorm:"type:varchar(32);not null;default:''"`
+	// DeliveryWindowFrom, DeliveryWindowTo - интервал доставки, только у заказов к интервалу
+	DeliveryWindowFrom *time.Time
+	DeliveryWindowTo   *time.Time
+}
+
+type AddressDTO struct {
+	Country   string
+	City      string
+	Street    string
+	House     string
+	Apartment string
 }
 
 type LocationDTO struct {
 	X int
 	Y int
+	// Lat, Lon - заполнены только для координат WGS84
+	Lat *float64
+	Lon *float64
 }
 
 func (OrderDTO) TableName() string {
@@ -26,18 +55,67 @@ func (OrderDTO) TableName() string {
 func DomainToDTO(aggregate *order.Order) OrderDTO {
 	var orderDTO OrderDTO
 	orderDTO.ID = aggregate.ID()
+	orderDTO.Region = aggregate.Region().String()
+	orderDTO.Tier = aggregate.Tier()
+	if window := aggregate.DeliveryWindow(); !window.IsEmpty() {
+		from, to := window.From(), window.To()
+		orderDTO.DeliveryWindowFrom = &from
+		orderDTO.DeliveryWindowTo = &to
+	}
 	orderDTO.CourierID = aggregate.AssignedCourier()
-	orderDTO.Location = LocationDTO{
-		X: aggregate.Location().X(),
-		Y: aggregate.Location().Y(),
+	orderDTO.Address = AddressDTO{
+		Country:   aggregate.Address().Country(),
+		City:      aggregate.Address().City(),
+		Street:    aggregate.Address().Street(),
+		House:     aggregate.Address().House(),
+		Apartment: aggregate.Address().Apartment(),
 	}
+	orderDTO.Location = locationToDTO(aggregate.Location())
 	orderDTO.Status = aggregate.Status()
+	timestamps := aggregate.Timestamps()
+	orderDTO.CreatedAt = timestamps.CreatedAt
+	orderDTO.AssignedAt = timestamps.AssignedAt
+	orderDTO.PickedUpAt = timestamps.PickedUpAt
+	orderDTO.CompletedAt = timestamps.CompletedAt
+	orderDTO.AssignSLABreachedAt = timestamps.AssignSLABreachedAt
+	orderDTO.DeliverSLABreachedAt = timestamps.DeliverSLABreachedAt
+	orderDTO.UnassignReason = aggregate.UnassignReason()
 	return orderDTO
 }
 
 func DtoToDomain(dto OrderDTO) *order.Order {
 	var aggregate *order.Order
-	location, _ := kernel.NewLocation(dto.Location.X, dto.Location.Y)
-	aggregate = order.RestoreOrder(dto.ID, dto.CourierID, location, dto.Status)
+	address, _ := kernel.NewAddress(dto.Address.Country, dto.Address.City, dto.Address.Street,
+		dto.Address.House, dto.Address.Apartment)
+	location, _ := dtoToLocation(dto.Location)
+	region, _ := kernel.NewRegionCode(dto.Region)
+	var window order.DeliveryWindow
+	if dto.DeliveryWindowFrom != nil && dto.DeliveryWindowTo != nil {
+		window, _ = order.NewDeliveryWindow(*dto.DeliveryWindowFrom, *dto.DeliveryWindowTo)
+	}
+	aggregate = order.RestoreOrder(dto.ID, region, dto.Tier, window, dto.CourierID, address, location, dto.Status,
+		order.Timestamps{
+			CreatedAt:            dto.CreatedAt,
+			AssignedAt:           dto.AssignedAt,
+			PickedUpAt:           dto.PickedUpAt,
+			CompletedAt:          dto.CompletedAt,
+			AssignSLABreachedAt:  dto.AssignSLABreachedAt,
+			DeliverSLABreachedAt: dto.DeliverSLABreachedAt,
+		}, dto.UnassignReason)
 	return aggregate
 }
+
+func locationToDTO(location kernel.Location) LocationDTO {
+	if location.IsGeo() {
+		lat, lon := location.Lat(), location.Lon()
+		return LocationDTO{Lat: &lat, Lon: &lon}
+	}
+	return LocationDTO{X: location.X(), Y: location.Y()}
+}
+
+func dtoToLocation(dto LocationDTO) (kernel.Location, error) {
+	if dto.Lat != nil && dto.Lon != nil {
+		return kernel.NewGeoLocation(*dto.Lat, *dto.Lon)
+	}
+	return kernel.NewLocation(dto.X, dto.Y)
+}
@@ -3,12 +3,16 @@ package orderrepo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
@@ -16,6 +20,18 @@ import (
 
 var _ ports.OrderRepository = &Repository{}
 
+// dispatchOrder - порядок order.CompareForDispatch: по Tier.Priority, внутри уровня по времени создания
+var dispatchOrder = func() string {
+	var builder strings.Builder
+	builder.WriteString("CASE tier")
+	for _, tier := range order.Tiers() {
+		fmt.Fprintf(&builder, " WHEN '%s' THEN %d", tier, tier.Priority())
+	}
+	// Заказы, созданные до появления уровней, - обычные
+	fmt.Fprintf(&builder, " ELSE %d END, created_at, id", order.TierStandard.Priority())
+	return builder.String()
+}()
+
 type Repository struct {
 	db *gorm.DB
 }
@@ -68,8 +84,11 @@ func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*order.Order, error
 	result := tx.
 		Preload(clause.Associations).
 		Find(&dto, ID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 	if result.RowsAffected == 0 {
-		return nil, nil
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
 	}
 
 	aggregate := DtoToDomain(dto)
@@ -86,10 +105,12 @@ func (r *Repository) GetFirstInCreatedStatus(ctx context.Context) (*order.Order,
 	result := tx.
 		Preload(clause.Associations).
 		Where("status = ?", order.StatusCreated).
-		First(&dto)
+		Order(dispatchOrder).
+		Limit(1).
+		Take(&dto)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errs.NewObjectNotFoundError("Free courier", nil)
+			return nil, errs.NewObjectNotFoundError("Created order", nil)
 		}
 		return nil, result.Error
 	}
@@ -98,7 +119,7 @@ func (r *Repository) GetFirstInCreatedStatus(ctx context.Context) (*order.Order,
 	return aggregate, nil
 }
 
-func (r *Repository) GetAllInAssignedStatus(ctx context.Context) ([]*order.Order, error) {
+func (r *Repository) GetAllInCreatedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error) {
 	var dtos []OrderDTO
 
 	tx := postgres.GetTxFromContext(ctx)
@@ -107,13 +128,103 @@ func (r *Repository) GetAllInAssignedStatus(ctx context.Context) ([]*order.Order
 	}
 	result := tx.
 		Preload(clause.Associations).
-		Where("status = ?", order.StatusAssigned).
+		Where("status = ? AND region = ?", order.StatusCreated, region.String()).
+		Order(dispatchOrder).
 		Find(&dtos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	if result.RowsAffected == 0 {
-		return nil, errs.NewObjectNotFoundError("Assigned orders", nil)
+
+	aggregates := make([]*order.Order, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllInAssignedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error) {
+	var dtos []OrderDTO
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.
+		Preload(clause.Associations).
+		Where("status = ? AND region = ?", order.StatusAssigned, region.String()).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	aggregates := make([]*order.Order, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllInPendingGeocodeStatus(ctx context.Context) ([]*order.Order, error) {
+	var dtos []OrderDTO
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.
+		Preload(clause.Associations).
+		Where("status = ?", order.StatusPendingGeocode).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	aggregates := make([]*order.Order, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllNotFinal(ctx context.Context) ([]*order.Order, error) {
+	var dtos []OrderDTO
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.
+		Preload(clause.Associations).
+		Where("status NOT IN ?", []order.Status{order.StatusCompleted, order.StatusCancelled}).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	aggregates := make([]*order.Order, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllAssignedToCourier(ctx context.Context, courierID uuid.UUID) ([]*order.Order, error) {
+	var dtos []OrderDTO
+
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	result := tx.
+		Preload(clause.Associations).
+		Where("status = ? AND courier_id = ?", order.StatusAssigned, courierID).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
 	aggregates := make([]*order.Order, len(dtos))
@@ -10,9 +10,13 @@ import (
 	postgresgorm "gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports/portstest"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/testutil"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
 )
 
 func setupTest(t *testing.T) (context.Context, *gorm.DB, error) {
@@ -51,7 +55,7 @@ func Test_OrderRepositoryShouldCanAddOrder(t *testing.T) {
 
 	location, err := kernel.MaxLocation()
 	require.NoError(t, err)
-	orderAggregate, err := order.NewOrder(uuid.New(), location)
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MustNewAddress("Россия", "Москва", "Бажная", "1", "2"), location)
 	err = orderRepository.Add(ctx, orderAggregate)
 	require.NoError(t, err)
 
@@ -64,3 +68,19 @@ func Test_OrderRepositoryShouldCanAddOrder(t *testing.T) {
 	require.Equal(t, orderAggregate.ID(), orderFromDb.ID)
 	require.Equal(t, orderAggregate.Status(), orderFromDb.Status)
 }
+
+func Test_OrderRepositoryContract(t *testing.T) {
+	_, db, err := setupTest(t)
+	require.NoError(t, err)
+
+	portstest.RunOrderRepositoryContract(t, func(t *testing.T) (uow.UnitOfWork, ports.OrderRepository) {
+		// Каждый сценарий начинается с пустой таблицы
+		require.NoError(t, db.Exec("TRUNCATE TABLE orders").Error)
+
+		unitOfWork, err := postgres.NewUnitOfWork(db)
+		require.NoError(t, err)
+		orderRepository, err := NewRepository(db)
+		require.NoError(t, err)
+		return unitOfWork, orderRepository
+	})
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 
 	"gorm.io/gorm"
 
@@ -24,8 +25,10 @@ func NewUnitOfWork(db *gorm.DB) (*UnitOfWork, error) {
 	return &UnitOfWork{db: db}, nil
 }
 
+// Begin - в repeatable read изменение строки, которую после начала транзакции сохранил кто-то другой,
+// завершается ошибкой сериализации, а не затирает чужие изменения
 func (u *UnitOfWork) Begin(ctx context.Context) context.Context {
-	tx := u.db.Begin()
+	tx := u.db.Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	return context.WithValue(ctx, txKey{}, tx)
 }
 
@@ -0,0 +1,89 @@
+package webhookrepo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+)
+
+type SubscriptionDTO struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	URL        string
+	Secret     string
+	EventTypes string
+	CreatedAt  time.Time
+}
+
+type DeliveryDTO struct {
+	ID             uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	SubscriptionID uuid.UUID              `gorm:"type:uuid;index"`
+	EventID        uuid.UUID              `gorm:"type:uuid"`
+	EventType      webhook.EventType      `gorm:"type:varchar(50)"`
+	Payload        []byte                 `gorm:"type:jsonb"`
+	Status         webhook.DeliveryStatus `gorm:"type:varchar(20);index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int
+	ResponseCode   int
+	LastError      string
+	NextAttemptAt  time.Time `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (SubscriptionDTO) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (DeliveryDTO) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Типы событий храним строкой через запятую, они короткие и без запятых
+func SubscriptionToDTO(subscription *webhook.Subscription) SubscriptionDTO {
+	eventTypes := make([]string, 0, len(subscription.EventTypes()))
+	for _, eventType := range subscription.EventTypes() {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return SubscriptionDTO{
+		ID:         subscription.ID(),
+		URL:        subscription.URL(),
+		Secret:     subscription.Secret(),
+		EventTypes: strings.Join(eventTypes, ","),
+		CreatedAt:  subscription.CreatedAt(),
+	}
+}
+
+func DtoToSubscription(dto SubscriptionDTO) *webhook.Subscription {
+	var eventTypes []webhook.EventType
+	for _, eventType := range strings.Split(dto.EventTypes, ",") {
+		if eventType != "" {
+			eventTypes = append(eventTypes, webhook.EventType(eventType))
+		}
+	}
+	return webhook.RestoreSubscription(dto.ID, dto.URL, dto.Secret, eventTypes, dto.CreatedAt)
+}
+
+func DeliveryToDTO(delivery *webhook.Delivery) DeliveryDTO {
+	return DeliveryDTO{
+		ID:             delivery.ID(),
+		SubscriptionID: delivery.SubscriptionID(),
+		EventID:        delivery.EventID(),
+		EventType:      delivery.EventType(),
+		Payload:        delivery.Payload(),
+		Status:         delivery.Status(),
+		Attempts:       delivery.Attempts(),
+		ResponseCode:   delivery.ResponseCode(),
+		LastError:      delivery.LastError(),
+		NextAttemptAt:  delivery.NextAttemptAt(),
+		CreatedAt:      delivery.CreatedAt(),
+		UpdatedAt:      delivery.UpdatedAt(),
+	}
+}
+
+func DtoToDelivery(dto DeliveryDTO) *webhook.Delivery {
+	return webhook.RestoreDelivery(dto.ID, dto.SubscriptionID, dto.EventID, dto.EventType, dto.Payload,
+		dto.Status, dto.Attempts, dto.ResponseCode, dto.LastError,
+		dto.NextAttemptAt, dto.CreatedAt, dto.UpdatedAt)
+}
@@ -0,0 +1,173 @@
+package webhookrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/postgres"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var (
+	_ ports.WebhookSubscriptionRepository = &SubscriptionRepository{}
+	_ ports.WebhookDeliveryRepository     = &DeliveryRepository{}
+)
+
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) (*SubscriptionRepository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &SubscriptionRepository{
+		db: db,
+	}, nil
+}
+
+func (r *SubscriptionRepository) Add(ctx context.Context, subscription *webhook.Subscription) error {
+	dto := SubscriptionToDTO(subscription)
+	return r.db.WithContext(ctx).Create(&dto).Error
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&SubscriptionDTO{}, "id = ?", ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) Get(ctx context.Context, ID uuid.UUID) (*webhook.Subscription, error) {
+	var dtos []SubscriptionDTO
+	result := r.db.WithContext(ctx).Where("id = ?", ID).Limit(1).Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(dtos) == 0 {
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return DtoToSubscription(dtos[0]), nil
+}
+
+func (r *SubscriptionRepository) GetAll(ctx context.Context) ([]*webhook.Subscription, error) {
+	var dtos []SubscriptionDTO
+	result := r.db.WithContext(ctx).Order("created_at").Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	subscriptions := make([]*webhook.Subscription, len(dtos))
+	for i, dto := range dtos {
+		subscriptions[i] = DtoToSubscription(dto)
+	}
+	return subscriptions, nil
+}
+
+type DeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) (*DeliveryRepository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &DeliveryRepository{
+		db: db,
+	}, nil
+}
+
+func (r *DeliveryRepository) Add(ctx context.Context, deliveries ...*webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	dtos := make([]DeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = DeliveryToDTO(delivery)
+	}
+
+	// Доставки сохраняются в транзакции изменения заказа, если она есть
+	tx := postgres.GetTxFromContext(ctx)
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.WithContext(ctx).Create(&dtos).Error
+}
+
+func (r *DeliveryRepository) Update(ctx context.Context, delivery *webhook.Delivery) error {
+	dto := DeliveryToDTO(delivery)
+	return r.db.WithContext(ctx).Save(&dto).Error
+}
+
+// ClaimDue - строки, которые уже забрал другой экземпляр, пропускаются, а не ждут его транзакцию
+func (r *DeliveryRepository) ClaimDue(ctx context.Context, now time.Time, claimUntil time.Time,
+	limit int) ([]*webhook.Delivery, error) {
+	var deliveries []*webhook.Delivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", webhook.DeliveryStatusPending, now).
+			Order("next_attempt_at")
+		due, err := find(query, limit)
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(due))
+		for i, delivery := range due {
+			err = delivery.Claim(claimUntil)
+			if err != nil {
+				return err
+			}
+			ids[i] = delivery.ID()
+		}
+		err = tx.Model(&DeliveryDTO{}).Where("id IN ?", ids).Update("next_attempt_at", claimUntil).Error
+		if err != nil {
+			return err
+		}
+		deliveries = due
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *DeliveryRepository) GetAllBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*webhook.Delivery, error) {
+	query := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC")
+	return find(query, limit)
+}
+
+func find(query *gorm.DB, limit int) ([]*webhook.Delivery, error) {
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var dtos []DeliveryDTO
+	result := query.Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	deliveries := make([]*webhook.Delivery, len(dtos))
+	for i, dto := range dtos {
+		deliveries[i] = DtoToDelivery(dto)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,113 @@
+package zonerepo
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+)
+
+type ZoneDTO struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Region string    `gorm:"type:varchar(32);not null;default:'default';index"`
+	Name   string
+	// Cells* - диапазон клеток, у многоугольника нули
+	CellsMinX int
+	CellsMinY int
+	CellsMaxX int
+	CellsMaxY int
+	// Polygon - вершины многоугольника, у диапазона клеток пусто
+	Polygon   []byte    `gorm:"type:jsonb"`
+	CreatedAt time.Time `gorm:"not null;default:now();index"`
+}
+
+type VertexDTO struct {
+	X int `json:"x,omitempty"`
+	Y int `json:"y,omitempty"`
+	// Lat, Lon - заполнены только для координат WGS84
+	Lat *float64 `json:"lat,omitempty"`
+	Lon *float64 `json:"lon,omitempty"`
+}
+
+// TableName - вернуть имя таблицы для зон
+func (ZoneDTO) TableName() string {
+	return "zones"
+}
+
+func DomainToDTO(aggregate *zone.Zone) (ZoneDTO, error) {
+	dto := ZoneDTO{
+		ID:        aggregate.ID(),
+		Region:    aggregate.Region().String(),
+		Name:      aggregate.Name(),
+		CreatedAt: aggregate.CreatedAt(),
+	}
+	shape := aggregate.Shape()
+	if !shape.IsPolygon() {
+		cells := shape.Cells()
+		dto.CellsMinX, dto.CellsMinY = cells.Min().X(), cells.Min().Y()
+		dto.CellsMaxX, dto.CellsMaxY = cells.Max().X(), cells.Max().Y()
+		return dto, nil
+	}
+
+	vertices := make([]VertexDTO, 0, len(shape.Polygon()))
+	for _, vertex := range shape.Polygon() {
+		if vertex.IsGeo() {
+			lat, lon := vertex.Lat(), vertex.Lon()
+			vertices = append(vertices, VertexDTO{Lat: &lat, Lon: &lon})
+			continue
+		}
+		vertices = append(vertices, VertexDTO{X: vertex.X(), Y: vertex.Y()})
+	}
+	polygon, err := json.Marshal(vertices)
+	if err != nil {
+		return ZoneDTO{}, err
+	}
+	dto.Polygon = polygon
+	return dto, nil
+}
+
+func DtoToDomain(dto ZoneDTO) (*zone.Zone, error) {
+	shape, err := dtoToShape(dto)
+	if err != nil {
+		return nil, err
+	}
+	region, err := kernel.NewRegionCode(dto.Region)
+	if err != nil {
+		return nil, err
+	}
+	return zone.RestoreZone(dto.ID, region, dto.Name, shape, dto.CreatedAt), nil
+}
+
+func dtoToShape(dto ZoneDTO) (zone.Shape, error) {
+	if len(dto.Polygon) == 0 {
+		cells, err := kernel.NewArea(dto.CellsMinX, dto.CellsMinY, dto.CellsMaxX, dto.CellsMaxY)
+		if err != nil {
+			return zone.Shape{}, err
+		}
+		return zone.NewCellsShape(cells)
+	}
+
+	var vertices []VertexDTO
+	if err := json.Unmarshal(dto.Polygon, &vertices); err != nil {
+		return zone.Shape{}, err
+	}
+	locations := make([]kernel.Location, 0, len(vertices))
+	for _, vertex := range vertices {
+		location, err := vertexToLocation(vertex)
+		if err != nil {
+			return zone.Shape{}, err
+		}
+		locations = append(locations, location)
+	}
+	return zone.NewPolygonShape(locations)
+}
+
+func vertexToLocation(dto VertexDTO) (kernel.Location, error) {
+	if dto.Lat != nil && dto.Lon != nil {
+		return kernel.NewGeoLocation(*dto.Lat, *dto.Lon)
+	}
+	return kernel.NewLocation(dto.X, dto.Y)
+}
@@ -0,0 +1,94 @@
+package zonerepo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.ZoneRepository = &Repository{}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) (*Repository, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	return &Repository{
+		db: db,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, aggregate *zone.Zone) error {
+	dto, err := DomainToDTO(aggregate)
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Create(&dto).Error
+}
+
+func (r *Repository) Update(ctx context.Context, aggregate *zone.Zone) error {
+	dto, err := DomainToDTO(aggregate)
+	if err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).Model(&ZoneDTO{}).Where("id = ?", dto.ID).
+		Select("name", "cells_min_x", "cells_min_y", "cells_max_x", "cells_max_y", "polygon").
+		Updates(&dto)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewObjectNotFoundError(dto.ID.String(), dto.ID)
+	}
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, ID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&ZoneDTO{}, "id = ?", ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*zone.Zone, error) {
+	var dtos []ZoneDTO
+	result := r.db.WithContext(ctx).Where("id = ?", ID).Limit(1).Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(dtos) == 0 {
+		return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+	}
+	return DtoToDomain(dtos[0])
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]*zone.Zone, error) {
+	var dtos []ZoneDTO
+	result := r.db.WithContext(ctx).Order("created_at, id").Find(&dtos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	zones := make([]*zone.Zone, len(dtos))
+	for i, dto := range dtos {
+		aggregate, err := DtoToDomain(dto)
+		if err != nil {
+			return nil, err
+		}
+		zones[i] = aggregate
+	}
+	return zones, nil
+}
@@ -0,0 +1,36 @@
+package zonerepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	postgresgorm "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports/portstest"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/testutil"
+)
+
+func Test_ZoneRepositoryContract(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, dsn, err := testutil.StartPostgresContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		postgresContainer.Terminate(ctx)
+	})
+
+	db, err := gorm.Open(postgresgorm.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ZoneDTO{}))
+
+	portstest.RunZoneRepositoryContract(t, func(t *testing.T) ports.ZoneRepository {
+		// Каждый сценарий начинается с пустой таблицы
+		require.NoError(t, db.Exec("TRUNCATE TABLE zones").Error)
+
+		repository, err := NewRepository(db)
+		require.NoError(t, err)
+		return repository
+	})
+}
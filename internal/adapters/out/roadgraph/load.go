@@ -0,0 +1,117 @@
+package roadgraph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+)
+
+// Load - прочитать дороги города из текстового файла, по дороге на строку:
+//
+//	# from to [oneway] [street|highway|footway]
+//	1 1 1 10 highway
+//	1 10 10 10 oneway
+//
+// На сетке точка задаётся как x y, в координатах WGS84 - как lat lon. Все перекрёстки должны попадать в bounds
+func Load(path string, bounds kernel.Bounds) (*routing.Graph, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	graph, err := Read(file, bounds)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return graph, nil
+}
+
+func Read(r io.Reader, bounds kernel.Bounds) (*routing.Graph, error) {
+	graph := routing.NewGraph()
+	scanner := bufio.NewScanner(r)
+	var errs []error
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if err := addRoad(graph, fields, bounds); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return graph, nil
+}
+
+func addRoad(graph *routing.Graph, fields []string, bounds kernel.Bounds) error {
+	if len(fields) < 4 {
+		return fmt.Errorf("expected 4 coordinates, got %q", strings.Join(fields, " "))
+	}
+	oneWay := false
+	class := routing.RoadStreet
+	for _, option := range fields[4:] {
+		switch {
+		case option == "oneway":
+			oneWay = true
+		case routing.RoadClass(option).IsValid():
+			class = routing.RoadClass(option)
+		default:
+			return fmt.Errorf("unknown road option %q", option)
+		}
+	}
+
+	from, err := parseLocation(fields[0], fields[1], bounds)
+	if err != nil {
+		return err
+	}
+	to, err := parseLocation(fields[2], fields[3], bounds)
+	if err != nil {
+		return err
+	}
+	return graph.AddRoad(from, to, class, oneWay)
+}
+
+func parseLocation(first, second string, bounds kernel.Bounds) (kernel.Location, error) {
+	var location kernel.Location
+	if _, ok := bounds.(kernel.GeoBounds); ok {
+		lat, err := strconv.ParseFloat(first, 64)
+		if err != nil {
+			return kernel.Location{}, err
+		}
+		lon, err := strconv.ParseFloat(second, 64)
+		if err != nil {
+			return kernel.Location{}, err
+		}
+		location, err = kernel.NewGeoLocation(lat, lon)
+		if err != nil {
+			return kernel.Location{}, err
+		}
+	} else {
+		x, err := strconv.Atoi(first)
+		if err != nil {
+			return kernel.Location{}, err
+		}
+		y, err := strconv.Atoi(second)
+		if err != nil {
+			return kernel.Location{}, err
+		}
+		location, err = kernel.NewLocation(x, y)
+		if err != nil {
+			return kernel.Location{}, err
+		}
+	}
+	return location, bounds.Validate(location)
+}
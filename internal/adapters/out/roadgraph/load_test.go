@@ -0,0 +1,57 @@
+package roadgraph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+)
+
+func Test_ReadShouldBuildGraph(t *testing.T) {
+	graph, err := Read(strings.NewReader(`
+# комментарий
+1 1 5 1 oneway
+5 1 5 5 highway
+`), kernel.DefaultArea())
+	require.NoError(t, err)
+	assert.Equal(t, 3, graph.Nodes())
+
+	path, err := graph.Route(kernel.MustNewLocation(1, 1), kernel.MustNewLocation(5, 5), nil)
+	require.NoError(t, err)
+	segments := path.Segments()
+	require.Len(t, segments, 2)
+	assert.Equal(t, routing.RoadStreet, segments[0].Class)
+	assert.Equal(t, routing.RoadHighway, segments[1].Class)
+
+	_, err = graph.Route(kernel.MustNewLocation(5, 5), kernel.MustNewLocation(1, 1), nil)
+	assert.ErrorIs(t, err, routing.ErrNoRoute)
+}
+
+func Test_ReadShouldReportInvalidLines(t *testing.T) {
+	_, err := Read(strings.NewReader("1 1 5\n1 1 a 1\n1 1 20 1\n1 1 5 1 twoway\n"), kernel.DefaultArea())
+
+	require.Error(t, err)
+	for _, line := range []string{"line 1", "line 2", "line 3", "line 4"} {
+		assert.Contains(t, err.Error(), line)
+	}
+	assert.ErrorIs(t, err, kernel.ErrLocationOutOfArea)
+}
+
+func Test_ReadShouldParseWgs84(t *testing.T) {
+	graph, err := Read(strings.NewReader("55.75 37.60 55.76 37.60\n"), kernel.DefaultGeoBounds())
+	require.NoError(t, err)
+
+	path, err := graph.Route(kernel.MustNewGeoLocation(55.75, 37.60), kernel.MustNewGeoLocation(55.76, 37.60), nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 1112, path.Length(), 1)
+}
+
+func Test_LoadCityRoads(t *testing.T) {
+	graph, err := Load("../../../../deps/roads/city.txt", kernel.DefaultArea())
+	require.NoError(t, err)
+	assert.NotZero(t, graph.Nodes())
+}
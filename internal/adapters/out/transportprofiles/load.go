@@ -0,0 +1,139 @@
+package transportprofiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+)
+
+type fileDTO struct {
+	// Zones - зоны города: на сетке minX, minY, maxX, maxY, в координатах WGS84 south, west, north, east
+	Zones    map[string][4]float64 `json:"zones"`
+	Profiles []profileDTO          `json:"profiles"`
+}
+
+type profileDTO struct {
+	Name  string                        `json:"name"`
+	Speed int                           `json:"speed"`
+	Roads map[routing.RoadClass]float64 `json:"roads"`
+	Zones map[string]float64            `json:"zones"`
+	Hours []hoursDTO                    `json:"hours"`
+}
+
+type hoursDTO struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Factor float64 `json:"factor"`
+}
+
+// Load - прочитать профили транспорта из JSON-файла:
+//
+//	{
+//	  "zones": {"old_town": [1, 8, 3, 10]},
+//	  "profiles": [{"name": "car", "speed": 3, "roads": {"street": 1, "highway": 1.5}, "zones": {"old_town": 0},
+//	    "hours": [{"from": "08:00", "to": "10:00", "factor": 0.5}]}]
+//	}
+//
+// Зоны задаются в координатах города bounds
+func Load(path string, bounds kernel.Bounds) (*courier.Profiles, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles, err := Read(file, bounds)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return profiles, nil
+}
+
+func Read(r io.Reader, bounds kernel.Bounds) (*courier.Profiles, error) {
+	var file fileDTO
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	zones := make(map[string]kernel.Bounds, len(file.Zones))
+	for name, corners := range file.Zones {
+		zone, err := parseZone(corners, bounds)
+		if err != nil {
+			return nil, fmt.Errorf("zone %s: %w", name, err)
+		}
+		zones[name] = zone
+	}
+
+	profiles := make([]*courier.Profile, 0, len(file.Profiles))
+	for _, dto := range file.Profiles {
+		profile, err := toProfile(dto, zones)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", dto.Name, err)
+		}
+		profiles = append(profiles, profile)
+	}
+	return courier.NewProfiles(profiles...)
+}
+
+func parseZone(corners [4]float64, bounds kernel.Bounds) (kernel.Bounds, error) {
+	if _, ok := bounds.(kernel.GeoBounds); ok {
+		return kernel.NewGeoBounds(corners[0], corners[1], corners[2], corners[3])
+	}
+	for _, corner := range corners {
+		if corner != float64(int(corner)) {
+			return nil, fmt.Errorf("grid zone corner %v is not a cell", corner)
+		}
+	}
+	return kernel.NewArea(int(corners[0]), int(corners[1]), int(corners[2]), int(corners[3]))
+}
+
+func toProfile(dto profileDTO, zones map[string]kernel.Bounds) (*courier.Profile, error) {
+	// Зона, в которую попадает точка первой, задаёт скорость, поэтому порядок должен быть стабильным
+	names := make([]string, 0, len(dto.Zones))
+	for name := range dto.Zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zoneRules := make([]courier.ZoneRule, 0, len(names))
+	for _, name := range names {
+		zone, ok := zones[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown zone %s", name)
+		}
+		zoneRules = append(zoneRules, courier.ZoneRule{Name: name, Bounds: zone, Factor: dto.Zones[name]})
+	}
+
+	hoursRules := make([]courier.HoursRule, 0, len(dto.Hours))
+	for _, hours := range dto.Hours {
+		from, err := parseClock(hours.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseClock(hours.To)
+		if err != nil {
+			return nil, err
+		}
+		hoursRules = append(hoursRules, courier.HoursRule{From: from, To: to, Factor: hours.Factor})
+	}
+
+	return courier.NewProfile(dto.Name, dto.Speed, dto.Roads, zoneRules, hoursRules)
+}
+
+// parseClock - время суток HH:MM от полуночи
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
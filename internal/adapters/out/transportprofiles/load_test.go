@@ -0,0 +1,64 @@
+package transportprofiles
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+)
+
+func Test_LoadProfiles(t *testing.T) {
+	profiles, err := Load("../../../../deps/transport/profiles.json", kernel.DefaultArea())
+	require.NoError(t, err)
+
+	for _, name := range []string{courier.ProfileWalking, courier.ProfileBicycle, courier.ProfileScooter, courier.ProfileCar} {
+		_, ok := profiles.Get(name)
+		assert.True(t, ok, name)
+	}
+
+	car, _ := profiles.Get(courier.ProfileCar)
+	transport, err := car.NewTransport("Машина")
+	require.NoError(t, err)
+	rush := profiles.Vehicle(transport, time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC))
+	night := profiles.Vehicle(transport, time.Date(2025, 1, 1, 23, 0, 0, 0, time.UTC))
+	from, to := kernel.MustNewLocation(10, 1), kernel.MustNewLocation(10, 2)
+	assert.Less(t, rush.Speed(from, to, routing.RoadStreet), night.Speed(from, to, routing.RoadStreet))
+	// В старый город на машине нельзя
+	assert.Zero(t, night.Speed(kernel.MustNewLocation(4, 9), kernel.MustNewLocation(3, 9), routing.RoadStreet))
+}
+
+func Test_ReadShouldRejectInvalidProfiles(t *testing.T) {
+	testCases := map[string]string{
+		"unknown zone":   `{"profiles": [{"name": "car", "speed": 3, "roads": {"street": 1}, "zones": {"park": 0}}]}`,
+		"unknown field":  `{"profiles": [{"name": "car", "speed": 3, "roads": {"street": 1}, "lanes": 2}]}`,
+		"fraction cell":  `{"zones": {"park": [1.5, 1, 2, 2]}, "profiles": []}`,
+		"bad hours":      `{"profiles": [{"name": "car", "speed": 3, "roads": {"street": 1}, "hours": [{"from": "25:00", "to": "10:00", "factor": 1}]}]}`,
+		"unknown road":   `{"profiles": [{"name": "car", "speed": 3, "roads": {"river": 1}}]}`,
+		"duplicate name": `{"profiles": [{"name": "car", "speed": 3, "roads": {"street": 1}}, {"name": "car", "speed": 2, "roads": {"street": 1}}]}`,
+	}
+
+	for name, file := range testCases {
+		_, err := Read(strings.NewReader(file), kernel.DefaultArea())
+		assert.Error(t, err, name)
+	}
+}
+
+func Test_ReadShouldParseGeoZones(t *testing.T) {
+	profiles, err := Read(strings.NewReader(`{
+		"zones": {"kremlin": [55.748, 37.612, 55.755, 37.623]},
+		"profiles": [{"name": "car", "speed": 3, "roads": {"street": 1}, "zones": {"kremlin": 0}}]
+	}`), kernel.DefaultGeoBounds())
+	require.NoError(t, err)
+
+	car, _ := profiles.Get(courier.ProfileCar)
+	vehicle := car.Vehicle(car.Speed(), time.Now())
+	outside := kernel.MustNewGeoLocation(55.76, 37.60)
+	assert.Zero(t, vehicle.Speed(outside, kernel.MustNewGeoLocation(55.75, 37.62), routing.RoadStreet))
+	assert.Equal(t, 3.0, vehicle.Speed(outside, kernel.MustNewGeoLocation(55.77, 37.60), routing.RoadStreet))
+}
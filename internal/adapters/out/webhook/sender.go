@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+const (
+	HeaderSignature = "X-Delivery-Signature"
+	HeaderTimestamp = "X-Delivery-Timestamp"
+	HeaderEvent     = "X-Delivery-Event"
+	HeaderID        = "X-Delivery-Id"
+)
+
+// ErrForbiddenTarget - адрес получателя внутренний: loopback, частная сеть, link-local и т.п.
+var ErrForbiddenTarget = errors.New("webhook target address is not allowed")
+
+var _ ports.WebhookSender = &Sender{}
+
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender - allowPrivateTargets разрешает отправку на внутренние адреса, например в локальной разработке.
+// Иначе адрес проверяется после разрешения имени при каждом соединении, в том числе после редиректа,
+// поэтому подписка не может превратить сервис в прокси во внутреннюю сеть
+func NewSender(timeout time.Duration, allowPrivateTargets bool) (*Sender, error) {
+	if timeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("timeout")
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateTargets {
+		dialer.Control = rejectPrivateTargets
+	}
+	transport := &http.Transport{
+		// Прокси соединялся бы с получателем сам, в обход проверки адреса
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   timeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &Sender{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+		now:    time.Now,
+	}, nil
+}
+
+func (s *Sender) Send(ctx context.Context, request ports.WebhookRequest) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, Sign(request.Secret, timestamp, request.Payload))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderEvent, string(request.EventType))
+	req.Header.Set(HeaderID, request.DeliveryID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Тело не нужно, но дочитываем его, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// rejectPrivateTargets - вызывается для уже разрешённого адреса перед соединением
+func rejectPrivateTargets(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenTarget, address)
+	}
+	if !IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenTarget, addrPort.Addr())
+	}
+	return nil
+}
+
+// IsPublicAddr - адрес доступен из интернета: не loopback, не частная сеть, не link-local,
+// не multicast и не служебный диапазон
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// reservedPrefixes - служебные диапазоны, которые netip не относит к частным
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// Sign - подпись "sha256=<hex>" от HMAC-SHA256(secret, timestamp + "." + body).
+// Получатель повторяет вычисление и сравнивает с заголовком X-Delivery-Signature
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
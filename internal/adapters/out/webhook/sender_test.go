@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+)
+
+func Test_SenderShouldRejectPrivateTargets(t *testing.T) {
+	received := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	request := ports.WebhookRequest{
+		URL:        server.URL,
+		Secret:     "secret",
+		DeliveryID: uuid.New(),
+		EventType:  webhook.EventOrderCreated,
+		Payload:    []byte(`{}`),
+	}
+
+	sender, err := NewSender(time.Second, false)
+	require.NoError(t, err)
+	_, err = sender.Send(context.Background(), request)
+	assert.ErrorIs(t, err, ErrForbiddenTarget)
+
+	// Редирект на внутренний адрес тоже отклоняется
+	redirect := httptest.NewServer(http.RedirectHandler(server.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+	request.URL = redirect.URL
+	_, err = sender.Send(context.Background(), request)
+	assert.ErrorIs(t, err, ErrForbiddenTarget)
+	assert.Equal(t, 0, received)
+
+	allowing, err := NewSender(time.Second, true)
+	require.NoError(t, err)
+	request.URL = server.URL
+	statusCode, err := allowing.Send(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+	assert.Equal(t, 1, received)
+}
+
+func Test_IsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
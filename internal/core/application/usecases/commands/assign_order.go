@@ -4,7 +4,13 @@ import (
 	"context"
 	"errors"
 	"log"
+	"time"
 
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/services"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
@@ -16,19 +22,34 @@ var (
 	NotAvailableCouriers = errors.New("not available couriers")
 )
 
+// AssignOrdersCommandHandler - назначает заказы одного региона его курьерам
 type AssignOrdersCommandHandler struct {
+	region            kernel.RegionCode
 	unitOfWork        uow.UnitOfWork
 	orderRepository   ports.OrderRepository
 	courierRepository ports.CourierRepository
+	zoneRepository    ports.ZoneRepository
 	orderDispatcher   *services.Dispatcher
+	eventPublisher    ports.DomainEventPublisher
+	outbox            ports.DomainEventOutbox
+	// crossZoneWait - сколько заказ ждёт курьера своей зоны, прежде чем его предложат курьерам других зон
+	crossZoneWait time.Duration
 }
 
 func NewAssignOrdersCommandHandler(
+	region kernel.RegionCode,
 	unitOfWork uow.UnitOfWork,
 	orderRepository ports.OrderRepository,
 	courierRepository ports.CourierRepository,
+	zoneRepository ports.ZoneRepository,
 	orderDispatcher *services.Dispatcher,
+	eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox,
+	crossZoneWait time.Duration,
 ) (*AssignOrdersCommandHandler, error) {
+	if region.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("region")
+	}
 	if unitOfWork == nil {
 		return nil, errs.NewValueIsRequiredError("unitOfWork")
 	}
@@ -38,32 +59,58 @@ func NewAssignOrdersCommandHandler(
 	if courierRepository == nil {
 		return nil, errs.NewValueIsRequiredError("courierRepository")
 	}
+	if zoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("zoneRepository")
+	}
 	if orderDispatcher == nil {
 		return nil, errs.NewValueIsRequiredError("orderDispatcher")
 	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+	if crossZoneWait < 0 {
+		return nil, errs.NewValueIsInvalidError("crossZoneWait")
+	}
 
 	return &AssignOrdersCommandHandler{
+		region:            region,
 		unitOfWork:        unitOfWork,
 		orderRepository:   orderRepository,
 		courierRepository: courierRepository,
-		orderDispatcher:   orderDispatcher}, nil
+		zoneRepository:    zoneRepository,
+		orderDispatcher:   orderDispatcher,
+		eventPublisher:    eventPublisher,
+		outbox:            outbox,
+		crossZoneWait:     crossZoneWait}, nil
 }
 
+// Handle - назначить курьера самому старому заказу, которому он сейчас доступен. Заказ, ждущий курьера
+// своей зоны или недоступный по дорогам, не задерживает заказы за ним. Заказ к интервалу ждёт начала интервала
 func (ch *AssignOrdersCommandHandler) Handle(ctx context.Context, command AssignOrdersCommand) error {
 	if command.isEmpty() {
 		return errs.NewValueIsRequiredError("add address command")
 	}
 
 	// Восстановили
-	orderAggregate, err := ch.orderRepository.GetFirstInCreatedStatus(ctx)
+	created, err := ch.orderRepository.GetAllInCreatedStatus(ctx, ch.region)
 	if err != nil {
 		return err
 	}
-	if orderAggregate == nil {
+	now := time.Now()
+	orders := make([]*order.Order, 0, len(created))
+	for _, orderAggregate := range created {
+		if !orderAggregate.WaitsForDeliveryWindow(now) {
+			orders = append(orders, orderAggregate)
+		}
+	}
+	if len(orders) == 0 {
 		return NotAvailableOrders
 	}
 
-	couriers, err := ch.courierRepository.GetAllInFreeStatus(ctx)
+	couriers, err := ch.courierRepository.GetAllInFreeStatus(ctx, ch.region)
 	if err != nil {
 		return err
 	}
@@ -71,13 +118,32 @@ func (ch *AssignOrdersCommandHandler) Handle(ctx context.Context, command Assign
 		return NotAvailableCouriers
 	}
 
-	// Изменили
-	courier, err := ch.orderDispatcher.Dispatch(orderAggregate, couriers)
+	allZones, err := ch.zoneRepository.GetAll(ctx)
 	if err != nil {
 		return err
 	}
+	zones := make([]*zone.Zone, 0, len(allZones))
+	for _, z := range allZones {
+		if z.Region() == ch.region {
+			zones = append(zones, z)
+		}
+	}
 
-	// Сохранили
+	// Изменили
+	for _, orderAggregate := range orders {
+		courier, err := ch.orderDispatcher.DispatchInZones(orderAggregate, couriers, zones, ch.crossZoneWait)
+		if errors.Is(err, services.ErrNoCouriersInZone) || errors.Is(err, routing.ErrNoRoute) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return ch.save(ctx, orderAggregate, courier)
+	}
+	return NotAvailableCouriers
+}
+
+func (ch *AssignOrdersCommandHandler) save(ctx context.Context, orderAggregate *order.Order, courier *courier.Courier) error {
 	ctx = ch.unitOfWork.Begin(ctx)
 	defer func() {
 		err := ch.unitOfWork.Rollback(ctx)
@@ -86,7 +152,7 @@ func (ch *AssignOrdersCommandHandler) Handle(ctx context.Context, command Assign
 		}
 	}()
 
-	err = ch.orderRepository.Update(ctx, orderAggregate)
+	err := ch.orderRepository.Update(ctx, orderAggregate)
 	if err != nil {
 		return err
 	}
@@ -95,10 +161,16 @@ func (ch *AssignOrdersCommandHandler) Handle(ctx context.Context, command Assign
 		return err
 	}
 
+	err = saveDomainEvents(ctx, ch.outbox, orderAggregate, courier)
+	if err != nil {
+		return err
+	}
+
 	err = ch.unitOfWork.Commit(ctx)
 	if err != nil {
 		return err
 	}
+	publishDomainEvents(ctx, ch.eventPublisher, orderAggregate, courier)
 
 	return nil
 }
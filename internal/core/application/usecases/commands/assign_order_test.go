@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/services"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
@@ -52,7 +57,7 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			name: "GetFirstInCreatedStatus error should be returned",
+			name: "GetAllInCreatedStatus error should be returned",
 			command: func() AssignOrdersCommand {
 				cmd, _ := NewAssignOrdersCommand()
 				return cmd
@@ -65,7 +70,7 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 			expectedError: errors.New("database error"),
 			checkStateAfterError: func(t *testing.T, uow *stubUnitOfWork, orderRepo *stubOrderRepository, courierRepo *stubCourierRepository) {
 				if uow.beginCalled || uow.commitCalled || orderRepo.updateCalled || courierRepo.updateCalled {
-					t.Error("No operations should be performed when GetFirstInCreatedStatus returns an error")
+					t.Error("No operations should be performed when GetAllInCreatedStatus returns an error")
 				}
 			},
 		},
@@ -112,7 +117,7 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 				return cmd
 			}(),
 			setupStubs: func() (*stubUnitOfWork, *stubOrderRepository, *stubCourierRepository) {
-				testOrder := order.MustNewOrder(uuid.New(), kernel.CreateRandomLocation())
+				testOrder := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.CreateRandomLocation())
 				testCourier := courier.MustNewCourier("courier-1", "transport", 1, kernel.CreateRandomLocation())
 				return &stubUnitOfWork{},
 					&stubOrderRepository{
@@ -135,7 +140,7 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 				return cmd
 			}(),
 			setupStubs: func() (*stubUnitOfWork, *stubOrderRepository, *stubCourierRepository) {
-				testOrder := order.MustNewOrder(uuid.New(), kernel.CreateRandomLocation())
+				testOrder := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.CreateRandomLocation())
 				testCourier := courier.MustNewCourier("courier-1", "transport", 1, kernel.CreateRandomLocation())
 				return &stubUnitOfWork{},
 					&stubOrderRepository{order: testOrder},
@@ -158,7 +163,7 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 				return cmd
 			}(),
 			setupStubs: func() (*stubUnitOfWork, *stubOrderRepository, *stubCourierRepository) {
-				testOrder := order.MustNewOrder(uuid.New(), kernel.CreateRandomLocation())
+				testOrder := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.CreateRandomLocation())
 				testCourier := courier.MustNewCourier("courier-1", "transport", 1, kernel.CreateRandomLocation())
 				return &stubUnitOfWork{commitError: errors.New("commit error")},
 					&stubOrderRepository{order: testOrder},
@@ -178,7 +183,7 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 				return cmd
 			}(),
 			setupStubs: func() (*stubUnitOfWork, *stubOrderRepository, *stubCourierRepository) {
-				testOrder := order.MustNewOrder(uuid.New(), kernel.CreateRandomLocation())
+				testOrder := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.CreateRandomLocation())
 				testCourier := courier.MustNewCourier("courier-1", "transport", 1, kernel.CreateRandomLocation())
 				return &stubUnitOfWork{},
 					&stubOrderRepository{order: testOrder},
@@ -204,10 +209,15 @@ func TestAssignOrdersCommandHandler_Handle(t *testing.T) {
 
 			// Create the handler with stubs
 			handler, err := NewAssignOrdersCommandHandler(
+				kernel.DefaultRegion(),
 				uowStub,
 				orderRepoStub,
 				courierRepoStub,
-				services.NewOrderDispatcher(),
+				&stubZoneRepository{},
+				services.NewOrderDispatcher(routing.Direct{}, nil),
+				&recordingEventPublisher{},
+				&recordingOutbox{},
+				time.Minute,
 			)
 			if err != nil {
 				t.Fatalf("Failed to create handler: %v", err)
@@ -254,10 +264,13 @@ func (s *stubUnitOfWork) Rollback(ctx context.Context) error {
 }
 
 type stubOrderRepository struct {
-	order         *order.Order
+	order *order.Order
+	// orders - ожидающие курьера заказы, по умолчанию только order
+	orders        []*order.Order
 	getFirstError error
 	updateCalled  bool
 	updateError   error
+	updatedOrder  *order.Order
 }
 
 func (s *stubOrderRepository) Add(ctx context.Context, aggregate *order.Order) error {
@@ -268,16 +281,36 @@ func (s *stubOrderRepository) Get(ctx context.Context, ID uuid.UUID) (*order.Ord
 	return s.order, nil
 }
 
-func (s *stubOrderRepository) GetAllInAssignedStatus(ctx context.Context) ([]*order.Order, error) {
+func (s *stubOrderRepository) GetAllInAssignedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error) {
 	return []*order.Order{s.order}, nil
 }
 
+func (s *stubOrderRepository) GetAllInPendingGeocodeStatus(ctx context.Context) ([]*order.Order, error) {
+	return nil, nil
+}
+
+func (s *stubOrderRepository) GetAllNotFinal(ctx context.Context) ([]*order.Order, error) {
+	return nil, nil
+}
+
+func (s *stubOrderRepository) GetAllAssignedToCourier(ctx context.Context, courierID uuid.UUID) ([]*order.Order, error) {
+	return nil, nil
+}
+
 func (s *stubOrderRepository) GetFirstInCreatedStatus(ctx context.Context) (*order.Order, error) {
 	return s.order, s.getFirstError
 }
 
+func (s *stubOrderRepository) GetAllInCreatedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error) {
+	if s.orders != nil || s.order == nil {
+		return s.orders, s.getFirstError
+	}
+	return []*order.Order{s.order}, s.getFirstError
+}
+
 func (s *stubOrderRepository) Update(ctx context.Context, order *order.Order) error {
 	s.updateCalled = true
+	s.updatedOrder = order
 	return s.updateError
 }
 
@@ -297,12 +330,109 @@ func (s *stubCourierRepository) Get(ctx context.Context, ID uuid.UUID) (*courier
 	return s.couriers[0], nil
 }
 
-func (s *stubCourierRepository) GetAllInFreeStatus(ctx context.Context) ([]*courier.Courier, error) {
+func (s *stubCourierRepository) GetAllInFreeStatus(ctx context.Context, region kernel.RegionCode) ([]*courier.Courier, error) {
 	return s.couriers, s.getAllError
 }
 
+func (s *stubCourierRepository) GetAllStale(ctx context.Context, seenBefore time.Time) ([]*courier.Courier, error) {
+	return nil, nil
+}
+
+func (s *stubCourierRepository) GetAllNeverSeen(ctx context.Context, createdBefore time.Time) ([]*courier.Courier, error) {
+	return nil, nil
+}
+
 func (s *stubCourierRepository) Update(ctx context.Context, courier *courier.Courier) error {
 	s.updateCalled = true
 	s.updatedCourier = courier
 	return s.updateError
 }
+
+type stubZoneRepository struct {
+	zones []*zone.Zone
+}
+
+func (s *stubZoneRepository) Add(ctx context.Context, aggregate *zone.Zone) error {
+	return nil
+}
+
+func (s *stubZoneRepository) Update(ctx context.Context, aggregate *zone.Zone) error {
+	return nil
+}
+
+func (s *stubZoneRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	return nil
+}
+
+func (s *stubZoneRepository) Get(ctx context.Context, ID uuid.UUID) (*zone.Zone, error) {
+	return nil, errs.NewObjectNotFoundError(ID.String(), ID)
+}
+
+func (s *stubZoneRepository) GetAll(ctx context.Context) ([]*zone.Zone, error) {
+	return s.zones, nil
+}
+
+func TestAssignOrdersCommandHandler_OrderWaitingForZoneCourierDoesNotBlockQueue(t *testing.T) {
+	address := kernel.MustNewAddress("", "", "Бажная", "", "")
+	northShape, err := zone.NewCellsShape(kernel.MustNewArea(1, 6, 10, 10))
+	require.NoError(t, err)
+	southShape, err := zone.NewCellsShape(kernel.MustNewArea(1, 1, 10, 5))
+	require.NoError(t, err)
+	north, south := zone.MustNewZone("Север", northShape), zone.MustNewZone("Юг", southShape)
+
+	// Первый заказ на юге ждёт курьера юга, второй на севере может взять курьер севера
+	southOrder := order.MustNewOrder(uuid.New(), address, kernel.MustNewLocation(5, 2))
+	northOrder := order.MustNewOrder(uuid.New(), address, kernel.MustNewLocation(5, 9))
+	northern := courier.MustNewCourier("northern", "bike", 1, kernel.MustNewLocation(5, 6))
+	northID := north.ID()
+	northern.SetHomeZone(&northID)
+
+	orderRepository := &stubOrderRepository{orders: []*order.Order{southOrder, northOrder}}
+	courierRepository := &stubCourierRepository{couriers: []*courier.Courier{northern}}
+	handler, err := NewAssignOrdersCommandHandler(kernel.DefaultRegion(), &stubUnitOfWork{}, orderRepository,
+		courierRepository, &stubZoneRepository{zones: []*zone.Zone{north, south}},
+		services.NewOrderDispatcher(routing.Direct{}, nil), &recordingEventPublisher{}, &recordingOutbox{}, time.Minute)
+	require.NoError(t, err)
+	command, err := NewAssignOrdersCommand()
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(context.Background(), command))
+
+	require.NotNil(t, orderRepository.updatedOrder)
+	assert.Equal(t, northOrder.ID(), orderRepository.updatedOrder.ID())
+	assert.Equal(t, order.StatusCreated, southOrder.Status())
+}
+
+func TestAssignOrdersCommandHandler_ScheduledOrderWaitsForDeliveryWindow(t *testing.T) {
+	address := kernel.MustNewAddress("", "", "Бажная", "", "")
+	now := time.Now()
+	newScheduled := func(from time.Time) *order.Order {
+		window := order.MustNewDeliveryWindow(from, from.Add(2*time.Hour))
+		aggregate, err := order.NewOrderInRegion(uuid.New(), kernel.DefaultRegion(), order.TierScheduled, window,
+			address, kernel.MustNewLocation(5, 5))
+		require.NoError(t, err)
+		return aggregate
+	}
+	tomorrow := newScheduled(now.Add(24 * time.Hour))
+	opened := newScheduled(now.Add(-time.Hour))
+	free := courier.MustNewCourier("free", "bike", 1, kernel.MustNewLocation(5, 6))
+
+	orderRepository := &stubOrderRepository{orders: []*order.Order{tomorrow}}
+	handler, err := NewAssignOrdersCommandHandler(kernel.DefaultRegion(), &stubUnitOfWork{}, orderRepository,
+		&stubCourierRepository{couriers: []*courier.Courier{free}}, &stubZoneRepository{},
+		services.NewOrderDispatcher(routing.Direct{}, nil), &recordingEventPublisher{}, &recordingOutbox{}, time.Minute)
+	require.NoError(t, err)
+	command, err := NewAssignOrdersCommand()
+	require.NoError(t, err)
+
+	// Интервал ещё не начался - заказ не назначается
+	assert.ErrorIs(t, handler.Handle(context.Background(), command), NotAvailableOrders)
+	assert.Equal(t, order.StatusCreated, tomorrow.Status())
+
+	// Начавшийся интервал назначается, завтрашний заказ его не задерживает
+	orderRepository.orders = []*order.Order{tomorrow, opened}
+	require.NoError(t, handler.Handle(context.Background(), command))
+	require.NotNil(t, orderRepository.updatedOrder)
+	assert.Equal(t, opened.ID(), orderRepository.updatedOrder.ID())
+	assert.Equal(t, order.StatusCreated, tomorrow.Status())
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+type CancelOrderCommandHandler struct {
+	unitOfWork        uow.UnitOfWork
+	orderRepository   ports.OrderRepository
+	courierRepository ports.CourierRepository
+	eventPublisher    ports.DomainEventPublisher
+	outbox            ports.DomainEventOutbox
+}
+
+func NewCancelOrderCommandHandler(
+	unitOfWork uow.UnitOfWork,
+	orderRepository ports.OrderRepository,
+	courierRepository ports.CourierRepository,
+	eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox,
+) (*CancelOrderCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+
+	return &CancelOrderCommandHandler{
+		unitOfWork:        unitOfWork,
+		orderRepository:   orderRepository,
+		courierRepository: courierRepository,
+		eventPublisher:    eventPublisher,
+		outbox:            outbox}, nil
+}
+
+func (ch *CancelOrderCommandHandler) Handle(ctx context.Context, command CancelOrderCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("cancel order command")
+	}
+
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("CancelOrderCommandHandler Rollback error:", err)
+		}
+	}()
+
+	// Восстановили
+	orderAggregate, err := ch.orderRepository.Get(ctx, command.orderID)
+	if err != nil {
+		return err
+	}
+	assigned := orderAggregate.IsAssigned()
+
+	// Изменили и сохранили
+	err = orderAggregate.Cancel()
+	if err != nil {
+		return err
+	}
+	err = ch.orderRepository.Update(ctx, orderAggregate)
+	if err != nil {
+		return err
+	}
+
+	// Курьер отменённого заказа снова свободен
+	if assigned {
+		courier, err := ch.courierRepository.Get(ctx, *orderAggregate.AssignedCourier())
+		if err != nil {
+			return err
+		}
+		err = courier.SetFree()
+		if err != nil {
+			return err
+		}
+		err = ch.courierRepository.Update(ctx, courier)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = saveDomainEvents(ctx, ch.outbox, orderAggregate)
+	if err != nil {
+		return err
+	}
+
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, orderAggregate)
+
+	return nil
+}
+
+type CancelOrderCommand struct {
+	orderID uuid.UUID
+
+	isSet bool
+}
+
+func NewCancelOrderCommand(orderID uuid.UUID) (CancelOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return CancelOrderCommand{}, errs.NewValueIsRequiredError("orderID")
+	}
+	return CancelOrderCommand{orderID: orderID, isSet: true}, nil
+}
+
+func (c CancelOrderCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+func Test_CancelOrderShouldFreeAssignedCourier(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	busyCourier := courier.MustNewCourier("Иван", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, busyCourier.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, busyCourier))
+	assignedOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, assignedOrder.AssignToCourier(busyCourier.ID()))
+	require.NoError(t, orderRepository.Add(ctx, assignedOrder))
+
+	publisher := &recordingEventPublisher{}
+	handler, err := NewCancelOrderCommandHandler(unitOfWork, orderRepository, courierRepository, publisher, &recordingOutbox{})
+	require.NoError(t, err)
+	command, err := NewCancelOrderCommand(assignedOrder.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+
+	storedOrder, err := orderRepository.Get(ctx, assignedOrder.ID())
+	require.NoError(t, err)
+	assert.True(t, storedOrder.IsCancelled())
+	storedCourier, err := courierRepository.Get(ctx, busyCourier.ID())
+	require.NoError(t, err)
+	assert.Equal(t, courier.StatusFree, storedCourier.Status())
+
+	require.Len(t, publisher.events, 1)
+	statusChanged, ok := publisher.events[0].(order.StatusChangedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, order.StatusCancelled, statusChanged.Status())
+
+	// Повторная отмена и отмена неизвестного заказа - ошибки
+	assert.ErrorIs(t, handler.Handle(ctx, command), order.ErrOrderCancelled)
+	unknown, err := NewCancelOrderCommand(uuid.New())
+	require.NoError(t, err)
+	assert.ErrorIs(t, handler.Handle(ctx, unknown), errs.ErrObjectNotFound)
+	assert.Len(t, publisher.events, 1)
+}
+
+func Test_CancelOrderShouldRejectCompletedOrder(t *testing.T) {
+	completedOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, completedOrder.AssignToCourier(uuid.New()))
+	require.NoError(t, completedOrder.Complete())
+
+	assert.ErrorIs(t, completedOrder.Cancel(), order.ErrOrderCompleted)
+	assert.Equal(t, order.StatusCompleted, completedOrder.Status())
+
+	cancelledOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, cancelledOrder.Cancel())
+	assert.ErrorIs(t, cancelledOrder.AssignToCourier(uuid.New()), order.ErrOrderCancelled)
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// CourierHeartbeatCommandHandler - курьер сообщает, что он на связи
+type CourierHeartbeatCommandHandler struct {
+	courierRepository ports.CourierRepository
+	now               func() time.Time
+}
+
+func NewCourierHeartbeatCommandHandler(courierRepository ports.CourierRepository) (*CourierHeartbeatCommandHandler, error) {
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	return &CourierHeartbeatCommandHandler{courierRepository: courierRepository, now: time.Now}, nil
+}
+
+// Handle - ErrObjectNotFound, если курьера нет
+func (ch *CourierHeartbeatCommandHandler) Handle(ctx context.Context, command CourierHeartbeatCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("courier heartbeat command")
+	}
+
+	aggregate, err := ch.courierRepository.Get(ctx, command.courierID)
+	if err != nil {
+		return err
+	}
+	aggregate.Heartbeat(ch.now())
+
+	return ch.courierRepository.Update(ctx, aggregate)
+}
+
+type CourierHeartbeatCommand struct {
+	courierID uuid.UUID
+
+	isSet bool
+}
+
+func NewCourierHeartbeatCommand(courierID uuid.UUID) (CourierHeartbeatCommand, error) {
+	if courierID == uuid.Nil {
+		return CourierHeartbeatCommand{}, errs.NewValueIsRequiredError("courierID")
+	}
+	return CourierHeartbeatCommand{courierID: courierID, isSet: true}, nil
+}
+
+func (c CourierHeartbeatCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -3,31 +3,65 @@ package commands
 import (
 	"context"
 	"errors"
-	"strings"
+	"log"
 
 	"github.com/google/uuid"
 
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
 )
 
 var OrderAlreadyExists = errors.New("order already exists")
 
 type CreateOrderCommandHandler struct {
+	unitOfWork      uow.UnitOfWork
 	orderRepository ports.OrderRepository
 	geoClient       ports.GeoClient
+	regions         *region.Catalog
+	eventPublisher  ports.DomainEventPublisher
+	outbox          ports.DomainEventOutbox
+	deferGeocoding  bool
 }
 
+// NewCreateOrderCommandHandler - deferGeocoding разрешает сохранить заказ без геопозиции,
+// если Geo недоступен. Такой заказ позже дополнит ResolvePendingGeocodesCommandHandler.
+// Регион заказа определяется по городу в адресе. Заказ с адресом за пределами границ своего региона
+// или с координатами другого вида не создаётся
 func NewCreateOrderCommandHandler(
-	orderRepository ports.OrderRepository, geoClient ports.GeoClient) (*CreateOrderCommandHandler, error) {
+	unitOfWork uow.UnitOfWork, orderRepository ports.OrderRepository, geoClient ports.GeoClient,
+	regions *region.Catalog, eventPublisher ports.DomainEventPublisher, outbox ports.DomainEventOutbox,
+	deferGeocoding bool) (*CreateOrderCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
 	if orderRepository == nil {
 		return nil, errs.NewValueIsRequiredError("orderRepository")
 	}
+	if geoClient == nil {
+		return nil, errs.NewValueIsRequiredError("geoClient")
+	}
+	if regions == nil {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
 
 	return &CreateOrderCommandHandler{
+		unitOfWork:      unitOfWork,
 		orderRepository: orderRepository,
-		geoClient:       geoClient}, nil
+		geoClient:       geoClient,
+		regions:         regions,
+		eventPublisher:  eventPublisher,
+		outbox:          outbox,
+		deferGeocoding:  deferGeocoding}, nil
 }
 
 func (ch *CreateOrderCommandHandler) Handle(ctx context.Context, command CreateOrderCommand) error {
@@ -36,54 +70,126 @@ func (ch *CreateOrderCommandHandler) Handle(ctx context.Context, command CreateO
 	}
 
 	// Проверяем нет ли уже такого заказа
-	orderAggregate, err := ch.orderRepository.Get(ctx, command.orderID)
-	if err != nil {
+	_, err := ch.orderRepository.Get(ctx, command.orderID)
+	if err == nil {
+		return OrderAlreadyExists
+	}
+	if !errors.Is(err, errs.ErrObjectNotFound) {
 		return err
 	}
-	if orderAggregate != nil {
-		return OrderAlreadyExists
+
+	orderRegion, err := ch.regions.ForAddress(command.Address())
+	if err != nil {
+		return err
 	}
 
 	// Получили геопозицию из Geo.
-	location, err := ch.geoClient.GetGeolocation(ctx, command.Street())
+	location, err := ch.geoClient.GetGeolocation(ctx, command.Address())
+	if errors.Is(err, ports.ErrGeoServiceUnavailable) && ch.deferGeocoding {
+		// Geo недоступен - сохраняем заказ и определим геопозицию позже
+		orderAggregate, err := order.NewPendingGeocodeOrderInRegion(command.orderID, orderRegion.Code(),
+			command.tier, command.window, command.Address())
+		if err != nil {
+			return err
+		}
+		return ch.add(ctx, orderAggregate)
+	}
+	if err != nil {
+		return err
+	}
+	err = orderRegion.Bounds().Validate(location)
 	if err != nil {
 		return err
 	}
 
 	// Изменили
-	orderAggregate, err = order.NewOrder(command.orderID, location)
+	orderAggregate, err := order.NewOrderInRegion(command.orderID, orderRegion.Code(), command.tier, command.window,
+		command.Address(), location)
 	if err != nil {
 		return err
 	}
 
 	// Сохранили
-	err = ch.orderRepository.Add(ctx, orderAggregate)
+	return ch.add(ctx, orderAggregate)
+}
+
+// add - сохранить новый заказ вместе с его событиями
+func (ch *CreateOrderCommandHandler) add(ctx context.Context, orderAggregate *order.Order) error {
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("CreateOrderCommandHandler Rollback error:", err)
+		}
+	}()
+
+	err := ch.orderRepository.Add(ctx, orderAggregate)
+	if err != nil {
+		return err
+	}
+	err = saveDomainEvents(ctx, ch.outbox, orderAggregate)
 	if err != nil {
 		return err
 	}
 
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, orderAggregate)
+
 	return nil
 }
 
 type CreateOrderCommand struct {
 	orderID uuid.UUID
-	street  string
+	address kernel.Address
+	tier    order.Tier
+	window  order.DeliveryWindow
 
 	isSet bool
 }
 
-func NewCreateOrderCommand(orderID uuid.UUID, street string) (CreateOrderCommand, error) {
+func NewCreateOrderCommand(orderID uuid.UUID, address kernel.Address) (CreateOrderCommand, error) {
+	return NewCreateOrderCommandWithTier(orderID, address, order.TierStandard, order.DeliveryWindow{})
+}
+
+// NewCreateOrderCommandWithTier - заказ с уровнем доставки, например экспресс. Интервал доставки window
+// задаётся только заказу к интервалу
+func NewCreateOrderCommandWithTier(orderID uuid.UUID, address kernel.Address, tier order.Tier,
+	window order.DeliveryWindow) (CreateOrderCommand, error) {
 	if orderID == uuid.Nil {
 		return CreateOrderCommand{}, errs.NewValueIsInvalidError("basketID")
 	}
-	if strings.TrimSpace(street) == "" {
-		return CreateOrderCommand{}, errs.NewValueIsRequiredError("street")
+	if address.IsEmpty() {
+		return CreateOrderCommand{}, errs.NewValueIsRequiredError("address")
 	}
-	return CreateOrderCommand{orderID: orderID, street: street, isSet: true}, nil
+	if !tier.IsValid() {
+		return CreateOrderCommand{}, errs.NewValueIsInvalidError("tier")
+	}
+	if tier == order.TierScheduled && window.IsEmpty() {
+		return CreateOrderCommand{}, errs.NewValueIsRequiredError("deliveryWindow")
+	}
+	if tier != order.TierScheduled && !window.IsEmpty() {
+		return CreateOrderCommand{}, errs.NewValueIsInvalidError("deliveryWindow")
+	}
+	return CreateOrderCommand{orderID: orderID, address: address, tier: tier, window: window, isSet: true}, nil
+}
+
+func (c CreateOrderCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c CreateOrderCommand) Address() kernel.Address {
+	return c.address
+}
+
+func (c CreateOrderCommand) Tier() order.Tier {
+	return c.tier
 }
 
-func (c CreateOrderCommand) Street() string {
-	return c.street
+func (c CreateOrderCommand) DeliveryWindow() order.DeliveryWindow {
+	return c.window
 }
 
 func (c CreateOrderCommand) isEmpty() bool {
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/fakegeo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/grpc/geo"
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var testAddress = kernel.MustNewAddress("Россия", "Москва", "Бажная", "1", "2")
+
+type stubGeoClient struct {
+	location kernel.Location
+	err      error
+}
+
+func (s *stubGeoClient) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	return s.location, s.err
+}
+
+func singleRegion(t *testing.T) *region.Catalog {
+	regions, err := region.NewSingleRegionCatalog(kernel.DefaultArea())
+	require.NoError(t, err)
+	return regions
+}
+
+func setupOrderStorage(t *testing.T) (ports.OrderRepository, *memory.UnitOfWork) {
+	storage := memory.NewStorage()
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	return orderRepository, unitOfWork
+}
+
+func setupCreateOrderTest(t *testing.T, deferGeocoding bool) (*CreateOrderCommandHandler, ports.OrderRepository, *stubGeoClient) {
+	orderRepository, unitOfWork := setupOrderStorage(t)
+
+	geoClient := &stubGeoClient{err: ports.ErrGeoServiceUnavailable}
+	handler, err := NewCreateOrderCommandHandler(unitOfWork, orderRepository, geoClient, singleRegion(t),
+		&recordingEventPublisher{}, &recordingOutbox{}, deferGeocoding)
+	require.NoError(t, err)
+	return handler, orderRepository, geoClient
+}
+
+func Test_CreateOrderShouldFailWhenGeoUnavailable(t *testing.T) {
+	ctx := context.Background()
+	handler, orderRepository, _ := setupCreateOrderTest(t, false)
+
+	command, err := NewCreateOrderCommand(uuid.New(), testAddress)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, command)
+	assert.ErrorIs(t, err, ports.ErrGeoServiceUnavailable)
+
+	_, err = orderRepository.Get(ctx, command.orderID)
+	assert.Error(t, err)
+}
+
+func Test_CreateOrderShouldDeferGeocodingWhenGeoUnavailable(t *testing.T) {
+	ctx := context.Background()
+	orderRepository, unitOfWork := setupOrderStorage(t)
+	geoClient := &stubGeoClient{err: ports.ErrGeoServiceUnavailable}
+	handler, err := NewCreateOrderCommandHandler(unitOfWork, orderRepository, geoClient, singleRegion(t),
+		&recordingEventPublisher{}, &recordingOutbox{}, true)
+	require.NoError(t, err)
+
+	command, err := NewCreateOrderCommand(uuid.New(), testAddress)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+
+	pendingOrder, err := orderRepository.Get(ctx, command.orderID)
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusPendingGeocode, pendingOrder.Status())
+	assert.True(t, pendingOrder.Address().Equals(testAddress))
+
+	// Пока Geo недоступен, заказ остаётся ждать
+	resolveHandler, err := NewResolvePendingGeocodesCommandHandler(unitOfWork, orderRepository, geoClient, singleRegion(t),
+		&recordingEventPublisher{}, &recordingOutbox{})
+	require.NoError(t, err)
+	resolveCommand, err := NewResolvePendingGeocodesCommand()
+	require.NoError(t, err)
+	assert.ErrorIs(t, resolveHandler.Handle(ctx, resolveCommand), ports.ErrGeoServiceUnavailable)
+
+	// Geo восстановился - заказ получил геопозицию и доступен для назначения
+	geoClient.location, geoClient.err = kernel.MustNewLocation(3, 4), nil
+	require.NoError(t, resolveHandler.Handle(ctx, resolveCommand))
+
+	resolvedOrder, err := orderRepository.Get(ctx, command.orderID)
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, resolvedOrder.Status())
+	assert.True(t, resolvedOrder.Location().Equals(kernel.MustNewLocation(3, 4)))
+
+	pendingOrders, err := orderRepository.GetAllInPendingGeocodeStatus(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pendingOrders)
+}
+
+func Test_CreateOrderShouldUseGeoOverGrpc(t *testing.T) {
+	ctx := context.Background()
+	server, err := fakegeo.NewServer(fakegeo.Config{
+		Streets: map[string]kernel.Location{"Бажная": kernel.MustNewLocation(8, 9)},
+	})
+	require.NoError(t, err)
+	inProcess := fakegeo.StartInProcess(server)
+	defer inProcess.Stop()
+
+	geoClient, err := geo.NewClient(inProcess.Target(), geo.DefaultConfig(), inProcess.DialOption())
+	require.NoError(t, err)
+	defer geoClient.Close()
+
+	orderRepository, unitOfWork := setupOrderStorage(t)
+	handler, err := NewCreateOrderCommandHandler(unitOfWork, orderRepository, geoClient, singleRegion(t),
+		&recordingEventPublisher{}, &recordingOutbox{}, false)
+	require.NoError(t, err)
+
+	command, err := NewCreateOrderCommand(uuid.New(), testAddress)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+
+	createdOrder, err := orderRepository.Get(ctx, command.orderID)
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, createdOrder.Status())
+	assert.True(t, createdOrder.Location().Equals(kernel.MustNewLocation(8, 9)))
+}
+
+func Test_CreateOrderShouldPlaceOrderIntoRegionOfAddressCity(t *testing.T) {
+	ctx := context.Background()
+	moscow, err := region.NewRegion(kernel.MustNewRegionCode("msk"), kernel.DefaultArea(), []string{"Москва"})
+	require.NoError(t, err)
+	kazan, err := region.NewRegion(kernel.MustNewRegionCode("kzn"), kernel.MustNewArea(1, 1, 20, 20), []string{"Казань"})
+	require.NoError(t, err)
+	regions, err := region.NewCatalog(moscow, kazan)
+	require.NoError(t, err)
+
+	orderRepository, unitOfWork := setupOrderStorage(t)
+	geoClient := &stubGeoClient{location: kernel.MustNewLocation(15, 15)}
+	handler, err := NewCreateOrderCommandHandler(unitOfWork, orderRepository, geoClient, regions,
+		&recordingEventPublisher{}, &recordingOutbox{}, false)
+	require.NoError(t, err)
+
+	// Точка (15, 15) есть в сетке Казани, но не в сетке Москвы 10x10
+	command, err := NewCreateOrderCommand(uuid.New(), kernel.MustNewAddress("Россия", "Казань", "Баумана", "1", ""))
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+
+	kazanOrder, err := orderRepository.Get(ctx, command.orderID)
+	require.NoError(t, err)
+	assert.Equal(t, kazan.Code(), kazanOrder.Region())
+
+	command, err = NewCreateOrderCommand(uuid.New(), testAddress)
+	require.NoError(t, err)
+	assert.ErrorIs(t, handler.Handle(ctx, command), kernel.ErrLocationOutOfArea)
+}
+
+func Test_CreateOrderShouldRejectAddressOutsideConfiguredRegions(t *testing.T) {
+	ctx := context.Background()
+	moscow, err := region.NewRegion(kernel.MustNewRegionCode("msk"), kernel.DefaultArea(), []string{"Москва"})
+	require.NoError(t, err)
+	regions, err := region.NewCatalog(moscow)
+	require.NoError(t, err)
+
+	orderRepository, unitOfWork := setupOrderStorage(t)
+	geoClient := &stubGeoClient{location: kernel.MustNewLocation(5, 5)}
+	handler, err := NewCreateOrderCommandHandler(unitOfWork, orderRepository, geoClient, regions,
+		&recordingEventPublisher{}, &recordingOutbox{}, false)
+	require.NoError(t, err)
+
+	for _, address := range []kernel.Address{kernel.MustNewAddress("Россия", "Тверь", "Бажная", "1", ""),
+		kernel.MustNewAddress("", "", "Бажная", "", "")} {
+		command, err := NewCreateOrderCommand(uuid.New(), address)
+		require.NoError(t, err)
+		assert.ErrorIs(t, handler.Handle(ctx, command), region.ErrUnknownRegion)
+
+		_, err = orderRepository.Get(ctx, command.orderID)
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	}
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type CreateWebhookSubscriptionCommandHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+}
+
+func NewCreateWebhookSubscriptionCommandHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+) (*CreateWebhookSubscriptionCommandHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+
+	return &CreateWebhookSubscriptionCommandHandler{
+		subscriptionRepository: subscriptionRepository}, nil
+}
+
+func (ch *CreateWebhookSubscriptionCommandHandler) Handle(ctx context.Context, command CreateWebhookSubscriptionCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("create webhook subscription command")
+	}
+
+	subscription, err := webhook.NewSubscription(command.subscriptionID, command.url, command.secret,
+		command.eventTypes, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	return ch.subscriptionRepository.Add(ctx, subscription)
+}
+
+type CreateWebhookSubscriptionCommand struct {
+	subscriptionID uuid.UUID
+	url            string
+	secret         string
+	eventTypes     []webhook.EventType
+
+	isSet bool
+}
+
+// NewCreateWebhookSubscriptionCommand - url, secret и типы событий проверяет агрегат подписки
+func NewCreateWebhookSubscriptionCommand(subscriptionID uuid.UUID, url string, secret string,
+	eventTypes []webhook.EventType) (CreateWebhookSubscriptionCommand, error) {
+	if subscriptionID == uuid.Nil {
+		return CreateWebhookSubscriptionCommand{}, errs.NewValueIsRequiredError("subscriptionID")
+	}
+
+	return CreateWebhookSubscriptionCommand{
+		subscriptionID: subscriptionID,
+		url:            url,
+		secret:         secret,
+		eventTypes:     eventTypes,
+		isSet:          true,
+	}, nil
+}
+
+func (c CreateWebhookSubscriptionCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type CreateZoneCommandHandler struct {
+	zoneRepository ports.ZoneRepository
+	regions        *region.Catalog
+}
+
+// NewCreateZoneCommandHandler - regions - регионы сервиса, зона не должна выходить за границы своего региона
+func NewCreateZoneCommandHandler(zoneRepository ports.ZoneRepository, regions *region.Catalog) (*CreateZoneCommandHandler, error) {
+	if zoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("zoneRepository")
+	}
+	if regions == nil {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+
+	return &CreateZoneCommandHandler{
+		zoneRepository: zoneRepository,
+		regions:        regions}, nil
+}
+
+func (ch *CreateZoneCommandHandler) Handle(ctx context.Context, command CreateZoneCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("create zone command")
+	}
+
+	zoneRegion := ch.regions.Default()
+	if !command.region.IsEmpty() {
+		var ok bool
+		zoneRegion, ok = ch.regions.Get(command.region)
+		if !ok {
+			return fmt.Errorf("%w: %s", region.ErrUnknownRegion, command.region)
+		}
+	}
+	if err := command.shape.Validate(zoneRegion.Bounds()); err != nil {
+		return err
+	}
+	aggregate, err := zone.NewZone(command.zoneID, zoneRegion.Code(), command.name, command.shape, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	return ch.zoneRepository.Add(ctx, aggregate)
+}
+
+type CreateZoneCommand struct {
+	zoneID uuid.UUID
+	region kernel.RegionCode
+	name   string
+	shape  zone.Shape
+
+	isSet bool
+}
+
+// NewCreateZoneCommand - название и границу проверяет агрегат зоны. Пустой regionCode - регион по умолчанию
+func NewCreateZoneCommand(zoneID uuid.UUID, regionCode kernel.RegionCode, name string,
+	shape zone.Shape) (CreateZoneCommand, error) {
+	if zoneID == uuid.Nil {
+		return CreateZoneCommand{}, errs.NewValueIsRequiredError("zoneID")
+	}
+
+	return CreateZoneCommand{
+		zoneID: zoneID,
+		region: regionCode,
+		name:   name,
+		shape:  shape,
+		isSet:  true,
+	}, nil
+}
+
+func (c CreateZoneCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type DeleteWebhookSubscriptionCommandHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+}
+
+func NewDeleteWebhookSubscriptionCommandHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+) (*DeleteWebhookSubscriptionCommandHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+
+	return &DeleteWebhookSubscriptionCommandHandler{
+		subscriptionRepository: subscriptionRepository}, nil
+}
+
+// Handle - журнал доставок удалённой подписки сохраняется, недоставленное больше не отправляется
+func (ch *DeleteWebhookSubscriptionCommandHandler) Handle(ctx context.Context, command DeleteWebhookSubscriptionCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("delete webhook subscription command")
+	}
+
+	return ch.subscriptionRepository.Delete(ctx, command.subscriptionID)
+}
+
+type DeleteWebhookSubscriptionCommand struct {
+	subscriptionID uuid.UUID
+
+	isSet bool
+}
+
+func NewDeleteWebhookSubscriptionCommand(subscriptionID uuid.UUID) (DeleteWebhookSubscriptionCommand, error) {
+	if subscriptionID == uuid.Nil {
+		return DeleteWebhookSubscriptionCommand{}, errs.NewValueIsRequiredError("subscriptionID")
+	}
+	return DeleteWebhookSubscriptionCommand{subscriptionID: subscriptionID, isSet: true}, nil
+}
+
+func (c DeleteWebhookSubscriptionCommand) isEmpty() bool {
+	return !c.isSet
+}
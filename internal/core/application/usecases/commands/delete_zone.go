@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type DeleteZoneCommandHandler struct {
+	zoneRepository ports.ZoneRepository
+}
+
+func NewDeleteZoneCommandHandler(zoneRepository ports.ZoneRepository) (*DeleteZoneCommandHandler, error) {
+	if zoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("zoneRepository")
+	}
+
+	return &DeleteZoneCommandHandler{
+		zoneRepository: zoneRepository}, nil
+}
+
+// Handle - курьеры удалённой зоны работают по всему городу, пока их не закрепят за другой
+func (ch *DeleteZoneCommandHandler) Handle(ctx context.Context, command DeleteZoneCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("delete zone command")
+	}
+
+	return ch.zoneRepository.Delete(ctx, command.zoneID)
+}
+
+type DeleteZoneCommand struct {
+	zoneID uuid.UUID
+
+	isSet bool
+}
+
+func NewDeleteZoneCommand(zoneID uuid.UUID) (DeleteZoneCommand, error) {
+	if zoneID == uuid.Nil {
+		return DeleteZoneCommand{}, errs.NewValueIsRequiredError("zoneID")
+	}
+	return DeleteZoneCommand{zoneID: zoneID, isSet: true}, nil
+}
+
+func (c DeleteZoneCommand) isEmpty() bool {
+	return !c.isSet
+}
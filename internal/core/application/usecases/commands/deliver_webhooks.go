@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type DeliverWebhooksConfig struct {
+	// BatchSize - сколько доставок отправляется за один запуск, параллельно
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// ClaimTimeout - на сколько взятая доставка скрыта от других запусков, должен быть больше таймаута отправки
+	ClaimTimeout time.Duration
+}
+
+func DefaultDeliverWebhooksConfig() DeliverWebhooksConfig {
+	return DeliverWebhooksConfig{
+		BatchSize:    50,
+		MaxAttempts:  8,
+		BaseBackoff:  5 * time.Second,
+		MaxBackoff:   time.Hour,
+		ClaimTimeout: 30 * time.Second,
+	}
+}
+
+type DeliverWebhooksCommandHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+	deliveryRepository     ports.WebhookDeliveryRepository
+	sender                 ports.WebhookSender
+	cfg                    DeliverWebhooksConfig
+	now                    func() time.Time
+}
+
+func NewDeliverWebhooksCommandHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+	deliveryRepository ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+	cfg DeliverWebhooksConfig,
+) (*DeliverWebhooksCommandHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+	if deliveryRepository == nil {
+		return nil, errs.NewValueIsRequiredError("deliveryRepository")
+	}
+	if sender == nil {
+		return nil, errs.NewValueIsRequiredError("sender")
+	}
+	if cfg.BatchSize < 1 {
+		return nil, errs.NewValueIsInvalidError("batchSize")
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, errs.NewValueIsInvalidError("maxAttempts")
+	}
+	if cfg.BaseBackoff <= 0 || cfg.MaxBackoff < cfg.BaseBackoff {
+		return nil, errs.NewValueIsInvalidError("backoff")
+	}
+	if cfg.ClaimTimeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("claimTimeout")
+	}
+
+	return &DeliverWebhooksCommandHandler{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		sender:                 sender,
+		cfg:                    cfg,
+		now:                    func() time.Time { return time.Now().UTC() },
+	}, nil
+}
+
+func (ch *DeliverWebhooksCommandHandler) Handle(ctx context.Context, command DeliverWebhooksCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("deliver webhooks command")
+	}
+
+	now := ch.now()
+	deliveries, err := ch.deliveryRepository.ClaimDue(ctx, now, now.Add(ch.cfg.ClaimTimeout), ch.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := ch.deliver(ctx, delivery)
+			if err != nil {
+				log.Printf("Deliver webhook %s error: %v", delivery.ID(), err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (ch *DeliverWebhooksCommandHandler) deliver(ctx context.Context, delivery *webhook.Delivery) error {
+	subscription, err := ch.subscriptionRepository.Get(ctx, delivery.SubscriptionID())
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		// Подписку удалили, отправлять больше некому
+		err = delivery.Fail(0, "subscription deleted", ch.now(), nil)
+		if err != nil {
+			return err
+		}
+		return ch.deliveryRepository.Update(ctx, delivery)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = send(ctx, ch.sender, subscription, delivery, ch.now, ch.retryAt)
+	if err != nil {
+		return err
+	}
+	return ch.deliveryRepository.Update(ctx, delivery)
+}
+
+// retryAt - время следующей попытки после attempts неудачных; nil, если попытки исчерпаны
+func (ch *DeliverWebhooksCommandHandler) retryAt(attempts int, now time.Time) *time.Time {
+	if attempts >= ch.cfg.MaxAttempts {
+		return nil
+	}
+	delay := ch.cfg.BaseBackoff << (attempts - 1)
+	if delay <= 0 || delay > ch.cfg.MaxBackoff {
+		delay = ch.cfg.MaxBackoff
+	}
+	retryAt := now.Add(delay)
+	return &retryAt
+}
+
+// send - одна попытка доставки с записью результата в агрегат
+func send(ctx context.Context, sender ports.WebhookSender, subscription *webhook.Subscription, delivery *webhook.Delivery,
+	now func() time.Time, retryAt func(attempts int, now time.Time) *time.Time) error {
+	statusCode, err := sender.Send(ctx, ports.WebhookRequest{
+		URL:        subscription.URL(),
+		Secret:     subscription.Secret(),
+		DeliveryID: delivery.ID(),
+		EventType:  delivery.EventType(),
+		Payload:    delivery.Payload(),
+	})
+	if err == nil {
+		return delivery.Succeed(statusCode, now())
+	}
+
+	sentAt := now()
+	return delivery.Fail(statusCode, err.Error(), sentAt, retryAt(delivery.Attempts()+1, sentAt))
+}
+
+type DeliverWebhooksCommand struct {
+	isSet bool
+}
+
+func NewDeliverWebhooksCommand() (DeliverWebhooksCommand, error) {
+	return DeliverWebhooksCommand{isSet: true}, nil
+}
+
+func (c DeliverWebhooksCommand) isEmpty() bool {
+	return !c.isSet
+}
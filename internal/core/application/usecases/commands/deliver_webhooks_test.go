@@ -0,0 +1,328 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	webhookout "github.com/IgorAleksandroff/delivery/internal/adapters/out/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+)
+
+const testWebhookSecret = "s3cr3t"
+
+// webhookReceiver - получатель, проверяющий подпись; первые failures запросов отвечает 500
+type webhookReceiver struct {
+	t        *testing.T
+	mu       sync.Mutex
+	failures int
+	// delay - сколько получатель думает над каждым запросом
+	delay    time.Duration
+	payloads []WebhookPayload
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	time.Sleep(r.delay)
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+	signature := webhookout.Sign(testWebhookSecret, req.Header.Get(webhookout.HeaderTimestamp), body)
+	assert.Equal(r.t, signature, req.Header.Get(webhookout.HeaderSignature))
+	assert.NotEmpty(r.t, req.Header.Get(webhookout.HeaderID))
+
+	var payload WebhookPayload
+	require.NoError(r.t, json.Unmarshal(body, &payload))
+	assert.Equal(r.t, string(payload.Type), req.Header.Get(webhookout.HeaderEvent))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.payloads = append(r.payloads, payload)
+	if len(r.payloads) <= r.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *webhookReceiver) received() []WebhookPayload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]WebhookPayload(nil), r.payloads...)
+}
+
+type webhookTest struct {
+	subscriptions *memory.WebhookSubscriptionRepository
+	deliveries    *memory.WebhookDeliveryRepository
+	enqueue       *EnqueueWebhooksEventHandler
+	deliver       *DeliverWebhooksCommandHandler
+	receiver      *webhookReceiver
+	url           string
+	now           *time.Time
+}
+
+func setupWebhookTest(t *testing.T, failures int, maxAttempts int) *webhookTest {
+	receiver := &webhookReceiver{t: t, failures: failures}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	subscriptions := memory.NewWebhookSubscriptionRepository()
+	deliveries := memory.NewWebhookDeliveryRepository()
+	enqueue, err := NewEnqueueWebhooksEventHandler(subscriptions, deliveries)
+	require.NoError(t, err)
+	sender, err := webhookout.NewSender(time.Second, true)
+	require.NoError(t, err)
+	deliver, err := NewDeliverWebhooksCommandHandler(subscriptions, deliveries, sender, DeliverWebhooksConfig{
+		BatchSize:    10,
+		MaxAttempts:  maxAttempts,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   time.Hour,
+		ClaimTimeout: time.Minute,
+	})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Add(time.Second)
+	deliver.now = func() time.Time { return now }
+
+	return &webhookTest{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		enqueue:       enqueue,
+		deliver:       deliver,
+		receiver:      receiver,
+		url:           server.URL,
+		now:           &now,
+	}
+}
+
+func (wt *webhookTest) subscribe(t *testing.T, url string, eventTypes ...webhook.EventType) *webhook.Subscription {
+	subscription, err := webhook.NewSubscription(uuid.New(), url, testWebhookSecret, eventTypes, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, wt.subscriptions.Add(context.Background(), subscription))
+	return subscription
+}
+
+func (wt *webhookTest) runDelivery(t *testing.T) {
+	command, err := NewDeliverWebhooksCommand()
+	require.NoError(t, err)
+	require.NoError(t, wt.deliver.Handle(context.Background(), command))
+}
+
+func (wt *webhookTest) log(t *testing.T, subscription *webhook.Subscription) []*webhook.Delivery {
+	deliveries, err := wt.deliveries.GetAllBySubscription(context.Background(), subscription.ID(), 0)
+	require.NoError(t, err)
+	return deliveries
+}
+
+func Test_WebhooksShouldDeliverSubscribedOrderEvents(t *testing.T) {
+	ctx := context.Background()
+	wt := setupWebhookTest(t, 0, 3)
+	subscription := wt.subscribe(t, wt.url, webhook.EventOrderCreated, webhook.EventOrderCancelled)
+	other := wt.subscribe(t, wt.url, webhook.EventOrderCompleted)
+
+	newOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, newOrder.AssignToCourier(uuid.New()))
+	require.NoError(t, newOrder.Cancel())
+	require.NoError(t, wt.enqueue.Save(ctx, newOrder.DomainEvents()...))
+
+	wt.runDelivery(t)
+
+	received := wt.receiver.received()
+	require.Len(t, received, 2)
+	types := []webhook.EventType{received[0].Type, received[1].Type}
+	assert.ElementsMatch(t, []webhook.EventType{webhook.EventOrderCreated, webhook.EventOrderCancelled}, types)
+
+	deliveries := wt.log(t, subscription)
+	require.Len(t, deliveries, 2)
+	for _, delivery := range deliveries {
+		assert.Equal(t, webhook.DeliveryStatusSucceeded, delivery.Status())
+		assert.Equal(t, http.StatusNoContent, delivery.ResponseCode())
+		assert.Equal(t, 1, delivery.Attempts())
+	}
+	assert.Empty(t, wt.log(t, other))
+}
+
+func Test_WebhooksShouldRetryWithExponentialBackoff(t *testing.T) {
+	ctx := context.Background()
+	wt := setupWebhookTest(t, 2, 5)
+	subscription := wt.subscribe(t, wt.url, webhook.EventOrderCreated)
+
+	newOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, wt.enqueue.Save(ctx, newOrder.DomainEvents()...))
+
+	// Первая попытка неудачна, следующая через BaseBackoff
+	wt.runDelivery(t)
+	delivery := wt.log(t, subscription)[0]
+	assert.Equal(t, webhook.DeliveryStatusPending, delivery.Status())
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseCode())
+	assert.Equal(t, wt.now.Add(time.Minute), delivery.NextAttemptAt())
+
+	// До наступления времени повтора ничего не отправляется
+	wt.runDelivery(t)
+	assert.Len(t, wt.receiver.received(), 1)
+
+	// Вторая неудача удваивает задержку
+	*wt.now = wt.now.Add(time.Minute)
+	wt.runDelivery(t)
+	delivery = wt.log(t, subscription)[0]
+	assert.Equal(t, 2, delivery.Attempts())
+	assert.Equal(t, wt.now.Add(2*time.Minute), delivery.NextAttemptAt())
+
+	*wt.now = wt.now.Add(2 * time.Minute)
+	wt.runDelivery(t)
+	delivery = wt.log(t, subscription)[0]
+	assert.Equal(t, webhook.DeliveryStatusSucceeded, delivery.Status())
+	assert.Equal(t, 3, delivery.Attempts())
+
+	// Все попытки несут одно и то же событие
+	received := wt.receiver.received()
+	require.Len(t, received, 3)
+	assert.Equal(t, received[0].ID, received[2].ID)
+}
+
+func Test_WebhooksShouldSendDeliveryOnceFromConcurrentRuns(t *testing.T) {
+	ctx := context.Background()
+	wt := setupWebhookTest(t, 0, 3)
+	wt.receiver.delay = 100 * time.Millisecond
+	subscription := wt.subscribe(t, wt.url, webhook.EventOrderCreated)
+
+	newOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, wt.enqueue.Save(ctx, newOrder.DomainEvents()...))
+
+	// Следующий запуск начинается, пока получатель ещё отвечает на предыдущий
+	command, err := NewDeliverWebhooksCommand()
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, wt.deliver.Handle(ctx, command))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, wt.receiver.received(), 1)
+	delivery := wt.log(t, subscription)[0]
+	assert.Equal(t, webhook.DeliveryStatusSucceeded, delivery.Status())
+	assert.Equal(t, 1, delivery.Attempts())
+}
+
+func Test_WebhooksShouldGiveUp(t *testing.T) {
+	ctx := context.Background()
+	wt := setupWebhookTest(t, 100, 2)
+	subscription := wt.subscribe(t, wt.url, webhook.EventOrderCreated)
+	deleted := wt.subscribe(t, wt.url, webhook.EventOrderCreated)
+
+	newOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, wt.enqueue.Save(ctx, newOrder.DomainEvents()...))
+	require.NoError(t, wt.subscriptions.Delete(ctx, deleted.ID()))
+
+	wt.runDelivery(t)
+	*wt.now = wt.now.Add(time.Minute)
+	wt.runDelivery(t)
+	*wt.now = wt.now.Add(time.Hour)
+	wt.runDelivery(t)
+
+	// Попытки исчерпаны
+	delivery := wt.log(t, subscription)[0]
+	assert.Equal(t, webhook.DeliveryStatusFailed, delivery.Status())
+	assert.Equal(t, 2, delivery.Attempts())
+	assert.Len(t, wt.receiver.received(), 2)
+
+	// Удалённой подписке ничего не отправлялось
+	delivery = wt.log(t, deleted)[0]
+	assert.Equal(t, webhook.DeliveryStatusFailed, delivery.Status())
+	assert.Equal(t, "subscription deleted", delivery.LastError())
+}
+
+// failingDeliveryRepository - не может сохранить доставки
+type failingDeliveryRepository struct {
+	*memory.WebhookDeliveryRepository
+}
+
+func (r failingDeliveryRepository) Add(ctx context.Context, deliveries ...*webhook.Delivery) error {
+	return errors.New("webhook deliveries are unavailable")
+}
+
+func Test_WebhooksShouldBeEnqueuedInOrderTransaction(t *testing.T) {
+	ctx := context.Background()
+	wt := setupWebhookTest(t, 0, 3)
+	subscription := wt.subscribe(t, wt.url, webhook.EventOrderCancelled)
+
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	newOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, orderRepository.Add(ctx, newOrder))
+	command, err := NewCancelOrderCommand(newOrder.ID())
+	require.NoError(t, err)
+
+	// Доставку не удалось сохранить - отмена откатывается и не публикуется
+	failingOutbox, err := NewEnqueueWebhooksEventHandler(wt.subscriptions,
+		failingDeliveryRepository{wt.deliveries})
+	require.NoError(t, err)
+	publisher := &recordingEventPublisher{}
+	handler, err := NewCancelOrderCommandHandler(unitOfWork, orderRepository, courierRepository, publisher,
+		failingOutbox)
+	require.NoError(t, err)
+	assert.Error(t, handler.Handle(ctx, command))
+
+	storedOrder, err := orderRepository.Get(ctx, newOrder.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, storedOrder.Status())
+	assert.Empty(t, publisher.events)
+	assert.Empty(t, wt.log(t, subscription))
+
+	// Доставка сохраняется вместе с отменой
+	handler, err = NewCancelOrderCommandHandler(unitOfWork, orderRepository, courierRepository, publisher, wt.enqueue)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+
+	storedOrder, err = orderRepository.Get(ctx, newOrder.ID())
+	require.NoError(t, err)
+	assert.True(t, storedOrder.IsCancelled())
+	require.Len(t, wt.log(t, subscription), 1)
+	wt.runDelivery(t)
+	require.Len(t, wt.receiver.received(), 1)
+	assert.Equal(t, webhook.EventOrderCancelled, wt.receiver.received()[0].Type)
+}
+
+func Test_SendTestWebhookShouldReportResult(t *testing.T) {
+	ctx := context.Background()
+	wt := setupWebhookTest(t, 1, 3)
+	subscription := wt.subscribe(t, wt.url, webhook.EventOrderCreated)
+	sender, err := webhookout.NewSender(time.Second, true)
+	require.NoError(t, err)
+	handler, err := NewSendTestWebhookCommandHandler(wt.subscriptions, wt.deliveries, sender)
+	require.NoError(t, err)
+	command, err := NewSendTestWebhookCommand(subscription.ID())
+	require.NoError(t, err)
+
+	// Неудачная проверка не повторяется
+	delivery, err := handler.Handle(ctx, command)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryStatusFailed, delivery.Status())
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseCode())
+
+	delivery, err = handler.Handle(ctx, command)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryStatusSucceeded, delivery.Status())
+	assert.Equal(t, webhook.EventTest, wt.receiver.received()[1].Type)
+	assert.Len(t, wt.log(t, subscription), 2)
+}
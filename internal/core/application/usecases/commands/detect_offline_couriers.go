@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+// DetectOfflineCouriersCommandHandler - признаёт недоступными курьеров, молчащих дольше timeout, и возвращает
+// их заказы в очередь на назначение. С trackNeverSeen недоступными признаются и курьеры, ни разу не выходившие
+// на связь за timeout с момента создания: когда позиции присылают приложения, такой курьер не в сети
+type DetectOfflineCouriersCommandHandler struct {
+	unitOfWork        uow.UnitOfWork
+	courierRepository ports.CourierRepository
+	orderRepository   ports.OrderRepository
+	eventPublisher    ports.DomainEventPublisher
+	outbox            ports.DomainEventOutbox
+	timeout           time.Duration
+	trackNeverSeen    bool
+	now               func() time.Time
+}
+
+func NewDetectOfflineCouriersCommandHandler(
+	unitOfWork uow.UnitOfWork,
+	courierRepository ports.CourierRepository,
+	orderRepository ports.OrderRepository,
+	eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox,
+	timeout time.Duration,
+	trackNeverSeen bool,
+) (*DetectOfflineCouriersCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+	if timeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("timeout")
+	}
+
+	return &DetectOfflineCouriersCommandHandler{
+		unitOfWork:        unitOfWork,
+		courierRepository: courierRepository,
+		orderRepository:   orderRepository,
+		eventPublisher:    eventPublisher,
+		outbox:            outbox,
+		timeout:           timeout,
+		trackNeverSeen:    trackNeverSeen,
+		now:               time.Now}, nil
+}
+
+func (ch *DetectOfflineCouriersCommandHandler) Handle(ctx context.Context, command DetectOfflineCouriersCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("detect offline couriers command")
+	}
+
+	// Восстановили
+	silentSince := ch.now().Add(-ch.timeout)
+	staleCouriers, err := ch.courierRepository.GetAllStale(ctx, silentSince)
+	if err != nil {
+		return err
+	}
+	if ch.trackNeverSeen {
+		neverSeen, err := ch.courierRepository.GetAllNeverSeen(ctx, silentSince)
+		if err != nil {
+			return err
+		}
+		staleCouriers = append(staleCouriers, neverSeen...)
+	}
+	if len(staleCouriers) == 0 {
+		return nil
+	}
+
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("DetectOfflineCouriersCommandHandler Rollback error:", err)
+		}
+	}()
+
+	var changed []eventSource
+	for _, courier := range staleCouriers {
+		// Изменили и сохранили: курьер больше не получает заказы, его заказы ждут другого курьера
+		err = courier.GoOffline()
+		if err != nil {
+			return err
+		}
+		err = ch.courierRepository.Update(ctx, courier)
+		if err != nil {
+			return err
+		}
+
+		orders, err := ch.orderRepository.GetAllAssignedToCourier(ctx, courier.ID())
+		if err != nil {
+			return err
+		}
+		for _, assignedOrder := range orders {
+			err = assignedOrder.Unassign(order.UnassignReasonCourierOffline)
+			if err != nil {
+				return err
+			}
+			err = ch.orderRepository.Update(ctx, assignedOrder)
+			if err != nil {
+				return err
+			}
+			changed = append(changed, assignedOrder)
+		}
+		log.Printf("courier %v is offline, %d orders returned to dispatch", courier.ID(), len(orders))
+	}
+
+	err = saveDomainEvents(ctx, ch.outbox, changed...)
+	if err != nil {
+		return err
+	}
+
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, changed...)
+
+	return nil
+}
+
+type DetectOfflineCouriersCommand struct {
+	isSet bool
+}
+
+func NewDetectOfflineCouriersCommand() (DetectOfflineCouriersCommand, error) {
+	return DetectOfflineCouriersCommand{isSet: true}, nil
+}
+
+func (c DetectOfflineCouriersCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+func Test_DetectOfflineCouriersShouldReturnOrdersToDispatch(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	silentCourier := courier.MustNewCourier("Иван", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	silentCourier.Heartbeat(now.Add(-5 * time.Minute))
+	require.NoError(t, silentCourier.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, silentCourier))
+	onlineCourier := courier.MustNewCourier("Пётр", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	onlineCourier.Heartbeat(now)
+	require.NoError(t, onlineCourier.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, onlineCourier))
+
+	lostOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, lostOrder.AssignToCourier(silentCourier.ID()))
+	require.NoError(t, lostOrder.PickUp())
+	require.NoError(t, orderRepository.Add(ctx, lostOrder))
+	keptOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, keptOrder.AssignToCourier(onlineCourier.ID()))
+	require.NoError(t, orderRepository.Add(ctx, keptOrder))
+
+	publisher := &recordingEventPublisher{}
+	handler, err := NewDetectOfflineCouriersCommandHandler(unitOfWork, courierRepository, orderRepository, publisher,
+		&recordingOutbox{}, 2*time.Minute, false)
+	require.NoError(t, err)
+	command, err := NewDetectOfflineCouriersCommand()
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+
+	storedCourier, err := courierRepository.Get(ctx, silentCourier.ID())
+	require.NoError(t, err)
+	assert.True(t, storedCourier.IsOffline())
+	storedOrder, err := orderRepository.Get(ctx, lostOrder.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, storedOrder.Status())
+	assert.Nil(t, storedOrder.AssignedCourier())
+	assert.Nil(t, storedOrder.AssignedAt())
+	assert.Nil(t, storedOrder.PickedUpAt())
+	assert.Equal(t, order.UnassignReasonCourierOffline, storedOrder.UnassignReason())
+
+	storedCourier, err = courierRepository.Get(ctx, onlineCourier.ID())
+	require.NoError(t, err)
+	assert.True(t, storedCourier.IsBusy())
+	storedOrder, err = orderRepository.Get(ctx, keptOrder.ID())
+	require.NoError(t, err)
+	assert.True(t, storedOrder.IsAssigned())
+
+	require.Len(t, publisher.events, 1)
+	unassigned, ok := publisher.events[0].(order.UnassignedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, lostOrder.ID(), unassigned.OrderID())
+	assert.Equal(t, silentCourier.ID(), unassigned.CourierID())
+	assert.Equal(t, order.UnassignReasonCourierOffline, unassigned.Reason())
+
+	// Недоступного курьера повторно не проверяем
+	require.NoError(t, handler.Handle(ctx, command))
+	assert.Len(t, publisher.events, 1)
+}
+
+func Test_DetectOfflineCouriersShouldTrackNeverSeenCouriersOnlyWhenAsked(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	// Приложение курьера так и не вышло на связь
+	neverSeen := courier.RestoreCourier(uuid.New(), kernel.DefaultRegion(), "Иван",
+		courier.MustNewTransport("Велосипед", 2), kernel.MustNewLocation(1, 1), courier.StatusFree,
+		now.Add(-time.Hour), nil, nil, nil)
+	require.NoError(t, courierRepository.Add(ctx, neverSeen))
+	// Только что добавленному курьеру ещё не вышел срок
+	justAdded := courier.MustNewCourier("Пётр", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, courierRepository.Add(ctx, justAdded))
+
+	command, err := NewDetectOfflineCouriersCommand()
+	require.NoError(t, err)
+
+	// С симуляцией движения курьеры сигналов не шлют, и их не отслеживаем
+	handler, err := NewDetectOfflineCouriersCommandHandler(unitOfWork, courierRepository, orderRepository,
+		&recordingEventPublisher{}, &recordingOutbox{}, 2*time.Minute, false)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+	stored, err := courierRepository.Get(ctx, neverSeen.ID())
+	require.NoError(t, err)
+	assert.True(t, stored.IsFree())
+
+	handler, err = NewDetectOfflineCouriersCommandHandler(unitOfWork, courierRepository, orderRepository,
+		&recordingEventPublisher{}, &recordingOutbox{}, 2*time.Minute, true)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(ctx, command))
+	stored, err = courierRepository.Get(ctx, neverSeen.ID())
+	require.NoError(t, err)
+	assert.True(t, stored.IsOffline())
+	stored, err = courierRepository.Get(ctx, justAdded.ID())
+	require.NoError(t, err)
+	assert.True(t, stored.IsFree())
+}
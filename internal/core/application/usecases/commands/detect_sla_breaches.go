@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+// DetectSLABreachesCommandHandler - находит заказы, не успевшие к срокам политики, и сообщает о каждом
+// нарушении событием order.SLABreachedDomainEvent
+type DetectSLABreachesCommandHandler struct {
+	unitOfWork      uow.UnitOfWork
+	orderRepository ports.OrderRepository
+	eventPublisher  ports.DomainEventPublisher
+	outbox          ports.DomainEventOutbox
+	policy          order.SLAPolicy
+	now             func() time.Time
+}
+
+func NewDetectSLABreachesCommandHandler(
+	unitOfWork uow.UnitOfWork, orderRepository ports.OrderRepository, eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox, policy order.SLAPolicy) (*DetectSLABreachesCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+	if policy.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("policy")
+	}
+
+	return &DetectSLABreachesCommandHandler{
+		unitOfWork:      unitOfWork,
+		orderRepository: orderRepository,
+		eventPublisher:  eventPublisher,
+		outbox:          outbox,
+		policy:          policy,
+		now:             time.Now}, nil
+}
+
+func (ch *DetectSLABreachesCommandHandler) Handle(ctx context.Context, command DetectSLABreachesCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("detect sla breaches command")
+	}
+
+	// Восстановили
+	orders, err := ch.orderRepository.GetAllNotFinal(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := ch.now()
+	for _, aggregate := range orders {
+		if !aggregate.CheckSLA(ch.policy, now) {
+			continue
+		}
+		err = ch.markBreached(ctx, aggregate.ID(), now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// markBreached - перечитать заказ в транзакции и сохранить отметки о нарушениях вместе с событиями о них
+func (ch *DetectSLABreachesCommandHandler) markBreached(ctx context.Context, orderID uuid.UUID, now time.Time) error {
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("DetectSLABreachesCommandHandler Rollback error:", err)
+		}
+	}()
+
+	// Восстановили
+	aggregate, err := ch.orderRepository.Get(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	// Изменили
+	if !aggregate.CheckSLA(ch.policy, now) {
+		return nil
+	}
+
+	// Сохранили: отметка о нарушении не даёт сообщить о нём повторно
+	err = ch.orderRepository.Update(ctx, aggregate)
+	if err != nil {
+		return err
+	}
+	err = saveDomainEvents(ctx, ch.outbox, aggregate)
+	if err != nil {
+		return err
+	}
+
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, aggregate)
+
+	return nil
+}
+
+type DetectSLABreachesCommand struct {
+	isSet bool
+}
+
+func NewDetectSLABreachesCommand() (DetectSLABreachesCommand, error) {
+	return DetectSLABreachesCommand{isSet: true}, nil
+}
+
+func (c DetectSLABreachesCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+func Test_DetectSLABreachesShouldReportEachBreachOnce(t *testing.T) {
+	ctx := context.Background()
+	orderRepository, unitOfWork := setupOrderStorage(t)
+
+	createdAt := time.Now().UTC().Add(-time.Hour)
+	restore := func(tier order.Tier, status order.Status, assignedAt *time.Time) *order.Order {
+		var courierID *uuid.UUID
+		if assignedAt != nil {
+			id := uuid.New()
+			courierID = &id
+		}
+		aggregate := order.RestoreOrder(uuid.New(), kernel.DefaultRegion(), tier, order.DeliveryWindow{}, courierID,
+			testAddress, kernel.MustNewLocation(2, 2), status,
+			order.Timestamps{CreatedAt: createdAt, AssignedAt: assignedAt}, "")
+		require.NoError(t, orderRepository.Add(ctx, aggregate))
+		return aggregate
+	}
+	onTime := createdAt.Add(time.Minute)
+	// Экспресс ждёт курьера час: нарушены оба срока
+	waitingExpress := restore(order.TierExpress, order.StatusCreated, nil)
+	// Обычный заказ назначен вовремя, но за час не доставлен
+	lateStandard := restore(order.TierStandard, order.StatusAssigned, &onTime)
+	// Заказ к интервалу укладывается в свои сроки
+	restore(order.TierScheduled, order.StatusAssigned, &onTime)
+
+	policy, err := order.NewSLAPolicy(
+		order.SLALimits{Assign: 10 * time.Minute, Deliver: 30 * time.Minute},
+		order.SLALimits{Assign: 20 * time.Minute, Deliver: 50 * time.Minute},
+		order.SLALimits{Assign: time.Hour, Deliver: 4 * time.Hour},
+	)
+	require.NoError(t, err)
+	publisher := &recordingEventPublisher{}
+	outbox := &recordingOutbox{}
+	handler, err := NewDetectSLABreachesCommandHandler(unitOfWork, orderRepository, publisher, outbox, policy)
+	require.NoError(t, err)
+	command, err := NewDetectSLABreachesCommand()
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+
+	type breach struct {
+		orderID uuid.UUID
+		stage   order.SLAStage
+	}
+	var breaches []breach
+	for _, event := range publisher.events {
+		breached, ok := event.(order.SLABreachedDomainEvent)
+		require.True(t, ok)
+		breaches = append(breaches, breach{orderID: breached.OrderID(), stage: breached.Stage()})
+	}
+	assert.ElementsMatch(t, []breach{
+		{orderID: waitingExpress.ID(), stage: order.SLAStageAssign},
+		{orderID: waitingExpress.ID(), stage: order.SLAStageDeliver},
+		{orderID: lateStandard.ID(), stage: order.SLAStageDeliver},
+	}, breaches)
+
+	assert.Equal(t, publisher.events, outbox.events)
+
+	// Отмеченные нарушения сохранены и повторно не сообщаются
+	require.NoError(t, handler.Handle(ctx, command))
+	assert.Len(t, publisher.events, 3)
+	assert.Len(t, outbox.events, 3)
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ ports.DomainEventOutbox = &EnqueueWebhooksEventHandler{}
+
+// EnqueueWebhooksEventHandler - превращает события заказов в доставки вебхуков в транзакции, изменившей заказ.
+// Сами запросы отправляет DeliverWebhooksCommandHandler, поэтому сохранение не ждёт получателей
+type EnqueueWebhooksEventHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+	deliveryRepository     ports.WebhookDeliveryRepository
+}
+
+func NewEnqueueWebhooksEventHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+	deliveryRepository ports.WebhookDeliveryRepository,
+) (*EnqueueWebhooksEventHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+	if deliveryRepository == nil {
+		return nil, errs.NewValueIsRequiredError("deliveryRepository")
+	}
+
+	return &EnqueueWebhooksEventHandler{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository}, nil
+}
+
+func (h *EnqueueWebhooksEventHandler) Save(ctx context.Context, events ...ddd.DomainEvent) error {
+	var subscriptions []*webhook.Subscription
+	var deliveries []*webhook.Delivery
+	now := time.Now().UTC()
+	for _, event := range events {
+		eventType, data, ok := toWebhookEvent(event)
+		if !ok {
+			continue
+		}
+
+		// Подписки читаем один раз и только если есть что отправлять
+		if subscriptions == nil {
+			var err error
+			subscriptions, err = h.subscriptionRepository.GetAll(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		payload, err := json.Marshal(WebhookPayload{
+			ID:         event.EventID(),
+			Type:       eventType,
+			OccurredAt: event.OccurredAt(),
+			Data:       data,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, subscription := range subscriptions {
+			if !subscription.Accepts(eventType) {
+				continue
+			}
+			delivery, err := webhook.NewDelivery(subscription.ID(), event.EventID(), eventType, payload, now)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return h.deliveryRepository.Add(ctx, deliveries...)
+}
+
+// toWebhookEvent - тип и данные вебхука для события, false - о событии партнёрам не сообщаем
+func toWebhookEvent(event ddd.DomainEvent) (webhook.EventType, any, bool) {
+	switch e := event.(type) {
+	case order.StatusChangedDomainEvent:
+		eventType, ok := orderEventTypes[e.Status()]
+		return eventType, WebhookOrderData{
+			OrderID:   e.OrderID(),
+			Status:    string(e.Status()),
+			CourierID: e.CourierID(),
+		}, ok
+	case order.SLABreachedDomainEvent:
+		return webhook.EventOrderSLABreached, WebhookSLABreachData{
+			OrderID:  e.OrderID(),
+			Tier:     string(e.Tier()),
+			Stage:    string(e.Stage()),
+			Deadline: e.Deadline(),
+		}, true
+	case order.UnassignedDomainEvent:
+		return webhook.EventOrderUnassigned, WebhookUnassignData{
+			OrderID:   e.OrderID(),
+			CourierID: e.CourierID(),
+			Reason:    string(e.Reason()),
+		}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// orderEventTypes - статусы, о которых сообщаем партнёрам; ожидание геокодирования внутреннее
+var orderEventTypes = map[order.Status]webhook.EventType{
+	order.StatusCreated:   webhook.EventOrderCreated,
+	order.StatusAssigned:  webhook.EventOrderAssigned,
+	order.StatusCompleted: webhook.EventOrderCompleted,
+	order.StatusCancelled: webhook.EventOrderCancelled,
+}
+
+// WebhookPayload - тело запроса к получателю
+type WebhookPayload struct {
+	ID         uuid.UUID         `json:"id"`
+	Type       webhook.EventType `json:"type"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	Data       any               `json:"data"`
+}
+
+type WebhookOrderData struct {
+	OrderID   uuid.UUID  `json:"orderId"`
+	Status    string     `json:"status"`
+	CourierID *uuid.UUID `json:"courierId,omitempty"`
+}
+
+type WebhookUnassignData struct {
+	OrderID   uuid.UUID `json:"orderId"`
+	CourierID uuid.UUID `json:"courierId"`
+	Reason    string    `json:"reason"`
+}
+
+type WebhookSLABreachData struct {
+	OrderID  uuid.UUID `json:"orderId"`
+	Tier     string    `json:"tier"`
+	Stage    string    `json:"stage"`
+	Deadline time.Time `json:"deadline"`
+}
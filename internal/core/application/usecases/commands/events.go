@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"log"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+type eventSource interface {
+	DomainEvents() []ddd.DomainEvent
+	ClearDomainEvents()
+}
+
+// saveDomainEvents - вызывается внутри транзакции до Commit. Ошибка должна откатить транзакцию.
+// События не очищаются: после Commit их разошлёт publishDomainEvents
+func saveDomainEvents(ctx context.Context, outbox ports.DomainEventOutbox, sources ...eventSource) error {
+	var events []ddd.DomainEvent
+	for _, source := range sources {
+		events = append(events, source.DomainEvents()...)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	return outbox.Save(ctx, events...)
+}
+
+// publishDomainEvents - вызывается после сохранения агрегатов. Изменения уже зафиксированы,
+// поэтому ошибка публикации только логируется
+func publishDomainEvents(ctx context.Context, publisher ports.DomainEventPublisher, sources ...eventSource) {
+	var events []ddd.DomainEvent
+	for _, source := range sources {
+		events = append(events, source.DomainEvents()...)
+		source.ClearDomainEvents()
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	err := publisher.Publish(ctx, events...)
+	if err != nil {
+		log.Println("Publish domain events error:", err)
+	}
+}
@@ -4,23 +4,41 @@ import (
 	"context"
 	"errors"
 	"log"
+	"time"
 
+	model "github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
 	"github.com/IgorAleksandroff/delivery/internal/core/ports"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
 )
 
+// MoveCouriersCommandHandler - двигает курьеров одного региона, у каждого региона свой интервал хода
 type MoveCouriersCommandHandler struct {
+	region            kernel.RegionCode
 	unitOfWork        uow.UnitOfWork
 	orderRepository   ports.OrderRepository
 	courierRepository ports.CourierRepository
+	eventPublisher    ports.DomainEventPublisher
+	outbox            ports.DomainEventOutbox
+	router            routing.Router
+	profiles          *model.Profiles
 }
 
 func NewMoveCouriersCommandHandler(
+	region kernel.RegionCode,
 	unitOfWork uow.UnitOfWork,
 	orderRepository ports.OrderRepository,
 	courierRepository ports.CourierRepository,
+	eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox,
+	router routing.Router,
+	profiles *model.Profiles,
 ) (*MoveCouriersCommandHandler, error) {
+	if region.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("region")
+	}
 	if unitOfWork == nil {
 		return nil, errs.NewValueIsRequiredError("unitOfWork")
 	}
@@ -30,11 +48,28 @@ func NewMoveCouriersCommandHandler(
 	if courierRepository == nil {
 		return nil, errs.NewValueIsRequiredError("courierRepository")
 	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+	if router == nil {
+		return nil, errs.NewValueIsRequiredError("router")
+	}
+	if profiles == nil {
+		return nil, errs.NewValueIsRequiredError("profiles")
+	}
 
 	return &MoveCouriersCommandHandler{
+		region:            region,
 		unitOfWork:        unitOfWork,
 		orderRepository:   orderRepository,
-		courierRepository: courierRepository}, nil
+		courierRepository: courierRepository,
+		eventPublisher:    eventPublisher,
+		outbox:            outbox,
+		router:            router,
+		profiles:          profiles}, nil
 }
 
 func (ch *MoveCouriersCommandHandler) Handle(ctx context.Context, command MoveCouriersCommand) error {
@@ -43,13 +78,13 @@ func (ch *MoveCouriersCommandHandler) Handle(ctx context.Context, command MoveCo
 	}
 
 	// Восстановили
-	assignedOrders, err := ch.orderRepository.GetAllInAssignedStatus(ctx)
+	assignedOrders, err := ch.orderRepository.GetAllInAssignedStatus(ctx, ch.region)
 	if err != nil {
-		if errors.Is(err, errs.ErrObjectNotFound) {
-			return nil
-		}
 		return err
 	}
+	if len(assignedOrders) == 0 {
+		return nil
+	}
 
 	// Изменили и сохранили
 	ctx = ch.unitOfWork.Begin(ctx)
@@ -60,6 +95,8 @@ func (ch *MoveCouriersCommandHandler) Handle(ctx context.Context, command MoveCo
 		}
 	}()
 
+	now := time.Now()
+	var changed []eventSource
 	for _, assignedOrder := range assignedOrders {
 		courier, err := ch.courierRepository.Get(ctx, *assignedOrder.AssignedCourier())
 		if err != nil {
@@ -69,7 +106,21 @@ func (ch *MoveCouriersCommandHandler) Handle(ctx context.Context, command MoveCo
 			return err
 		}
 
-		err = courier.Move(assignedOrder.Location())
+		err = assignedOrder.PickUp()
+		if err != nil {
+			return err
+		}
+
+		// Путь строим каждый ход заново: курьер мог остановиться посреди дороги, а скорости - смениться
+		vehicle := ch.profiles.Vehicle(courier.Transport(), now)
+		path, err := ch.router.Route(courier.Location(), assignedOrder.Location(), vehicle)
+		if err == nil {
+			err = courier.MoveAlong(path, vehicle)
+		}
+		if errors.Is(err, routing.ErrNoRoute) {
+			log.Printf("courier %v is stuck: %v", courier.ID(), err)
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -93,6 +144,12 @@ func (ch *MoveCouriersCommandHandler) Handle(ctx context.Context, command MoveCo
 		if err != nil {
 			return err
 		}
+		changed = append(changed, courier, assignedOrder)
+	}
+
+	err = saveDomainEvents(ctx, ch.outbox, changed...)
+	if err != nil {
+		return err
 	}
 
 	err = ch.unitOfWork.Commit(ctx)
@@ -100,6 +157,9 @@ func (ch *MoveCouriersCommandHandler) Handle(ctx context.Context, command MoveCo
 		return err
 	}
 
+	// Потеря позиции не критична: на следующем ходу курьер сдвинется снова
+	publishDomainEvents(ctx, ch.eventPublisher, changed...)
+
 	return nil
 }
 
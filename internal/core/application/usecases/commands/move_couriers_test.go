@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+type recordingEventPublisher struct {
+	events []ddd.DomainEvent
+}
+
+func (p *recordingEventPublisher) Publish(ctx context.Context, events ...ddd.DomainEvent) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+// recordingOutbox - err имитирует сбой сохранения событий
+type recordingOutbox struct {
+	events []ddd.DomainEvent
+	err    error
+}
+
+func (o *recordingOutbox) Save(ctx context.Context, events ...ddd.DomainEvent) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.events = append(o.events, events...)
+	return nil
+}
+
+func Test_MoveCouriersShouldPublishDomainEvents(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	movingCourier := courier.MustNewCourier("Иван", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, movingCourier.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, movingCourier))
+	assignedOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(1, 4))
+	require.NoError(t, assignedOrder.AssignToCourier(movingCourier.ID()))
+	require.NoError(t, orderRepository.Add(ctx, assignedOrder))
+
+	publisher := &recordingEventPublisher{}
+	handler, err := NewMoveCouriersCommandHandler(kernel.DefaultRegion(), unitOfWork, orderRepository, courierRepository,
+		publisher, &recordingOutbox{}, routing.Direct{}, &courier.Profiles{})
+	require.NoError(t, err)
+	command, err := NewMoveCouriersCommand()
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+	require.NoError(t, handler.Handle(ctx, command))
+
+	require.Len(t, publisher.events, 3)
+	var locations []kernel.Location
+	for _, event := range publisher.events[:2] {
+		locationChanged, ok := event.(courier.LocationChangedDomainEvent)
+		require.True(t, ok)
+		assert.Equal(t, movingCourier.ID(), locationChanged.CourierID())
+		locations = append(locations, locationChanged.Location())
+	}
+	assert.Equal(t, []kernel.Location{kernel.MustNewLocation(1, 3), kernel.MustNewLocation(1, 4)}, locations)
+
+	// Курьер добрался, заказ доставлен
+	statusChanged, ok := publisher.events[2].(order.StatusChangedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, assignedOrder.ID(), statusChanged.OrderID())
+	assert.Equal(t, order.StatusCompleted, statusChanged.Status())
+	delivered, err := orderRepository.Get(ctx, assignedOrder.ID())
+	require.NoError(t, err)
+	require.NotNil(t, delivered.PickedUpAt())
+	require.NotNil(t, delivered.CompletedAt())
+	assert.False(t, delivered.CompletedAt().Before(*delivered.PickedUpAt()))
+
+	// Двигать больше некого
+	require.NoError(t, handler.Handle(ctx, command))
+	assert.Len(t, publisher.events, 3)
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+var CourierInAnotherRegion = errors.New("courier works in another region")
+
+// ReassignOrderCommandHandler - диспетчер передаёт заказ выбранному свободному курьеру того же региона
+type ReassignOrderCommandHandler struct {
+	unitOfWork        uow.UnitOfWork
+	orderRepository   ports.OrderRepository
+	courierRepository ports.CourierRepository
+	eventPublisher    ports.DomainEventPublisher
+	outbox            ports.DomainEventOutbox
+}
+
+func NewReassignOrderCommandHandler(
+	unitOfWork uow.UnitOfWork,
+	orderRepository ports.OrderRepository,
+	courierRepository ports.CourierRepository,
+	eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox,
+) (*ReassignOrderCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+
+	return &ReassignOrderCommandHandler{
+		unitOfWork:        unitOfWork,
+		orderRepository:   orderRepository,
+		courierRepository: courierRepository,
+		eventPublisher:    eventPublisher,
+		outbox:            outbox}, nil
+}
+
+// Handle - ErrObjectNotFound, если нет заказа или курьера; CourierInAnotherRegion, ошибки занятого или
+// недоступного курьера и доставленного или отменённого заказа - если передать заказ нельзя
+func (ch *ReassignOrderCommandHandler) Handle(ctx context.Context, command ReassignOrderCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("reassign order command")
+	}
+
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("ReassignOrderCommandHandler Rollback error:", err)
+		}
+	}()
+
+	// Восстановили
+	orderAggregate, err := ch.orderRepository.Get(ctx, command.orderID)
+	if err != nil {
+		return err
+	}
+	previousCourierID := orderAggregate.AssignedCourier()
+	if previousCourierID != nil && *previousCourierID == command.courierID {
+		return nil
+	}
+	target, err := ch.courierRepository.Get(ctx, command.courierID)
+	if err != nil {
+		return err
+	}
+	if target.Region() != orderAggregate.Region() {
+		return CourierInAnotherRegion
+	}
+
+	// Изменили: прежний курьер, если он на связи, снова свободен
+	err = target.SetBusy()
+	if err != nil {
+		return err
+	}
+	if previousCourierID != nil {
+		previous, err := ch.courierRepository.Get(ctx, *previousCourierID)
+		if err != nil {
+			return err
+		}
+		err = orderAggregate.Unassign(order.UnassignReasonReassigned)
+		if err != nil {
+			return err
+		}
+		if !previous.IsOffline() {
+			err = previous.SetFree()
+			if err != nil {
+				return err
+			}
+			err = ch.courierRepository.Update(ctx, previous)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = orderAggregate.AssignToCourier(target.ID())
+	if err != nil {
+		return err
+	}
+
+	// Сохранили
+	err = ch.orderRepository.Update(ctx, orderAggregate)
+	if err != nil {
+		return err
+	}
+	err = ch.courierRepository.Update(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	err = saveDomainEvents(ctx, ch.outbox, orderAggregate)
+	if err != nil {
+		return err
+	}
+
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, orderAggregate)
+
+	return nil
+}
+
+type ReassignOrderCommand struct {
+	orderID   uuid.UUID
+	courierID uuid.UUID
+
+	isSet bool
+}
+
+func NewReassignOrderCommand(orderID uuid.UUID, courierID uuid.UUID) (ReassignOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return ReassignOrderCommand{}, errs.NewValueIsRequiredError("orderID")
+	}
+	if courierID == uuid.Nil {
+		return ReassignOrderCommand{}, errs.NewValueIsRequiredError("courierID")
+	}
+	return ReassignOrderCommand{orderID: orderID, courierID: courierID, isSet: true}, nil
+}
+
+func (c ReassignOrderCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+func Test_ReassignOrderShouldMoveOrderToAnotherCourier(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	previousCourier := courier.MustNewCourier("Иван", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, previousCourier.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, previousCourier))
+	targetCourier := courier.MustNewCourier("Пётр", "Машина", 3, kernel.MustNewLocation(9, 9))
+	require.NoError(t, courierRepository.Add(ctx, targetCourier))
+	assignedOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, assignedOrder.AssignToCourier(previousCourier.ID()))
+	require.NoError(t, orderRepository.Add(ctx, assignedOrder))
+
+	publisher := &recordingEventPublisher{}
+	handler, err := NewReassignOrderCommandHandler(unitOfWork, orderRepository, courierRepository, publisher, &recordingOutbox{})
+	require.NoError(t, err)
+	command, err := NewReassignOrderCommand(assignedOrder.ID(), targetCourier.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+
+	storedOrder, err := orderRepository.Get(ctx, assignedOrder.ID())
+	require.NoError(t, err)
+	assert.Equal(t, targetCourier.ID(), *storedOrder.AssignedCourier())
+	assert.Equal(t, order.UnassignReasonReassigned, storedOrder.UnassignReason())
+	storedCourier, err := courierRepository.Get(ctx, previousCourier.ID())
+	require.NoError(t, err)
+	assert.True(t, storedCourier.IsFree())
+	storedCourier, err = courierRepository.Get(ctx, targetCourier.ID())
+	require.NoError(t, err)
+	assert.True(t, storedCourier.IsBusy())
+
+	require.Len(t, publisher.events, 2)
+	unassigned, ok := publisher.events[0].(order.UnassignedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, previousCourier.ID(), unassigned.CourierID())
+	statusChanged, ok := publisher.events[1].(order.StatusChangedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, order.StatusAssigned, statusChanged.Status())
+
+	// Повторная передача тому же курьеру ничего не меняет
+	require.NoError(t, handler.Handle(ctx, command))
+	assert.Len(t, publisher.events, 2)
+}
+
+func Test_ReassignOrderShouldRejectUnavailableCourier(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	waitingOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+	require.NoError(t, orderRepository.Add(ctx, waitingOrder))
+	busyCourier := courier.MustNewCourier("Иван", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, busyCourier.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, busyCourier))
+	offlineCourier := courier.MustNewCourier("Пётр", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, offlineCourier.GoOffline())
+	require.NoError(t, courierRepository.Add(ctx, offlineCourier))
+	transport, err := courier.NewTransport("Велосипед", 2)
+	require.NoError(t, err)
+	kazanCourier, err := courier.NewCourierInRegion(kernel.MustNewRegionCode("kzn"), "Олег", transport,
+		kernel.MustNewLocation(1, 1))
+	require.NoError(t, err)
+	require.NoError(t, courierRepository.Add(ctx, kazanCourier))
+
+	publisher := &recordingEventPublisher{}
+	handler, err := NewReassignOrderCommandHandler(unitOfWork, orderRepository, courierRepository, publisher, &recordingOutbox{})
+	require.NoError(t, err)
+	reassign := func(courierID uuid.UUID) error {
+		command, err := NewReassignOrderCommand(waitingOrder.ID(), courierID)
+		require.NoError(t, err)
+		return handler.Handle(ctx, command)
+	}
+
+	assert.ErrorIs(t, reassign(busyCourier.ID()), courier.ErrCourierAlreadyBusy)
+	assert.ErrorIs(t, reassign(offlineCourier.ID()), courier.ErrCourierOffline)
+	assert.ErrorIs(t, reassign(kazanCourier.ID()), CourierInAnotherRegion)
+
+	storedOrder, err := orderRepository.Get(ctx, waitingOrder.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, storedOrder.Status())
+	assert.Empty(t, publisher.events)
+}
@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	model "github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+// MaxLocationReports - сколько позиций приложение курьера может прислать одним пакетом
+const MaxLocationReports = 500
+
+// ReportCourierLocationsCommandHandler - принимает позиции из приложения курьера вместо симуляции движения.
+// Заказ считается забранным с первой принятой позицией и доставленным, когда курьер добрался до адреса
+type ReportCourierLocationsCommandHandler struct {
+	unitOfWork        uow.UnitOfWork
+	courierRepository ports.CourierRepository
+	orderRepository   ports.OrderRepository
+	eventPublisher    ports.DomainEventPublisher
+	outbox            ports.DomainEventOutbox
+	regions           *region.Catalog
+	speedLimits       map[kernel.RegionCode]model.SpeedLimit
+	// arrivalRadius - на каком расстоянии в метрах от адреса в координатах WGS84 заказ считается доставленным,
+	// на сетке курьер должен оказаться в клетке заказа
+	arrivalRadius int
+	now           func() time.Time
+}
+
+// NewReportCourierLocationsCommandHandler - speedLimits - правдоподобная скорость для каждого региона
+func NewReportCourierLocationsCommandHandler(
+	unitOfWork uow.UnitOfWork,
+	courierRepository ports.CourierRepository,
+	orderRepository ports.OrderRepository,
+	eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox,
+	regions *region.Catalog,
+	speedLimits map[kernel.RegionCode]model.SpeedLimit,
+	arrivalRadius int,
+) (*ReportCourierLocationsCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+	if regions == nil {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+	if len(speedLimits) == 0 {
+		return nil, errs.NewValueIsRequiredError("speedLimits")
+	}
+	if arrivalRadius < 0 {
+		return nil, errs.NewValueIsInvalidError("arrivalRadius")
+	}
+
+	return &ReportCourierLocationsCommandHandler{
+		unitOfWork:        unitOfWork,
+		courierRepository: courierRepository,
+		orderRepository:   orderRepository,
+		eventPublisher:    eventPublisher,
+		outbox:            outbox,
+		regions:           regions,
+		speedLimits:       speedLimits,
+		arrivalRadius:     arrivalRadius,
+		now:               time.Now}, nil
+}
+
+// Handle - позиции применяются по возрастанию времени. Неправдоподобные, устаревшие и вне региона курьера
+// отклоняются по одной, остальные принимаются. ErrObjectNotFound, если курьера нет
+func (ch *ReportCourierLocationsCommandHandler) Handle(ctx context.Context,
+	command ReportCourierLocationsCommand) (ReportCourierLocationsResponse, error) {
+	if command.isEmpty() {
+		return ReportCourierLocationsResponse{}, errs.NewValueIsRequiredError("report courier locations command")
+	}
+
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("ReportCourierLocationsCommandHandler Rollback error:", err)
+		}
+	}()
+
+	// Восстановили
+	courier, err := ch.courierRepository.Get(ctx, command.courierID)
+	if err != nil {
+		return ReportCourierLocationsResponse{}, err
+	}
+	courierRegion, ok := ch.regions.Get(courier.Region())
+	speedLimit, hasLimit := ch.speedLimits[courier.Region()]
+	if !ok || !hasLimit {
+		return ReportCourierLocationsResponse{}, fmt.Errorf("%w: %s", region.ErrUnknownRegion, courier.Region())
+	}
+	assignedOrders, err := ch.orderRepository.GetAllAssignedToCourier(ctx, courier.ID())
+	if err != nil {
+		return ReportCourierLocationsResponse{}, err
+	}
+
+	// Изменили
+	response := ReportCourierLocationsResponse{Rejected: make([]RejectedLocation, 0)}
+	now := ch.now()
+	for _, i := range command.order() {
+		report := command.reports[i]
+		reportedAt := report.ReportedAt
+		if reportedAt.After(now) {
+			// Часы телефона могут спешить, из будущего позиции не принимаем
+			reportedAt = now
+		}
+		err = courierRegion.Bounds().Validate(report.Location)
+		if err == nil {
+			err = courier.ReportLocation(report.Location, reportedAt, speedLimit)
+		}
+		if isRejectedLocation(err) {
+			response.Rejected = append(response.Rejected, RejectedLocation{Index: i, Reason: err})
+			continue
+		}
+		if err != nil {
+			return ReportCourierLocationsResponse{}, err
+		}
+		response.Accepted++
+
+		completed, err := ch.deliverArrived(courier, assignedOrders)
+		if err != nil {
+			return ReportCourierLocationsResponse{}, err
+		}
+		response.CompletedOrders = append(response.CompletedOrders, completed...)
+	}
+	if response.Accepted == 0 {
+		return response, nil
+	}
+
+	// Сохранили
+	changed := make([]eventSource, 0, len(assignedOrders)+1)
+	for _, assignedOrder := range assignedOrders {
+		err = ch.orderRepository.Update(ctx, assignedOrder)
+		if err != nil {
+			return ReportCourierLocationsResponse{}, err
+		}
+		changed = append(changed, assignedOrder)
+	}
+	err = ch.courierRepository.Update(ctx, courier)
+	if err != nil {
+		return ReportCourierLocationsResponse{}, err
+	}
+	changed = append(changed, courier)
+
+	err = saveDomainEvents(ctx, ch.outbox, changed...)
+	if err != nil {
+		return ReportCourierLocationsResponse{}, err
+	}
+
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return ReportCourierLocationsResponse{}, err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, changed...)
+
+	return response, nil
+}
+
+// deliverArrived - курьер двинулся с заказами; доставить те, до адреса которых он добрался,
+// и освободить курьера, когда доставлять больше нечего
+func (ch *ReportCourierLocationsCommandHandler) deliverArrived(courier *model.Courier,
+	assignedOrders []*order.Order) ([]uuid.UUID, error) {
+	var completed []uuid.UUID
+	remaining := 0
+	for _, assignedOrder := range assignedOrders {
+		if !assignedOrder.IsAssigned() {
+			continue
+		}
+		err := assignedOrder.PickUp()
+		if err != nil {
+			return nil, err
+		}
+		if !ch.arrived(courier.Location(), assignedOrder.Location()) {
+			remaining++
+			continue
+		}
+		err = assignedOrder.Complete()
+		if err != nil {
+			return nil, err
+		}
+		completed = append(completed, assignedOrder.ID())
+	}
+	if len(completed) > 0 && remaining == 0 && courier.IsBusy() {
+		err := courier.SetFree()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return completed, nil
+}
+
+func (ch *ReportCourierLocationsCommandHandler) arrived(courierLocation, orderLocation kernel.Location) bool {
+	if courierLocation.IsGeo() {
+		return courierLocation.DistanceTo(orderLocation) <= ch.arrivalRadius
+	}
+	return courierLocation.Equals(orderLocation)
+}
+
+// isRejectedLocation - позиция отклоняется, но остальные позиции пакета применяются
+func isRejectedLocation(err error) bool {
+	return errors.Is(err, model.ErrImplausibleLocation) ||
+		errors.Is(err, model.ErrStaleLocation) ||
+		errors.Is(err, model.ErrInvalidLocation) ||
+		errors.Is(err, kernel.ErrLocationKindMismatch) ||
+		errors.Is(err, kernel.ErrLocationOutOfArea)
+}
+
+// LocationReport - позиция курьера по данным его приложения
+type LocationReport struct {
+	Location   kernel.Location
+	ReportedAt time.Time
+}
+
+type ReportCourierLocationsCommand struct {
+	courierID uuid.UUID
+	reports   []LocationReport
+
+	isSet bool
+}
+
+func NewReportCourierLocationsCommand(courierID uuid.UUID, reports []LocationReport) (ReportCourierLocationsCommand, error) {
+	if courierID == uuid.Nil {
+		return ReportCourierLocationsCommand{}, errs.NewValueIsRequiredError("courierID")
+	}
+	if len(reports) == 0 {
+		return ReportCourierLocationsCommand{}, errs.NewValueIsRequiredError("reports")
+	}
+	if len(reports) > MaxLocationReports {
+		return ReportCourierLocationsCommand{}, errs.NewValueIsOutOfRangeError("reports", len(reports), 1,
+			MaxLocationReports)
+	}
+	for _, report := range reports {
+		if report.ReportedAt.IsZero() {
+			return ReportCourierLocationsCommand{}, errs.NewValueIsRequiredError("reportedAt")
+		}
+	}
+	return ReportCourierLocationsCommand{courierID: courierID, reports: slices.Clone(reports), isSet: true}, nil
+}
+
+func (c ReportCourierLocationsCommand) isEmpty() bool {
+	return !c.isSet
+}
+
+// order - номера позиций по возрастанию времени
+func (c ReportCourierLocationsCommand) order() []int {
+	indexes := make([]int, len(c.reports))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		return cmp.Compare(c.reports[a].ReportedAt.UnixNano(), c.reports[b].ReportedAt.UnixNano())
+	})
+	return indexes
+}
+
+type ReportCourierLocationsResponse struct {
+	Accepted int
+	Rejected []RejectedLocation
+	// CompletedOrders - заказы, доставленные по принятым позициям
+	CompletedOrders []uuid.UUID
+}
+
+// RejectedLocation - номер позиции в пакете и почему её не приняли
+type RejectedLocation struct {
+	Index  int
+	Reason error
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+)
+
+func Test_ReportCourierLocationsShouldDeliverOnArrival(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+
+	bike := courier.MustNewCourier("Иван", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	require.NoError(t, bike.SetBusy())
+	require.NoError(t, courierRepository.Add(ctx, bike))
+	assignedOrder := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 3))
+	require.NoError(t, assignedOrder.AssignToCourier(bike.ID()))
+	require.NoError(t, orderRepository.Add(ctx, assignedOrder))
+
+	regions, err := region.NewSingleRegionCatalog(kernel.DefaultArea())
+	require.NoError(t, err)
+	limit, err := courier.NewSpeedLimit(time.Second, 1)
+	require.NoError(t, err)
+	publisher := &recordingEventPublisher{}
+	handler, err := NewReportCourierLocationsCommandHandler(unitOfWork, courierRepository, orderRepository, publisher,
+		&recordingOutbox{}, regions, map[kernel.RegionCode]courier.SpeedLimit{kernel.DefaultRegion(): limit}, 0)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Add(-time.Minute)
+	command, err := NewReportCourierLocationsCommand(bike.ID(), []LocationReport{
+		// Пакет приходит не по порядку: позиции применяются по времени
+		{Location: kernel.MustNewLocation(3, 3), ReportedAt: now.Add(2 * time.Second)},
+		{Location: kernel.MustNewLocation(2, 2), ReportedAt: now},
+		// Телепорт через весь город
+		{Location: kernel.MustNewLocation(10, 10), ReportedAt: now.Add(time.Second)},
+	})
+	require.NoError(t, err)
+
+	response, err := handler.Handle(ctx, command)
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.Accepted)
+	require.Len(t, response.Rejected, 1)
+	assert.Equal(t, 2, response.Rejected[0].Index)
+	assert.ErrorIs(t, response.Rejected[0].Reason, courier.ErrImplausibleLocation)
+	assert.Equal(t, []uuid.UUID{assignedOrder.ID()}, response.CompletedOrders)
+
+	storedOrder, err := orderRepository.Get(ctx, assignedOrder.ID())
+	require.NoError(t, err)
+	assert.True(t, storedOrder.IsCompleted())
+	assert.NotNil(t, storedOrder.PickedUpAt())
+	storedCourier, err := courierRepository.Get(ctx, bike.ID())
+	require.NoError(t, err)
+	assert.True(t, storedCourier.IsFree())
+	assert.Equal(t, kernel.MustNewLocation(3, 3), storedCourier.Location())
+	assert.Equal(t, now.Add(2*time.Second), *storedCourier.LastSeenAt())
+
+	var moves int
+	for _, event := range publisher.events {
+		if _, ok := event.(courier.LocationChangedDomainEvent); ok {
+			moves++
+		}
+	}
+	assert.Equal(t, 2, moves)
+
+	// Позиция вне города отклоняется, курьер остаётся на месте
+	outside, err := NewReportCourierLocationsCommand(bike.ID(), []LocationReport{
+		{Location: kernel.MustNewLocation(100, 100), ReportedAt: now.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	response, err = handler.Handle(ctx, outside)
+	require.NoError(t, err)
+	assert.Zero(t, response.Accepted)
+	require.Len(t, response.Rejected, 1)
+	assert.ErrorIs(t, response.Rejected[0].Reason, kernel.ErrLocationOutOfArea)
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+// ResolvePendingGeocodesCommandHandler - определить геопозицию заказов, созданных, пока Geo был недоступен.
+// Заказ, адрес которого Geo не знает или который лежит вне региона, отменяется: повтор ответ не изменит
+type ResolvePendingGeocodesCommandHandler struct {
+	unitOfWork      uow.UnitOfWork
+	orderRepository ports.OrderRepository
+	geoClient       ports.GeoClient
+	regions         *region.Catalog
+	eventPublisher  ports.DomainEventPublisher
+	outbox          ports.DomainEventOutbox
+}
+
+func NewResolvePendingGeocodesCommandHandler(
+	unitOfWork uow.UnitOfWork, orderRepository ports.OrderRepository, geoClient ports.GeoClient,
+	regions *region.Catalog, eventPublisher ports.DomainEventPublisher,
+	outbox ports.DomainEventOutbox) (*ResolvePendingGeocodesCommandHandler, error) {
+	if unitOfWork == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if geoClient == nil {
+		return nil, errs.NewValueIsRequiredError("geoClient")
+	}
+	if regions == nil {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+	if eventPublisher == nil {
+		return nil, errs.NewValueIsRequiredError("eventPublisher")
+	}
+	if outbox == nil {
+		return nil, errs.NewValueIsRequiredError("outbox")
+	}
+
+	return &ResolvePendingGeocodesCommandHandler{
+		unitOfWork:      unitOfWork,
+		orderRepository: orderRepository,
+		geoClient:       geoClient,
+		regions:         regions,
+		eventPublisher:  eventPublisher,
+		outbox:          outbox}, nil
+}
+
+func (ch *ResolvePendingGeocodesCommandHandler) Handle(ctx context.Context, command ResolvePendingGeocodesCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("resolve pending geocodes command")
+	}
+
+	pendingOrders, err := ch.orderRepository.GetAllInPendingGeocodeStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pendingOrder := range pendingOrders {
+		location, err := ch.geoClient.GetGeolocation(ctx, pendingOrder.Address())
+		if errors.Is(err, ports.ErrGeolocationNotFound) {
+			log.Printf("geolocation for order %v not found, cancelling: %v", pendingOrder.ID(), err)
+			err = ch.change(ctx, pendingOrder.ID(), (*order.Order).Cancel)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			// Geo всё ещё недоступен, попробуем в следующий раз
+			return err
+		}
+		orderRegion, ok := ch.regions.Get(pendingOrder.Region())
+		if !ok {
+			// Регион могут вернуть в конфигурацию, заказ подождёт
+			log.Printf("order %v: %v %s", pendingOrder.ID(), region.ErrUnknownRegion, pendingOrder.Region())
+			continue
+		}
+		if err := orderRegion.Bounds().Validate(location); err != nil {
+			log.Printf("geolocation for order %v, cancelling: %v", pendingOrder.ID(), err)
+			err = ch.change(ctx, pendingOrder.ID(), (*order.Order).Cancel)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = ch.change(ctx, pendingOrder.ID(), func(o *order.Order) error {
+			return o.ResolveLocation(location)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// change - перечитать заказ в транзакции и изменить, только если он всё ещё ждёт геокодирования.
+// Заказ, который успели отменить, пропускается
+func (ch *ResolvePendingGeocodesCommandHandler) change(ctx context.Context, orderID uuid.UUID,
+	apply func(*order.Order) error) error {
+	ctx = ch.unitOfWork.Begin(ctx)
+	defer func() {
+		err := ch.unitOfWork.Rollback(ctx)
+		if err != nil {
+			log.Println("ResolvePendingGeocodesCommandHandler Rollback error:", err)
+		}
+	}()
+
+	// Восстановили
+	pendingOrder, err := ch.orderRepository.Get(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if !pendingOrder.IsPendingGeocode() {
+		return nil
+	}
+
+	// Изменили
+	err = apply(pendingOrder)
+	if err != nil {
+		return err
+	}
+
+	// Сохранили
+	err = ch.orderRepository.Update(ctx, pendingOrder)
+	if err != nil {
+		return err
+	}
+	err = saveDomainEvents(ctx, ch.outbox, pendingOrder)
+	if err != nil {
+		return err
+	}
+
+	err = ch.unitOfWork.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	publishDomainEvents(ctx, ch.eventPublisher, pendingOrder)
+
+	return nil
+}
+
+type ResolvePendingGeocodesCommand struct {
+	isSet bool
+}
+
+func NewResolvePendingGeocodesCommand() (ResolvePendingGeocodesCommand, error) {
+	return ResolvePendingGeocodesCommand{isSet: true}, nil
+}
+
+func (c ResolvePendingGeocodesCommand) isEmpty() bool {
+	return !c.isSet
+}
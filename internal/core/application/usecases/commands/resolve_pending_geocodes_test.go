@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+)
+
+// cancellingGeoClient - отменяет заказ, пока идёт запрос к Geo
+type cancellingGeoClient struct {
+	orderRepository ports.OrderRepository
+	orderID         uuid.UUID
+	location        kernel.Location
+}
+
+func (c *cancellingGeoClient) GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error) {
+	o, err := c.orderRepository.Get(ctx, c.orderID)
+	if err != nil {
+		return kernel.Location{}, err
+	}
+	if err := o.Cancel(); err != nil {
+		return kernel.Location{}, err
+	}
+	return c.location, c.orderRepository.Update(ctx, o)
+}
+
+func addPendingOrder(t *testing.T, orderRepository ports.OrderRepository) *order.Order {
+	pendingOrder, err := order.NewPendingGeocodeOrder(uuid.New(), testAddress)
+	require.NoError(t, err)
+	require.NoError(t, orderRepository.Add(context.Background(), pendingOrder))
+	return pendingOrder
+}
+
+func Test_ResolvePendingGeocodesShouldCancelUnresolvableOrders(t *testing.T) {
+	testCases := []struct {
+		name      string
+		geoClient *stubGeoClient
+	}{
+		{name: "Address not found", geoClient: &stubGeoClient{err: ports.ErrGeolocationNotFound}},
+		{name: "Location out of region", geoClient: &stubGeoClient{location: kernel.MustNewLocation(15, 15)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			orderRepository, unitOfWork := setupOrderStorage(t)
+			pendingOrder := addPendingOrder(t, orderRepository)
+			eventPublisher := &recordingEventPublisher{}
+			handler, err := NewResolvePendingGeocodesCommandHandler(unitOfWork, orderRepository, tc.geoClient,
+				singleRegion(t), eventPublisher, &recordingOutbox{})
+			require.NoError(t, err)
+			command, err := NewResolvePendingGeocodesCommand()
+			require.NoError(t, err)
+
+			require.NoError(t, handler.Handle(ctx, command))
+
+			cancelledOrder, err := orderRepository.Get(ctx, pendingOrder.ID())
+			require.NoError(t, err)
+			assert.Equal(t, order.StatusCancelled, cancelledOrder.Status())
+			pendingOrders, err := orderRepository.GetAllInPendingGeocodeStatus(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, pendingOrders)
+			require.Len(t, eventPublisher.events, 1)
+			assert.Equal(t, order.StatusCancelled, eventPublisher.events[0].(order.StatusChangedDomainEvent).Status())
+		})
+	}
+}
+
+func Test_ResolvePendingGeocodesShouldNotRestoreCancelledOrder(t *testing.T) {
+	ctx := context.Background()
+	orderRepository, unitOfWork := setupOrderStorage(t)
+	pendingOrder := addPendingOrder(t, orderRepository)
+	geoClient := &cancellingGeoClient{
+		orderRepository: orderRepository,
+		orderID:         pendingOrder.ID(),
+		location:        kernel.MustNewLocation(3, 4),
+	}
+	eventPublisher := &recordingEventPublisher{}
+	handler, err := NewResolvePendingGeocodesCommandHandler(unitOfWork, orderRepository, geoClient, singleRegion(t),
+		eventPublisher, &recordingOutbox{})
+	require.NoError(t, err)
+	command, err := NewResolvePendingGeocodesCommand()
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, command))
+
+	cancelledOrder, err := orderRepository.Get(ctx, pendingOrder.ID())
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCancelled, cancelledOrder.Status())
+	assert.Empty(t, eventPublisher.events)
+}
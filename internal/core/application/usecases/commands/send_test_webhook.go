@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// SendTestWebhookCommandHandler - отправляет проверочное событие сразу, без повторов,
+// чтобы партнёр увидел результат в ответе на запрос. Попытка попадает в журнал доставок
+type SendTestWebhookCommandHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+	deliveryRepository     ports.WebhookDeliveryRepository
+	sender                 ports.WebhookSender
+}
+
+func NewSendTestWebhookCommandHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+	deliveryRepository ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+) (*SendTestWebhookCommandHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+	if deliveryRepository == nil {
+		return nil, errs.NewValueIsRequiredError("deliveryRepository")
+	}
+	if sender == nil {
+		return nil, errs.NewValueIsRequiredError("sender")
+	}
+
+	return &SendTestWebhookCommandHandler{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		sender:                 sender}, nil
+}
+
+func (ch *SendTestWebhookCommandHandler) Handle(ctx context.Context, command SendTestWebhookCommand) (*webhook.Delivery, error) {
+	if command.isEmpty() {
+		return nil, errs.NewValueIsRequiredError("send test webhook command")
+	}
+
+	subscription, err := ch.subscriptionRepository.Get(ctx, command.subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	eventID := uuid.New()
+	payload, err := json.Marshal(WebhookPayload{
+		ID:         eventID,
+		Type:       webhook.EventTest,
+		OccurredAt: now,
+		Data:       struct{}{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := webhook.NewDelivery(subscription.ID(), eventID, webhook.EventTest, payload, now)
+	if err != nil {
+		return nil, err
+	}
+
+	noRetry := func(int, time.Time) *time.Time { return nil }
+	err = send(ctx, ch.sender, subscription, delivery, func() time.Time { return time.Now().UTC() }, noRetry)
+	if err != nil {
+		return nil, err
+	}
+	err = ch.deliveryRepository.Add(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+type SendTestWebhookCommand struct {
+	subscriptionID uuid.UUID
+
+	isSet bool
+}
+
+func NewSendTestWebhookCommand(subscriptionID uuid.UUID) (SendTestWebhookCommand, error) {
+	if subscriptionID == uuid.Nil {
+		return SendTestWebhookCommand{}, errs.NewValueIsRequiredError("subscriptionID")
+	}
+	return SendTestWebhookCommand{subscriptionID: subscriptionID, isSet: true}, nil
+}
+
+func (c SendTestWebhookCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type SetCourierHomeZoneCommandHandler struct {
+	courierRepository ports.CourierRepository
+	zoneRepository    ports.ZoneRepository
+}
+
+func NewSetCourierHomeZoneCommandHandler(
+	courierRepository ports.CourierRepository,
+	zoneRepository ports.ZoneRepository,
+) (*SetCourierHomeZoneCommandHandler, error) {
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if zoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("zoneRepository")
+	}
+
+	return &SetCourierHomeZoneCommandHandler{
+		courierRepository: courierRepository,
+		zoneRepository:    zoneRepository}, nil
+}
+
+// Handle - ErrObjectNotFound, если нет курьера или зоны
+func (ch *SetCourierHomeZoneCommandHandler) Handle(ctx context.Context, command SetCourierHomeZoneCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("set courier home zone command")
+	}
+
+	if command.zoneID != nil {
+		if _, err := ch.zoneRepository.Get(ctx, *command.zoneID); err != nil {
+			return err
+		}
+	}
+	aggregate, err := ch.courierRepository.Get(ctx, command.courierID)
+	if err != nil {
+		return err
+	}
+	aggregate.SetHomeZone(command.zoneID)
+
+	return ch.courierRepository.Update(ctx, aggregate)
+}
+
+type SetCourierHomeZoneCommand struct {
+	courierID uuid.UUID
+	zoneID    *uuid.UUID
+
+	isSet bool
+}
+
+// NewSetCourierHomeZoneCommand - zoneID nil снимает курьера с зоны
+func NewSetCourierHomeZoneCommand(courierID uuid.UUID, zoneID *uuid.UUID) (SetCourierHomeZoneCommand, error) {
+	if courierID == uuid.Nil {
+		return SetCourierHomeZoneCommand{}, errs.NewValueIsRequiredError("courierID")
+	}
+	if zoneID != nil && *zoneID == uuid.Nil {
+		return SetCourierHomeZoneCommand{}, errs.NewValueIsInvalidError("zoneID")
+	}
+	return SetCourierHomeZoneCommand{courierID: courierID, zoneID: zoneID, isSet: true}, nil
+}
+
+func (c SetCourierHomeZoneCommand) isEmpty() bool {
+	return !c.isSet
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+type UpdateZoneCommandHandler struct {
+	zoneRepository ports.ZoneRepository
+	regions        *region.Catalog
+}
+
+func NewUpdateZoneCommandHandler(zoneRepository ports.ZoneRepository, regions *region.Catalog) (*UpdateZoneCommandHandler, error) {
+	if zoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("zoneRepository")
+	}
+	if regions == nil {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+
+	return &UpdateZoneCommandHandler{
+		zoneRepository: zoneRepository,
+		regions:        regions}, nil
+}
+
+// Handle - курьеры зоны остаются за ней, заказы попадают в зону по новой границе со следующего назначения.
+// Регион зоны не меняется
+func (ch *UpdateZoneCommandHandler) Handle(ctx context.Context, command UpdateZoneCommand) error {
+	if command.isEmpty() {
+		return errs.NewValueIsRequiredError("update zone command")
+	}
+
+	aggregate, err := ch.zoneRepository.Get(ctx, command.zoneID)
+	if err != nil {
+		return err
+	}
+	zoneRegion, ok := ch.regions.Get(aggregate.Region())
+	if !ok {
+		return fmt.Errorf("%w: %s", region.ErrUnknownRegion, aggregate.Region())
+	}
+	if err := command.shape.Validate(zoneRegion.Bounds()); err != nil {
+		return err
+	}
+	if err := aggregate.Update(command.name, command.shape); err != nil {
+		return err
+	}
+
+	return ch.zoneRepository.Update(ctx, aggregate)
+}
+
+type UpdateZoneCommand struct {
+	zoneID uuid.UUID
+	name   string
+	shape  zone.Shape
+
+	isSet bool
+}
+
+func NewUpdateZoneCommand(zoneID uuid.UUID, name string, shape zone.Shape) (UpdateZoneCommand, error) {
+	if zoneID == uuid.Nil {
+		return UpdateZoneCommand{}, errs.NewValueIsRequiredError("zoneID")
+	}
+
+	return UpdateZoneCommand{
+		zoneID: zoneID,
+		name:   name,
+		shape:  shape,
+		isSet:  true,
+	}, nil
+}
+
+func (c UpdateZoneCommand) isEmpty() bool {
+	return !c.isSet
+}
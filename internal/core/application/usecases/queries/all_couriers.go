@@ -1,61 +1,315 @@
 package queries
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/google/uuid"
 
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
-type GetAllCouriersQueryHandler struct {
+var (
+	CourierSortFields  = []string{SortByCreatedAt, SortByName}
+	DefaultCourierSort = Sort{Field: SortByCreatedAt}
+)
+
+type GetAllCouriersQueryHandler interface {
+	Handle(query GetAllCouriersQuery) (GetAllCouriersResponse, error)
+}
+
+type getAllCouriersQueryHandler struct {
 	db *gorm.DB
 }
 
-func NewGetAllCouriersQueryHandler(db *gorm.DB) (*GetAllCouriersQueryHandler, error) {
+func NewGetAllCouriersQueryHandler(db *gorm.DB) (GetAllCouriersQueryHandler, error) {
 	if db == nil {
-		return &GetAllCouriersQueryHandler{}, errs.NewValueIsRequiredError("db")
+		return nil, errs.NewValueIsRequiredError("db")
 	}
-	return &GetAllCouriersQueryHandler{db: db}, nil
+	return &getAllCouriersQueryHandler{db: db}, nil
+}
+
+type courierRow struct {
+	ID             uuid.UUID
+	Name           string
+	Region         string
+	LocationX      int
+	LocationY      int
+	LocationLat    *float64
+	LocationLon    *float64
+	Status         string
+	TransportName  string
+	TransportSpeed int
+	CreatedAt      time.Time
+	LastAssignedAt *time.Time
+	HomeZoneID     *uuid.UUID
+	LastSeenAt     *time.Time
 }
 
-func (q *GetAllCouriersQueryHandler) Handle(query GetAllCouriersQuery) (GetAllCouriersResponse, error) {
-	if query.isEmpty() {
+type assignedOrderRow struct {
+	ID          uuid.UUID
+	CourierID   uuid.UUID
+	LocationX   int
+	LocationY   int
+	LocationLat *float64
+	LocationLon *float64
+}
+
+func (q *getAllCouriersQueryHandler) Handle(query GetAllCouriersQuery) (GetAllCouriersResponse, error) {
+	if query.IsEmpty() {
 		return GetAllCouriersResponse{}, errs.NewValueIsRequiredError("query")
 	}
 
-	var couriers []CourierResponse
-	result := q.db.Raw("SELECT id,name, location_x, location_y FROM couriers").Scan(&couriers)
+	db := q.db.Table("couriers AS c").
+		Select("c.id, c.name, c.region, c.location_x, c.location_y, c.location_lat, c.location_lon, c.status, c.created_at, c.last_assigned_at, " +
+			"c.home_zone_id, c.last_seen_at, t.name AS transport_name, t.speed AS transport_speed").
+		Joins("LEFT JOIN transports t ON t.courier_id = c.id")
+	filter := query.filter
+	if filter.Status != "" {
+		db = db.Where("c.status = ?", filter.Status)
+	}
+	if !filter.Region.IsEmpty() {
+		db = db.Where("c.region = ?", filter.Region.String())
+	}
+	if filter.Transport != "" {
+		db = db.Where("EXISTS (SELECT 1 FROM transports t WHERE t.courier_id = c.id AND LOWER(t.name) = LOWER(?))",
+			filter.Transport)
+	}
+	if filter.Box != nil {
+		condition, args := filter.Box.where("c.")
+		db = db.Where(condition, args...)
+	}
+	if filter.Created.From != nil {
+		db = db.Where("c.created_at >= ?", *filter.Created.From)
+	}
+	if filter.Created.To != nil {
+		db = db.Where("c.created_at < ?", *filter.Created.To)
+	}
+
+	column, parseKey := "c.created_at", parseTimeKey
+	if query.sort.Field == SortByName {
+		column, parseKey = "c.name", parseStringKey
+	}
+	db, err := applyKeyset(db, column, "c.id", query.sort, query.page, parseKey)
+	if err != nil {
+		return GetAllCouriersResponse{}, err
+	}
 
+	var rows []courierRow
+	result := db.Scan(&rows)
 	if result.Error != nil {
 		return GetAllCouriersResponse{}, result.Error
 	}
 
-	return GetAllCouriersResponse{Couriers: couriers}, nil
+	var response GetAllCouriersResponse
+	if len(rows) > query.page.Size {
+		rows = rows[:query.page.Size]
+		last := rows[len(rows)-1]
+		response.NextCursor = query.NextCursor(last.ID, last.Name, last.CreatedAt)
+	}
+	if len(rows) == 0 {
+		return response, nil
+	}
+
+	// Назначенные заказы одним запросом на всю страницу
+	courierIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		courierIDs[i] = row.ID
+	}
+	var orderRows []assignedOrderRow
+	result = q.db.Table("orders").
+		Select("id, courier_id, location_x, location_y, location_lat, location_lon").
+		Where("status = ? AND courier_id IN ?", order.StatusAssigned, courierIDs).
+		Order("created_at, id").
+		Scan(&orderRows)
+	if result.Error != nil {
+		return GetAllCouriersResponse{}, result.Error
+	}
+	ordersByCourier := make(map[uuid.UUID][]assignedOrderRow, len(rows))
+	for _, row := range orderRows {
+		ordersByCourier[row.CourierID] = append(ordersByCourier[row.CourierID], row)
+	}
+
+	for _, row := range rows {
+		courierLocation, err := rowLocation(row.LocationX, row.LocationY, row.LocationLat, row.LocationLon)
+		if err != nil {
+			return GetAllCouriersResponse{}, fmt.Errorf("courier %s: %w", row.ID, err)
+		}
+		courierResponse := CourierResponse{
+			ID:             row.ID,
+			Name:           row.Name,
+			Region:         row.Region,
+			Location:       NewLocationResponse(courierLocation),
+			Status:         row.Status,
+			Transport:      TransportResponse{Name: row.TransportName, Speed: row.TransportSpeed},
+			Orders:         make([]AssignedOrderResponse, 0),
+			LastAssignedAt: row.LastAssignedAt,
+			HomeZoneID:     row.HomeZoneID,
+			LastSeenAt:     row.LastSeenAt,
+		}
+		for _, orderRow := range ordersByCourier[row.ID] {
+			orderLocation, err := rowLocation(orderRow.LocationX, orderRow.LocationY, orderRow.LocationLat,
+				orderRow.LocationLon)
+			if err != nil {
+				return GetAllCouriersResponse{}, fmt.Errorf("order %s: %w", orderRow.ID, err)
+			}
+			courierResponse.Orders = append(courierResponse.Orders, AssignedOrderResponse{
+				ID:       orderRow.ID,
+				Location: NewLocationResponse(orderLocation),
+				Distance: courierLocation.DistanceTo(orderLocation),
+			})
+		}
+		response.Couriers = append(response.Couriers, courierResponse)
+	}
+	return response, nil
+}
+
+// CouriersFilter - пустые поля не ограничивают выборку
+type CouriersFilter struct {
+	Status courier.Status
+	Region kernel.RegionCode
+	// Transport - название транспорта без учёта регистра
+	Transport string
+	Box       *BoundingBox
+	Created   TimeRange
 }
 
 type GetAllCouriersQuery struct {
+	filter CouriersFilter
+	sort   Sort
+	page   Page
+
 	isSet bool
 }
 
-func NewGetAllCouriersQuery() (GetAllCouriersQuery, error) {
-	return GetAllCouriersQuery{isSet: true}, nil
+func NewGetAllCouriersQuery(filter CouriersFilter, sort Sort, page Page) (GetAllCouriersQuery, error) {
+	if filter.Status != "" && filter.Status != courier.StatusFree && filter.Status != courier.StatusBusy &&
+		filter.Status != courier.StatusOffline {
+		return GetAllCouriersQuery{}, ErrInvalidFilter
+	}
+	if sort.Field == "" {
+		sort = DefaultCourierSort
+	}
+	if !slices.Contains(CourierSortFields, sort.Field) {
+		return GetAllCouriersQuery{}, ErrInvalidSort
+	}
+	page, err := page.validate(sort)
+	if err != nil {
+		return GetAllCouriersQuery{}, err
+	}
+	return GetAllCouriersQuery{filter: filter, sort: sort, page: page, isSet: true}, nil
 }
-func (q GetAllCouriersQuery) isEmpty() bool {
+
+func (q GetAllCouriersQuery) IsEmpty() bool {
 	return !q.isSet
 }
 
+func (q GetAllCouriersQuery) Filter() CouriersFilter {
+	return q.filter
+}
+
+func (q GetAllCouriersQuery) Sort() Sort {
+	return q.sort
+}
+
+func (q GetAllCouriersQuery) Page() Page {
+	return q.page
+}
+
+// NextCursor - курсор страницы, начинающейся после курьера с этими значениями полей сортировки
+func (q GetAllCouriersQuery) NextCursor(ID uuid.UUID, name string, createdAt time.Time) string {
+	key := TimeKey(createdAt)
+	if q.sort.Field == SortByName {
+		key = name
+	}
+	return Cursor{Sort: q.sort.String(), Key: key, ID: ID}.Encode()
+}
+
 type GetAllCouriersResponse struct {
 	Couriers []CourierResponse
+	// NextCursor - пусто на последней странице
+	NextCursor string
 }
 
 type CourierResponse struct {
+	ID        uuid.UUID
+	Name      string
+	Region    string
+	Location  LocationResponse
+	Status    string
+	Transport TransportResponse
+	// Orders - заказы, которые курьер везёт сейчас
+	Orders []AssignedOrderResponse
+	// LastAssignedAt - nil, если курьер ещё не получал заказов
+	LastAssignedAt *time.Time
+	// HomeZoneID - nil, если курьер работает по всему городу
+	HomeZoneID *uuid.UUID
+	// LastSeenAt - последний сигнал от курьера, nil - на связь не выходил
+	LastSeenAt *time.Time
+}
+
+type TransportResponse struct {
+	Name  string
+	Speed int
+}
+
+type AssignedOrderResponse struct {
 	ID       uuid.UUID
-	Name     string
 	Location LocationResponse
+	// Distance - сколько клеток или, в координатах WGS84, метров осталось курьеру до заказа
+	Distance int
+}
+
+// NewCourierResponse - курьер со всеми назначенными ему заказами
+func NewCourierResponse(aggregate *courier.Courier, assigned []*order.Order) CourierResponse {
+	response := CourierResponse{
+		ID:             aggregate.ID(),
+		Name:           aggregate.Name(),
+		Region:         aggregate.Region().String(),
+		Location:       NewLocationResponse(aggregate.Location()),
+		Status:         string(aggregate.Status()),
+		Transport:      TransportResponse{Name: aggregate.Transport().Name(), Speed: aggregate.Transport().Speed()},
+		Orders:         make([]AssignedOrderResponse, 0, len(assigned)),
+		LastAssignedAt: aggregate.LastAssignedAt(),
+		HomeZoneID:     aggregate.HomeZoneID(),
+		LastSeenAt:     aggregate.LastSeenAt(),
+	}
+	for _, o := range assigned {
+		response.Orders = append(response.Orders, AssignedOrderResponse{
+			ID:       o.ID(),
+			Location: NewLocationResponse(o.Location()),
+			Distance: aggregate.Location().DistanceTo(o.Location()),
+		})
+	}
+	return response
 }
 
 type LocationResponse struct {
 	X int
 	Y int
+	// Lat, Lon - заполнены только для координат WGS84
+	Lat *float64
+	Lon *float64
+}
+
+func NewLocationResponse(location kernel.Location) LocationResponse {
+	if location.IsGeo() {
+		lat, lon := location.Lat(), location.Lon()
+		return LocationResponse{Lat: &lat, Lon: &lon}
+	}
+	return LocationResponse{X: location.X(), Y: location.Y()}
+}
+
+// rowLocation - координаты из колонок location_*, lat и lon есть только у точек WGS84
+func rowLocation(x, y int, lat, lon *float64) (kernel.Location, error) {
+	if lat != nil && lon != nil {
+		return kernel.NewGeoLocation(*lat, *lon)
+	}
+	return kernel.NewLocation(x, y)
 }
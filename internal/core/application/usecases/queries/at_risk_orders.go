@@ -0,0 +1,113 @@
+package queries
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// DefaultAtRiskWithin - заказ под угрозой, если до ближайшего срока осталось меньше этого времени
+const DefaultAtRiskWithin = 10 * time.Minute
+
+// GetAtRiskOrdersQueryHandler - заказы, которые не успевают или уже не успели к сроку политики SLA
+type GetAtRiskOrdersQueryHandler struct {
+	orderRepository ports.OrderRepository
+	policy          order.SLAPolicy
+	now             func() time.Time
+}
+
+func NewGetAtRiskOrdersQueryHandler(orderRepository ports.OrderRepository,
+	policy order.SLAPolicy) (*GetAtRiskOrdersQueryHandler, error) {
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if policy.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("policy")
+	}
+	return &GetAtRiskOrdersQueryHandler{orderRepository: orderRepository, policy: policy, now: time.Now}, nil
+}
+
+// Handle - заказы по возрастанию оставшегося времени, с уже нарушенным сроком - первыми
+func (q *GetAtRiskOrdersQueryHandler) Handle(ctx context.Context, query GetAtRiskOrdersQuery) ([]AtRiskOrderResponse, error) {
+	if query.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("query")
+	}
+
+	orders, err := q.orderRepository.GetAllNotFinal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := q.now()
+	response := make([]AtRiskOrderResponse, 0)
+	for _, aggregate := range orders {
+		if !query.region.IsEmpty() && aggregate.Region() != query.region {
+			continue
+		}
+		stage, deadline, ok := q.policy.Next(aggregate)
+		if !ok {
+			continue
+		}
+		remaining := deadline.Sub(now)
+		if remaining >= query.within {
+			continue
+		}
+		response = append(response, AtRiskOrderResponse{
+			ID:        aggregate.ID(),
+			Region:    aggregate.Region().String(),
+			Tier:      string(aggregate.Tier()),
+			Status:    string(aggregate.Status()),
+			CourierID: aggregate.AssignedCourier(),
+			Stage:     string(stage),
+			Deadline:  deadline,
+			Remaining: remaining,
+		})
+	}
+	slices.SortFunc(response, func(a, b AtRiskOrderResponse) int {
+		return cmp.Or(cmp.Compare(a.Remaining, b.Remaining), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return response, nil
+}
+
+type GetAtRiskOrdersQuery struct {
+	region kernel.RegionCode
+	within time.Duration
+
+	isSet bool
+}
+
+// NewGetAtRiskOrdersQuery - пустой region - все регионы, within - запас до срока, 0 - DefaultAtRiskWithin
+func NewGetAtRiskOrdersQuery(region kernel.RegionCode, within time.Duration) (GetAtRiskOrdersQuery, error) {
+	if within < 0 {
+		return GetAtRiskOrdersQuery{}, errs.NewValueIsInvalidError("within")
+	}
+	if within == 0 {
+		within = DefaultAtRiskWithin
+	}
+	return GetAtRiskOrdersQuery{region: region, within: within, isSet: true}, nil
+}
+
+func (q GetAtRiskOrdersQuery) IsEmpty() bool {
+	return !q.isSet
+}
+
+type AtRiskOrderResponse struct {
+	ID        uuid.UUID
+	Region    string
+	Tier      string
+	Status    string
+	CourierID *uuid.UUID
+	// Stage - ближайший непройденный этап: assign или deliver
+	Stage    string
+	Deadline time.Time
+	// Remaining - сколько осталось до срока, отрицательное - срок уже нарушен
+	Remaining time.Duration
+}
@@ -0,0 +1,52 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+)
+
+func Test_GetAtRiskOrdersShouldSortByRemainingTime(t *testing.T) {
+	ctx := context.Background()
+	orderRepository, err := memory.NewOrderRepository(memory.NewStorage())
+	require.NoError(t, err)
+
+	address := kernel.MustNewAddress("", "", "Бажная", "", "")
+	add := func(tier order.Tier, age time.Duration) *order.Order {
+		aggregate := order.RestoreOrder(uuid.New(), kernel.DefaultRegion(), tier, order.DeliveryWindow{}, nil, address,
+			kernel.MustNewLocation(2, 2), order.StatusCreated, order.Timestamps{CreatedAt: time.Now().UTC().Add(-age)}, "")
+		require.NoError(t, orderRepository.Add(ctx, aggregate))
+		return aggregate
+	}
+	// По политике по умолчанию экспресс назначается за 10 минут, обычный заказ - за 20
+	breached := add(order.TierExpress, 15*time.Minute)
+	soon := add(order.TierStandard, 15*time.Minute)
+	add(order.TierStandard, time.Minute)
+	cancelled := add(order.TierExpress, time.Hour)
+	require.NoError(t, cancelled.Cancel())
+	require.NoError(t, orderRepository.Update(ctx, cancelled))
+
+	handler, err := queries.NewGetAtRiskOrdersQueryHandler(orderRepository, order.DefaultSLAPolicy())
+	require.NoError(t, err)
+	query, err := queries.NewGetAtRiskOrdersQuery(kernel.RegionCode{}, 0)
+	require.NoError(t, err)
+
+	response, err := handler.Handle(ctx, query)
+
+	require.NoError(t, err)
+	require.Len(t, response, 2)
+	assert.Equal(t, breached.ID(), response[0].ID)
+	assert.Equal(t, string(order.SLAStageAssign), response[0].Stage)
+	assert.Negative(t, response[0].Remaining)
+	assert.Equal(t, soon.ID(), response[1].ID)
+	assert.Positive(t, response[1].Remaining)
+}
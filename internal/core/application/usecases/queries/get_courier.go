@@ -0,0 +1,60 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// GetCourierQueryHandler - курьер по id с транспортом и назначенными заказами
+type GetCourierQueryHandler struct {
+	courierRepository ports.CourierRepository
+	orderRepository   ports.OrderRepository
+}
+
+func NewGetCourierQueryHandler(
+	courierRepository ports.CourierRepository, orderRepository ports.OrderRepository) (*GetCourierQueryHandler, error) {
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	return &GetCourierQueryHandler{courierRepository: courierRepository, orderRepository: orderRepository}, nil
+}
+
+func (q *GetCourierQueryHandler) Handle(ctx context.Context, query GetCourierQuery) (CourierResponse, error) {
+	if query.IsEmpty() {
+		return CourierResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	aggregate, err := q.courierRepository.Get(ctx, query.courierID)
+	if err != nil {
+		return CourierResponse{}, err
+	}
+	assigned, err := q.orderRepository.GetAllAssignedToCourier(ctx, query.courierID)
+	if err != nil {
+		return CourierResponse{}, err
+	}
+	return NewCourierResponse(aggregate, assigned), nil
+}
+
+type GetCourierQuery struct {
+	courierID uuid.UUID
+
+	isSet bool
+}
+
+func NewGetCourierQuery(courierID uuid.UUID) (GetCourierQuery, error) {
+	if courierID == uuid.Nil {
+		return GetCourierQuery{}, errs.NewValueIsRequiredError("courierID")
+	}
+	return GetCourierQuery{courierID: courierID, isSet: true}, nil
+}
+
+func (q GetCourierQuery) IsEmpty() bool {
+	return !q.isSet
+}
@@ -0,0 +1,124 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// GetOrderQueryHandler - заказ по id в любом статусе
+type GetOrderQueryHandler struct {
+	orderRepository ports.OrderRepository
+	slaPolicy       order.SLAPolicy
+}
+
+// NewGetOrderQueryHandler - slaPolicy - сроки уровней, по ним считается SLADeadline
+func NewGetOrderQueryHandler(orderRepository ports.OrderRepository,
+	slaPolicy order.SLAPolicy) (*GetOrderQueryHandler, error) {
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if slaPolicy.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("slaPolicy")
+	}
+	return &GetOrderQueryHandler{orderRepository: orderRepository, slaPolicy: slaPolicy}, nil
+}
+
+func (q *GetOrderQueryHandler) Handle(ctx context.Context, query GetOrderQuery) (GetOrderResponse, error) {
+	if query.IsEmpty() {
+		return GetOrderResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	aggregate, err := q.orderRepository.Get(ctx, query.orderID)
+	if err != nil {
+		return GetOrderResponse{}, err
+	}
+
+	response := GetOrderResponse{
+		ID:          aggregate.ID(),
+		Region:      aggregate.Region().String(),
+		Tier:        string(aggregate.Tier()),
+		Window:      NewDeliveryWindowResponse(aggregate.DeliveryWindow()),
+		Status:      string(aggregate.Status()),
+		CourierID:   aggregate.AssignedCourier(),
+		SLADeadline: q.slaPolicy.Deadline(aggregate, order.SLAStageDeliver),
+		CreatedAt:   aggregate.CreatedAt(),
+		AssignedAt:  aggregate.AssignedAt(),
+		PickedUpAt:  aggregate.PickedUpAt(),
+		CompletedAt: aggregate.CompletedAt(),
+		Address: AddressResponse{
+			Country:   aggregate.Address().Country(),
+			City:      aggregate.Address().City(),
+			Street:    aggregate.Address().Street(),
+			House:     aggregate.Address().House(),
+			Apartment: aggregate.Address().Apartment(),
+		},
+	}
+	if !aggregate.IsPendingGeocode() {
+		location := NewLocationResponse(aggregate.Location())
+		response.Location = &location
+	}
+	return response, nil
+}
+
+type GetOrderQuery struct {
+	orderID uuid.UUID
+
+	isSet bool
+}
+
+func NewGetOrderQuery(orderID uuid.UUID) (GetOrderQuery, error) {
+	if orderID == uuid.Nil {
+		return GetOrderQuery{}, errs.NewValueIsRequiredError("orderID")
+	}
+	return GetOrderQuery{orderID: orderID, isSet: true}, nil
+}
+
+func (q GetOrderQuery) IsEmpty() bool {
+	return !q.isSet
+}
+
+type GetOrderResponse struct {
+	ID        uuid.UUID
+	Region    string
+	Tier      string
+	Status    string
+	CourierID *uuid.UUID
+	Address   AddressResponse
+	// SLADeadline - к какому времени заказ должен быть доставлен по сроку своего уровня
+	SLADeadline time.Time
+	CreatedAt   time.Time
+	// AssignedAt, PickedUpAt, CompletedAt - nil, пока этап не пройден
+	AssignedAt  *time.Time
+	PickedUpAt  *time.Time
+	CompletedAt *time.Time
+	// Location - nil, пока адрес не геокодирован
+	Location *LocationResponse
+	// Window - интервал доставки, nil у заказов не к интервалу
+	Window *DeliveryWindowResponse
+}
+
+type DeliveryWindowResponse struct {
+	From time.Time
+	To   time.Time
+}
+
+func NewDeliveryWindowResponse(window order.DeliveryWindow) *DeliveryWindowResponse {
+	if window.IsEmpty() {
+		return nil
+	}
+	return &DeliveryWindowResponse{From: window.From(), To: window.To()}
+}
+
+type AddressResponse struct {
+	Country   string
+	City      string
+	Street    string
+	House     string
+	Apartment string
+}
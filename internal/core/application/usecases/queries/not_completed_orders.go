@@ -1,57 +1,189 @@
 package queries
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/google/uuid"
 
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
-type GetNotCompletedOrdersQueryHandler struct {
+var (
+	OrderSortFields  = []string{SortByCreatedAt}
+	DefaultOrderSort = Sort{Field: SortByCreatedAt}
+)
+
+type GetNotCompletedOrdersQueryHandler interface {
+	Handle(query GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error)
+}
+
+type getNotCompletedOrdersQueryHandler struct {
 	db *gorm.DB
 }
 
-func NewGetNotCompletedOrdersQueryHandler(db *gorm.DB) (*GetNotCompletedOrdersQueryHandler, error) {
+func NewGetNotCompletedOrdersQueryHandler(db *gorm.DB) (GetNotCompletedOrdersQueryHandler, error) {
 	if db == nil {
-		return &GetNotCompletedOrdersQueryHandler{}, errs.NewValueIsRequiredError("db")
+		return nil, errs.NewValueIsRequiredError("db")
 	}
-	return &GetNotCompletedOrdersQueryHandler{db: db}, nil
+	return &getNotCompletedOrdersQueryHandler{db: db}, nil
+}
+
+type orderRow struct {
+	ID          uuid.UUID
+	CourierID   *uuid.UUID
+	Region      string
+	Tier        string
+	LocationX   int
+	LocationY   int
+	LocationLat *float64
+	LocationLon *float64
+	Status      string
+	CreatedAt   time.Time
+	// DeliveryWindowFrom, DeliveryWindowTo - только у заказов к интервалу
+	DeliveryWindowFrom *time.Time
+	DeliveryWindowTo   *time.Time
 }
 
-func (q *GetNotCompletedOrdersQueryHandler) Handle(query GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error) {
-	if query.isEmpty() {
+func (q *getNotCompletedOrdersQueryHandler) Handle(query GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error) {
+	if query.IsEmpty() {
 		return GetNotCompletedOrdersResponse{}, errs.NewValueIsRequiredError("query")
 	}
 
-	var orders []OrderResponse
-	result := q.db.Raw("SELECT id, courier_id, location_x, location_y, status FROM public.orders where status!=?",
-		order.StatusCompleted).Scan(&orders)
+	db := q.db.Table("orders").Select("id, courier_id, region, tier, location_x, location_y, location_lat, location_lon, status, created_at, " +
+		"delivery_window_from, delivery_window_to")
+	filter := query.filter
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	} else {
+		db = db.Where("status IN ?", ActiveOrderStatuses)
+	}
+	if !filter.Region.IsEmpty() {
+		db = db.Where("region = ?", filter.Region.String())
+	}
+	if filter.Box != nil {
+		condition, args := filter.Box.where("")
+		db = db.Where(condition, args...)
+	}
+	if filter.Created.From != nil {
+		db = db.Where("created_at >= ?", *filter.Created.From)
+	}
+	if filter.Created.To != nil {
+		db = db.Where("created_at < ?", *filter.Created.To)
+	}
+	db, err := applyKeyset(db, "created_at", "id", query.sort, query.page, parseTimeKey)
+	if err != nil {
+		return GetNotCompletedOrdersResponse{}, err
+	}
 
+	var rows []orderRow
+	result := db.Scan(&rows)
 	if result.Error != nil {
 		return GetNotCompletedOrdersResponse{}, result.Error
 	}
 
-	return GetNotCompletedOrdersResponse{Orders: orders}, nil
+	var response GetNotCompletedOrdersResponse
+	if len(rows) > query.page.Size {
+		rows = rows[:query.page.Size]
+		last := rows[len(rows)-1]
+		response.NextCursor = query.NextCursor(last.ID, last.CreatedAt)
+	}
+	for _, row := range rows {
+		location, err := rowLocation(row.LocationX, row.LocationY, row.LocationLat, row.LocationLon)
+		if err != nil {
+			return GetNotCompletedOrdersResponse{}, fmt.Errorf("order %s: %w", row.ID, err)
+		}
+		orderResponse := OrderResponse{
+			ID:        row.ID,
+			CourierID: row.CourierID,
+			Region:    row.Region,
+			Tier:      row.Tier,
+			Status:    row.Status,
+			Location:  NewLocationResponse(location),
+		}
+		if row.DeliveryWindowFrom != nil && row.DeliveryWindowTo != nil {
+			orderResponse.Window = &DeliveryWindowResponse{From: *row.DeliveryWindowFrom, To: *row.DeliveryWindowTo}
+		}
+		response.Orders = append(response.Orders, orderResponse)
+	}
+	return response, nil
+}
+
+// ActiveOrderStatuses - заказы, ждущие курьера или в пути. Заказ без геопозиции нечего показывать на карте
+var ActiveOrderStatuses = []order.Status{order.StatusCreated, order.StatusAssigned}
+
+// OrdersFilter - пустые поля не ограничивают выборку, без Status - все активные заказы
+type OrdersFilter struct {
+	Status  order.Status
+	Region  kernel.RegionCode
+	Box     *BoundingBox
+	Created TimeRange
 }
 
 type GetNotCompletedOrdersQuery struct {
+	filter OrdersFilter
+	sort   Sort
+	page   Page
+
 	isSet bool
 }
 
-func NewGetNotCompletedOrdersQuery() (GetNotCompletedOrdersQuery, error) {
-	return GetNotCompletedOrdersQuery{isSet: true}, nil
+func NewGetNotCompletedOrdersQuery(filter OrdersFilter, sort Sort, page Page) (GetNotCompletedOrdersQuery, error) {
+	if filter.Status != "" && !slices.Contains(ActiveOrderStatuses, filter.Status) {
+		return GetNotCompletedOrdersQuery{}, ErrInvalidFilter
+	}
+	if sort.Field == "" {
+		sort = DefaultOrderSort
+	}
+	if !slices.Contains(OrderSortFields, sort.Field) {
+		return GetNotCompletedOrdersQuery{}, ErrInvalidSort
+	}
+	page, err := page.validate(sort)
+	if err != nil {
+		return GetNotCompletedOrdersQuery{}, err
+	}
+	return GetNotCompletedOrdersQuery{filter: filter, sort: sort, page: page, isSet: true}, nil
 }
-func (q GetNotCompletedOrdersQuery) isEmpty() bool {
+
+func (q GetNotCompletedOrdersQuery) IsEmpty() bool {
 	return !q.isSet
 }
 
+func (q GetNotCompletedOrdersQuery) Filter() OrdersFilter {
+	return q.filter
+}
+
+func (q GetNotCompletedOrdersQuery) Sort() Sort {
+	return q.sort
+}
+
+func (q GetNotCompletedOrdersQuery) Page() Page {
+	return q.page
+}
+
+// NextCursor - курсор страницы, начинающейся после заказа с этими значениями полей сортировки
+func (q GetNotCompletedOrdersQuery) NextCursor(ID uuid.UUID, createdAt time.Time) string {
+	return Cursor{Sort: q.sort.String(), Key: TimeKey(createdAt), ID: ID}.Encode()
+}
+
 type GetNotCompletedOrdersResponse struct {
 	Orders []OrderResponse
+	// NextCursor - пусто на последней странице
+	NextCursor string
 }
 
 type OrderResponse struct {
-	ID       uuid.UUID
-	Location LocationResponse
+	ID        uuid.UUID
+	CourierID *uuid.UUID
+	Region    string
+	Tier      string
+	Status    string
+	Location  LocationResponse
+	// Window - интервал доставки, nil у заказов не к интервалу
+	Window *DeliveryWindowResponse
 }
@@ -0,0 +1,227 @@
+package queries
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// Поля сортировки списков
+const (
+	SortByCreatedAt = "created_at"
+	SortByName      = "name"
+)
+
+var (
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrInvalidSort     = errors.New("invalid sort")
+	ErrInvalidPageSize = errors.New("invalid page size")
+	ErrInvalidFilter   = errors.New("invalid filter")
+)
+
+// Sort - поле и направление; в строке "-created_at" минус означает убывание
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+func ParseSort(raw string, allowed []string, defaultSort Sort) (Sort, error) {
+	if raw == "" {
+		return defaultSort, nil
+	}
+	sort := Sort{Field: strings.TrimPrefix(raw, "-"), Desc: strings.HasPrefix(raw, "-")}
+	if !slices.Contains(allowed, sort.Field) {
+		return Sort{}, ErrInvalidSort
+	}
+	return sort, nil
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Cursor - позиция последней выданной строки: значение поля сортировки и id для однозначности.
+// Клиент получает его непрозрачной строкой и передаёт обратно за следующей страницей
+type Cursor struct {
+	Sort string    `json:"s"`
+	Key  string    `json:"k"`
+	ID   uuid.UUID `json:"i"`
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(raw string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// TimeKey - значение created_at в курсоре
+func TimeKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func ParseTimeKey(key string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// Page - размер страницы и курсор, после которого она начинается
+type Page struct {
+	Size  int
+	After *Cursor
+}
+
+// NewPage - size 0 означает размер по умолчанию
+func NewPage(size int, cursor string) (Page, error) {
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	if size < 0 || size > MaxPageSize {
+		return Page{}, ErrInvalidPageSize
+	}
+	page := Page{Size: size}
+	if cursor == "" {
+		return page, nil
+	}
+	after, err := DecodeCursor(cursor)
+	if err != nil {
+		return Page{}, err
+	}
+	page.After = &after
+	return page, nil
+}
+
+// validate - курсор действителен только для той сортировки, с которой он выдан
+func (p Page) validate(sort Sort) (Page, error) {
+	if p.Size == 0 {
+		p.Size = DefaultPageSize
+	}
+	if p.Size < 0 || p.Size > MaxPageSize {
+		return Page{}, ErrInvalidPageSize
+	}
+	if p.After == nil {
+		return p, nil
+	}
+	if p.After.Sort != sort.String() {
+		return Page{}, ErrInvalidCursor
+	}
+	if sort.Field == SortByCreatedAt {
+		if _, err := ParseTimeKey(p.After.Key); err != nil {
+			return Page{}, err
+		}
+	}
+	return p, nil
+}
+
+// BoundingBox - прямоугольник клеток или, в координатах WGS84, широт и долгот, границы включительно.
+// Точки другого вида в него не попадают
+type BoundingBox struct {
+	bounds kernel.Bounds
+}
+
+func NewBoundingBox(minX, minY, maxX, maxY int) (BoundingBox, error) {
+	area, err := kernel.NewArea(minX, minY, maxX, maxY)
+	if err != nil {
+		return BoundingBox{}, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return BoundingBox{bounds: area}, nil
+}
+
+func NewGeoBoundingBox(south, west, north, east float64) (BoundingBox, error) {
+	bounds, err := kernel.NewGeoBounds(south, west, north, east)
+	if err != nil {
+		return BoundingBox{}, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return BoundingBox{bounds: bounds}, nil
+}
+
+func (b BoundingBox) Contains(location kernel.Location) bool {
+	return b.bounds != nil && b.bounds.Contains(location)
+}
+
+// where - условие SQL по колонкам location_* с префиксом таблицы prefix
+func (b BoundingBox) where(prefix string) (string, []any) {
+	switch bounds := b.bounds.(type) {
+	case kernel.GeoBounds:
+		return prefix + "location_lat BETWEEN ? AND ? AND " + prefix + "location_lon BETWEEN ? AND ?",
+			[]any{bounds.SouthWest().Lat(), bounds.NorthEast().Lat(), bounds.SouthWest().Lon(), bounds.NorthEast().Lon()}
+	case kernel.Area:
+		return prefix + "location_lat IS NULL AND " + prefix + "location_x BETWEEN ? AND ? AND " +
+				prefix + "location_y BETWEEN ? AND ?",
+			[]any{bounds.Min().X(), bounds.Max().X(), bounds.Min().Y(), bounds.Max().Y()}
+	default:
+		return "FALSE", nil
+	}
+}
+
+// TimeRange - полуинтервал [From, To), любая граница может отсутствовать
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func NewTimeRange(from, to *time.Time) (TimeRange, error) {
+	if from != nil && to != nil && !from.Before(*to) {
+		return TimeRange{}, ErrInvalidFilter
+	}
+	return TimeRange{From: from, To: to}, nil
+}
+
+func (r TimeRange) Contains(t time.Time) bool {
+	return (r.From == nil || !t.Before(*r.From)) && (r.To == nil || t.Before(*r.To))
+}
+
+// applyKeyset - условие "после курсора", сортировка и лимит с запасом в одну строку,
+// по которой видно, есть ли следующая страница. column и idColumn берутся только из кода, не из запроса
+func applyKeyset(db *gorm.DB, column string, idColumn string, sort Sort, page Page, parseKey func(string) (any, error)) (*gorm.DB, error) {
+	direction, operator := "ASC", ">"
+	if sort.Desc {
+		direction, operator = "DESC", "<"
+	}
+	if page.After != nil {
+		key, err := parseKey(page.After.Key)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, operator), key, page.After.ID)
+	}
+	return db.Order(fmt.Sprintf("%s %s, %s %s", column, direction, idColumn, direction)).Limit(page.Size + 1), nil
+}
+
+func parseTimeKey(key string) (any, error) {
+	return ParseTimeKey(key)
+}
+
+func parseStringKey(key string) (any, error) {
+	return key, nil
+}
@@ -0,0 +1,312 @@
+package queries
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// TrackOrderQueryHandler - поток изменений заказа: статус, позиция назначенного курьера и ETA
+type TrackOrderQueryHandler struct {
+	orderRepository   ports.OrderRepository
+	courierRepository ports.CourierRepository
+	subscriber        ports.DomainEventSubscriber
+	// regions - по дорогам, профилям и интервалу шага региона курьера считается ETA
+	regions map[kernel.RegionCode]TrackingRegion
+}
+
+// TrackingRegion - дороги, профили транспорта и интервал шага курьеров региона
+type TrackingRegion struct {
+	Router       routing.Router
+	Profiles     *courier.Profiles
+	StepInterval time.Duration
+}
+
+func NewTrackOrderQueryHandler(
+	orderRepository ports.OrderRepository,
+	courierRepository ports.CourierRepository,
+	subscriber ports.DomainEventSubscriber,
+	regions map[kernel.RegionCode]TrackingRegion,
+) (*TrackOrderQueryHandler, error) {
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	if courierRepository == nil {
+		return nil, errs.NewValueIsRequiredError("courierRepository")
+	}
+	if subscriber == nil {
+		return nil, errs.NewValueIsRequiredError("subscriber")
+	}
+	if len(regions) == 0 {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+	for code, region := range regions {
+		if region.Router == nil {
+			return nil, errs.NewValueIsRequiredError("router of region " + code.String())
+		}
+		if region.Profiles == nil {
+			return nil, errs.NewValueIsRequiredError("profiles of region " + code.String())
+		}
+		if region.StepInterval <= 0 {
+			return nil, errs.NewValueIsInvalidError("stepInterval of region " + code.String())
+		}
+	}
+
+	return &TrackOrderQueryHandler{
+		orderRepository:   orderRepository,
+		courierRepository: courierRepository,
+		subscriber:        subscriber,
+		regions:           regions,
+	}, nil
+}
+
+func (q *TrackOrderQueryHandler) Handle(ctx context.Context, query TrackOrderQuery) (<-chan OrderTrackingResponse, error) {
+	if query.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("query")
+	}
+
+	// Подписываемся до чтения заказа, чтобы не пропустить изменения между ними.
+	// Фильтр пропускает только события заказа и его курьера, иначе шаги всех курьеров переполнили бы буфер
+	filter := &trackingFilter{orderID: query.orderID}
+	events, unsubscribe := q.subscriber.Subscribe(filter.accept)
+
+	orderAggregate, err := q.orderRepository.Get(ctx, query.orderID)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	state := &trackingState{
+		orderID:       orderAggregate.ID(),
+		orderLocation: orderAggregate.Location(),
+		status:        orderAggregate.Status(),
+	}
+	if courierID := orderAggregate.AssignedCourier(); courierID != nil && !orderAggregate.Status().IsFinal() {
+		filter.trackInitial(*courierID)
+		err = q.trackCourier(ctx, state, *courierID)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+	}
+
+	updates := make(chan OrderTrackingResponse, 16)
+	go func() {
+		defer close(updates)
+		defer unsubscribe()
+
+		if !send(ctx, updates, q.response(state, time.Now().UTC())) || state.status.IsFinal() {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				changed := false
+				switch e := event.(type) {
+				case order.StatusChangedDomainEvent:
+					changed = q.applyStatusChanged(ctx, state, e)
+				case order.UnassignedDomainEvent:
+					// Заказ снова ждёт курьера, следующий придёт с назначением
+					changed = e.OrderID() == state.orderID
+					if changed {
+						state.status = order.StatusCreated
+						state.courier = nil
+					}
+				case courier.LocationChangedDomainEvent:
+					changed = state.courier != nil && state.courier.ID() == e.CourierID()
+					if changed {
+						state.courierLocation = e.Location()
+					}
+				}
+				if !changed {
+					continue
+				}
+				if !send(ctx, updates, q.response(state, event.OccurredAt())) || state.status.IsFinal() {
+					return
+				}
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// trackingFilter - отбирает события заказа и позиции назначенного ему курьера.
+// Курьера фильтр узнаёт из событий заказа сам, до того как их обработает горутина отслеживания
+type trackingFilter struct {
+	orderID uuid.UUID
+
+	mu        sync.Mutex
+	courierID uuid.UUID
+	// changed - курьер уже определён событием, прочитанный до него заказ устарел
+	changed bool
+}
+
+func (f *trackingFilter) accept(event ddd.DomainEvent) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch e := event.(type) {
+	case order.StatusChangedDomainEvent:
+		if e.OrderID() != f.orderID {
+			return false
+		}
+		f.changed = true
+		f.courierID = uuid.Nil
+		if e.CourierID() != nil && !e.Status().IsFinal() {
+			f.courierID = *e.CourierID()
+		}
+		return true
+	case order.UnassignedDomainEvent:
+		if e.OrderID() != f.orderID {
+			return false
+		}
+		f.changed = true
+		f.courierID = uuid.Nil
+		return true
+	case courier.LocationChangedDomainEvent:
+		return f.courierID != uuid.Nil && e.CourierID() == f.courierID
+	}
+	return false
+}
+
+func (f *trackingFilter) trackInitial(courierID uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.changed {
+		f.courierID = courierID
+	}
+}
+
+type trackingState struct {
+	orderID       uuid.UUID
+	orderLocation kernel.Location
+	status        order.Status
+
+	courier         *courier.Courier
+	courierLocation kernel.Location
+}
+
+func (q *TrackOrderQueryHandler) applyStatusChanged(ctx context.Context, state *trackingState, e order.StatusChangedDomainEvent) bool {
+	if e.OrderID() != state.orderID {
+		return false
+	}
+	state.status = e.Status()
+
+	switch {
+	case e.Status().IsFinal():
+		state.courier = nil
+	case e.CourierID() != nil && (state.courier == nil || state.courier.ID() != *e.CourierID()):
+		err := q.trackCourier(ctx, state, *e.CourierID())
+		if err != nil {
+			// Статус всё равно отдаём, позиция придёт со следующим шагом курьера
+			log.Printf("TrackOrderQueryHandler courier %v error: %v", *e.CourierID(), err)
+		}
+	}
+
+	if state.orderLocation.IsEmpty() && e.Status() == order.StatusCreated {
+		// Геопозиция отложенного заказа только что определилась
+		orderAggregate, err := q.orderRepository.Get(ctx, state.orderID)
+		if err == nil {
+			state.orderLocation = orderAggregate.Location()
+		}
+	}
+	return true
+}
+
+func (q *TrackOrderQueryHandler) trackCourier(ctx context.Context, state *trackingState, courierID uuid.UUID) error {
+	courierAggregate, err := q.courierRepository.Get(ctx, courierID)
+	if err != nil {
+		return err
+	}
+	state.courier = courierAggregate
+	state.courierLocation = courierAggregate.Location()
+	return nil
+}
+
+func (q *TrackOrderQueryHandler) response(state *trackingState, occurredAt time.Time) OrderTrackingResponse {
+	response := OrderTrackingResponse{
+		OrderID:    state.orderID,
+		Status:     state.status,
+		OccurredAt: occurredAt,
+	}
+	if state.courier == nil {
+		return response
+	}
+
+	courierID := state.courier.ID()
+	response.CourierID = &courierID
+	courierLocation := NewLocationResponse(state.courierLocation)
+	response.CourierLocation = &courierLocation
+
+	// Курьер региона, которого нет в настройках, остаётся без ETA
+	region, ok := q.regions[state.courier.Region()]
+	if !ok {
+		return response
+	}
+	transport := state.courier.Transport()
+	vehicle := region.Profiles.Vehicle(transport, time.Now())
+	path, err := region.Router.Route(state.courierLocation, state.orderLocation, vehicle)
+	if err != nil {
+		return response
+	}
+	steps, err := transport.StepsAlong(state.courierLocation, path, vehicle)
+	if err == nil {
+		eta := time.Duration(steps) * region.StepInterval
+		response.ETA = &eta
+	}
+	return response
+}
+
+func send(ctx context.Context, updates chan<- OrderTrackingResponse, response OrderTrackingResponse) bool {
+	select {
+	case updates <- response:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+type TrackOrderQuery struct {
+	orderID uuid.UUID
+
+	isSet bool
+}
+
+func NewTrackOrderQuery(orderID uuid.UUID) (TrackOrderQuery, error) {
+	if orderID == uuid.Nil {
+		return TrackOrderQuery{}, errs.NewValueIsRequiredError("orderID")
+	}
+	return TrackOrderQuery{orderID: orderID, isSet: true}, nil
+}
+
+func (q TrackOrderQuery) IsEmpty() bool {
+	return !q.isSet
+}
+
+type OrderTrackingResponse struct {
+	OrderID         uuid.UUID
+	Status          order.Status
+	CourierID       *uuid.UUID
+	CourierLocation *LocationResponse
+	// ETA - оценка времени до доставки, только когда курьер назначен
+	ETA        *time.Duration
+	OccurredAt time.Time
+}
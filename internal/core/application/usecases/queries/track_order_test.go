@@ -0,0 +1,175 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/adapters/out/memory"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/commands"
+	"github.com/IgorAleksandroff/delivery/internal/core/application/usecases/queries"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/services"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/eventbus"
+)
+
+func receive(t *testing.T, updates <-chan queries.OrderTrackingResponse) queries.OrderTrackingResponse {
+	select {
+	case update, ok := <-updates:
+		require.True(t, ok, "updates channel is closed")
+		return update
+	case <-time.After(time.Second):
+		require.FailNow(t, "no tracking update")
+		return queries.OrderTrackingResponse{}
+	}
+}
+
+func trackingRegions(stepInterval time.Duration) map[kernel.RegionCode]queries.TrackingRegion {
+	return map[kernel.RegionCode]queries.TrackingRegion{
+		kernel.DefaultRegion(): {Router: routing.Direct{}, Profiles: &courier.Profiles{}, StepInterval: stepInterval},
+	}
+}
+
+func Test_TrackOrderShouldStreamStatusCourierLocationAndETA(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := memory.NewStorage()
+	unitOfWork, err := memory.NewUnitOfWork(storage)
+	require.NoError(t, err)
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	bus := eventbus.New(16)
+	outbox, err := commands.NewEnqueueWebhooksEventHandler(memory.NewWebhookSubscriptionRepository(),
+		memory.NewWebhookDeliveryRepository())
+	require.NoError(t, err)
+
+	walker := courier.MustNewCourier("Пеший", "Пешком", 1, kernel.MustNewLocation(1, 1))
+	require.NoError(t, courierRepository.Add(ctx, walker))
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""),
+		kernel.MustNewLocation(1, 4))
+	require.NoError(t, orderRepository.Add(ctx, orderAggregate))
+
+	assignHandler, err := commands.NewAssignOrdersCommandHandler(kernel.DefaultRegion(), unitOfWork, orderRepository,
+		courierRepository, memory.NewZoneRepository(), services.NewOrderDispatcher(routing.Direct{}, nil), bus,
+		outbox, 0)
+	require.NoError(t, err)
+	moveHandler, err := commands.NewMoveCouriersCommandHandler(kernel.DefaultRegion(), unitOfWork, orderRepository,
+		courierRepository, bus, outbox, routing.Direct{}, &courier.Profiles{})
+	require.NoError(t, err)
+	trackHandler, err := queries.NewTrackOrderQueryHandler(orderRepository, courierRepository, bus, trackingRegions(2*time.Second))
+	require.NoError(t, err)
+
+	query, err := queries.NewTrackOrderQuery(orderAggregate.ID())
+	require.NoError(t, err)
+	updates, err := trackHandler.Handle(ctx, query)
+	require.NoError(t, err)
+
+	// Текущее состояние
+	update := receive(t, updates)
+	assert.Equal(t, order.StatusCreated, update.Status)
+	assert.Nil(t, update.CourierID)
+	assert.Nil(t, update.ETA)
+
+	// Назначение курьера
+	assignCommand, err := commands.NewAssignOrdersCommand()
+	require.NoError(t, err)
+	require.NoError(t, assignHandler.Handle(ctx, assignCommand))
+	update = receive(t, updates)
+	assert.Equal(t, order.StatusAssigned, update.Status)
+	require.NotNil(t, update.CourierID)
+	assert.Equal(t, walker.ID(), *update.CourierID)
+	assert.Equal(t, &queries.LocationResponse{X: 1, Y: 1}, update.CourierLocation)
+	require.NotNil(t, update.ETA)
+	assert.Equal(t, 6*time.Second, *update.ETA)
+
+	// Курьер идёт к заказу
+	moveCommand, err := commands.NewMoveCouriersCommand()
+	require.NoError(t, err)
+	for _, eta := range []time.Duration{4 * time.Second, 2 * time.Second, 0} {
+		require.NoError(t, moveHandler.Handle(ctx, moveCommand))
+		update = receive(t, updates)
+		assert.Equal(t, order.StatusAssigned, update.Status)
+		require.NotNil(t, update.ETA)
+		assert.Equal(t, eta, *update.ETA)
+	}
+
+	// Доставлен - последнее обновление, поток закрывается
+	update = receive(t, updates)
+	assert.Equal(t, order.StatusCompleted, update.Status)
+	assert.Nil(t, update.CourierID)
+	_, ok := <-updates
+	assert.False(t, ok)
+	assert.Equal(t, 0, bus.Subscribers())
+}
+
+func Test_TrackOrderShouldFailForUnknownOrder(t *testing.T) {
+	storage := memory.NewStorage()
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	bus := eventbus.New(16)
+	trackHandler, err := queries.NewTrackOrderQueryHandler(orderRepository, courierRepository, bus, trackingRegions(2*time.Second))
+	require.NoError(t, err)
+
+	query, err := queries.NewTrackOrderQuery(uuid.New())
+	require.NoError(t, err)
+	_, err = trackHandler.Handle(context.Background(), query)
+	assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	assert.Equal(t, 0, bus.Subscribers())
+}
+
+func Test_TrackOrderShouldIgnoreUnrelatedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := memory.NewStorage()
+	orderRepository, err := memory.NewOrderRepository(storage)
+	require.NoError(t, err)
+	courierRepository, err := memory.NewCourierRepository(storage)
+	require.NoError(t, err)
+	bus := eventbus.New(2)
+
+	walker := courier.MustNewCourier("Пеший", "Пешком", 1, kernel.MustNewLocation(1, 1))
+	require.NoError(t, courierRepository.Add(ctx, walker))
+	orderAggregate := order.MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""),
+		kernel.MustNewLocation(1, 4))
+	require.NoError(t, orderRepository.Add(ctx, orderAggregate))
+
+	trackHandler, err := queries.NewTrackOrderQueryHandler(orderRepository, courierRepository, bus, trackingRegions(2*time.Second))
+	require.NoError(t, err)
+	query, err := queries.NewTrackOrderQuery(orderAggregate.ID())
+	require.NoError(t, err)
+	updates, err := trackHandler.Handle(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, receive(t, updates).Status)
+
+	// Шаги чужих курьеров и события чужих заказов - больше, чем вмещает буфер подписки
+	for i := 0; i < 20; i++ {
+		require.NoError(t, bus.Publish(ctx,
+			courier.NewLocationChangedDomainEvent(uuid.New(), kernel.MustNewLocation(2, 2)),
+			order.NewStatusChangedDomainEvent(uuid.New(), order.StatusCreated, nil)))
+	}
+
+	// Курьер назначен: его шаги проходят фильтр, поток не отключён
+	walkerID := walker.ID()
+	require.NoError(t, bus.Publish(ctx, order.NewStatusChangedDomainEvent(orderAggregate.ID(), order.StatusAssigned, &walkerID)))
+	require.NoError(t, bus.Publish(ctx, courier.NewLocationChangedDomainEvent(walkerID, kernel.MustNewLocation(1, 2))))
+
+	update := receive(t, updates)
+	assert.Equal(t, order.StatusAssigned, update.Status)
+	update = receive(t, updates)
+	assert.Equal(t, &queries.LocationResponse{X: 1, Y: 2}, update.CourierLocation)
+	assert.Equal(t, 1, bus.Subscribers())
+}
@@ -0,0 +1,111 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+const maxWebhookDeliveries = 100
+
+// GetWebhookDeliveriesQueryHandler - журнал последних доставок подписки
+type GetWebhookDeliveriesQueryHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+	deliveryRepository     ports.WebhookDeliveryRepository
+}
+
+func NewGetWebhookDeliveriesQueryHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+	deliveryRepository ports.WebhookDeliveryRepository,
+) (*GetWebhookDeliveriesQueryHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+	if deliveryRepository == nil {
+		return nil, errs.NewValueIsRequiredError("deliveryRepository")
+	}
+	return &GetWebhookDeliveriesQueryHandler{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+	}, nil
+}
+
+func (q *GetWebhookDeliveriesQueryHandler) Handle(ctx context.Context, query GetWebhookDeliveriesQuery) (GetWebhookDeliveriesResponse, error) {
+	if query.IsEmpty() {
+		return GetWebhookDeliveriesResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	_, err := q.subscriptionRepository.Get(ctx, query.subscriptionID)
+	if err != nil {
+		return GetWebhookDeliveriesResponse{}, err
+	}
+	deliveries, err := q.deliveryRepository.GetAllBySubscription(ctx, query.subscriptionID, maxWebhookDeliveries)
+	if err != nil {
+		return GetWebhookDeliveriesResponse{}, err
+	}
+
+	response := GetWebhookDeliveriesResponse{Deliveries: make([]WebhookDeliveryResponse, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, NewWebhookDeliveryResponse(delivery))
+	}
+	return response, nil
+}
+
+type GetWebhookDeliveriesQuery struct {
+	subscriptionID uuid.UUID
+
+	isSet bool
+}
+
+func NewGetWebhookDeliveriesQuery(subscriptionID uuid.UUID) (GetWebhookDeliveriesQuery, error) {
+	if subscriptionID == uuid.Nil {
+		return GetWebhookDeliveriesQuery{}, errs.NewValueIsRequiredError("subscriptionID")
+	}
+	return GetWebhookDeliveriesQuery{subscriptionID: subscriptionID, isSet: true}, nil
+}
+
+func (q GetWebhookDeliveriesQuery) IsEmpty() bool {
+	return !q.isSet
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse
+}
+
+type WebhookDeliveryResponse struct {
+	ID            uuid.UUID
+	EventID       uuid.UUID
+	EventType     string
+	Status        string
+	Attempts      int
+	ResponseCode  int
+	LastError     string
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func NewWebhookDeliveryResponse(delivery *webhook.Delivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:           delivery.ID(),
+		EventID:      delivery.EventID(),
+		EventType:    string(delivery.EventType()),
+		Status:       string(delivery.Status()),
+		Attempts:     delivery.Attempts(),
+		ResponseCode: delivery.ResponseCode(),
+		LastError:    delivery.LastError(),
+		CreatedAt:    delivery.CreatedAt(),
+		UpdatedAt:    delivery.UpdatedAt(),
+	}
+	// Время следующей попытки имеет смысл только для ожидающих доставок
+	if !delivery.IsFinished() {
+		nextAttemptAt := delivery.NextAttemptAt()
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	return response
+}
@@ -0,0 +1,74 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// GetWebhookSubscriptionsQueryHandler - подписки без секретов, секрет знает только тот, кто его задал
+type GetWebhookSubscriptionsQueryHandler struct {
+	subscriptionRepository ports.WebhookSubscriptionRepository
+}
+
+func NewGetWebhookSubscriptionsQueryHandler(
+	subscriptionRepository ports.WebhookSubscriptionRepository,
+) (*GetWebhookSubscriptionsQueryHandler, error) {
+	if subscriptionRepository == nil {
+		return nil, errs.NewValueIsRequiredError("subscriptionRepository")
+	}
+	return &GetWebhookSubscriptionsQueryHandler{subscriptionRepository: subscriptionRepository}, nil
+}
+
+func (q *GetWebhookSubscriptionsQueryHandler) Handle(ctx context.Context, query GetWebhookSubscriptionsQuery) (GetWebhookSubscriptionsResponse, error) {
+	if query.IsEmpty() {
+		return GetWebhookSubscriptionsResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	subscriptions, err := q.subscriptionRepository.GetAll(ctx)
+	if err != nil {
+		return GetWebhookSubscriptionsResponse{}, err
+	}
+
+	response := GetWebhookSubscriptionsResponse{Subscriptions: make([]WebhookSubscriptionResponse, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		eventTypes := make([]string, 0, len(subscription.EventTypes()))
+		for _, eventType := range subscription.EventTypes() {
+			eventTypes = append(eventTypes, string(eventType))
+		}
+		response.Subscriptions = append(response.Subscriptions, WebhookSubscriptionResponse{
+			ID:         subscription.ID(),
+			URL:        subscription.URL(),
+			EventTypes: eventTypes,
+			CreatedAt:  subscription.CreatedAt(),
+		})
+	}
+	return response, nil
+}
+
+type GetWebhookSubscriptionsQuery struct {
+	isSet bool
+}
+
+func NewGetWebhookSubscriptionsQuery() (GetWebhookSubscriptionsQuery, error) {
+	return GetWebhookSubscriptionsQuery{isSet: true}, nil
+}
+
+func (q GetWebhookSubscriptionsQuery) IsEmpty() bool {
+	return !q.isSet
+}
+
+type GetWebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscriptionResponse
+}
+
+type WebhookSubscriptionResponse struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	CreatedAt  time.Time
+}
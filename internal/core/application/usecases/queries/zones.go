@@ -0,0 +1,158 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// GetZonesQueryHandler - зоны с очередью заказов, ждущих курьера
+type GetZonesQueryHandler struct {
+	zoneRepository  ports.ZoneRepository
+	orderRepository ports.OrderRepository
+}
+
+func NewGetZonesQueryHandler(
+	zoneRepository ports.ZoneRepository, orderRepository ports.OrderRepository) (*GetZonesQueryHandler, error) {
+	if zoneRepository == nil {
+		return nil, errs.NewValueIsRequiredError("zoneRepository")
+	}
+	if orderRepository == nil {
+		return nil, errs.NewValueIsRequiredError("orderRepository")
+	}
+	return &GetZonesQueryHandler{zoneRepository: zoneRepository, orderRepository: orderRepository}, nil
+}
+
+func (q *GetZonesQueryHandler) Handle(ctx context.Context, query GetZonesQuery) (GetZonesResponse, error) {
+	if query.IsEmpty() {
+		return GetZonesResponse{}, errs.NewValueIsRequiredError("query")
+	}
+
+	zones, err := q.zoneRepository.GetAll(ctx)
+	if err != nil {
+		return GetZonesResponse{}, err
+	}
+	queueDepths, err := q.queueDepths(ctx, zones)
+	if err != nil {
+		return GetZonesResponse{}, err
+	}
+
+	response := GetZonesResponse{Zones: make([]ZoneResponse, 0, len(zones))}
+	for _, aggregate := range zones {
+		if query.zoneID != nil && aggregate.ID() != *query.zoneID {
+			continue
+		}
+		if !query.region.IsEmpty() && aggregate.Region() != query.region {
+			continue
+		}
+		response.Zones = append(response.Zones, NewZoneResponse(aggregate, queueDepths[aggregate.ID()]))
+	}
+	if query.zoneID != nil && len(response.Zones) == 0 {
+		return GetZonesResponse{}, errs.NewObjectNotFoundError(query.zoneID.String(), *query.zoneID)
+	}
+	return response, nil
+}
+
+// queueDepths - сколько заказов ждут курьера в каждой зоне. Заказ на пересечении зон считается там же,
+// где его назначает диспетчер, заказы других регионов в зону не попадают
+func (q *GetZonesQueryHandler) queueDepths(ctx context.Context, zones []*zone.Zone) (map[uuid.UUID]int, error) {
+	var regions []kernel.RegionCode
+	zonesByRegion := make(map[kernel.RegionCode][]*zone.Zone)
+	for _, z := range zones {
+		if _, ok := zonesByRegion[z.Region()]; !ok {
+			regions = append(regions, z.Region())
+		}
+		zonesByRegion[z.Region()] = append(zonesByRegion[z.Region()], z)
+	}
+
+	depths := make(map[uuid.UUID]int, len(zones))
+	for _, regionCode := range regions {
+		orders, err := q.orderRepository.GetAllInCreatedStatus(ctx, regionCode)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range orders {
+			if orderZone, ok := zone.Locate(zonesByRegion[regionCode], o.Location()); ok {
+				depths[orderZone.ID()]++
+			}
+		}
+	}
+	return depths, nil
+}
+
+type GetZonesQuery struct {
+	// zoneID - только эта зона, nil - все
+	zoneID *uuid.UUID
+	// region - только зоны региона, пустой - всех регионов
+	region kernel.RegionCode
+
+	isSet bool
+}
+
+func NewGetZonesQuery(regionCode kernel.RegionCode) (GetZonesQuery, error) {
+	return GetZonesQuery{region: regionCode, isSet: true}, nil
+}
+
+// NewGetZoneQuery - одна зона, ErrObjectNotFound, если её нет
+func NewGetZoneQuery(zoneID uuid.UUID) (GetZonesQuery, error) {
+	if zoneID == uuid.Nil {
+		return GetZonesQuery{}, errs.NewValueIsRequiredError("zoneID")
+	}
+	return GetZonesQuery{zoneID: &zoneID, isSet: true}, nil
+}
+
+func (q GetZonesQuery) IsEmpty() bool {
+	return !q.isSet
+}
+
+type GetZonesResponse struct {
+	Zones []ZoneResponse
+}
+
+type ZoneResponse struct {
+	ID     uuid.UUID
+	Region string
+	Name   string
+	// Cells - диапазон клеток, nil у многоугольника
+	Cells *CellsResponse
+	// Polygon - вершины многоугольника, пусто у диапазона клеток
+	Polygon []LocationResponse
+	// QueueDepth - сколько заказов в зоне ждут курьера
+	QueueDepth int
+	CreatedAt  time.Time
+}
+
+type CellsResponse struct {
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+func NewZoneResponse(aggregate *zone.Zone, queueDepth int) ZoneResponse {
+	response := ZoneResponse{
+		ID:         aggregate.ID(),
+		Region:     aggregate.Region().String(),
+		Name:       aggregate.Name(),
+		QueueDepth: queueDepth,
+		CreatedAt:  aggregate.CreatedAt(),
+	}
+	shape := aggregate.Shape()
+	if !shape.IsPolygon() {
+		cells := shape.Cells()
+		response.Cells = &CellsResponse{
+			MinX: cells.Min().X(), MinY: cells.Min().Y(), MaxX: cells.Max().X(), MaxY: cells.Max().Y(),
+		}
+		return response
+	}
+	for _, vertex := range shape.Polygon() {
+		response.Polygon = append(response.Polygon, NewLocationResponse(vertex))
+	}
+	return response
+}
@@ -3,10 +3,13 @@ package courier
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
@@ -15,21 +18,38 @@ type Status string
 const (
 	StatusFree Status = "free"
 	StatusBusy Status = "busy"
+	// StatusOffline - курьер перестал выходить на связь и заказов не получает
+	StatusOffline Status = "offline"
 )
 
 type Courier struct {
+	ddd.AggregateRoot
+
 	id        uuid.UUID
+	region    kernel.RegionCode
 	name      string
 	transport *Transport
 	location  kernel.Location
 	status    Status
+	createdAt time.Time
+	// lastAssignedAt - когда курьер последний раз получил заказ, nil - ещё не получал
+	lastAssignedAt *time.Time
+	// homeZoneID - зона, заказы которой курьер получает первым, nil - курьер работает по всему городу
+	homeZoneID *uuid.UUID
+	// lastSeenAt - последний сигнал от курьера, nil - курьер на связь не выходил и не отслеживается
+	lastSeenAt *time.Time
 }
 
 var (
 	ErrCourierAlreadyBusy = errors.New("courier is already busy")
 	ErrCourierAlreadyFree = errors.New("courier is already free")
+	ErrCourierOffline     = errors.New("courier is offline")
 	ErrInvalidCourierName = errors.New("invalid courier name")
 	ErrInvalidLocation    = errors.New("invalid Location")
+	// ErrImplausibleLocation - транспорт курьера не мог так быстро оказаться в новой позиции
+	ErrImplausibleLocation = errors.New("location is implausible for courier transport")
+	// ErrStaleLocation - позиция не новее последнего сигнала от курьера
+	ErrStaleLocation = errors.New("location is older than last courier signal")
 )
 
 func NewCourier(name string, transportName string, transportSpeed int, location kernel.Location) (*Courier, error) {
@@ -41,6 +61,28 @@ func NewCourier(name string, transportName string, transportSpeed int, location
 	if err != nil {
 		return nil, err
 	}
+	return NewCourierWithTransport(name, transport, location)
+}
+
+// NewCourierWithTransport - курьер на уже созданном транспорте, например из профиля
+func NewCourierWithTransport(name string, transport *Transport, location kernel.Location) (*Courier, error) {
+	return NewCourierInRegion(kernel.DefaultRegion(), name, transport, location)
+}
+
+// NewCourierInRegion - курьер, который получает заказы только своего региона
+func NewCourierInRegion(region kernel.RegionCode, name string, transport *Transport,
+	location kernel.Location) (*Courier, error) {
+	if region.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("region")
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrInvalidCourierName
+	}
+
+	if transport == nil {
+		return nil, errs.NewValueIsRequiredError("transport")
+	}
 
 	if location.IsEmpty() {
 		return nil, ErrInvalidLocation
@@ -48,10 +90,12 @@ func NewCourier(name string, transportName string, transportSpeed int, location
 
 	return &Courier{
 		id:        uuid.New(),
+		region:    region,
 		name:      name,
 		transport: transport,
 		location:  location,
 		status:    StatusFree,
+		createdAt: time.Now().UTC(),
 	}, nil
 }
 
@@ -64,15 +108,23 @@ func MustNewCourier(name string, transportName string, transportSpeed int, locat
 }
 
 func (c *Courier) SetBusy() error {
+	if c.IsOffline() {
+		return ErrCourierOffline
+	}
 	if c.IsBusy() {
 		return ErrCourierAlreadyBusy
 	}
 
 	c.status = StatusBusy
+	assignedAt := time.Now().UTC()
+	c.lastAssignedAt = &assignedAt
 	return nil
 }
 
 func (c *Courier) SetFree() error {
+	if c.IsOffline() {
+		return ErrCourierOffline
+	}
 	if c.IsFree() {
 		return ErrCourierAlreadyFree
 	}
@@ -81,27 +133,108 @@ func (c *Courier) SetFree() error {
 	return nil
 }
 
+// Heartbeat - курьер на связи. Курьер, признанный недоступным, снова получает заказы
+func (c *Courier) Heartbeat(now time.Time) {
+	seenAt := now.UTC()
+	c.lastSeenAt = &seenAt
+	if c.IsOffline() {
+		c.status = StatusFree
+	}
+}
+
+// ReportLocation - позиция из приложения курьера на момент reportedAt. Позиция - это и сигнал, что курьер на связи,
+// поэтому скорость считается от последнего сигнала. Первая позиция принимается без проверки скорости
+func (c *Courier) ReportLocation(location kernel.Location, reportedAt time.Time, limit SpeedLimit) error {
+	if location.IsEmpty() {
+		return ErrInvalidLocation
+	}
+	if !location.SameKind(c.location) {
+		return kernel.ErrLocationKindMismatch
+	}
+	if limit.IsEmpty() {
+		return errs.NewValueIsRequiredError("limit")
+	}
+
+	reportedAt = reportedAt.UTC()
+	if c.lastSeenAt != nil {
+		if !reportedAt.After(*c.lastSeenAt) {
+			return ErrStaleLocation
+		}
+		maxDistance := limit.MaxDistance(c.transport, location.IsGeo(), reportedAt.Sub(*c.lastSeenAt))
+		if c.location.DistanceTo(location) > maxDistance {
+			return ErrImplausibleLocation
+		}
+	}
+
+	c.Heartbeat(reportedAt)
+	return c.moveTo(location)
+}
+
+// GoOffline - курьер недоступен. Его заказы снимает и передаёт другим вызывающий
+func (c *Courier) GoOffline() error {
+	if c.IsOffline() {
+		return ErrCourierOffline
+	}
+	c.status = StatusOffline
+	return nil
+}
+
+// IsStale - курьер на связь выходил, но молчит дольше timeout
+func (c *Courier) IsStale(now time.Time, timeout time.Duration) bool {
+	if c.lastSeenAt == nil || c.IsOffline() {
+		return false
+	}
+	return now.Sub(*c.lastSeenAt) > timeout
+}
+
+// SetHomeZone - закрепить курьера за зоной, nil - снять с зоны
+func (c *Courier) SetHomeZone(zoneID *uuid.UUID) {
+	if zoneID == nil {
+		c.homeZoneID = nil
+		return
+	}
+	id := *zoneID
+	c.homeZoneID = &id
+}
+
 func (c *Courier) StepsToOrder(orderLocation kernel.Location) (steps int, _ error) {
 	if orderLocation.IsEmpty() {
 		return steps, errs.NewValueIsRequiredError("orderLocation")
 	}
 
-	for c.location != orderLocation {
-		err := c.Move(orderLocation)
-		if err != nil {
-			return steps, err
-		}
-		steps++
-	}
-	return steps, nil
+	// Сам курьер при оценке никуда не двигается
+	return c.transport.Steps(c.location, orderLocation)
+}
+
+// StepsAlong - сколько ходов курьеру идти по пути от его текущей позиции, vehicle - правила его транспорта
+func (c *Courier) StepsAlong(path routing.Path, vehicle routing.Vehicle) (int, error) {
+	return c.transport.StepsAlong(c.location, path, vehicle)
 }
 
+// Move - ход к target по прямой
 func (c *Courier) Move(target kernel.Location) error {
 	newLocation, err := c.transport.Move(c.location, target)
 	if err != nil {
 		return err
 	}
+	return c.moveTo(newLocation)
+}
+
+// MoveAlong - ход по пути, построенному от текущей позиции курьера
+func (c *Courier) MoveAlong(path routing.Path, vehicle routing.Vehicle) error {
+	newLocation, err := c.transport.MoveAlong(c.location, path, vehicle)
+	if err != nil {
+		return err
+	}
+	return c.moveTo(newLocation)
+}
+
+func (c *Courier) moveTo(newLocation kernel.Location) error {
+	if newLocation.Equals(c.location) {
+		return nil
+	}
 	c.location = newLocation
+	c.RaiseDomainEvent(NewLocationChangedDomainEvent(c.id, newLocation))
 	return nil
 }
 
@@ -113,10 +246,18 @@ func (c *Courier) IsBusy() bool {
 	return c.status == StatusBusy
 }
 
+func (c *Courier) IsOffline() bool {
+	return c.status == StatusOffline
+}
+
 func (c *Courier) ID() uuid.UUID {
 	return c.id
 }
 
+func (c *Courier) Region() kernel.RegionCode {
+	return c.region
+}
+
 func (c *Courier) Name() string {
 	return c.name
 }
@@ -125,6 +266,22 @@ func (c *Courier) Status() Status {
 	return c.status
 }
 
+func (c *Courier) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *Courier) LastAssignedAt() *time.Time {
+	return c.lastAssignedAt
+}
+
+func (c *Courier) LastSeenAt() *time.Time {
+	return c.lastSeenAt
+}
+
+func (c *Courier) HomeZoneID() *uuid.UUID {
+	return c.homeZoneID
+}
+
 func (c *Courier) Transport() *Transport {
 	return c.transport
 }
@@ -1,6 +1,8 @@
 package courier
 
 import (
+	"time"
+
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -88,6 +90,91 @@ func TestCourier_StepsToOrder(t *testing.T) {
 			gotSteps, err := c.StepsToOrder(tt.args.orderLocation)
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantSteps, gotSteps)
+			// Оценка не двигает курьера
+			assert.Equal(t, tt.fields.location, c.Location())
+			assert.Empty(t, c.DomainEvents())
 		})
 	}
 }
+
+func TestCourier_MoveShouldRaiseLocationChanged(t *testing.T) {
+	c := MustNewCourier("Тестовый курьер", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+
+	require.NoError(t, c.Move(kernel.MustNewLocation(5, 1)))
+	require.NoError(t, c.Move(kernel.MustNewLocation(5, 1)))
+	// Курьер уже на месте, событие не нужно
+	require.NoError(t, c.Move(kernel.MustNewLocation(5, 1)))
+
+	events := c.DomainEvents()
+	require.Len(t, events, 2)
+	last, ok := events[1].(LocationChangedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, c.ID(), last.CourierID())
+	assert.Equal(t, kernel.MustNewLocation(5, 1), last.Location())
+	assert.NotEqual(t, events[0].EventID(), last.EventID())
+
+	c.ClearDomainEvents()
+	assert.Empty(t, c.DomainEvents())
+}
+
+func TestCourier_SetBusyShouldRememberAssignmentTime(t *testing.T) {
+	courier := MustNewCourier("Тестовый курьер", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	assert.Nil(t, courier.LastAssignedAt())
+
+	require.NoError(t, courier.SetBusy())
+	require.NotNil(t, courier.LastAssignedAt())
+	first := *courier.LastAssignedAt()
+
+	// Освобождение время назначения не сбрасывает
+	require.NoError(t, courier.SetFree())
+	assert.Equal(t, first, *courier.LastAssignedAt())
+
+	require.NoError(t, courier.SetBusy())
+	assert.False(t, courier.LastAssignedAt().Before(first))
+}
+
+func TestCourier_HeartbeatAndOffline(t *testing.T) {
+	courier := MustNewCourier("Тестовый курьер", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	now := time.Now().UTC()
+
+	// Курьер без сигналов не отслеживается
+	assert.False(t, courier.IsStale(now.Add(time.Hour), time.Minute))
+
+	courier.Heartbeat(now)
+	assert.False(t, courier.IsStale(now.Add(time.Minute), time.Minute))
+	assert.True(t, courier.IsStale(now.Add(2*time.Minute), time.Minute))
+
+	require.NoError(t, courier.SetBusy())
+	require.NoError(t, courier.GoOffline())
+	assert.True(t, courier.IsOffline())
+	assert.ErrorIs(t, courier.GoOffline(), ErrCourierOffline)
+	assert.ErrorIs(t, courier.SetBusy(), ErrCourierOffline)
+	assert.ErrorIs(t, courier.SetFree(), ErrCourierOffline)
+	assert.False(t, courier.IsStale(now.Add(time.Hour), time.Minute))
+
+	// Снова на связи - снова свободен
+	courier.Heartbeat(now.Add(time.Hour))
+	assert.True(t, courier.IsFree())
+	assert.Equal(t, now.Add(time.Hour), *courier.LastSeenAt())
+}
+
+func TestCourier_ReportLocationShouldRejectImplausibleSpeed(t *testing.T) {
+	courier := MustNewCourier("Тестовый курьер", "Велосипед", 2, kernel.MustNewLocation(1, 1))
+	limit, err := NewSpeedLimit(time.Second, 1)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+
+	// Первая позиция принимается без проверки скорости
+	require.NoError(t, courier.ReportLocation(kernel.MustNewLocation(5, 5), now, limit))
+	assert.Equal(t, kernel.MustNewLocation(5, 5), courier.Location())
+	assert.Equal(t, now, *courier.LastSeenAt())
+
+	// За 2 секунды велосипед проходит не больше 4 клеток
+	assert.ErrorIs(t, courier.ReportLocation(kernel.MustNewLocation(10, 5), now.Add(2*time.Second), limit),
+		ErrImplausibleLocation)
+	require.NoError(t, courier.ReportLocation(kernel.MustNewLocation(9, 5), now.Add(2*time.Second), limit))
+	assert.ErrorIs(t, courier.ReportLocation(kernel.MustNewLocation(9, 6), now.Add(time.Second), limit),
+		ErrStaleLocation)
+	assert.Equal(t, kernel.MustNewLocation(9, 5), courier.Location())
+	assert.Len(t, courier.DomainEvents(), 2)
+}
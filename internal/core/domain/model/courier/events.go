@@ -0,0 +1,51 @@
+package courier
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+var _ ddd.DomainEvent = LocationChangedDomainEvent{}
+
+const LocationChangedEventName = "courier.location_changed"
+
+// LocationChangedDomainEvent - курьер переместился
+type LocationChangedDomainEvent struct {
+	id         uuid.UUID
+	courierID  uuid.UUID
+	location   kernel.Location
+	occurredAt time.Time
+}
+
+func NewLocationChangedDomainEvent(courierID uuid.UUID, location kernel.Location) LocationChangedDomainEvent {
+	return LocationChangedDomainEvent{
+		id:         uuid.New(),
+		courierID:  courierID,
+		location:   location,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e LocationChangedDomainEvent) EventID() uuid.UUID {
+	return e.id
+}
+
+func (e LocationChangedDomainEvent) EventName() string {
+	return LocationChangedEventName
+}
+
+func (e LocationChangedDomainEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e LocationChangedDomainEvent) CourierID() uuid.UUID {
+	return e.courierID
+}
+
+func (e LocationChangedDomainEvent) Location() kernel.Location {
+	return e.location
+}
@@ -0,0 +1,213 @@
+package courier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// Профили транспорта, с которыми работает сервис. Правила профилей задаются файлом
+const (
+	ProfileWalking = "walking"
+	ProfileBicycle = "bicycle"
+	ProfileScooter = "scooter"
+	ProfileCar     = "car"
+)
+
+// ZoneRule - скорость в зоне города: Factor - множитель скорости, 0 - въезд в зону запрещён
+type ZoneRule struct {
+	Name   string
+	Bounds kernel.Bounds
+	Factor float64
+}
+
+// HoursRule - множитель скорости с From до To от начала суток, например в час пик.
+// To меньше From - интервал через полночь
+type HoursRule struct {
+	From   time.Duration
+	To     time.Duration
+	Factor float64
+}
+
+func (r HoursRule) contains(at time.Time) bool {
+	hour, minute, second := at.Clock()
+	sinceMidnight := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+	if r.From <= r.To {
+		return sinceMidnight >= r.From && sinceMidnight < r.To
+	}
+	return sinceMidnight >= r.From || sinceMidnight < r.To
+}
+
+// Profile - профиль транспорта: базовая скорость, по каким дорогам и в какие зоны можно и как быстро
+type Profile struct {
+	name  string
+	speed int
+	// roads - множитель скорости по классу дороги, по дорогам других классов ехать нельзя
+	roads map[routing.RoadClass]float64
+	zones []ZoneRule
+	hours []HoursRule
+}
+
+func NewProfile(name string, speed int, roads map[routing.RoadClass]float64, zones []ZoneRule,
+	hours []HoursRule) (*Profile, error) {
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("name")
+	}
+	if speed < 1 {
+		return nil, errs.NewValueIsInvalidError("speed")
+	}
+	if len(roads) == 0 {
+		return nil, errs.NewValueIsRequiredError("roads")
+	}
+	for class, factor := range roads {
+		if !class.IsValid() || factor <= 0 {
+			return nil, errs.NewValueIsInvalidError(fmt.Sprintf("road %s factor %v", class, factor))
+		}
+	}
+	for _, zone := range zones {
+		if zone.Bounds == nil || zone.Bounds.IsEmpty() {
+			return nil, errs.NewValueIsRequiredError(fmt.Sprintf("zone %s bounds", zone.Name))
+		}
+		if zone.Factor < 0 {
+			return nil, errs.NewValueIsInvalidError(fmt.Sprintf("zone %s factor %v", zone.Name, zone.Factor))
+		}
+	}
+	for _, hours := range hours {
+		if hours.From < 0 || hours.From >= 24*time.Hour || hours.To < 0 || hours.To >= 24*time.Hour ||
+			hours.From == hours.To || hours.Factor <= 0 {
+			return nil, errs.NewValueIsInvalidError(fmt.Sprintf("hours %s-%s factor %v", hours.From, hours.To, hours.Factor))
+		}
+	}
+
+	profileRoads := make(map[routing.RoadClass]float64, len(roads))
+	for class, factor := range roads {
+		profileRoads[class] = factor
+	}
+	return &Profile{
+		name:  name,
+		speed: speed,
+		roads: profileRoads,
+		zones: append([]ZoneRule(nil), zones...),
+		hours: append([]HoursRule(nil), hours...),
+	}, nil
+}
+
+func (p *Profile) Name() string {
+	return p.name
+}
+
+func (p *Profile) Speed() int {
+	return p.speed
+}
+
+// NewTransport - транспорт этого профиля с его базовой скоростью
+func (p *Profile) NewTransport(name string) (*Transport, error) {
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("Transport name cannot be empty")
+	}
+	return &Transport{
+		id:      uuid.New(),
+		name:    name,
+		speed:   p.speed,
+		profile: p.name,
+	}, nil
+}
+
+// Vehicle - транспорт со скоростью speed по правилам профиля в момент at
+func (p *Profile) Vehicle(speed int, at time.Time) routing.Vehicle {
+	factor := 1.0
+	for _, hours := range p.hours {
+		if hours.contains(at) {
+			factor *= hours.Factor
+		}
+	}
+	return profileVehicle{speed: float64(speed) * factor, profile: p}
+}
+
+var _ routing.Vehicle = profileVehicle{}
+
+// profileVehicle - без профиля едет по любой дороге с одной скоростью
+type profileVehicle struct {
+	speed   float64
+	profile *Profile
+}
+
+// Speed - скорость на отрезке: въезд в закрытую зону запрещён, скорость зависит от класса дороги и зоны, где отрезок начинается
+func (v profileVehicle) Speed(from, to kernel.Location, class routing.RoadClass) float64 {
+	if v.profile == nil {
+		return v.speed
+	}
+	factor, ok := v.profile.roads[class]
+	if !ok {
+		return 0
+	}
+	for _, zone := range v.profile.zones {
+		if zone.Factor == 0 && zone.Bounds.Contains(to) {
+			return 0
+		}
+	}
+	for _, zone := range v.profile.zones {
+		if zone.Factor > 0 && zone.Bounds.Contains(from) {
+			factor *= zone.Factor
+			break
+		}
+	}
+	return v.speed * factor
+}
+
+func (v profileVehicle) MaxSpeed() float64 {
+	if v.profile == nil {
+		return v.speed
+	}
+	factor := 0.0
+	for _, roadFactor := range v.profile.roads {
+		factor = max(factor, roadFactor)
+	}
+	zoneFactor := 1.0
+	for _, zone := range v.profile.zones {
+		zoneFactor = max(zoneFactor, zone.Factor)
+	}
+	return v.speed * factor * zoneFactor
+}
+
+// Profiles - профили транспорта по имени
+type Profiles struct {
+	profiles map[string]*Profile
+}
+
+func NewProfiles(profiles ...*Profile) (*Profiles, error) {
+	byName := make(map[string]*Profile, len(profiles))
+	for _, profile := range profiles {
+		if profile == nil {
+			return nil, errs.NewValueIsRequiredError("profile")
+		}
+		if _, ok := byName[profile.Name()]; ok {
+			return nil, errs.NewValueIsInvalidError(fmt.Sprintf("duplicate profile %s", profile.Name()))
+		}
+		byName[profile.Name()] = profile
+	}
+	return &Profiles{profiles: byName}, nil
+}
+
+func (p *Profiles) Get(name string) (*Profile, bool) {
+	if p == nil {
+		return nil, false
+	}
+	profile, ok := p.profiles[name]
+	return profile, ok
+}
+
+// Vehicle - транспорт курьера в момент at. Транспорт без профиля или с неизвестным профилем
+// едет по любой дороге со своей скоростью
+func (p *Profiles) Vehicle(transport *Transport, at time.Time) routing.Vehicle {
+	profile, ok := p.Get(transport.Profile())
+	if !ok {
+		return profileVehicle{speed: float64(transport.Speed())}
+	}
+	return profile.Vehicle(transport.Speed(), at)
+}
@@ -0,0 +1,107 @@
+package courier_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+)
+
+var (
+	streets   = map[routing.RoadClass]float64{routing.RoadStreet: 1}
+	oldTown   = kernel.MustNewArea(1, 8, 3, 10)
+	rushHours = []courier.HoursRule{{From: 8 * time.Hour, To: 10 * time.Hour, Factor: 0.5}}
+)
+
+func TestNewProfile(t *testing.T) {
+	testCases := []struct {
+		name  string
+		speed int
+		roads map[routing.RoadClass]float64
+		zones []courier.ZoneRule
+		hours []courier.HoursRule
+		valid bool
+	}{
+		{name: "car", speed: 3, roads: streets, zones: []courier.ZoneRule{{Name: "old", Bounds: oldTown}},
+			hours: rushHours, valid: true},
+		{name: "", speed: 3, roads: streets},
+		{name: "car", speed: 0, roads: streets},
+		{name: "car", speed: 3},
+		{name: "car", speed: 3, roads: map[routing.RoadClass]float64{"river": 1}},
+		{name: "car", speed: 3, roads: map[routing.RoadClass]float64{routing.RoadStreet: 0}},
+		{name: "car", speed: 3, roads: streets, zones: []courier.ZoneRule{{Name: "old"}}},
+		{name: "car", speed: 3, roads: streets, zones: []courier.ZoneRule{{Name: "old", Bounds: oldTown, Factor: -1}}},
+		{name: "car", speed: 3, roads: streets, hours: []courier.HoursRule{{From: time.Hour, To: time.Hour, Factor: 1}}},
+		{name: "car", speed: 3, roads: streets, hours: []courier.HoursRule{{From: 0, To: 25 * time.Hour, Factor: 1}}},
+	}
+
+	for _, tc := range testCases {
+		_, err := courier.NewProfile(tc.name, tc.speed, tc.roads, tc.zones, tc.hours)
+		if tc.valid {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err, "%+v", tc)
+		}
+	}
+}
+
+func TestProfile_VehicleSpeed(t *testing.T) {
+	profile, err := courier.NewProfile(courier.ProfileCar, 3,
+		map[routing.RoadClass]float64{routing.RoadStreet: 1, routing.RoadHighway: 2},
+		[]courier.ZoneRule{
+			{Name: "old_town", Bounds: oldTown, Factor: 0},
+			{Name: "center", Bounds: kernel.MustNewArea(4, 4, 7, 7), Factor: 0.5},
+		},
+		[]courier.HoursRule{{From: 22 * time.Hour, To: 6 * time.Hour, Factor: 2}, rushHours[0]})
+	require.NoError(t, err)
+
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	vehicle := profile.Vehicle(profile.Speed(), noon)
+	outside, center := kernel.MustNewLocation(9, 1), kernel.MustNewLocation(5, 5)
+
+	assert.Equal(t, 3.0, vehicle.Speed(outside, kernel.MustNewLocation(9, 2), routing.RoadStreet))
+	assert.Equal(t, 6.0, vehicle.Speed(outside, kernel.MustNewLocation(9, 2), routing.RoadHighway))
+	assert.Zero(t, vehicle.Speed(outside, kernel.MustNewLocation(9, 2), routing.RoadFootway))
+	assert.Equal(t, 1.5, vehicle.Speed(center, kernel.MustNewLocation(9, 2), routing.RoadStreet))
+	assert.Equal(t, 6.0, vehicle.MaxSpeed())
+
+	// Въезжать в старый город нельзя, выезжать можно
+	assert.Zero(t, vehicle.Speed(outside, kernel.MustNewLocation(2, 9), routing.RoadStreet))
+	assert.Equal(t, 3.0, vehicle.Speed(kernel.MustNewLocation(2, 9), outside, routing.RoadStreet))
+
+	// Час пик и ночь через полночь
+	morning := profile.Vehicle(profile.Speed(), time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC))
+	assert.Equal(t, 1.5, morning.Speed(outside, kernel.MustNewLocation(9, 2), routing.RoadStreet))
+	night := profile.Vehicle(profile.Speed(), time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC))
+	assert.Equal(t, 6.0, night.Speed(outside, kernel.MustNewLocation(9, 2), routing.RoadStreet))
+}
+
+func TestProfiles_Vehicle(t *testing.T) {
+	car, err := courier.NewProfile(courier.ProfileCar, 3, streets, nil, rushHours)
+	require.NoError(t, err)
+	profiles, err := courier.NewProfiles(car)
+	require.NoError(t, err)
+	_, err = courier.NewProfiles(car, car)
+	assert.Error(t, err)
+
+	transport, err := car.NewTransport("Машина")
+	require.NoError(t, err)
+	assert.Equal(t, 3, transport.Speed())
+	assert.Equal(t, courier.ProfileCar, transport.Profile())
+
+	rush := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	from, to := kernel.MustNewLocation(1, 1), kernel.MustNewLocation(1, 2)
+	assert.Equal(t, 1.5, profiles.Vehicle(transport, rush).Speed(from, to, routing.RoadStreet))
+	assert.Zero(t, profiles.Vehicle(transport, rush).Speed(from, to, routing.RoadHighway))
+
+	// Транспорт без профиля едет по любой дороге со своей скоростью
+	bicycle := courier.MustNewTransport("Велосипед", 2)
+	assert.Equal(t, 2.0, profiles.Vehicle(bicycle, rush).Speed(from, to, routing.RoadHighway))
+	var noProfiles *courier.Profiles
+	assert.Equal(t, 3.0, noProfiles.Vehicle(transport, rush).Speed(from, to, routing.RoadHighway))
+}
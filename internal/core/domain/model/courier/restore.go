@@ -1,25 +1,34 @@
 package courier
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 )
 
-func RestoreCourier(ID uuid.UUID, name string, transport *Transport, location kernel.Location, status Status) *Courier {
+func RestoreCourier(ID uuid.UUID, region kernel.RegionCode, name string, transport *Transport, location kernel.Location, status Status,
+	createdAt time.Time, lastAssignedAt *time.Time, homeZoneID *uuid.UUID, lastSeenAt *time.Time) *Courier {
 	return &Courier{
-		id:        ID,
-		name:      name,
-		transport: transport,
-		location:  location,
-		status:    status,
+		id:             ID,
+		region:         region,
+		name:           name,
+		transport:      transport,
+		location:       location,
+		status:         status,
+		createdAt:      createdAt,
+		lastAssignedAt: lastAssignedAt,
+		homeZoneID:     homeZoneID,
+		lastSeenAt:     lastSeenAt,
 	}
 }
 
-func RestoreTransport(ID uuid.UUID, name string, speed int) *Transport {
+func RestoreTransport(ID uuid.UUID, name string, speed int, profile string) *Transport {
 	return &Transport{
-		id:    ID,
-		name:  name,
-		speed: speed,
+		id:      ID,
+		name:    name,
+		speed:   speed,
+		profile: profile,
 	}
 }
@@ -0,0 +1,40 @@
+package courier
+
+import (
+	"math"
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// SpeedLimit - правдоподобная скорость для позиций из приложения курьера: за ход длиной tick транспорт проходит
+// Speed клеток или MetersPerTick метров, tolerance - запас на погрешность GPS и быстрые дороги
+type SpeedLimit struct {
+	tick      time.Duration
+	tolerance float64
+}
+
+func NewSpeedLimit(tick time.Duration, tolerance float64) (SpeedLimit, error) {
+	if tick <= 0 {
+		return SpeedLimit{}, errs.NewValueIsInvalidError("tick")
+	}
+	if tolerance < 1 {
+		return SpeedLimit{}, errs.NewValueIsInvalidError("tolerance")
+	}
+	return SpeedLimit{tick: tick, tolerance: tolerance}, nil
+}
+
+func (l SpeedLimit) IsEmpty() bool {
+	return l.tick == 0
+}
+
+// MaxDistance - сколько клеток или, в координатах WGS84, метров транспорт может пройти за elapsed.
+// Меньше одного хода не бывает: приложение присылает позиции чаще, чем курьер делает ход
+func (l SpeedLimit) MaxDistance(transport *Transport, geo bool, elapsed time.Duration) int {
+	perTick := transport.Speed()
+	if geo {
+		perTick = transport.MetersPerTick()
+	}
+	ticks := max(float64(elapsed)/float64(l.tick), 1)
+	return int(math.Ceil(float64(perTick) * ticks * l.tolerance))
+}
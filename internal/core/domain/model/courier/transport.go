@@ -6,18 +6,24 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
 const (
-	SPEED_MIN = 1
-	SPEED_MAX = 3
+	// METERS_PER_SPEED - сколько метров за ход проходит транспорт со скоростью 1 в координатах WGS84
+	METERS_PER_SPEED = 100
+
+	// timeTolerance - погрешность при делении хода между отрезками пути
+	timeTolerance = 1e-9
 )
 
 type Transport struct {
 	id    uuid.UUID
 	name  string
 	speed int
+	// profile - имя профиля транспорта, пустое - без ограничений по дорогам и зонам
+	profile string
 }
 
 func NewTransport(name string, speed int) (*Transport, error) {
@@ -25,8 +31,8 @@ func NewTransport(name string, speed int) (*Transport, error) {
 		return nil, errs.NewValueIsRequiredError("Transport name cannot be empty")
 	}
 
-	if speed < SPEED_MIN || speed > SPEED_MAX {
-		return nil, errs.NewValueIsOutOfRangeError("speed", speed, SPEED_MIN, SPEED_MAX)
+	if speed < 1 {
+		return nil, errs.NewValueIsInvalidError("speed")
 	}
 
 	return &Transport{
@@ -56,10 +62,20 @@ func (t Transport) Speed() int {
 	return t.speed
 }
 
+func (t Transport) Profile() string {
+	return t.profile
+}
+
+// MetersPerTick - путь за один ход по большому кругу в координатах WGS84
+func (t Transport) MetersPerTick() int {
+	return t.speed * METERS_PER_SPEED
+}
+
 func (t Transport) Equals(other Transport) bool {
 	return t.id == other.id
 }
 
+// Move - ход по прямой к target
 func (t Transport) Move(current, target kernel.Location) (kernel.Location, error) {
 	if current.IsEmpty() {
 		return kernel.Location{}, errs.NewValueIsRequiredError("current")
@@ -69,10 +85,66 @@ func (t Transport) Move(current, target kernel.Location) (kernel.Location, error
 		return kernel.Location{}, errs.NewValueIsRequiredError("target")
 	}
 
-	if current.Equals(target) {
-		return current, nil
+	path, err := routing.Direct{}.Route(current, target, nil)
+	if err != nil {
+		return kernel.Location{}, err
+	}
+	return t.MoveAlong(current, path, nil)
+}
+
+// MoveAlong - ход по пути. vehicle задаёт скорость на каждом отрезке, без него транспорт
+// проходит speed клеток или MetersPerTick метров по любой дороге
+func (t Transport) MoveAlong(current kernel.Location, path routing.Path, vehicle routing.Vehicle) (kernel.Location, error) {
+	if current.IsEmpty() {
+		return kernel.Location{}, errs.NewValueIsRequiredError("current")
+	}
+
+	next, _, err := tick(current, path.Segments(), t.vehicle(vehicle))
+	return next, err
+}
+
+// tick - один ход по segments от current: время хода делится между отрезками по скорости на них.
+// На сетке транспорт проходит целое число клеток, но не меньше одной.
+// Возвращает новую позицию и сколько отрезков пройдено целиком
+func tick(current kernel.Location, segments []routing.Segment, vehicle routing.Vehicle) (kernel.Location, int, error) {
+	remaining := 1.0
+	for i, segment := range segments {
+		if !current.SameKind(segment.To) {
+			return kernel.Location{}, i, kernel.ErrLocationKindMismatch
+		}
+		if current.Equals(segment.To) {
+			continue
+		}
+		perTick := vehicle.Speed(current, segment.To, segment.Class)
+		if perTick <= 0 {
+			return current, i, fmt.Errorf("%w from %s to %s", routing.ErrNoRoute, current, segment.To)
+		}
+		if current.IsGeo() {
+			perTick *= METERS_PER_SPEED
+		}
+
+		duration := routing.SegmentLength(current, segment.To) / perTick
+		if duration <= remaining+timeTolerance {
+			current = segment.To
+			remaining -= duration
+			continue
+		}
+		if current.IsGeo() {
+			next, err := current.MoveTowards(segment.To, remaining*perTick)
+			return next, i, err
+		}
+		cells := int(remaining*perTick + timeTolerance)
+		if i == 0 {
+			cells = max(cells, 1)
+		}
+		next, err := moveOnGrid(current, segment.To, cells)
+		return next, i, err
 	}
+	return current, len(segments), nil
+}
 
+// moveOnGrid - пройти cells клеток к target, сначала по x, потом по y
+func moveOnGrid(current, target kernel.Location, cells int) (kernel.Location, error) {
 	dx := -1
 	if target.X() > current.X() {
 		dx = 1
@@ -86,8 +158,8 @@ func (t Transport) Move(current, target kernel.Location) (kernel.Location, error
 	absStepsX := dx * (target.X() - current.X())
 	absStepsY := dy * (target.Y() - current.Y())
 
-	stepsX := min(absStepsX, t.speed)
-	stepsY := min(absStepsY, max(t.speed-stepsX, 0))
+	stepsX := min(absStepsX, cells)
+	stepsY := min(absStepsY, max(cells-stepsX, 0))
 
 	newX := current.X() + dx*stepsX
 	newY := current.Y() + dy*stepsY
@@ -95,8 +167,47 @@ func (t Transport) Move(current, target kernel.Location) (kernel.Location, error
 	return kernel.NewLocation(newX, newY)
 }
 
+// Steps - сколько ходов нужно, чтобы добраться из current в target по прямой
+func (t Transport) Steps(current, target kernel.Location) (steps int, _ error) {
+	if current.IsEmpty() {
+		return 0, errs.NewValueIsRequiredError("current")
+	}
+	if target.IsEmpty() {
+		return 0, errs.NewValueIsRequiredError("target")
+	}
+	path, err := routing.Direct{}.Route(current, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	return t.StepsAlong(current, path, nil)
+}
+
+// StepsAlong - сколько ходов MoveAlong нужно, чтобы пройти путь целиком
+func (t Transport) StepsAlong(current kernel.Location, path routing.Path, vehicle routing.Vehicle) (steps int, _ error) {
+	vehicle = t.vehicle(vehicle)
+	segments := path.Segments()
+	for len(segments) > 0 {
+		next, done, err := tick(current, segments, vehicle)
+		if err != nil {
+			return 0, err
+		}
+		current = next
+		segments = segments[done:]
+		steps++
+	}
+	return steps, nil
+}
+
+// vehicle - без профиля транспорт едет по любой дороге со своей скоростью
+func (t Transport) vehicle(vehicle routing.Vehicle) routing.Vehicle {
+	if vehicle == nil {
+		return profileVehicle{speed: float64(t.speed)}
+	}
+	return vehicle
+}
+
 func (t Transport) String() string {
-	return fmt.Sprintf("Transport{id=%s, name=%s, speed=%d}", t.id, t.name, t.speed)
+	return fmt.Sprintf("Transport{id=%s, name=%s, speed=%d, profile=%s}", t.id, t.name, t.speed, t.profile)
 }
 
 func (t Transport) IsEmpty() bool {
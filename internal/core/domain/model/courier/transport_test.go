@@ -1,12 +1,17 @@
 package courier_test
 
 import (
+	"math"
 	"testing"
+	"testing/quick"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
 )
 
 func TestTransportMoveTowards(t *testing.T) {
@@ -103,3 +108,108 @@ func TestTransportMoveTowards(t *testing.T) {
 		})
 	}
 }
+
+func TestTransport_MoveProperties(t *testing.T) {
+	// Курьер на произвольной сетке: каждый ход не выводит его за сетку, сокращает путь ровно на speed клеток
+	// или до нуля, а Steps совпадает с числом ходов
+	property := func(width, height uint16, speed uint8, cells [4]uint32) bool {
+		area := kernel.MustNewArea(1, 1, 1+int(width%2000), 1+int(height%2000))
+		cell := func(x, y uint32) kernel.Location {
+			return area.MustNewLocation(1+int(x%uint32(area.Width())), 1+int(y%uint32(area.Height())))
+		}
+		current, target := cell(cells[0], cells[1]), cell(cells[2], cells[3])
+		transport := courier.MustNewTransport("Транспорт", 1+int(speed%3))
+
+		expectedSteps, err := transport.Steps(current, target)
+		if err != nil {
+			return false
+		}
+		steps := 0
+		for !current.Equals(target) {
+			next, err := transport.Move(current, target)
+			if err != nil || !area.Contains(next) {
+				return false
+			}
+			before, after := current.DistanceTo(target), next.DistanceTo(target)
+			if before-after != min(transport.Speed(), before) {
+				return false
+			}
+			current = next
+			steps++
+		}
+		return steps == expectedSteps
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestTransport_MoveShouldWalkMetersPerTickInGeoLocations(t *testing.T) {
+	transport := courier.MustNewTransport("Велосипед", 2)
+	current := kernel.MustNewGeoLocation(55.7539, 37.6208)
+	target := kernel.MustNewGeoLocation(55.7600, 37.6300)
+
+	steps, err := transport.Steps(current, target)
+	require.NoError(t, err)
+	assert.Equal(t, int(math.Ceil(current.DistanceMeters(target)/200)), steps)
+
+	for range steps - 1 {
+		next, err := transport.Move(current, target)
+		require.NoError(t, err)
+		assert.InDelta(t, 200, current.DistanceMeters(next), 0.01)
+		current = next
+	}
+	last, err := transport.Move(current, target)
+	require.NoError(t, err)
+	assert.True(t, last.Equals(target))
+
+	_, err = transport.Move(current, kernel.MustNewLocation(1, 1))
+	assert.ErrorIs(t, err, kernel.ErrLocationKindMismatch)
+	_, err = transport.Steps(current, kernel.MustNewLocation(1, 1))
+	assert.ErrorIs(t, err, kernel.ErrLocationKindMismatch)
+}
+
+func TestTransport_MoveAlongShouldFollowWaypoints(t *testing.T) {
+	transport := courier.MustNewTransport("Велосипед", 3)
+	current := kernel.MustNewLocation(1, 5)
+	path, err := routing.NewPath(current,
+		kernel.MustNewLocation(1, 1), kernel.MustNewLocation(8, 1), kernel.MustNewLocation(8, 5))
+	require.NoError(t, err)
+	steps, err := transport.StepsAlong(current, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, steps)
+
+	next, err := transport.MoveAlong(current, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, kernel.MustNewLocation(1, 2), next)
+
+	// Поворот на перекрёстке (1,1) внутри одного хода
+	next, err = transport.MoveAlong(next, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, kernel.MustNewLocation(3, 1), next)
+}
+
+func TestTransport_MoveAlongShouldChangeSpeedBySegment(t *testing.T) {
+	profile, err := courier.NewProfile(courier.ProfileCar, 2,
+		map[routing.RoadClass]float64{routing.RoadStreet: 1, routing.RoadHighway: 2}, nil, nil)
+	require.NoError(t, err)
+	transport, err := profile.NewTransport("Машина")
+	require.NoError(t, err)
+	vehicle := profile.Vehicle(transport.Speed(), time.Now())
+
+	// По шоссе 4 клетки за ход, по улице 2: половина хода на шоссе, половина на улице
+	current := kernel.MustNewLocation(1, 1)
+	path, err := routing.NewSegmentPath(current,
+		routing.Segment{To: kernel.MustNewLocation(3, 1), Class: routing.RoadHighway},
+		routing.Segment{To: kernel.MustNewLocation(3, 9), Class: routing.RoadStreet})
+	require.NoError(t, err)
+
+	next, err := transport.MoveAlong(current, path, vehicle)
+	require.NoError(t, err)
+	assert.Equal(t, kernel.MustNewLocation(3, 2), next)
+
+	steps, err := transport.StepsAlong(current, path, vehicle)
+	require.NoError(t, err)
+	assert.Equal(t, 5, steps)
+
+	_, err = transport.MoveAlong(current, path, profile.Vehicle(0, time.Now()))
+	assert.ErrorIs(t, err, routing.ErrNoRoute)
+}
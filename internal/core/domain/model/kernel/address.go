@@ -0,0 +1,95 @@
+package kernel
+
+import (
+	"strings"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// Address - адрес доставки. Обязательна только улица: остальные части
+// уточняют точку, но старые события и Geo могут их не знать.
+type Address struct {
+	country   string
+	city      string
+	street    string
+	house     string
+	apartment string
+}
+
+func NewAddress(country, city, street, house, apartment string) (Address, error) {
+	street = strings.TrimSpace(street)
+	if street == "" {
+		return Address{}, errs.NewValueIsRequiredError("street")
+	}
+	return Address{
+		country:   strings.TrimSpace(country),
+		city:      strings.TrimSpace(city),
+		street:    street,
+		house:     strings.TrimSpace(house),
+		apartment: strings.TrimSpace(apartment),
+	}, nil
+}
+
+func MustNewAddress(country, city, street, house, apartment string) Address {
+	address, err := NewAddress(country, city, street, house, apartment)
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
+
+// NewStreetAddress - адрес, известный только по улице
+func NewStreetAddress(street string) (Address, error) {
+	return NewAddress("", "", street, "", "")
+}
+
+func (a Address) Country() string {
+	return a.country
+}
+
+func (a Address) City() string {
+	return a.city
+}
+
+func (a Address) Street() string {
+	return a.street
+}
+
+func (a Address) House() string {
+	return a.house
+}
+
+func (a Address) Apartment() string {
+	return a.apartment
+}
+
+func (a Address) Equals(other Address) bool {
+	return a == other
+}
+
+func (a Address) IsEmpty() bool {
+	return a == Address{}
+}
+
+// String - адрес одной строкой без пустых частей, например "Россия, Москва, Бажная, 1, кв. 2"
+func (a Address) String() string {
+	parts := make([]string, 0, 5)
+	for _, part := range []string{a.country, a.city, a.street, a.house} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if a.apartment != "" {
+		parts = append(parts, "кв. "+a.apartment)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Location не зависит от квартиры, поэтому ключ геокодирования строится без неё
+func (a Address) GeocodingKey() string {
+	parts := []string{a.country, a.city, a.street, a.house}
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.Join(strings.Fields(part), " "))
+	}
+	return strings.Join(parts, "|")
+}
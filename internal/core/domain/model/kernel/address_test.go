@@ -0,0 +1,51 @@
+package kernel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func TestNewAddress(t *testing.T) {
+	testCases := []struct {
+		name        string
+		street      string
+		expectError bool
+	}{
+		{name: "Valid street", street: "Бажная", expectError: false},
+		{name: "Empty street", street: "", expectError: true},
+		{name: "Blank street", street: "   ", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := kernel.NewAddress("Россия", "Москва", tc.street, "1", "2")
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddress_String(t *testing.T) {
+	full := kernel.MustNewAddress("Россия", "Москва", "Бажная", "1", "2")
+	assert.Equal(t, "Россия, Москва, Бажная, 1, кв. 2", full.String())
+
+	streetOnly := kernel.MustNewAddress("", "", "Бажная", "", "")
+	assert.Equal(t, "Бажная", streetOnly.String())
+}
+
+func TestAddress_GeocodingKey(t *testing.T) {
+	moscow := kernel.MustNewAddress("Россия", "Москва", "Бажная", "1", "2")
+	otherApartment := kernel.MustNewAddress("россия", " Москва ", "бажная", "1", "15")
+	otherCity := kernel.MustNewAddress("Россия", "Казань", "Бажная", "1", "2")
+	otherHouse := kernel.MustNewAddress("Россия", "Москва", "Бажная", "40", "2")
+
+	assert.Equal(t, moscow.GeocodingKey(), otherApartment.GeocodingKey())
+	assert.NotEqual(t, moscow.GeocodingKey(), otherCity.GeocodingKey())
+	assert.NotEqual(t, moscow.GeocodingKey(), otherHouse.GeocodingKey())
+}
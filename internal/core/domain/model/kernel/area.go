@@ -0,0 +1,126 @@
+package kernel
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// Границы сетки по умолчанию - город 10x10, с которого начинался сервис
+const (
+	DefaultAreaMin = 1
+	DefaultAreaMax = 10
+)
+
+var ErrLocationOutOfArea = errors.New("location is out of area")
+
+var _ Bounds = Area{}
+
+// Area - прямоугольная сетка города, границы включительно
+type Area struct {
+	min Location
+	max Location
+}
+
+func NewArea(minX, minY, maxX, maxY int) (Area, error) {
+	minLocation, err := NewLocation(minX, minY)
+	if err != nil {
+		return Area{}, err
+	}
+	maxLocation, err := NewLocation(maxX, maxY)
+	if err != nil {
+		return Area{}, err
+	}
+	if minX > maxX || minY > maxY {
+		return Area{}, fmt.Errorf("invalid area: min %s is greater than max %s", minLocation, maxLocation)
+	}
+	return Area{min: minLocation, max: maxLocation}, nil
+}
+
+func MustNewArea(minX, minY, maxX, maxY int) Area {
+	area, err := NewArea(minX, minY, maxX, maxY)
+	if err != nil {
+		panic(err)
+	}
+	return area
+}
+
+func DefaultArea() Area {
+	return MustNewArea(DefaultAreaMin, DefaultAreaMin, DefaultAreaMax, DefaultAreaMax)
+}
+
+// NewLocation - клетка этой сетки
+func (a Area) NewLocation(x, y int) (Location, error) {
+	location, err := NewLocation(x, y)
+	if err != nil {
+		return Location{}, err
+	}
+	if err := a.Validate(location); err != nil {
+		return Location{}, err
+	}
+	return location, nil
+}
+
+func (a Area) MustNewLocation(x, y int) Location {
+	location, err := a.NewLocation(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+func (a Area) Contains(location Location) bool {
+	return !location.IsEmpty() && !location.IsGeo() &&
+		location.X() >= a.min.X() && location.X() <= a.max.X() &&
+		location.Y() >= a.min.Y() && location.Y() <= a.max.Y()
+}
+
+func (a Area) Validate(location Location) error {
+	if !a.Contains(location) {
+		return fmt.Errorf("%w: %s is not in %s", ErrLocationOutOfArea, location, a)
+	}
+	return nil
+}
+
+// Clamp - ближайшая к location клетка сетки
+func (a Area) Clamp(location Location) Location {
+	x := min(max(location.X(), a.min.X()), a.max.X())
+	y := min(max(location.Y(), a.min.Y()), a.max.Y())
+	return Location{x: x, y: y, isSet: true}
+}
+
+func (a Area) RandomLocation() Location {
+	return Location{
+		x:     a.min.X() + rand.Intn(a.Width()),
+		y:     a.min.Y() + rand.Intn(a.Height()),
+		isSet: true,
+	}
+}
+
+func (a Area) Min() Location {
+	return a.min
+}
+
+func (a Area) Max() Location {
+	return a.max
+}
+
+func (a Area) Width() int {
+	return a.max.X() - a.min.X() + 1
+}
+
+func (a Area) Height() int {
+	return a.max.Y() - a.min.Y() + 1
+}
+
+func (a Area) Equals(other Area) bool {
+	return a.min.Equals(other.min) && a.max.Equals(other.max)
+}
+
+func (a Area) IsEmpty() bool {
+	return a.min.IsEmpty()
+}
+
+func (a Area) String() string {
+	return fmt.Sprintf("%s-%s", a.min, a.max)
+}
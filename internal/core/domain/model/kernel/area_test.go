@@ -0,0 +1,105 @@
+package kernel_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func TestNewArea(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		minX, minY, maxX, maxY int
+		expectError            bool
+	}{
+		{name: "Default grid", minX: 1, minY: 1, maxX: 10, maxY: 10},
+		{name: "Single cell", minX: 3, minY: 3, maxX: 3, maxY: 3},
+		{name: "Large grid from zero", minX: 0, minY: 0, maxX: 100000, maxY: 50000},
+		{name: "Min X greater than max X", minX: 5, minY: 1, maxX: 4, maxY: 10, expectError: true},
+		{name: "Min Y greater than max Y", minX: 1, minY: 5, maxX: 10, maxY: 4, expectError: true},
+		{name: "Negative bound", minX: -1, minY: 1, maxX: 10, maxY: 10, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			area, err := kernel.NewArea(tc.minX, tc.minY, tc.maxX, tc.maxY)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.maxX-tc.minX+1, area.Width())
+			assert.Equal(t, tc.maxY-tc.minY+1, area.Height())
+		})
+	}
+}
+
+func TestArea_NewLocationShouldRejectCellsOutsideGrid(t *testing.T) {
+	area := kernel.DefaultArea()
+
+	_, err := area.NewLocation(1, 10)
+	assert.NoError(t, err)
+	for _, cell := range [][2]int{{0, 5}, {5, 0}, {11, 5}, {5, 11}} {
+		_, err := area.NewLocation(cell[0], cell[1])
+		assert.ErrorIs(t, err, kernel.ErrLocationOutOfArea, "cell %v", cell)
+	}
+	assert.False(t, area.Contains(kernel.Location{}))
+}
+
+// gridCase - произвольная сетка и три клетки в ней, собранные из случайных чисел testing/quick
+type gridCase struct {
+	area    kernel.Area
+	a, b, c kernel.Location
+}
+
+func newGridCase(originX, originY uint8, width, height uint16, cells [6]uint32) gridCase {
+	area := kernel.MustNewArea(int(originX), int(originY), int(originX)+int(width), int(originY)+int(height))
+	cell := func(x, y uint32) kernel.Location {
+		return area.MustNewLocation(
+			area.Min().X()+int(x%uint32(area.Width())), area.Min().Y()+int(y%uint32(area.Height())))
+	}
+	return gridCase{
+		area: area,
+		a:    cell(cells[0], cells[1]),
+		b:    cell(cells[2], cells[3]),
+		c:    cell(cells[4], cells[5]),
+	}
+}
+
+func TestArea_DistanceProperties(t *testing.T) {
+	property := func(originX, originY uint8, width, height uint16, cells [6]uint32) bool {
+		g := newGridCase(originX, originY, width, height, cells)
+		ab, ba := g.a.DistanceTo(g.b), g.b.DistanceTo(g.a)
+		return ab == ba &&
+			g.a.DistanceTo(g.a) == 0 &&
+			(ab == 0) == g.a.Equals(g.b) &&
+			g.a.DistanceTo(g.c) <= ab+g.b.DistanceTo(g.c) &&
+			ab <= g.area.Width()-1+g.area.Height()-1
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestArea_ClampProperties(t *testing.T) {
+	property := func(originX, originY uint8, width, height uint16, x, y uint32) bool {
+		area := kernel.MustNewArea(int(originX), int(originY), int(originX)+int(width), int(originY)+int(height))
+		location := kernel.MustNewLocation(int(x%100000), int(y%100000))
+		clamped := area.Clamp(location)
+		// Клетка сетки не сдвигается, любая другая переносится на ближайшую клетку края
+		return area.Contains(clamped) &&
+			area.Clamp(clamped).Equals(clamped) &&
+			area.Contains(location) == clamped.Equals(location)
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestArea_RandomLocationShouldStayInside(t *testing.T) {
+	property := func(originX, originY uint8, width, height uint16) bool {
+		area := kernel.MustNewArea(int(originX), int(originY), int(originX)+int(width), int(originY)+int(height))
+		return area.Contains(area.RandomLocation())
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
@@ -0,0 +1,13 @@
+package kernel
+
+// Bounds - где могут находиться курьеры и заказы: клетки сетки Area или прямоугольник координат GeoBounds
+type Bounds interface {
+	Contains(location Location) bool
+	// Validate - ошибка ErrLocationOutOfArea, если location вне границ или другого вида
+	Validate(location Location) error
+	// Clamp - ближайшая к location точка внутри границ того же вида
+	Clamp(location Location) Location
+	RandomLocation() Location
+	IsEmpty() bool
+	String() string
+}
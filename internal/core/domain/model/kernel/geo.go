@@ -0,0 +1,99 @@
+package kernel
+
+import (
+	"errors"
+	"math"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// EarthRadiusMeters - средний радиус Земли для формулы гаверсинусов
+const EarthRadiusMeters = 6371008.8
+
+var ErrLocationKindMismatch = errors.New("grid and geographic locations cannot be mixed")
+
+// NewGeoLocation - точка WGS84, широта и долгота в градусах
+func NewGeoLocation(lat, lon float64) (Location, error) {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return Location{}, errs.NewValueIsOutOfRangeError("latitude", lat, -90, 90)
+	}
+	if math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return Location{}, errs.NewValueIsOutOfRangeError("longitude", lon, -180, 180)
+	}
+	return Location{lat: lat, lon: lon, geo: true, isSet: true}, nil
+}
+
+func MustNewGeoLocation(lat, lon float64) Location {
+	loc, err := NewGeoLocation(lat, lon)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+func (l Location) Lat() float64 {
+	return l.lat
+}
+
+func (l Location) Lon() float64 {
+	return l.lon
+}
+
+// IsGeo - точка WGS84, а не клетка сетки
+func (l Location) IsGeo() bool {
+	return l.geo
+}
+
+// SameKind - обе точки на сетке или обе в WGS84
+func (l Location) SameKind(other Location) bool {
+	return l.geo == other.geo
+}
+
+// DistanceMeters - расстояние по большому кругу между точками WGS84
+func (l Location) DistanceMeters(other Location) float64 {
+	return EarthRadiusMeters * l.angleTo(other)
+}
+
+// MoveTowards - точка на пути по большому кругу к target, отстоящая от l на meters.
+// Если до target ближе, возвращается сам target
+func (l Location) MoveTowards(target Location, meters float64) (Location, error) {
+	if !l.geo || !target.geo {
+		return Location{}, ErrLocationKindMismatch
+	}
+	angle := l.angleTo(target)
+	if meters >= EarthRadiusMeters*angle {
+		return target, nil
+	}
+	if meters <= 0 {
+		return l, nil
+	}
+
+	fraction := meters / (EarthRadiusMeters * angle)
+	lat1, lon1 := radians(l.lat), radians(l.lon)
+	lat2, lon2 := radians(target.lat), radians(target.lon)
+	a := math.Sin((1-fraction)*angle) / math.Sin(angle)
+	b := math.Sin(fraction*angle) / math.Sin(angle)
+	x := a*math.Cos(lat1)*math.Cos(lon1) + b*math.Cos(lat2)*math.Cos(lon2)
+	y := a*math.Cos(lat1)*math.Sin(lon1) + b*math.Cos(lat2)*math.Sin(lon2)
+	z := a*math.Sin(lat1) + b*math.Sin(lat2)
+	lat := math.Atan2(z, math.Sqrt(x*x+y*y))
+	lon := math.Atan2(y, x)
+	return NewGeoLocation(degrees(lat), degrees(lon))
+}
+
+// angleTo - центральный угол между точками в радианах по формуле гаверсинусов
+func (l Location) angleTo(other Location) float64 {
+	lat1, lat2 := radians(l.lat), radians(other.lat)
+	dLat := lat2 - lat1
+	dLon := radians(other.lon - l.lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
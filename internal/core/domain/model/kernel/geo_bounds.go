@@ -0,0 +1,96 @@
+package kernel
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+var _ Bounds = GeoBounds{}
+
+// GeoBounds - прямоугольник координат WGS84, в который попадает город. Через антимеридиан не переходит
+type GeoBounds struct {
+	southWest Location
+	northEast Location
+}
+
+func NewGeoBounds(south, west, north, east float64) (GeoBounds, error) {
+	southWest, err := NewGeoLocation(south, west)
+	if err != nil {
+		return GeoBounds{}, err
+	}
+	northEast, err := NewGeoLocation(north, east)
+	if err != nil {
+		return GeoBounds{}, err
+	}
+	if south > north || west > east {
+		return GeoBounds{}, fmt.Errorf("invalid geo bounds: south-west %s is not below north-east %s",
+			southWest, northEast)
+	}
+	return GeoBounds{southWest: southWest, northEast: northEast}, nil
+}
+
+func MustNewGeoBounds(south, west, north, east float64) GeoBounds {
+	bounds, err := NewGeoBounds(south, west, north, east)
+	if err != nil {
+		panic(err)
+	}
+	return bounds
+}
+
+// DefaultGeoBounds - центр Москвы
+func DefaultGeoBounds() GeoBounds {
+	return MustNewGeoBounds(55.70, 37.50, 55.80, 37.70)
+}
+
+func (b GeoBounds) Contains(location Location) bool {
+	return !location.IsEmpty() && location.IsGeo() &&
+		location.Lat() >= b.southWest.Lat() && location.Lat() <= b.northEast.Lat() &&
+		location.Lon() >= b.southWest.Lon() && location.Lon() <= b.northEast.Lon()
+}
+
+func (b GeoBounds) Validate(location Location) error {
+	if !b.Contains(location) {
+		return fmt.Errorf("%w: %s is not in %s", ErrLocationOutOfArea, location, b)
+	}
+	return nil
+}
+
+func (b GeoBounds) Clamp(location Location) Location {
+	lat := min(max(location.Lat(), b.southWest.Lat()), b.northEast.Lat())
+	lon := min(max(location.Lon(), b.southWest.Lon()), b.northEast.Lon())
+	return Location{lat: lat, lon: lon, geo: true, isSet: true}
+}
+
+// LocationAt - точка по долям ширины и высоты прямоугольника, от 0 (юго-запад) до 1 (северо-восток)
+func (b GeoBounds) LocationAt(eastFraction, northFraction float64) Location {
+	return b.Clamp(Location{
+		lat:   b.southWest.Lat() + northFraction*(b.northEast.Lat()-b.southWest.Lat()),
+		lon:   b.southWest.Lon() + eastFraction*(b.northEast.Lon()-b.southWest.Lon()),
+		geo:   true,
+		isSet: true,
+	})
+}
+
+func (b GeoBounds) RandomLocation() Location {
+	return b.LocationAt(rand.Float64(), rand.Float64())
+}
+
+func (b GeoBounds) SouthWest() Location {
+	return b.southWest
+}
+
+func (b GeoBounds) NorthEast() Location {
+	return b.northEast
+}
+
+func (b GeoBounds) Equals(other GeoBounds) bool {
+	return b.southWest.Equals(other.southWest) && b.northEast.Equals(other.northEast)
+}
+
+func (b GeoBounds) IsEmpty() bool {
+	return b.southWest.IsEmpty()
+}
+
+func (b GeoBounds) String() string {
+	return fmt.Sprintf("%s-%s", b.southWest, b.northEast)
+}
@@ -0,0 +1,94 @@
+package kernel_test
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func TestNewGeoLocation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lat, lon    float64
+		expectError bool
+	}{
+		{name: "Moscow", lat: 55.7539, lon: 37.6208},
+		{name: "Poles and antimeridian", lat: -90, lon: 180},
+		{name: "Latitude above 90", lat: 90.1, lon: 0, expectError: true},
+		{name: "Longitude below -180", lat: 0, lon: -180.1, expectError: true},
+		{name: "NaN", lat: math.NaN(), lon: 0, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			location, err := kernel.NewGeoLocation(tc.lat, tc.lon)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, location.IsGeo())
+			assert.Equal(t, tc.lat, location.Lat())
+			assert.Equal(t, tc.lon, location.Lon())
+		})
+	}
+}
+
+func TestLocation_DistanceToShouldUseHaversineForGeoLocations(t *testing.T) {
+	redSquare := kernel.MustNewGeoLocation(55.7539, 37.6208)
+	palaceSquare := kernel.MustNewGeoLocation(59.9391, 30.3159)
+
+	// Москва - Петербург по прямой около 634 км
+	assert.InDelta(t, 634_000, redSquare.DistanceTo(palaceSquare), 2_000)
+	assert.Equal(t, redSquare.DistanceTo(palaceSquare), palaceSquare.DistanceTo(redSquare))
+	// Градус меридиана - около 111,2 км
+	assert.InDelta(t, 111_195, kernel.MustNewGeoLocation(0, 0).DistanceMeters(kernel.MustNewGeoLocation(1, 0)), 1)
+
+	// Клетку и точку WGS84 сравнить нельзя
+	assert.Equal(t, math.MaxInt, redSquare.DistanceTo(kernel.MustNewLocation(1, 1)))
+	assert.False(t, redSquare.Equals(kernel.MustNewLocation(0, 0)))
+}
+
+func TestLocation_MoveTowardsShouldFollowGreatCircle(t *testing.T) {
+	property := func(fromFraction, toFraction [2]uint16, meters uint16) bool {
+		bounds := kernel.DefaultGeoBounds()
+		from := bounds.LocationAt(float64(fromFraction[0])/math.MaxUint16, float64(fromFraction[1])/math.MaxUint16)
+		to := bounds.LocationAt(float64(toFraction[0])/math.MaxUint16, float64(toFraction[1])/math.MaxUint16)
+
+		next, err := from.MoveTowards(to, float64(meters))
+		if err != nil {
+			return false
+		}
+		total := from.DistanceMeters(to)
+		if float64(meters) >= total {
+			return next.Equals(to)
+		}
+		// Точка лежит на пути: прошли ровно meters и осталось total - meters
+		return math.Abs(from.DistanceMeters(next)-float64(meters)) < 0.01 &&
+			math.Abs(from.DistanceMeters(next)+next.DistanceMeters(to)-total) < 0.01
+	}
+	require.NoError(t, quick.Check(property, nil))
+
+	_, err := kernel.MustNewGeoLocation(55.75, 37.62).MoveTowards(kernel.MustNewLocation(1, 1), 100)
+	assert.ErrorIs(t, err, kernel.ErrLocationKindMismatch)
+}
+
+func TestGeoBounds(t *testing.T) {
+	_, err := kernel.NewGeoBounds(55.8, 37.5, 55.7, 37.7)
+	assert.Error(t, err)
+
+	bounds := kernel.DefaultGeoBounds()
+	assert.True(t, bounds.Contains(bounds.RandomLocation()))
+	assert.NoError(t, bounds.Validate(kernel.MustNewGeoLocation(55.75, 37.62)))
+	assert.ErrorIs(t, bounds.Validate(kernel.MustNewGeoLocation(59.94, 30.32)), kernel.ErrLocationOutOfArea)
+	assert.ErrorIs(t, bounds.Validate(kernel.MustNewLocation(1, 1)), kernel.ErrLocationOutOfArea)
+	assert.ErrorIs(t, kernel.DefaultArea().Validate(kernel.MustNewGeoLocation(55.75, 37.62)), kernel.ErrLocationOutOfArea)
+
+	clamped := bounds.Clamp(kernel.MustNewGeoLocation(59.94, 30.32))
+	assert.True(t, clamped.Equals(kernel.MustNewGeoLocation(55.80, 37.50)))
+}
@@ -2,28 +2,29 @@ package kernel
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
+	"math"
 
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
-const (
-	COORDINATE_MIN = 1
-	COORDINATE_MAX = 10
-)
-
+// Location - клетка на сетке города или точка WGS84, см. NewGeoLocation. Сама по себе знает только,
+// что координаты допустимы, в какие границы она должна попадать, решают Area и GeoBounds
 type Location struct {
 	x int
 	y int
+
+	lat float64
+	lon float64
+	geo bool
+
+	isSet bool
 }
 
 func NewLocation(x, y int) (Location, error) {
-	if x < COORDINATE_MIN || x > COORDINATE_MAX ||
-		y < COORDINATE_MIN || y > COORDINATE_MAX {
-		return Location{}, errs.NewValueIsInvalidError("coordinates must be between 1 and 10 inclusive")
+	if x < 0 || y < 0 {
+		return Location{}, errs.NewValueIsInvalidError("coordinates must not be negative")
 	}
-	return Location{x: x, y: y}, nil
+	return Location{x: x, y: y, isSet: true}, nil
 }
 
 func MustNewLocation(x, y int) Location {
@@ -43,31 +44,33 @@ func (l Location) Y() int {
 }
 
 func (l Location) Equals(other Location) bool {
-	return l.x == other.x && l.y == other.y
+	return l.geo == other.geo && l.x == other.x && l.y == other.y && l.lat == other.lat && l.lon == other.lon
 }
 
+// DistanceTo - манхэттенское расстояние в клетках для сетки и расстояние по большому кругу в метрах для WGS84.
+// Клетку и точку WGS84 сравнить нельзя, для них расстояние math.MaxInt
 func (l Location) DistanceTo(other Location) int {
+	if l.geo != other.geo {
+		return math.MaxInt
+	}
+	if l.geo {
+		return int(math.Round(l.DistanceMeters(other)))
+	}
 	dx := abs(l.x - other.x)
 	dy := abs(l.y - other.y)
 	return dx + dy
 }
 
 func (l Location) String() string {
+	if l.geo {
+		return fmt.Sprintf("(%.6f,%.6f)", l.lat, l.lon)
+	}
 	return fmt.Sprintf("(%d,%d)", l.x, l.y)
 }
 
+// CreateRandomLocation - случайная клетка сетки по умолчанию
 func CreateRandomLocation() Location {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	x := r.Intn(COORDINATE_MAX) + COORDINATE_MIN
-	y := r.Intn(COORDINATE_MAX) + COORDINATE_MIN
-
-	location, err := NewLocation(x, y)
-	if err != nil {
-		fmt.Println(err)
-	}
-
-	return location
+	return DefaultArea().RandomLocation()
 }
 
 func abs(n int) int {
@@ -78,13 +81,13 @@ func abs(n int) int {
 }
 
 func (l Location) IsEmpty() bool {
-	return l == Location{}
+	return !l.isSet
 }
 
 func MaxLocation() (Location, error) {
-	return NewLocation(COORDINATE_MAX, COORDINATE_MAX)
+	return DefaultArea().Max(), nil
 }
 
 func MinLocation() (Location, error) {
-	return NewLocation(COORDINATE_MIN, COORDINATE_MIN)
+	return DefaultArea().Min(), nil
 }
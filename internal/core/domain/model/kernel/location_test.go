@@ -32,27 +32,27 @@ func TestNewLocation(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name:        "Invalid X coordinate (too small)",
+			name:        "Valid coordinates (zero)",
 			x:           0,
-			y:           5,
-			expectError: true,
-		},
-		{
-			name:        "Invalid Y coordinate (too small)",
-			x:           5,
 			y:           0,
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name:        "Invalid X coordinate (too large)",
+			name:        "Valid coordinates (outside default area)",
 			x:           11,
+			y:           1000,
+			expectError: false,
+		},
+		{
+			name:        "Invalid X coordinate (negative)",
+			x:           -1,
 			y:           5,
 			expectError: true,
 		},
 		{
-			name:        "Invalid Y coordinate (too large)",
+			name:        "Invalid Y coordinate (negative)",
 			x:           5,
-			y:           11,
+			y:           -1,
 			expectError: true,
 		},
 	}
@@ -0,0 +1,56 @@
+package kernel
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultRegionCode - регион сервиса, работающего в одном городе, и всех данных, созданных до регионов
+const DefaultRegionCode = "default"
+
+const maxRegionCodeLength = 32
+
+var ErrInvalidRegionCode = errors.New("region code must be 1-32 latin letters, digits, '-' or '_'")
+
+// RegionCode - код города или региона, например "msk". Курьеры и заказы разных регионов друг друга не видят
+type RegionCode struct {
+	code string
+}
+
+// NewRegionCode - код без учёта регистра и пробелов по краям
+func NewRegionCode(code string) (RegionCode, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" || len(code) > maxRegionCodeLength {
+		return RegionCode{}, ErrInvalidRegionCode
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return RegionCode{}, ErrInvalidRegionCode
+		}
+	}
+	return RegionCode{code: code}, nil
+}
+
+func MustNewRegionCode(code string) RegionCode {
+	region, err := NewRegionCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return region
+}
+
+func DefaultRegion() RegionCode {
+	return RegionCode{code: DefaultRegionCode}
+}
+
+func (r RegionCode) String() string {
+	return r.code
+}
+
+func (r RegionCode) Equals(other RegionCode) bool {
+	return r == other
+}
+
+func (r RegionCode) IsEmpty() bool {
+	return r.code == ""
+}
@@ -0,0 +1,44 @@
+package kernel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func TestNewRegionCode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		expected    string
+		expectError bool
+	}{
+		{name: "Lower case", code: "msk", expected: "msk"},
+		{name: "Trimmed and lowered", code: " KZN ", expected: "kzn"},
+		{name: "Digits, dash and underscore", code: "spb-2_north", expected: "spb-2_north"},
+		{name: "Empty", code: "  ", expectError: true},
+		{name: "Cyrillic", code: "мск", expectError: true},
+		{name: "Space inside", code: "new york", expectError: true},
+		{name: "Too long", code: "abcdefghijklmnopqrstuvwxyz0123456", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			region, err := kernel.NewRegionCode(tc.code)
+			if tc.expectError {
+				assert.ErrorIs(t, err, kernel.ErrInvalidRegionCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, region.String())
+		})
+	}
+}
+
+func TestDefaultRegion(t *testing.T) {
+	assert.True(t, kernel.DefaultRegion().Equals(kernel.MustNewRegionCode("Default")))
+	assert.True(t, kernel.RegionCode{}.IsEmpty())
+}
@@ -0,0 +1,156 @@
+package order
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+var _ ddd.DomainEvent = StatusChangedDomainEvent{}
+
+const StatusChangedEventName = "order.status_changed"
+
+// StatusChangedDomainEvent - заказ перешёл в новый статус
+type StatusChangedDomainEvent struct {
+	id         uuid.UUID
+	orderID    uuid.UUID
+	status     Status
+	courierID  *uuid.UUID
+	occurredAt time.Time
+}
+
+func NewStatusChangedDomainEvent(orderID uuid.UUID, status Status, courierID *uuid.UUID) StatusChangedDomainEvent {
+	return StatusChangedDomainEvent{
+		id:         uuid.New(),
+		orderID:    orderID,
+		status:     status,
+		courierID:  courierID,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e StatusChangedDomainEvent) EventID() uuid.UUID {
+	return e.id
+}
+
+func (e StatusChangedDomainEvent) EventName() string {
+	return StatusChangedEventName
+}
+
+func (e StatusChangedDomainEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e StatusChangedDomainEvent) OrderID() uuid.UUID {
+	return e.orderID
+}
+
+func (e StatusChangedDomainEvent) Status() Status {
+	return e.status
+}
+
+func (e StatusChangedDomainEvent) CourierID() *uuid.UUID {
+	return e.courierID
+}
+
+var _ ddd.DomainEvent = SLABreachedDomainEvent{}
+
+const SLABreachedEventName = "order.sla_breached"
+
+// SLABreachedDomainEvent - заказ не прошёл этап stage к сроку deadline
+type SLABreachedDomainEvent struct {
+	id         uuid.UUID
+	orderID    uuid.UUID
+	tier       Tier
+	stage      SLAStage
+	deadline   time.Time
+	occurredAt time.Time
+}
+
+func NewSLABreachedDomainEvent(orderID uuid.UUID, tier Tier, stage SLAStage, deadline time.Time) SLABreachedDomainEvent {
+	return SLABreachedDomainEvent{
+		id:         uuid.New(),
+		orderID:    orderID,
+		tier:       tier,
+		stage:      stage,
+		deadline:   deadline,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e SLABreachedDomainEvent) EventID() uuid.UUID {
+	return e.id
+}
+
+func (e SLABreachedDomainEvent) EventName() string {
+	return SLABreachedEventName
+}
+
+func (e SLABreachedDomainEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e SLABreachedDomainEvent) OrderID() uuid.UUID {
+	return e.orderID
+}
+
+func (e SLABreachedDomainEvent) Tier() Tier {
+	return e.tier
+}
+
+func (e SLABreachedDomainEvent) Stage() SLAStage {
+	return e.stage
+}
+
+func (e SLABreachedDomainEvent) Deadline() time.Time {
+	return e.deadline
+}
+
+var _ ddd.DomainEvent = UnassignedDomainEvent{}
+
+const UnassignedEventName = "order.unassigned"
+
+// UnassignedDomainEvent - заказ снят с курьера courierID и снова ждёт назначения
+type UnassignedDomainEvent struct {
+	id         uuid.UUID
+	orderID    uuid.UUID
+	courierID  uuid.UUID
+	reason     UnassignReason
+	occurredAt time.Time
+}
+
+func NewUnassignedDomainEvent(orderID uuid.UUID, courierID uuid.UUID, reason UnassignReason) UnassignedDomainEvent {
+	return UnassignedDomainEvent{
+		id:         uuid.New(),
+		orderID:    orderID,
+		courierID:  courierID,
+		reason:     reason,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e UnassignedDomainEvent) EventID() uuid.UUID {
+	return e.id
+}
+
+func (e UnassignedDomainEvent) EventName() string {
+	return UnassignedEventName
+}
+
+func (e UnassignedDomainEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e UnassignedDomainEvent) OrderID() uuid.UUID {
+	return e.orderID
+}
+
+func (e UnassignedDomainEvent) CourierID() uuid.UUID {
+	return e.courierID
+}
+
+func (e UnassignedDomainEvent) Reason() UnassignReason {
+	return e.reason
+}
@@ -2,71 +2,229 @@ package order
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
 type Status string
 
 const (
-	StatusCreated   Status = "created"
-	StatusAssigned  Status = "assigned"
-	StatusCompleted Status = "completed"
+	StatusPendingGeocode Status = "pending_geocode"
+	StatusCreated        Status = "created"
+	StatusAssigned       Status = "assigned"
+	StatusCompleted      Status = "completed"
+	StatusCancelled      Status = "cancelled"
 )
 
+// UnassignReason - почему заказ сняли с курьера
+type UnassignReason string
+
+const (
+	// UnassignReasonCourierOffline - курьер перестал выходить на связь
+	UnassignReasonCourierOffline UnassignReason = "courier_offline"
+	// UnassignReasonReassigned - диспетчер передал заказ другому курьеру
+	UnassignReasonReassigned UnassignReason = "reassigned"
+)
+
+// IsFinal - заказ в этом статусе больше не меняется
+func (s Status) IsFinal() bool {
+	return s == StatusCompleted || s == StatusCancelled
+}
+
 type Order struct {
-	id        uuid.UUID
+	ddd.AggregateRoot
+
+	id     uuid.UUID
+	region kernel.RegionCode
+	tier   Tier
+	// window - интервал доставки, задан только у заказа к интервалу
+	window    DeliveryWindow
+	address   kernel.Address
 	location  kernel.Location
 	status    Status
 	courierID *uuid.UUID
+	createdAt time.Time
+
+	assignedAt           *time.Time
+	pickedUpAt           *time.Time
+	completedAt          *time.Time
+	assignSLABreachedAt  *time.Time
+	deliverSLABreachedAt *time.Time
+	// unassignReason - почему заказ последний раз сняли с курьера, пусто - не снимали
+	unassignReason UnassignReason
+}
+
+// Timestamps - моменты жизни заказа, nil - ещё не наступил
+type Timestamps struct {
+	CreatedAt   time.Time
+	AssignedAt  *time.Time
+	PickedUpAt  *time.Time
+	CompletedAt *time.Time
+	// AssignSLABreachedAt, DeliverSLABreachedAt - когда замечено нарушение срока назначения и доставки
+	AssignSLABreachedAt  *time.Time
+	DeliverSLABreachedAt *time.Time
 }
 
 var (
 	ErrOrderAlreadyAssigned = errors.New("order is already assigned to courier")
 	ErrOrderNotAssigned     = errors.New("order is not assigned to courier")
 	ErrOrderCompleted       = errors.New("order is already completed")
+	ErrOrderCancelled       = errors.New("order is cancelled")
 	ErrInvalidLocation      = errors.New("invalid Location")
 	ErrInvalidOrderId       = errors.New("invalid order id")
+	ErrInvalidAddress       = errors.New("invalid address")
+	ErrOrderNotGeocoded     = errors.New("order location is not resolved yet")
+	ErrOrderAlreadyGeocoded = errors.New("order location is already resolved")
 )
 
-func NewOrder(id uuid.UUID, location kernel.Location) (*Order, error) {
+func NewOrder(id uuid.UUID, address kernel.Address, location kernel.Location) (*Order, error) {
+	return NewOrderInRegion(id, kernel.DefaultRegion(), TierStandard, DeliveryWindow{}, address, location)
+}
+
+// NewOrderInRegion - заказ уровня tier, который получат только курьеры его региона.
+// window задаётся только заказу к интервалу
+func NewOrderInRegion(id uuid.UUID, region kernel.RegionCode, tier Tier, window DeliveryWindow,
+	address kernel.Address, location kernel.Location) (*Order, error) {
 	if id == uuid.Nil {
 		return nil, ErrInvalidOrderId
 	}
 
+	if region.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("region")
+	}
+
+	if !tier.IsValid() {
+		return nil, errs.NewValueIsInvalidError("tier")
+	}
+
+	if address.IsEmpty() {
+		return nil, ErrInvalidAddress
+	}
+
 	if location.IsEmpty() {
 		return nil, ErrInvalidLocation
 	}
 
-	return &Order{
+	now := time.Now().UTC()
+	err := validateDeliveryWindow(tier, window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Order{
 		id:        id,
+		region:    region,
+		tier:      tier,
+		window:    window,
+		address:   address,
 		location:  location,
 		status:    StatusCreated,
 		courierID: nil,
-	}, nil
+		createdAt: now,
+	}
+	o.raiseStatusChanged()
+	return o, nil
 }
 
-func MustNewOrder(id uuid.UUID, location kernel.Location) *Order {
-	t, err := NewOrder(id, location)
+func MustNewOrder(id uuid.UUID, address kernel.Address, location kernel.Location) *Order {
+	t, err := NewOrder(id, address, location)
 	if err != nil {
 		panic(err)
 	}
 	return t
 }
 
+// NewPendingGeocodeOrder - создать заказ, геопозиция которого будет определена позже
+func NewPendingGeocodeOrder(id uuid.UUID, address kernel.Address) (*Order, error) {
+	return NewPendingGeocodeOrderInRegion(id, kernel.DefaultRegion(), TierStandard, DeliveryWindow{}, address)
+}
+
+// NewPendingGeocodeOrderInRegion - отложенный заказ региона
+func NewPendingGeocodeOrderInRegion(id uuid.UUID, region kernel.RegionCode, tier Tier, window DeliveryWindow,
+	address kernel.Address) (*Order, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidOrderId
+	}
+
+	if region.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("region")
+	}
+
+	if !tier.IsValid() {
+		return nil, errs.NewValueIsInvalidError("tier")
+	}
+
+	if address.IsEmpty() {
+		return nil, ErrInvalidAddress
+	}
+
+	now := time.Now().UTC()
+	err := validateDeliveryWindow(tier, window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Order{
+		id:        id,
+		region:    region,
+		tier:      tier,
+		window:    window,
+		address:   address,
+		status:    StatusPendingGeocode,
+		courierID: nil,
+		createdAt: now,
+	}
+	o.raiseStatusChanged()
+	return o, nil
+}
+
+// ResolveLocation - задать геопозицию отложенного заказа и сделать его доступным для назначения
+func (o *Order) ResolveLocation(location kernel.Location) error {
+	if !o.IsPendingGeocode() {
+		return ErrOrderAlreadyGeocoded
+	}
+
+	if location.IsEmpty() {
+		return ErrInvalidLocation
+	}
+
+	o.location = location
+	o.status = StatusCreated
+	o.raiseStatusChanged()
+
+	return nil
+}
+
 func (o *Order) AssignToCourier(courierId uuid.UUID) error {
 	if o.IsCompleted() {
 		return ErrOrderCompleted
 	}
 
-	if o.IsAssigned() && *o.courierID != courierId {
-		return ErrOrderAlreadyAssigned
+	if o.IsCancelled() {
+		return ErrOrderCancelled
+	}
+
+	if o.IsPendingGeocode() {
+		return ErrOrderNotGeocoded
 	}
 
+	if o.IsAssigned() {
+		if *o.courierID != courierId {
+			return ErrOrderAlreadyAssigned
+		}
+		return nil
+	}
+
+	now := time.Now().UTC()
 	o.status = StatusAssigned
 	o.courierID = &courierId
+	o.assignedAt = &now
+	o.raiseStatusChanged()
 
 	return nil
 }
@@ -76,11 +234,67 @@ func (o *Order) Complete() error {
 		return ErrOrderNotAssigned
 	}
 
+	now := time.Now().UTC()
 	o.status = StatusCompleted
+	o.completedAt = &now
+	o.raiseStatusChanged()
 
 	return nil
 }
 
+// Unassign - снять заказ с курьера и вернуть в очередь на назначение. Курьера освобождает вызывающий
+func (o *Order) Unassign(reason UnassignReason) error {
+	if !o.IsAssigned() {
+		return ErrOrderNotAssigned
+	}
+	if reason == "" {
+		return errs.NewValueIsRequiredError("reason")
+	}
+
+	courierID := *o.courierID
+	o.status = StatusCreated
+	o.courierID = nil
+	o.assignedAt = nil
+	o.pickedUpAt = nil
+	o.unassignReason = reason
+	o.RaiseDomainEvent(NewUnassignedDomainEvent(o.id, courierID, reason))
+
+	return nil
+}
+
+// PickUp - курьер забрал заказ. Склада в модели нет, поэтому заказ считается забранным с первым ходом
+// назначенного курьера. Повторный вызов ничего не меняет
+func (o *Order) PickUp() error {
+	if !o.IsAssigned() {
+		return ErrOrderNotAssigned
+	}
+	if o.pickedUpAt == nil {
+		now := time.Now().UTC()
+		o.pickedUpAt = &now
+	}
+	return nil
+}
+
+// Cancel - отменить заказ. Назначенного курьера освобождает вызывающий
+func (o *Order) Cancel() error {
+	if o.IsCompleted() {
+		return ErrOrderCompleted
+	}
+
+	if o.IsCancelled() {
+		return ErrOrderCancelled
+	}
+
+	o.status = StatusCancelled
+	o.raiseStatusChanged()
+
+	return nil
+}
+
+func (o *Order) raiseStatusChanged() {
+	o.RaiseDomainEvent(NewStatusChangedDomainEvent(o.id, o.status, o.courierID))
+}
+
 func (o *Order) Location() kernel.Location {
 	return o.location
 }
@@ -89,10 +303,71 @@ func (o *Order) ID() uuid.UUID {
 	return o.id
 }
 
+func (o *Order) Region() kernel.RegionCode {
+	return o.region
+}
+
+func (o *Order) Tier() Tier {
+	return o.tier
+}
+
+// DeliveryWindow - пустой у заказов не к интервалу
+func (o *Order) DeliveryWindow() DeliveryWindow {
+	return o.window
+}
+
+// WaitsForDeliveryWindow - заказ к интервалу не назначается курьеру, пока интервал не начался
+func (o *Order) WaitsForDeliveryWindow(now time.Time) bool {
+	return !o.window.IsEmpty() && !o.window.IsOpened(now)
+}
+
+func (o *Order) Address() kernel.Address {
+	return o.address
+}
+
 func (o *Order) Status() Status {
 	return o.status
 }
 
+func (o *Order) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+func (o *Order) AssignedAt() *time.Time {
+	return copyTime(o.assignedAt)
+}
+
+func (o *Order) PickedUpAt() *time.Time {
+	return copyTime(o.pickedUpAt)
+}
+
+func (o *Order) CompletedAt() *time.Time {
+	return copyTime(o.completedAt)
+}
+
+func (o *Order) Timestamps() Timestamps {
+	return Timestamps{
+		CreatedAt:            o.createdAt,
+		AssignedAt:           copyTime(o.assignedAt),
+		PickedUpAt:           copyTime(o.pickedUpAt),
+		CompletedAt:          copyTime(o.completedAt),
+		AssignSLABreachedAt:  copyTime(o.assignSLABreachedAt),
+		DeliverSLABreachedAt: copyTime(o.deliverSLABreachedAt),
+	}
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := *t
+	return &value
+}
+
+func (o *Order) UnassignReason() UnassignReason {
+	return o.unassignReason
+}
+
 func (o *Order) AssignedCourier() *uuid.UUID {
 	return o.courierID
 }
@@ -104,3 +379,11 @@ func (o *Order) IsAssigned() bool {
 func (o *Order) IsCompleted() bool {
 	return o.status == StatusCompleted
 }
+
+func (o *Order) IsCancelled() bool {
+	return o.status == StatusCancelled
+}
+
+func (o *Order) IsPendingGeocode() bool {
+	return o.status == StatusPendingGeocode
+}
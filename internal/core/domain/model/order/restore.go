@@ -6,11 +6,24 @@ import (
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 )
 
-func RestoreOrder(ID uuid.UUID, courierID *uuid.UUID, location kernel.Location, status Status) *Order {
+func RestoreOrder(ID uuid.UUID, region kernel.RegionCode, tier Tier, window DeliveryWindow, courierID *uuid.UUID,
+	address kernel.Address, location kernel.Location, status Status, timestamps Timestamps,
+	unassignReason UnassignReason) *Order {
 	return &Order{
-		id:        ID,
-		courierID: courierID,
-		location:  location,
-		status:    status,
+		id:                   ID,
+		region:               region,
+		tier:                 tier,
+		window:               window,
+		courierID:            courierID,
+		address:              address,
+		location:             location,
+		status:               status,
+		createdAt:            timestamps.CreatedAt,
+		assignedAt:           copyTime(timestamps.AssignedAt),
+		pickedUpAt:           copyTime(timestamps.PickedUpAt),
+		completedAt:          copyTime(timestamps.CompletedAt),
+		assignSLABreachedAt:  copyTime(timestamps.AssignSLABreachedAt),
+		deliverSLABreachedAt: copyTime(timestamps.DeliverSLABreachedAt),
+		unassignReason:       unassignReason,
 	}
 }
@@ -0,0 +1,132 @@
+package order
+
+import (
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// SLAStage - срок, за который отвечает политика: назначить курьера или доставить заказ
+type SLAStage string
+
+const (
+	SLAStageAssign  SLAStage = "assign"
+	SLAStageDeliver SLAStage = "deliver"
+)
+
+// SLALimits - сколько с момента создания заказа можно ждать назначения и доставки
+type SLALimits struct {
+	Assign  time.Duration
+	Deliver time.Duration
+}
+
+func (l SLALimits) validate(name string) error {
+	if l.Assign <= 0 || l.Deliver <= 0 || l.Assign > l.Deliver {
+		return errs.NewValueIsInvalidError(name)
+	}
+	return nil
+}
+
+// SLAPolicy - сроки назначения и доставки для каждого уровня заказа
+type SLAPolicy struct {
+	limits map[Tier]SLALimits
+}
+
+// NewSLAPolicy - сроки должны быть положительными, назначение - не позже доставки
+func NewSLAPolicy(express, standard, scheduled SLALimits) (SLAPolicy, error) {
+	if err := express.validate("express"); err != nil {
+		return SLAPolicy{}, err
+	}
+	if err := standard.validate("standard"); err != nil {
+		return SLAPolicy{}, err
+	}
+	if err := scheduled.validate("scheduled"); err != nil {
+		return SLAPolicy{}, err
+	}
+	return SLAPolicy{limits: map[Tier]SLALimits{
+		TierExpress:   express,
+		TierStandard:  standard,
+		TierScheduled: scheduled,
+	}}, nil
+}
+
+// DefaultSLAPolicy - экспресс: курьер за 10 минут, доставка за 30; обычная: 20 минут и час; к интервалу: час и 4 часа
+func DefaultSLAPolicy() SLAPolicy {
+	policy, _ := NewSLAPolicy(
+		SLALimits{Assign: 10 * time.Minute, Deliver: 30 * time.Minute},
+		SLALimits{Assign: 20 * time.Minute, Deliver: time.Hour},
+		SLALimits{Assign: time.Hour, Deliver: 4 * time.Hour},
+	)
+	return policy
+}
+
+func (p SLAPolicy) Limits(tier Tier) SLALimits {
+	return p.limits[tier]
+}
+
+func (p SLAPolicy) IsEmpty() bool {
+	return len(p.limits) == 0
+}
+
+// Deadline - к какому времени заказ должен пройти этап stage. Заказ с интервалом доставки отсчитывает срок
+// назначения от начала интервала и должен быть доставлен до его конца
+func (p SLAPolicy) Deadline(o *Order, stage SLAStage) time.Time {
+	limits := p.Limits(o.tier)
+	if !o.window.IsEmpty() {
+		if stage == SLAStageAssign {
+			assignBy := o.window.From().Add(limits.Assign)
+			if assignBy.After(o.window.To()) {
+				return o.window.To()
+			}
+			return assignBy
+		}
+		return o.window.To()
+	}
+	if stage == SLAStageAssign {
+		return o.createdAt.Add(limits.Assign)
+	}
+	return o.createdAt.Add(limits.Deliver)
+}
+
+// Next - ближайший ещё не пройденный этап заказа и его срок. false - заказ доставлен или отменён
+func (p SLAPolicy) Next(o *Order) (SLAStage, time.Time, bool) {
+	if o.status.IsFinal() {
+		return "", time.Time{}, false
+	}
+	if o.assignedAt == nil {
+		return SLAStageAssign, p.Deadline(o, SLAStageAssign), true
+	}
+	return SLAStageDeliver, p.Deadline(o, SLAStageDeliver), true
+}
+
+// CheckSLA - отметить сроки, нарушенные к моменту now. SLABreachedDomainEvent поднимается по каждому сроку
+// один раз. Назначение, случившееся после срока, тоже нарушение. true - найдены новые нарушения
+func (o *Order) CheckSLA(policy SLAPolicy, now time.Time) bool {
+	if o.status.IsFinal() {
+		return false
+	}
+	now = now.UTC()
+
+	breached := false
+	if o.assignSLABreachedAt == nil {
+		deadline := policy.Deadline(o, SLAStageAssign)
+		reachedAt := now
+		if o.assignedAt != nil {
+			reachedAt = *o.assignedAt
+		}
+		if reachedAt.After(deadline) {
+			o.assignSLABreachedAt = &now
+			o.RaiseDomainEvent(NewSLABreachedDomainEvent(o.id, o.tier, SLAStageAssign, deadline))
+			breached = true
+		}
+	}
+	if o.deliverSLABreachedAt == nil {
+		deadline := policy.Deadline(o, SLAStageDeliver)
+		if now.After(deadline) {
+			o.deliverSLABreachedAt = &now
+			o.RaiseDomainEvent(NewSLABreachedDomainEvent(o.id, o.tier, SLAStageDeliver, deadline))
+			breached = true
+		}
+	}
+	return breached
+}
@@ -0,0 +1,170 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func restoreOrderForSLA(tier Tier, window DeliveryWindow, status Status, timestamps Timestamps) *Order {
+	var courierID *uuid.UUID
+	if status == StatusAssigned {
+		id := uuid.New()
+		courierID = &id
+	}
+	return RestoreOrder(uuid.New(), kernel.DefaultRegion(), tier, window, courierID,
+		kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(1, 1), status, timestamps, "")
+}
+
+func TestSLAPolicy_Deadline(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	policy := DefaultSLAPolicy()
+
+	testCases := []struct {
+		name            string
+		tier            Tier
+		window          DeliveryWindow
+		expectedAssign  time.Time
+		expectedDeliver time.Time
+	}{
+		{
+			name:            "Standard counts from creation",
+			tier:            TierStandard,
+			expectedAssign:  createdAt.Add(20 * time.Minute),
+			expectedDeliver: createdAt.Add(time.Hour),
+		},
+		{
+			name:            "Express counts from creation",
+			tier:            TierExpress,
+			expectedAssign:  createdAt.Add(10 * time.Minute),
+			expectedDeliver: createdAt.Add(30 * time.Minute),
+		},
+		{
+			name:            "Scheduled counts from window start",
+			tier:            TierScheduled,
+			window:          MustNewDeliveryWindow(createdAt.Add(2*time.Hour), createdAt.Add(6*time.Hour)),
+			expectedAssign:  createdAt.Add(3 * time.Hour),
+			expectedDeliver: createdAt.Add(6 * time.Hour),
+		},
+		{
+			name:            "Scheduled assign capped by short window end",
+			tier:            TierScheduled,
+			window:          MustNewDeliveryWindow(createdAt.Add(2*time.Hour), createdAt.Add(150*time.Minute)),
+			expectedAssign:  createdAt.Add(150 * time.Minute),
+			expectedDeliver: createdAt.Add(150 * time.Minute),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := restoreOrderForSLA(tc.tier, tc.window, StatusCreated, Timestamps{CreatedAt: createdAt})
+
+			assert.Equal(t, tc.expectedAssign, policy.Deadline(o, SLAStageAssign))
+			assert.Equal(t, tc.expectedDeliver, policy.Deadline(o, SLAStageDeliver))
+		})
+	}
+}
+
+func TestOrder_CheckSLAShouldRaiseBreachOncePerStage(t *testing.T) {
+	createdAt := time.Now().UTC().Add(-30 * time.Minute)
+	policy := DefaultSLAPolicy()
+	o := restoreOrderForSLA(TierStandard, DeliveryWindow{}, StatusCreated, Timestamps{CreatedAt: createdAt})
+
+	// Через 30 минут нарушен только срок назначения
+	now := createdAt.Add(30 * time.Minute)
+	assert.True(t, o.CheckSLA(policy, now))
+	assert.False(t, o.CheckSLA(policy, now.Add(time.Minute)))
+
+	// Через полтора часа нарушен и срок доставки
+	assert.True(t, o.CheckSLA(policy, now.Add(time.Hour)))
+	assert.False(t, o.CheckSLA(policy, now.Add(2*time.Hour)))
+
+	events := o.DomainEvents()
+	require.Len(t, events, 2)
+	assignBreach, ok := events[0].(SLABreachedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, SLAStageAssign, assignBreach.Stage())
+	assert.Equal(t, createdAt.Add(20*time.Minute), assignBreach.Deadline())
+	deliverBreach, ok := events[1].(SLABreachedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, SLAStageDeliver, deliverBreach.Stage())
+	assert.Equal(t, createdAt.Add(time.Hour), deliverBreach.Deadline())
+	assert.NotNil(t, o.Timestamps().AssignSLABreachedAt)
+	assert.NotNil(t, o.Timestamps().DeliverSLABreachedAt)
+}
+
+func TestOrder_CheckSLAShouldCountLateAssignment(t *testing.T) {
+	createdAt := time.Now().UTC().Add(-30 * time.Minute)
+	assignedAt := createdAt.Add(25 * time.Minute)
+	o := restoreOrderForSLA(TierStandard, DeliveryWindow{}, StatusAssigned,
+		Timestamps{CreatedAt: createdAt, AssignedAt: &assignedAt})
+
+	assert.True(t, o.CheckSLA(DefaultSLAPolicy(), createdAt.Add(30*time.Minute)))
+	require.Len(t, o.DomainEvents(), 1)
+	assert.Equal(t, SLAStageAssign, o.DomainEvents()[0].(SLABreachedDomainEvent).Stage())
+}
+
+func TestOrder_CheckSLAAfterUnassign(t *testing.T) {
+	createdAt := time.Now().UTC().Add(-15 * time.Minute)
+	policy := DefaultSLAPolicy()
+
+	testCases := []struct {
+		name                string
+		assignedAfter       time.Duration
+		assignBreached      bool
+		checkAfter          time.Duration
+		expectedAssignEvent bool
+	}{
+		{
+			name:          "Assigned in time, back in queue before deadline",
+			assignedAfter: 5 * time.Minute,
+			checkAfter:    15 * time.Minute,
+		},
+		{
+			name:                "Assigned in time, back in queue past deadline",
+			assignedAfter:       5 * time.Minute,
+			checkAfter:          25 * time.Minute,
+			expectedAssignEvent: true,
+		},
+		{
+			name:           "Assign breach already reported",
+			assignedAfter:  25 * time.Minute,
+			assignBreached: true,
+			checkAfter:     40 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assignedAt := createdAt.Add(tc.assignedAfter)
+			timestamps := Timestamps{CreatedAt: createdAt, AssignedAt: &assignedAt}
+			if tc.assignBreached {
+				breachedAt := createdAt.Add(21 * time.Minute)
+				timestamps.AssignSLABreachedAt = &breachedAt
+			}
+			o := restoreOrderForSLA(TierStandard, DeliveryWindow{}, StatusAssigned, timestamps)
+
+			require.NoError(t, o.Unassign(UnassignReasonCourierOffline))
+			o.ClearDomainEvents()
+
+			// Снятый с курьера заказ снова ждёт назначения
+			stage, deadline, ok := policy.Next(o)
+			require.True(t, ok)
+			assert.Equal(t, SLAStageAssign, stage)
+			assert.Equal(t, createdAt.Add(20*time.Minute), deadline)
+
+			assert.Equal(t, tc.expectedAssignEvent, o.CheckSLA(policy, createdAt.Add(tc.checkAfter)))
+			if !tc.expectedAssignEvent {
+				assert.Empty(t, o.DomainEvents())
+				return
+			}
+			require.Len(t, o.DomainEvents(), 1)
+			assert.Equal(t, SLAStageAssign, o.DomainEvents()[0].(SLABreachedDomainEvent).Stage())
+		})
+	}
+}
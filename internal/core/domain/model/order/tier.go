@@ -0,0 +1,68 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tier - уровень доставки заказа
+type Tier string
+
+const (
+	// TierExpress - назначается раньше остальных и без правил честного распределения курьеров
+	TierExpress  Tier = "express"
+	TierStandard Tier = "standard"
+	// TierScheduled - доставка к согласованному интервалу, назначается после остальных
+	TierScheduled Tier = "scheduled"
+)
+
+var ErrUnknownTier = errors.New("unknown order tier")
+
+// Tiers - все уровни доставки
+func Tiers() []Tier {
+	return []Tier{TierExpress, TierStandard, TierScheduled}
+}
+
+// ParseTier - уровень без учёта регистра, пустая строка - обычная доставка
+func ParseTier(value string) (Tier, error) {
+	switch tier := Tier(strings.ToLower(strings.TrimSpace(value))); tier {
+	case TierExpress, TierStandard, TierScheduled:
+		return tier, nil
+	case "":
+		return TierStandard, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownTier, value)
+	}
+}
+
+// Priority - меньше значит раньше в очереди на назначение
+func (t Tier) Priority() int {
+	switch t {
+	case TierExpress:
+		return 0
+	case TierScheduled:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (t Tier) IsValid() bool {
+	return t == TierExpress || t == TierStandard || t == TierScheduled
+}
+
+func (t Tier) IsExpress() bool {
+	return t == TierExpress
+}
+
+// CompareForDispatch - порядок назначения: сначала уровни с большим приоритетом, внутри уровня по времени создания
+func CompareForDispatch(a, b *Order) int {
+	if result := a.tier.Priority() - b.tier.Priority(); result != 0 {
+		return result
+	}
+	if result := a.createdAt.Compare(b.createdAt); result != 0 {
+		return result
+	}
+	return strings.Compare(a.id.String(), b.id.String())
+}
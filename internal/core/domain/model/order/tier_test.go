@@ -0,0 +1,73 @@
+package order
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+func TestParseTier(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expected    Tier
+		expectError bool
+	}{
+		{name: "Express", value: "express", expected: TierExpress},
+		{name: "Case and spaces ignored", value: " Scheduled ", expected: TierScheduled},
+		{name: "Empty - standard", value: "", expected: TierStandard},
+		{name: "Unknown", value: "overnight", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tier, err := ParseTier(tc.value)
+			if tc.expectError {
+				assert.ErrorIs(t, err, ErrUnknownTier)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, tier)
+		})
+	}
+}
+
+func TestTier_Priority(t *testing.T) {
+	assert.Less(t, TierExpress.Priority(), TierStandard.Priority())
+	assert.Less(t, TierStandard.Priority(), TierScheduled.Priority())
+	for _, tier := range Tiers() {
+		assert.True(t, tier.IsValid())
+	}
+	assert.False(t, Tier("overnight").IsValid())
+}
+
+func TestCompareForDispatch(t *testing.T) {
+	address := kernel.MustNewAddress("", "", "Бажная", "", "")
+	createdAt := time.Now().UTC()
+	newOrder := func(tier Tier, createdAt time.Time) *Order {
+		return RestoreOrder(uuid.New(), kernel.DefaultRegion(), tier, DeliveryWindow{}, nil, address,
+			kernel.MustNewLocation(1, 1), StatusCreated, Timestamps{CreatedAt: createdAt}, "")
+	}
+
+	scheduled := newOrder(TierScheduled, createdAt.Add(-2*time.Hour))
+	olderStandard := newOrder(TierStandard, createdAt.Add(-time.Hour))
+	newerStandard := newOrder(TierStandard, createdAt)
+	express := newOrder(TierExpress, createdAt.Add(time.Hour))
+
+	orders := []*Order{newerStandard, scheduled, express, olderStandard}
+	slices.SortFunc(orders, CompareForDispatch)
+
+	// Уровень важнее времени создания, внутри уровня - раньше созданные
+	assert.Equal(t, []*Order{express, olderStandard, newerStandard, scheduled}, orders)
+
+	// При равном времени порядок всё равно определён
+	first, second := newOrder(TierStandard, createdAt), newOrder(TierStandard, createdAt)
+	assert.Equal(t, -CompareForDispatch(first, second), CompareForDispatch(second, first))
+	assert.NotZero(t, CompareForDispatch(first, second))
+}
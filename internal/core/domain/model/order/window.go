@@ -0,0 +1,71 @@
+package order
+
+import (
+	"time"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// DeliveryWindow - согласованный с клиентом интервал доставки [from, to) заказа к интервалу
+type DeliveryWindow struct {
+	from time.Time
+	to   time.Time
+
+	isSet bool
+}
+
+func NewDeliveryWindow(from, to time.Time) (DeliveryWindow, error) {
+	if from.IsZero() {
+		return DeliveryWindow{}, errs.NewValueIsRequiredError("deliveryWindow.from")
+	}
+	if to.IsZero() {
+		return DeliveryWindow{}, errs.NewValueIsRequiredError("deliveryWindow.to")
+	}
+	if !from.Before(to) {
+		return DeliveryWindow{}, errs.NewValueIsInvalidError("deliveryWindow")
+	}
+	return DeliveryWindow{from: from.UTC(), to: to.UTC(), isSet: true}, nil
+}
+
+func MustNewDeliveryWindow(from, to time.Time) DeliveryWindow {
+	window, err := NewDeliveryWindow(from, to)
+	if err != nil {
+		panic(err)
+	}
+	return window
+}
+
+func (w DeliveryWindow) From() time.Time {
+	return w.from
+}
+
+func (w DeliveryWindow) To() time.Time {
+	return w.to
+}
+
+// IsOpened - интервал уже начался к моменту now
+func (w DeliveryWindow) IsOpened(now time.Time) bool {
+	return !now.Before(w.from)
+}
+
+func (w DeliveryWindow) IsEmpty() bool {
+	return !w.isSet
+}
+
+// validateDeliveryWindow - интервал обязателен заказу к интервалу и не задаётся остальным,
+// к моменту создания заказа он не должен закончиться
+func validateDeliveryWindow(tier Tier, window DeliveryWindow, now time.Time) error {
+	if tier != TierScheduled {
+		if !window.IsEmpty() {
+			return errs.NewValueIsInvalidError("deliveryWindow")
+		}
+		return nil
+	}
+	if window.IsEmpty() {
+		return errs.NewValueIsRequiredError("deliveryWindow")
+	}
+	if !window.to.After(now) {
+		return errs.NewValueIsInvalidError("deliveryWindow.to")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+func TestNewDeliveryWindow(t *testing.T) {
+	from := time.Date(2026, 1, 1, 10, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	testCases := []struct {
+		name          string
+		from          time.Time
+		to            time.Time
+		expectedError error
+	}{
+		{name: "Valid", from: from, to: from.Add(2 * time.Hour)},
+		{name: "Without from", to: from, expectedError: errs.ErrValueIsRequired},
+		{name: "Without to", from: from, expectedError: errs.ErrValueIsRequired},
+		{name: "From equals to", from: from, to: from, expectedError: errs.ErrValueIsInvalid},
+		{name: "From after to", from: from, to: from.Add(-time.Hour), expectedError: errs.ErrValueIsInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window, err := NewDeliveryWindow(tc.from, tc.to)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.True(t, window.IsEmpty())
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, window.IsEmpty())
+			assert.True(t, tc.from.Equal(window.From()))
+			assert.Equal(t, time.UTC, window.From().Location())
+			assert.True(t, tc.to.Equal(window.To()))
+		})
+	}
+}
+
+func TestNewOrderInRegion_DeliveryWindow(t *testing.T) {
+	now := time.Now()
+	upcoming := MustNewDeliveryWindow(now.Add(time.Hour), now.Add(3*time.Hour))
+	started := MustNewDeliveryWindow(now.Add(-time.Hour), now.Add(time.Hour))
+	past := MustNewDeliveryWindow(now.Add(-3*time.Hour), now.Add(-time.Hour))
+
+	testCases := []struct {
+		name          string
+		tier          Tier
+		window        DeliveryWindow
+		expectedError error
+	}{
+		{name: "Scheduled with upcoming window", tier: TierScheduled, window: upcoming},
+		{name: "Scheduled with started window", tier: TierScheduled, window: started},
+		{name: "Scheduled without window", tier: TierScheduled, expectedError: errs.ErrValueIsRequired},
+		{name: "Scheduled with past window", tier: TierScheduled, window: past, expectedError: errs.ErrValueIsInvalid},
+		{name: "Standard without window", tier: TierStandard},
+		{name: "Standard with window", tier: TierStandard, window: upcoming, expectedError: errs.ErrValueIsInvalid},
+		{name: "Express with window", tier: TierExpress, window: upcoming, expectedError: errs.ErrValueIsInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := NewOrderInRegion(uuid.New(), kernel.DefaultRegion(), tc.tier, tc.window,
+				kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(1, 1))
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.window, o.DeliveryWindow())
+		})
+	}
+}
+
+func TestOrder_WaitsForDeliveryWindow(t *testing.T) {
+	now := time.Now().UTC()
+	window := MustNewDeliveryWindow(now.Add(time.Hour), now.Add(3*time.Hour))
+	scheduled, err := NewOrderInRegion(uuid.New(), kernel.DefaultRegion(), TierScheduled, window,
+		kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(1, 1))
+	require.NoError(t, err)
+
+	assert.True(t, scheduled.WaitsForDeliveryWindow(now))
+	assert.True(t, scheduled.WaitsForDeliveryWindow(window.From().Add(-time.Nanosecond)))
+	// Интервал начинается включительно
+	assert.False(t, scheduled.WaitsForDeliveryWindow(window.From()))
+	assert.False(t, scheduled.WaitsForDeliveryWindow(window.To().Add(time.Hour)))
+
+	// Заказы без интервала назначаются сразу
+	standard := MustNewOrder(uuid.New(), kernel.MustNewAddress("", "", "Бажная", "", ""), kernel.MustNewLocation(1, 1))
+	assert.False(t, standard.WaitsForDeliveryWindow(now))
+}
@@ -0,0 +1,134 @@
+package region
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var (
+	ErrDuplicateRegion = errors.New("region code or city is listed twice")
+	ErrUnknownRegion   = errors.New("unknown region")
+)
+
+// Region - город со своей сеткой. Заказ попадает в регион по названию города в адресе
+type Region struct {
+	code   kernel.RegionCode
+	bounds kernel.Bounds
+	cities []string
+}
+
+// NewRegion - cities - названия города в адресах, без учёта регистра
+func NewRegion(code kernel.RegionCode, bounds kernel.Bounds, cities []string) (*Region, error) {
+	if code.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("code")
+	}
+	if bounds == nil || bounds.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("bounds")
+	}
+	normalized := make([]string, 0, len(cities))
+	for _, city := range cities {
+		city = normalizeCity(city)
+		if city == "" {
+			return nil, errs.NewValueIsInvalidError("cities")
+		}
+		normalized = append(normalized, city)
+	}
+	return &Region{code: code, bounds: bounds, cities: normalized}, nil
+}
+
+func (r *Region) Code() kernel.RegionCode {
+	return r.code
+}
+
+// Bounds - сетка или прямоугольник WGS84, в которые попадают курьеры, заказы и зоны региона
+func (r *Region) Bounds() kernel.Bounds {
+	return r.bounds
+}
+
+func (r *Region) Cities() []string {
+	return append([]string(nil), r.cities...)
+}
+
+// Catalog - регионы сервиса. Первый - регион по умолчанию для новых зон
+type Catalog struct {
+	regions []*Region
+	byCode  map[kernel.RegionCode]*Region
+	byCity  map[string]*Region
+	// fallback - адреса без города или с незнакомым городом попадают в регион по умолчанию
+	fallback bool
+}
+
+// NewCatalog - явно заданные регионы, адрес с незнакомым городом не попадает ни в один из них
+func NewCatalog(regions ...*Region) (*Catalog, error) {
+	if len(regions) == 0 {
+		return nil, errs.NewValueIsRequiredError("regions")
+	}
+	catalog := &Catalog{
+		byCode: make(map[kernel.RegionCode]*Region, len(regions)),
+		byCity: make(map[string]*Region),
+	}
+	for _, region := range regions {
+		if region == nil {
+			return nil, errs.NewValueIsRequiredError("region")
+		}
+		if _, ok := catalog.byCode[region.code]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateRegion, region.code)
+		}
+		catalog.byCode[region.code] = region
+		for _, city := range region.cities {
+			if _, ok := catalog.byCity[city]; ok {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateRegion, city)
+			}
+			catalog.byCity[city] = region
+		}
+		catalog.regions = append(catalog.regions, region)
+	}
+	return catalog, nil
+}
+
+// NewSingleRegionCatalog - один регион по умолчанию на весь сервис, в него попадает любой адрес
+func NewSingleRegionCatalog(bounds kernel.Bounds) (*Catalog, error) {
+	region, err := NewRegion(kernel.DefaultRegion(), bounds, nil)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := NewCatalog(region)
+	if err != nil {
+		return nil, err
+	}
+	catalog.fallback = true
+	return catalog, nil
+}
+
+func (c *Catalog) Default() *Region {
+	return c.regions[0]
+}
+
+func (c *Catalog) All() []*Region {
+	return append([]*Region(nil), c.regions...)
+}
+
+func (c *Catalog) Get(code kernel.RegionCode) (*Region, bool) {
+	region, ok := c.byCode[code]
+	return region, ok
+}
+
+// ForAddress - регион города из адреса. Адрес без города или с незнакомым городом попадает в регион по умолчанию
+// только в каталоге из одного неявного региона, иначе - ErrUnknownRegion
+func (c *Catalog) ForAddress(address kernel.Address) (*Region, error) {
+	if region, ok := c.byCity[normalizeCity(address.City())]; ok {
+		return region, nil
+	}
+	if c.fallback {
+		return c.Default(), nil
+	}
+	return nil, fmt.Errorf("%w: city %q", ErrUnknownRegion, address.City())
+}
+
+func normalizeCity(city string) string {
+	return strings.ToLower(strings.Join(strings.Fields(city), " "))
+}
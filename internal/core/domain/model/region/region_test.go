@@ -0,0 +1,94 @@
+package region_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/region"
+)
+
+func newRegion(t *testing.T, code string, cities ...string) *region.Region {
+	r, err := region.NewRegion(kernel.MustNewRegionCode(code), kernel.DefaultArea(), cities)
+	require.NoError(t, err)
+	return r
+}
+
+func TestCatalog_ForAddress(t *testing.T) {
+	// Arrange
+	moscow := newRegion(t, "msk", "Москва")
+	kazan := newRegion(t, "kzn", "Казань", "Kazan")
+	catalog, err := region.NewCatalog(moscow, kazan)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		city     string
+		expected *region.Region
+	}{
+		{name: "Known city", city: "Казань", expected: kazan},
+		{name: "Case and spaces are ignored", city: "  KAZAN ", expected: kazan},
+		{name: "Unknown city is rejected", city: "Тверь", expected: nil},
+		{name: "Address without city is rejected", city: "", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := kernel.MustNewAddress("Россия", tc.city, "Несуществующая", "1", "")
+
+			// Act
+			result, err := catalog.ForAddress(address)
+
+			// Assert
+			if tc.expected == nil {
+				assert.ErrorIs(t, err, region.ErrUnknownRegion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestSingleRegionCatalog_ForAddress(t *testing.T) {
+	// Arrange
+	catalog, err := region.NewSingleRegionCatalog(kernel.DefaultArea())
+	require.NoError(t, err)
+
+	for _, city := range []string{"Тверь", ""} {
+		address := kernel.MustNewAddress("Россия", city, "Несуществующая", "1", "")
+
+		// Act
+		result, err := catalog.ForAddress(address)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, catalog.Default(), result)
+	}
+}
+
+func TestNewCatalog_RejectsDuplicates(t *testing.T) {
+	_, err := region.NewCatalog(newRegion(t, "msk"), newRegion(t, "msk"))
+	assert.ErrorIs(t, err, region.ErrDuplicateRegion)
+
+	_, err = region.NewCatalog(newRegion(t, "msk", "Москва"), newRegion(t, "mo", "москва"))
+	assert.ErrorIs(t, err, region.ErrDuplicateRegion)
+
+	_, err = region.NewCatalog()
+	assert.Error(t, err)
+}
+
+func TestCatalog_Get(t *testing.T) {
+	catalog, err := region.NewSingleRegionCatalog(kernel.DefaultArea())
+	require.NoError(t, err)
+
+	defaultRegion, ok := catalog.Get(kernel.DefaultRegion())
+	require.True(t, ok)
+	assert.Equal(t, catalog.Default(), defaultRegion)
+	assert.Equal(t, kernel.DefaultArea(), defaultRegion.Bounds())
+
+	_, ok = catalog.Get(kernel.MustNewRegionCode("msk"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,332 @@
+package routing
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var _ Router = &Graph{}
+
+// Graph - дороги города: перекрёстки и отрезки между ними, вес отрезка - время на него.
+// Точки вне дорог (адрес заказа, курьер посреди двора) соединяются с ближайшим перекрёстком напрямую по улице
+type Graph struct {
+	roads map[kernel.Location][]edge
+	nodes []kernel.Location
+}
+
+type edge struct {
+	to    kernel.Location
+	class RoadClass
+}
+
+func NewGraph() *Graph {
+	return &Graph{roads: make(map[kernel.Location][]edge)}
+}
+
+// AddRoad - дорога from-to класса class, по односторонней можно ехать только от from к to.
+// Дорога делится на отрезки в точках, где к ней примыкают или, на сетке, её пересекают другие дороги.
+// На сетке дороги прямые и идут вдоль осей, в координатах WGS84 перекрёстки должны быть общими точками дорог
+func (g *Graph) AddRoad(from, to kernel.Location, class RoadClass, oneWay bool) error {
+	if from.IsEmpty() {
+		return errs.NewValueIsRequiredError("from")
+	}
+	if to.IsEmpty() {
+		return errs.NewValueIsRequiredError("to")
+	}
+	if !class.IsValid() {
+		return errs.NewValueIsInvalidError(fmt.Sprintf("road class %q", class))
+	}
+	if from.Equals(to) {
+		return errs.NewValueIsInvalidError(fmt.Sprintf("road %s-%s has zero length", from, to))
+	}
+	if !from.SameKind(to) || (len(g.nodes) > 0 && !g.nodes[0].SameKind(from)) {
+		return kernel.ErrLocationKindMismatch
+	}
+	if !from.IsGeo() && from.X() != to.X() && from.Y() != to.Y() {
+		return errs.NewValueIsInvalidError(fmt.Sprintf("road %s-%s is not parallel to grid axes", from, to))
+	}
+
+	// Перекрёстки на новой дороге: её концы, пересечения и уже известные точки на ней
+	points := []kernel.Location{from, to}
+	for _, existing := range g.allRoads() {
+		if crossing, ok := cross(existing.from, existing.to, from, to); ok {
+			points = append(points, crossing)
+		}
+	}
+	for _, point := range points {
+		g.split(point)
+	}
+	for _, node := range g.nodes {
+		if onSegment(from, to, node) {
+			points = append(points, node)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return SegmentLength(from, points[i]) < SegmentLength(from, points[j])
+	})
+
+	for i := 1; i < len(points); i++ {
+		if points[i].Equals(points[i-1]) {
+			continue
+		}
+		g.connect(points[i-1], edge{to: points[i], class: class})
+		if !oneWay {
+			g.connect(points[i], edge{to: points[i-1], class: class})
+		}
+	}
+	return nil
+}
+
+// Nodes - сколько перекрёстков в графе
+func (g *Graph) Nodes() int {
+	return len(g.nodes)
+}
+
+// Route - самый быстрый для vehicle путь по дорогам, A* с оценкой по прямой на наибольшей скорости.
+// Отрезки, по которым vehicle ехать нельзя, пропускаются
+func (g *Graph) Route(from, to kernel.Location, vehicle Vehicle) (Path, error) {
+	if !from.SameKind(to) || (len(g.nodes) > 0 && !g.nodes[0].SameKind(from)) {
+		return Path{}, kernel.ErrLocationKindMismatch
+	}
+	if from.Equals(to) {
+		return Path{}, nil
+	}
+	if len(g.nodes) == 0 || maxSpeed(vehicle) <= 0 {
+		return Path{}, ErrNoRoute
+	}
+
+	// Обе точки на одной дороге, и по ней можно ехать от from к to
+	for _, road := range g.roadsThrough(from) {
+		if (road.to.Equals(to) || onSegment(road.from, road.to, to)) &&
+			SegmentLength(road.from, to) > SegmentLength(road.from, from) &&
+			speed(vehicle, from, to, road.class) > 0 {
+			return NewSegmentPath(from, Segment{To: to, Class: road.class})
+		}
+	}
+
+	// Точки старта и финиша, которых нет в графе, подключаем к нему виртуальными отрезками
+	starts := g.entries(from)
+	exits := g.exits(to)
+
+	cost := make(map[kernel.Location]float64)
+	previous := make(map[kernel.Location]kernel.Location)
+	classes := make(map[kernel.Location]RoadClass)
+	queue := &priorityQueue{}
+	estimate := func(location kernel.Location) float64 {
+		return SegmentLength(location, to) / maxSpeed(vehicle)
+	}
+	for _, start := range starts {
+		duration, ok := travel(vehicle, from, start)
+		if !ok {
+			continue
+		}
+		if known, ok := cost[start.to]; !ok || duration < known {
+			cost[start.to] = duration
+			previous[start.to] = from
+			classes[start.to] = start.class
+			heap.Push(queue, queueItem{location: start.to, priority: duration + estimate(start.to)})
+		}
+	}
+
+	visited := make(map[kernel.Location]bool)
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queueItem).location
+		if current.Equals(to) {
+			return g.path(from, to, previous, classes)
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		next := g.roads[current]
+		if class, ok := exits[current]; ok {
+			next = append(next[:len(next):len(next)], edge{to: to, class: class})
+		}
+		for _, road := range next {
+			duration, ok := travel(vehicle, current, road)
+			if !ok {
+				continue
+			}
+			duration += cost[current]
+			if known, ok := cost[road.to]; ok && known <= duration {
+				continue
+			}
+			cost[road.to] = duration
+			previous[road.to] = current
+			classes[road.to] = road.class
+			heap.Push(queue, queueItem{location: road.to, priority: duration + estimate(road.to)})
+		}
+	}
+	return Path{}, fmt.Errorf("%w from %s to %s", ErrNoRoute, from, to)
+}
+
+// travel - время на отрезок, false - vehicle по нему нельзя
+func travel(vehicle Vehicle, from kernel.Location, road edge) (float64, bool) {
+	if from.Equals(road.to) {
+		return 0, true
+	}
+	speed := speed(vehicle, from, road.to, road.class)
+	if speed <= 0 {
+		return 0, false
+	}
+	return SegmentLength(from, road.to) / speed, true
+}
+
+func (g *Graph) path(from, to kernel.Location, previous map[kernel.Location]kernel.Location,
+	classes map[kernel.Location]RoadClass) (Path, error) {
+	var reversed []Segment
+	for current := to; !current.Equals(from); current = previous[current] {
+		reversed = append(reversed, Segment{To: current, Class: classes[current]})
+	}
+	segments := make([]Segment, len(reversed))
+	for i, segment := range reversed {
+		segments[len(reversed)-1-i] = segment
+	}
+	return NewSegmentPath(from, segments...)
+}
+
+type road struct {
+	from, to kernel.Location
+	class    RoadClass
+}
+
+func (g *Graph) allRoads() []road {
+	var result []road
+	for _, from := range g.nodes {
+		for _, edge := range g.roads[from] {
+			result = append(result, road{from: from, to: edge.to, class: edge.class})
+		}
+	}
+	return result
+}
+
+// split - сделать точку перекрёстком, разрезав проходящие через неё дороги
+func (g *Graph) split(location kernel.Location) {
+	if _, ok := g.roads[location]; ok {
+		return
+	}
+	for _, road := range g.roadsThrough(location) {
+		g.disconnect(road.from, road.to)
+		g.connect(road.from, edge{to: location, class: road.class})
+		g.connect(location, edge{to: road.to, class: road.class})
+	}
+	g.addNode(location)
+}
+
+func (g *Graph) connect(from kernel.Location, road edge) {
+	g.addNode(from)
+	g.addNode(road.to)
+	if !slices.ContainsFunc(g.roads[from], func(existing edge) bool { return existing.to.Equals(road.to) }) {
+		g.roads[from] = append(g.roads[from], road)
+	}
+}
+
+func (g *Graph) disconnect(from, to kernel.Location) {
+	g.roads[from] = slices.DeleteFunc(g.roads[from], func(existing edge) bool { return existing.to.Equals(to) })
+}
+
+// cross - точка пересечения перпендикулярных дорог на сетке
+func cross(a, b, c, d kernel.Location) (kernel.Location, bool) {
+	if a.IsGeo() {
+		return kernel.Location{}, false
+	}
+	if a.Y() == b.Y() && c.X() == d.X() && a.X() != b.X() && c.Y() != d.Y() {
+		a, b, c, d = c, d, a, b
+	}
+	// a-b вертикальная, c-d горизонтальная
+	if a.X() != b.X() || c.Y() != d.Y() || a.Y() == b.Y() || c.X() == d.X() {
+		return kernel.Location{}, false
+	}
+	x, y := a.X(), c.Y()
+	if x < min(c.X(), d.X()) || x > max(c.X(), d.X()) || y < min(a.Y(), b.Y()) || y > max(a.Y(), b.Y()) {
+		return kernel.Location{}, false
+	}
+	crossing, err := kernel.NewLocation(x, y)
+	return crossing, err == nil
+}
+
+// roadsThrough - дороги, на которых лежит точка между перекрёстками, в разрешённом направлении
+func (g *Graph) roadsThrough(location kernel.Location) []road {
+	if _, ok := g.roads[location]; ok {
+		return nil
+	}
+	var result []road
+	for _, road := range g.allRoads() {
+		if onSegment(road.from, road.to, location) {
+			result = append(result, road)
+		}
+	}
+	return result
+}
+
+// entries - первые отрезки пути из location до перекрёстков
+func (g *Graph) entries(location kernel.Location) []edge {
+	if _, ok := g.roads[location]; ok {
+		return []edge{{to: location, class: RoadStreet}}
+	}
+	var result []edge
+	for _, road := range g.roadsThrough(location) {
+		result = append(result, edge{to: road.to, class: road.class})
+	}
+	if len(result) == 0 {
+		result = append(result, edge{to: g.nearest(location), class: RoadStreet})
+	}
+	return result
+}
+
+// exits - перекрёстки, из которых можно дойти до location последним отрезком, и класс этого отрезка
+func (g *Graph) exits(location kernel.Location) map[kernel.Location]RoadClass {
+	result := make(map[kernel.Location]RoadClass)
+	if _, ok := g.roads[location]; ok {
+		return result
+	}
+	for _, road := range g.roadsThrough(location) {
+		result[road.from] = road.class
+	}
+	if len(result) == 0 {
+		result[g.nearest(location)] = RoadStreet
+	}
+	return result
+}
+
+func (g *Graph) nearest(location kernel.Location) kernel.Location {
+	nearest := g.nodes[0]
+	for _, node := range g.nodes[1:] {
+		if SegmentLength(location, node) < SegmentLength(location, nearest) {
+			nearest = node
+		}
+	}
+	return nearest
+}
+
+func (g *Graph) addNode(location kernel.Location) {
+	if _, ok := g.roads[location]; ok {
+		return
+	}
+	g.roads[location] = nil
+	g.nodes = append(g.nodes, location)
+}
+
+type queueItem struct {
+	location kernel.Location
+	priority float64
+}
+
+type priorityQueue []queueItem
+
+func (q priorityQueue) Len() int           { return len(q) }
+func (q priorityQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q priorityQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue) Push(x any)        { *q = append(*q, x.(queueItem)) }
+func (q *priorityQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,160 @@
+package routing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+)
+
+func location(x, y int) kernel.Location {
+	return kernel.MustNewLocation(x, y)
+}
+
+// cityGraph - кольцо 1..10 с улицей x=8 и парком посередине, без дороги через парк
+func cityGraph(t *testing.T) *routing.Graph {
+	graph := routing.NewGraph()
+	for _, road := range [][2]kernel.Location{
+		{location(1, 1), location(10, 1)},
+		{location(10, 1), location(10, 10)},
+		{location(10, 10), location(1, 10)},
+		{location(1, 10), location(1, 1)},
+		{location(8, 1), location(8, 10)},
+	} {
+		require.NoError(t, graph.AddRoad(road[0], road[1], routing.RoadStreet, false))
+	}
+	return graph
+}
+
+func TestDirect_RouteIsManhattan(t *testing.T) {
+	path, err := routing.Direct{}.Route(location(1, 1), location(4, 5), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, float64(7), path.Length())
+	assert.Equal(t, []kernel.Location{location(4, 5)}, path.Waypoints())
+}
+
+func TestGraph_AddRoadShouldRejectInvalidRoads(t *testing.T) {
+	graph := routing.NewGraph()
+
+	assert.Error(t, graph.AddRoad(location(1, 1), location(1, 1), routing.RoadStreet, false))
+	assert.Error(t, graph.AddRoad(location(1, 1), location(3, 3), routing.RoadStreet, false))
+	assert.Error(t, graph.AddRoad(kernel.Location{}, location(3, 3), routing.RoadStreet, false))
+	require.NoError(t, graph.AddRoad(location(1, 1), location(1, 3), routing.RoadStreet, false))
+	assert.ErrorIs(t, graph.AddRoad(location(1, 1), kernel.MustNewGeoLocation(55.75, 37.6), routing.RoadStreet, false),
+		kernel.ErrLocationKindMismatch)
+}
+
+func TestGraph_RouteShouldGoAroundPark(t *testing.T) {
+	graph := cityGraph(t)
+
+	// По прямой 7 клеток через парк, по дорогам - через кольцо
+	path, err := graph.Route(location(1, 5), location(8, 5), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []kernel.Location{location(1, 1), location(8, 1), location(8, 5)}, path.Waypoints())
+	assert.Equal(t, float64(15), path.Length())
+}
+
+func TestGraph_RouteShouldFollowOneWayRoad(t *testing.T) {
+	graph := routing.NewGraph()
+	require.NoError(t, graph.AddRoad(location(1, 1), location(5, 1), routing.RoadStreet, true))
+	require.NoError(t, graph.AddRoad(location(5, 1), location(5, 5), routing.RoadStreet, false))
+	require.NoError(t, graph.AddRoad(location(5, 5), location(1, 5), routing.RoadStreet, false))
+	require.NoError(t, graph.AddRoad(location(1, 5), location(1, 1), routing.RoadStreet, false))
+
+	forward, err := graph.Route(location(1, 1), location(5, 1), nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(4), forward.Length())
+
+	// Против движения только в объезд
+	backward, err := graph.Route(location(5, 1), location(1, 1), nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(12), backward.Length())
+}
+
+func TestGraph_RouteShouldUseCrossings(t *testing.T) {
+	graph := routing.NewGraph()
+	require.NoError(t, graph.AddRoad(location(1, 3), location(5, 3), routing.RoadStreet, false))
+	require.NoError(t, graph.AddRoad(location(3, 1), location(3, 5), routing.RoadStreet, false))
+
+	// Дороги заданы целиком, перекрёсток (3,3) появляется сам
+	path, err := graph.Route(location(1, 3), location(3, 1), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []kernel.Location{location(3, 3), location(3, 1)}, path.Waypoints())
+	assert.Equal(t, 5, graph.Nodes())
+}
+
+func TestGraph_RouteShouldFailWithoutRoad(t *testing.T) {
+	graph := routing.NewGraph()
+	require.NoError(t, graph.AddRoad(location(1, 1), location(5, 1), routing.RoadStreet, true))
+
+	_, err := graph.Route(location(5, 1), location(1, 1), nil)
+
+	assert.ErrorIs(t, err, routing.ErrNoRoute)
+}
+
+func TestGraph_RouteShouldConnectPointsOffRoad(t *testing.T) {
+	graph := cityGraph(t)
+
+	// Заказ во дворе у (9,5): доезжаем до ближайшего перекрёстка и идём пешком
+	path, err := graph.Route(location(2, 1), location(9, 5), nil)
+
+	require.NoError(t, err)
+	waypoints := path.Waypoints()
+	assert.Equal(t, location(9, 5), waypoints[len(waypoints)-1])
+	assert.GreaterOrEqual(t, path.Length(), float64(location(2, 1).DistanceTo(location(9, 5))))
+}
+
+func TestGraph_RouteShouldRejectMixedLocations(t *testing.T) {
+	graph := cityGraph(t)
+
+	_, err := graph.Route(location(1, 1), kernel.MustNewGeoLocation(55.75, 37.6), nil)
+
+	assert.ErrorIs(t, err, kernel.ErrLocationKindMismatch)
+}
+
+// carVehicle - по шоссе вдвое быстрее, по пешеходным дорогам нельзя
+type carVehicle struct{}
+
+func (carVehicle) Speed(from, to kernel.Location, class routing.RoadClass) float64 {
+	switch class {
+	case routing.RoadHighway:
+		return 2
+	case routing.RoadStreet:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (carVehicle) MaxSpeed() float64 {
+	return 2
+}
+
+func TestGraph_RouteShouldDependOnVehicle(t *testing.T) {
+	graph := routing.NewGraph()
+	// Короткая пешеходная улица и длинный объезд по шоссе
+	require.NoError(t, graph.AddRoad(location(1, 1), location(5, 1), routing.RoadFootway, false))
+	require.NoError(t, graph.AddRoad(location(1, 1), location(1, 3), routing.RoadHighway, false))
+	require.NoError(t, graph.AddRoad(location(1, 3), location(5, 3), routing.RoadHighway, false))
+	require.NoError(t, graph.AddRoad(location(5, 3), location(5, 1), routing.RoadStreet, false))
+
+	walk, err := graph.Route(location(1, 1), location(5, 1), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []routing.Segment{{To: location(5, 1), Class: routing.RoadFootway}}, walk.Segments())
+
+	drive, err := graph.Route(location(1, 1), location(5, 1), carVehicle{})
+	require.NoError(t, err)
+	assert.Equal(t, []kernel.Location{location(1, 3), location(5, 3), location(5, 1)}, drive.Waypoints())
+	assert.Equal(t, routing.RoadHighway, drive.Segments()[0].Class)
+	assert.Equal(t, float64(8), drive.Length())
+
+	// С середины пешеходной улицы машине не выехать
+	_, err = graph.Route(location(3, 1), location(5, 3), carVehicle{})
+	assert.ErrorIs(t, err, routing.ErrNoRoute)
+}
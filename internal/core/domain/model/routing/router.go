@@ -0,0 +1,117 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+var ErrNoRoute = errors.New("no route")
+
+// Router - самый быстрый для vehicle путь между двумя точками города, без vehicle - кратчайший
+type Router interface {
+	Route(from, to kernel.Location, vehicle Vehicle) (Path, error)
+}
+
+// Segment - отрезок пути до точки To по дороге класса Class
+type Segment struct {
+	To    kernel.Location
+	Class RoadClass
+}
+
+// Path - путь из отрезков. Waypoints не включает начальную точку, последняя точка - цель
+type Path struct {
+	segments []Segment
+	length   float64
+}
+
+// NewPath - путь из from через waypoints по обычным улицам, длина - сумма длин отрезков
+func NewPath(from kernel.Location, waypoints ...kernel.Location) (Path, error) {
+	segments := make([]Segment, len(waypoints))
+	for i, waypoint := range waypoints {
+		segments[i] = Segment{To: waypoint, Class: RoadStreet}
+	}
+	return NewSegmentPath(from, segments...)
+}
+
+func NewSegmentPath(from kernel.Location, segments ...Segment) (Path, error) {
+	var length float64
+	current := from
+	for _, segment := range segments {
+		if !current.SameKind(segment.To) {
+			return Path{}, kernel.ErrLocationKindMismatch
+		}
+		length += SegmentLength(current, segment.To)
+		current = segment.To
+	}
+	return Path{segments: append([]Segment(nil), segments...), length: length}, nil
+}
+
+func (p Path) Waypoints() []kernel.Location {
+	waypoints := make([]kernel.Location, len(p.segments))
+	for i, segment := range p.segments {
+		waypoints[i] = segment.To
+	}
+	return waypoints
+}
+
+func (p Path) Segments() []Segment {
+	return append([]Segment(nil), p.segments...)
+}
+
+// Length - длина пути в клетках или, в координатах WGS84, в метрах
+func (p Path) Length() float64 {
+	return p.length
+}
+
+// IsEmpty - идти никуда не нужно
+func (p Path) IsEmpty() bool {
+	return len(p.segments) == 0
+}
+
+// SegmentLength - длина прямого отрезка: манхэттенское расстояние на сетке, большой круг в WGS84
+func SegmentLength(from, to kernel.Location) float64 {
+	if from.IsGeo() {
+		return from.DistanceMeters(to)
+	}
+	return float64(from.DistanceTo(to))
+}
+
+var _ Router = Direct{}
+
+// Direct - город без дорог, из любой точки можно идти прямо в любую по улице.
+// На сетке это граф из всех соседних клеток, кратчайший путь по которому - манхэттенское расстояние
+type Direct struct{}
+
+func (Direct) Route(from, to kernel.Location, vehicle Vehicle) (Path, error) {
+	if !from.SameKind(to) {
+		return Path{}, kernel.ErrLocationKindMismatch
+	}
+	if from.Equals(to) {
+		return Path{}, nil
+	}
+	if speed(vehicle, from, to, RoadStreet) <= 0 {
+		return Path{}, fmt.Errorf("%w from %s to %s", ErrNoRoute, from, to)
+	}
+	return NewPath(from, to)
+}
+
+// lengthTolerance - точка WGS84 считается лежащей на отрезке с такой погрешностью в метрах
+const lengthTolerance = 0.5
+
+// onSegment - лежит ли p на отрезке a-b, не совпадая с его концами
+func onSegment(a, b, p kernel.Location) bool {
+	if p.Equals(a) || p.Equals(b) {
+		return false
+	}
+	if p.IsGeo() {
+		return math.Abs(SegmentLength(a, p)+SegmentLength(p, b)-SegmentLength(a, b)) < lengthTolerance
+	}
+	// Дороги на сетке идут вдоль осей
+	if a.X() == b.X() {
+		return p.X() == a.X() && p.Y() > min(a.Y(), b.Y()) && p.Y() < max(a.Y(), b.Y())
+	}
+	return p.Y() == a.Y() && p.X() > min(a.X(), b.X()) && p.X() < max(a.X(), b.X())
+}
@@ -0,0 +1,46 @@
+package routing
+
+import (
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+// RoadClass - класс дороги, от него зависит, кому по ней можно и с какой скоростью
+type RoadClass string
+
+const (
+	RoadStreet  RoadClass = "street"
+	RoadHighway RoadClass = "highway"
+	RoadFootway RoadClass = "footway"
+)
+
+func (c RoadClass) IsValid() bool {
+	switch c {
+	case RoadStreet, RoadHighway, RoadFootway:
+		return true
+	default:
+		return false
+	}
+}
+
+// Vehicle - кто едет по пути: куда ему можно и как быстро
+type Vehicle interface {
+	// Speed - скорость на отрезке from-to дороги class, 0 - проезд запрещён
+	Speed(from, to kernel.Location, class RoadClass) float64
+	// MaxSpeed - наибольшая Speed, по ней A* оценивает оставшееся время
+	MaxSpeed() float64
+}
+
+// speed - без vehicle по любой дороге с одной скоростью
+func speed(vehicle Vehicle, from, to kernel.Location, class RoadClass) float64 {
+	if vehicle == nil {
+		return 1
+	}
+	return vehicle.Speed(from, to, class)
+}
+
+func maxSpeed(vehicle Vehicle) float64 {
+	if vehicle == nil {
+		return 1
+	}
+	return vehicle.MaxSpeed()
+}
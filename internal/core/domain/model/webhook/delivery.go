@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+var (
+	ErrInvalidDelivery         = errors.New("invalid webhook delivery")
+	ErrDeliveryAlreadyFinished = errors.New("webhook delivery is already finished")
+)
+
+// Delivery - отправка одного события одной подписке, она же запись в журнале доставок
+type Delivery struct {
+	id             uuid.UUID
+	subscriptionID uuid.UUID
+	eventID        uuid.UUID
+	eventType      EventType
+	payload        []byte
+	status         DeliveryStatus
+	attempts       int
+	responseCode   int
+	lastError      string
+	nextAttemptAt  time.Time
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+func NewDelivery(subscriptionID uuid.UUID, eventID uuid.UUID, eventType EventType, payload []byte, now time.Time) (*Delivery, error) {
+	if subscriptionID == uuid.Nil || eventID == uuid.Nil || eventType == "" || len(payload) == 0 {
+		return nil, ErrInvalidDelivery
+	}
+
+	return &Delivery{
+		id:             uuid.New(),
+		subscriptionID: subscriptionID,
+		eventID:        eventID,
+		eventType:      eventType,
+		payload:        payload,
+		status:         DeliveryStatusPending,
+		nextAttemptAt:  now,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// Claim - отправитель взял доставку; до until её не возьмёт никто другой,
+// а если отправитель пропадёт, после until попытка повторится
+func (d *Delivery) Claim(until time.Time) error {
+	if d.IsFinished() {
+		return ErrDeliveryAlreadyFinished
+	}
+	d.nextAttemptAt = until
+	return nil
+}
+
+// Succeed - получатель ответил 2xx
+func (d *Delivery) Succeed(responseCode int, now time.Time) error {
+	if d.IsFinished() {
+		return ErrDeliveryAlreadyFinished
+	}
+	d.attempts++
+	d.status = DeliveryStatusSucceeded
+	d.responseCode = responseCode
+	d.lastError = ""
+	d.updatedAt = now
+	return nil
+}
+
+// Fail - попытка не удалась. Без retryAt доставка завершается окончательно
+func (d *Delivery) Fail(responseCode int, reason string, now time.Time, retryAt *time.Time) error {
+	if d.IsFinished() {
+		return ErrDeliveryAlreadyFinished
+	}
+	d.attempts++
+	d.responseCode = responseCode
+	d.lastError = reason
+	d.updatedAt = now
+	if retryAt == nil {
+		d.status = DeliveryStatusFailed
+		return nil
+	}
+	d.nextAttemptAt = *retryAt
+	return nil
+}
+
+func (d *Delivery) IsFinished() bool {
+	return d.status != DeliveryStatusPending
+}
+
+func (d *Delivery) ID() uuid.UUID {
+	return d.id
+}
+
+func (d *Delivery) SubscriptionID() uuid.UUID {
+	return d.subscriptionID
+}
+
+func (d *Delivery) EventID() uuid.UUID {
+	return d.eventID
+}
+
+func (d *Delivery) EventType() EventType {
+	return d.eventType
+}
+
+func (d *Delivery) Payload() []byte {
+	return d.payload
+}
+
+func (d *Delivery) Status() DeliveryStatus {
+	return d.status
+}
+
+func (d *Delivery) Attempts() int {
+	return d.attempts
+}
+
+func (d *Delivery) ResponseCode() int {
+	return d.responseCode
+}
+
+func (d *Delivery) LastError() string {
+	return d.lastError
+}
+
+func (d *Delivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+func (d *Delivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *Delivery) UpdatedAt() time.Time {
+	return d.updatedAt
+}
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func RestoreSubscription(ID uuid.UUID, url string, secret string, eventTypes []EventType, createdAt time.Time) *Subscription {
+	return &Subscription{
+		id:         ID,
+		url:        url,
+		secret:     secret,
+		eventTypes: eventTypes,
+		createdAt:  createdAt,
+	}
+}
+
+func RestoreDelivery(ID uuid.UUID, subscriptionID uuid.UUID, eventID uuid.UUID, eventType EventType, payload []byte,
+	status DeliveryStatus, attempts int, responseCode int, lastError string,
+	nextAttemptAt time.Time, createdAt time.Time, updatedAt time.Time) *Delivery {
+	return &Delivery{
+		id:             ID,
+		subscriptionID: subscriptionID,
+		eventID:        eventID,
+		eventType:      eventType,
+		payload:        payload,
+		status:         status,
+		attempts:       attempts,
+		responseCode:   responseCode,
+		lastError:      lastError,
+		nextAttemptAt:  nextAttemptAt,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventOrderCreated   EventType = "order.created"
+	EventOrderAssigned  EventType = "order.assigned"
+	EventOrderCompleted EventType = "order.completed"
+	EventOrderCancelled EventType = "order.cancelled"
+	// EventOrderSLABreached - заказ не успел к сроку назначения или доставки своего уровня
+	EventOrderSLABreached EventType = "order.sla_breached"
+	// EventOrderUnassigned - заказ сняли с курьера, он снова ждёт назначения
+	EventOrderUnassigned EventType = "order.unassigned"
+
+	// EventTest - проверочное событие, отправляется только по запросу партнёра
+	EventTest EventType = "webhook.test"
+)
+
+var EventTypes = []EventType{EventOrderCreated, EventOrderAssigned, EventOrderCompleted, EventOrderCancelled,
+	EventOrderSLABreached, EventOrderUnassigned}
+
+var (
+	ErrInvalidSubscriptionId = errors.New("invalid subscription id")
+	ErrInvalidURL            = errors.New("webhook url must be absolute http or https url")
+	ErrInvalidSecret         = errors.New("webhook secret is required")
+	ErrInvalidEventTypes     = errors.New("webhook event types must be non-empty and known")
+)
+
+// Subscription - подписка партнёра на события заказов
+type Subscription struct {
+	id         uuid.UUID
+	url        string
+	secret     string
+	eventTypes []EventType
+	createdAt  time.Time
+}
+
+func NewSubscription(id uuid.UUID, rawURL string, secret string, eventTypes []EventType, now time.Time) (*Subscription, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidSubscriptionId
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	if strings.TrimSpace(secret) == "" {
+		return nil, ErrInvalidSecret
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, ErrInvalidEventTypes
+	}
+	unique := make([]EventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return nil, ErrInvalidEventTypes
+		}
+		if !slices.Contains(unique, eventType) {
+			unique = append(unique, eventType)
+		}
+	}
+
+	return &Subscription{
+		id:         id,
+		url:        rawURL,
+		secret:     secret,
+		eventTypes: unique,
+		createdAt:  now,
+	}, nil
+}
+
+// Accepts - нужно ли отправлять событие этого типа
+func (s *Subscription) Accepts(eventType EventType) bool {
+	return slices.Contains(s.eventTypes, eventType)
+}
+
+func (s *Subscription) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *Subscription) URL() string {
+	return s.url
+}
+
+func (s *Subscription) Secret() string {
+	return s.secret
+}
+
+func (s *Subscription) EventTypes() []EventType {
+	return slices.Clone(s.eventTypes)
+}
+
+func (s *Subscription) CreatedAt() time.Time {
+	return s.createdAt
+}
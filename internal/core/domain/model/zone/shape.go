@@ -0,0 +1,111 @@
+package zone
+
+import (
+	"errors"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+var ErrInvalidShape = errors.New("zone shape must be a cell range or a polygon of at least 3 vertices of one kind")
+
+// Shape - граница зоны: диапазон клеток сетки или многоугольник из клеток или точек WGS84
+type Shape struct {
+	cells kernel.Area
+	// polygon - вершины по порядку обхода, последняя соединяется с первой
+	polygon []kernel.Location
+}
+
+func NewCellsShape(cells kernel.Area) (Shape, error) {
+	if cells.IsEmpty() {
+		return Shape{}, ErrInvalidShape
+	}
+	return Shape{cells: cells}, nil
+}
+
+func NewPolygonShape(vertices []kernel.Location) (Shape, error) {
+	if len(vertices) < 3 {
+		return Shape{}, ErrInvalidShape
+	}
+	for _, vertex := range vertices {
+		if vertex.IsEmpty() || !vertex.SameKind(vertices[0]) {
+			return Shape{}, ErrInvalidShape
+		}
+	}
+	return Shape{polygon: append([]kernel.Location(nil), vertices...)}, nil
+}
+
+// Cells - диапазон клеток, пустой у многоугольника
+func (s Shape) Cells() kernel.Area {
+	return s.cells
+}
+
+// Polygon - вершины многоугольника, nil у диапазона клеток
+func (s Shape) Polygon() []kernel.Location {
+	if s.polygon == nil {
+		return nil
+	}
+	return append([]kernel.Location(nil), s.polygon...)
+}
+
+func (s Shape) IsPolygon() bool {
+	return len(s.polygon) > 0
+}
+
+// Contains - точки на границе многоугольника входят в зону
+func (s Shape) Contains(location kernel.Location) bool {
+	if !s.IsPolygon() {
+		return s.cells.Contains(location)
+	}
+	if location.IsEmpty() || !location.SameKind(s.polygon[0]) {
+		return false
+	}
+
+	x, y := coordinates(location)
+	inside := false
+	for i := range s.polygon {
+		x1, y1 := coordinates(s.polygon[i])
+		x2, y2 := coordinates(s.polygon[(i+1)%len(s.polygon)])
+		if onSegment(x, y, x1, y1, x2, y2) {
+			return true
+		}
+		if (y1 > y) != (y2 > y) && x < x1+(y-y1)*(x2-x1)/(y2-y1) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Validate - ошибка kernel.ErrLocationOutOfArea, если граница выходит за пределы города
+func (s Shape) Validate(bounds kernel.Bounds) error {
+	vertices := s.polygon
+	if !s.IsPolygon() {
+		vertices = []kernel.Location{s.cells.Min(), s.cells.Max()}
+	}
+	for _, vertex := range vertices {
+		if err := bounds.Validate(vertex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Shape) IsEmpty() bool {
+	return !s.IsPolygon() && s.cells.IsEmpty()
+}
+
+// coordinates - клетка как x и y, точка WGS84 как долгота и широта
+func coordinates(location kernel.Location) (float64, float64) {
+	if location.IsGeo() {
+		return location.Lon(), location.Lat()
+	}
+	return float64(location.X()), float64(location.Y())
+}
+
+func onSegment(x, y, x1, y1, x2, y2 float64) bool {
+	const tolerance = 1e-12
+	cross := (x2-x1)*(y-y1) - (y2-y1)*(x-x1)
+	if cross > tolerance || cross < -tolerance {
+		return false
+	}
+	return x >= min(x1, x2) && x <= max(x1, x2) && y >= min(y1, y2) && y <= max(y1, y2)
+}
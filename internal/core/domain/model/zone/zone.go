@@ -0,0 +1,107 @@
+package zone
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var (
+	ErrInvalidZoneId   = errors.New("invalid zone id")
+	ErrInvalidZoneName = errors.New("invalid zone name")
+)
+
+// Zone - район города. Заказы из зоны сначала предлагаются курьерам, для которых она домашняя
+type Zone struct {
+	id        uuid.UUID
+	region    kernel.RegionCode
+	name      string
+	shape     Shape
+	createdAt time.Time
+}
+
+// NewZone - зона внутри региона, координаты shape - в сетке этого региона
+func NewZone(id uuid.UUID, region kernel.RegionCode, name string, shape Shape, now time.Time) (*Zone, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidZoneId
+	}
+	if region.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("region")
+	}
+	zone := &Zone{id: id, region: region, createdAt: now}
+	if err := zone.Update(name, shape); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+func MustNewZone(name string, shape Shape) *Zone {
+	zone, err := NewZone(uuid.New(), kernel.DefaultRegion(), name, shape, time.Now().UTC())
+	if err != nil {
+		panic(err)
+	}
+	return zone
+}
+
+// Update - сменить название и границу зоны
+func (z *Zone) Update(name string, shape Shape) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrInvalidZoneName
+	}
+	if shape.IsEmpty() {
+		return ErrInvalidShape
+	}
+	z.name = name
+	z.shape = shape
+	return nil
+}
+
+func (z *Zone) Contains(location kernel.Location) bool {
+	return z.shape.Contains(location)
+}
+
+func (z *Zone) ID() uuid.UUID {
+	return z.id
+}
+
+func (z *Zone) Region() kernel.RegionCode {
+	return z.region
+}
+
+func (z *Zone) Name() string {
+	return z.name
+}
+
+func (z *Zone) Shape() Shape {
+	return z.shape
+}
+
+func (z *Zone) CreatedAt() time.Time {
+	return z.createdAt
+}
+
+func RestoreZone(ID uuid.UUID, region kernel.RegionCode, name string, shape Shape, createdAt time.Time) *Zone {
+	return &Zone{
+		id:        ID,
+		region:    region,
+		name:      name,
+		shape:     shape,
+		createdAt: createdAt,
+	}
+}
+
+// Locate - зона, в которую попадает location. Если зоны пересекаются, побеждает созданная раньше,
+// zones упорядочены по времени создания
+func Locate(zones []*Zone, location kernel.Location) (*Zone, bool) {
+	for _, zone := range zones {
+		if zone.Contains(location) {
+			return zone, true
+		}
+	}
+	return nil, false
+}
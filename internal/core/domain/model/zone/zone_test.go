@@ -0,0 +1,89 @@
+package zone_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+func location(x, y int) kernel.Location {
+	return kernel.MustNewLocation(x, y)
+}
+
+func TestNewZone(t *testing.T) {
+	cells, err := zone.NewCellsShape(kernel.MustNewArea(1, 1, 5, 5))
+	require.NoError(t, err)
+	now := time.Now()
+	region := kernel.DefaultRegion()
+
+	_, err = zone.NewZone(uuid.New(), region, "Центр", cells, now)
+	assert.NoError(t, err)
+	_, err = zone.NewZone(uuid.Nil, region, "Центр", cells, now)
+	assert.ErrorIs(t, err, zone.ErrInvalidZoneId)
+	_, err = zone.NewZone(uuid.New(), kernel.RegionCode{}, "Центр", cells, now)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	_, err = zone.NewZone(uuid.New(), region, " ", cells, now)
+	assert.ErrorIs(t, err, zone.ErrInvalidZoneName)
+	_, err = zone.NewZone(uuid.New(), region, "Центр", zone.Shape{}, now)
+	assert.ErrorIs(t, err, zone.ErrInvalidShape)
+}
+
+func TestNewPolygonShape(t *testing.T) {
+	_, err := zone.NewPolygonShape([]kernel.Location{location(1, 1), location(5, 1)})
+	assert.ErrorIs(t, err, zone.ErrInvalidShape)
+	_, err = zone.NewPolygonShape([]kernel.Location{location(1, 1), location(5, 1), kernel.MustNewGeoLocation(55.7, 37.6)})
+	assert.ErrorIs(t, err, zone.ErrInvalidShape)
+	_, err = zone.NewCellsShape(kernel.Area{})
+	assert.ErrorIs(t, err, zone.ErrInvalidShape)
+}
+
+func TestShape_Contains(t *testing.T) {
+	cells, err := zone.NewCellsShape(kernel.MustNewArea(1, 1, 5, 5))
+	require.NoError(t, err)
+	assert.True(t, cells.Contains(location(5, 5)))
+	assert.False(t, cells.Contains(location(6, 5)))
+
+	// Треугольник (1,1)-(9,1)-(1,9): клетки на гипотенузе входят в зону
+	triangle, err := zone.NewPolygonShape([]kernel.Location{location(1, 1), location(9, 1), location(1, 9)})
+	require.NoError(t, err)
+	assert.True(t, triangle.Contains(location(2, 2)))
+	assert.True(t, triangle.Contains(location(5, 5)))
+	assert.True(t, triangle.Contains(location(1, 1)))
+	assert.False(t, triangle.Contains(location(6, 6)))
+	assert.False(t, triangle.Contains(kernel.MustNewGeoLocation(2, 2)))
+
+	geo, err := zone.NewPolygonShape([]kernel.Location{
+		kernel.MustNewGeoLocation(55.70, 37.50),
+		kernel.MustNewGeoLocation(55.70, 37.60),
+		kernel.MustNewGeoLocation(55.80, 37.60),
+		kernel.MustNewGeoLocation(55.80, 37.50),
+	})
+	require.NoError(t, err)
+	assert.True(t, geo.Contains(kernel.MustNewGeoLocation(55.75, 37.55)))
+	assert.False(t, geo.Contains(kernel.MustNewGeoLocation(55.75, 37.65)))
+}
+
+func TestLocate(t *testing.T) {
+	center, err := zone.NewCellsShape(kernel.MustNewArea(4, 4, 7, 7))
+	require.NoError(t, err)
+	south, err := zone.NewCellsShape(kernel.MustNewArea(1, 1, 10, 5))
+	require.NoError(t, err)
+	first := zone.MustNewZone("Центр", center)
+	second := zone.MustNewZone("Юг", south)
+
+	got, ok := zone.Locate([]*zone.Zone{first, second}, location(5, 5))
+	require.True(t, ok)
+	assert.Equal(t, first.ID(), got.ID())
+	got, ok = zone.Locate([]*zone.Zone{first, second}, location(9, 2))
+	require.True(t, ok)
+	assert.Equal(t, second.ID(), got.ID())
+	_, ok = zone.Locate([]*zone.Zone{first, second}, location(9, 9))
+	assert.False(t, ok)
+}
@@ -1,17 +1,70 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
-type Dispatcher struct{}
+// Strategy - как диспетчер выбирает из курьеров, которые могут доехать до заказа
+type Strategy string
+
+const (
+	// StrategyFastest - курьер, который доберётся до заказа за меньшее число ходов
+	StrategyFastest Strategy = "fastest"
+	// StrategyFair - курьер, который дольше всех ждёт заказа, при равенстве - более быстрый
+	StrategyFair Strategy = "fair"
+)
+
+var ErrUnknownStrategy = errors.New("unknown dispatch strategy")
+
+func ParseStrategy(value string) (Strategy, error) {
+	switch strategy := Strategy(value); strategy {
+	case StrategyFastest, StrategyFair:
+		return strategy, nil
+	case "":
+		return StrategyFastest, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownStrategy, value)
+	}
+}
 
-func NewOrderDispatcher() *Dispatcher {
-	return &Dispatcher{}
+type Dispatcher struct {
+	router   routing.Router
+	profiles *courier.Profiles
+	strategy Strategy
+	now      func() time.Time
+}
+
+// NewOrderDispatcher - router считает путь курьера до заказа, routing.Direct - по прямой.
+// profiles - правила транспорта курьеров, без них любой транспорт едет по любой дороге со своей скоростью
+func NewOrderDispatcher(router routing.Router, profiles *courier.Profiles) *Dispatcher {
+	dispatcher, _ := NewOrderDispatcherWithStrategy(router, profiles, StrategyFastest)
+	return dispatcher
+}
+
+// NewOrderDispatcherWithStrategy - диспетчер со стратегией выбора курьера, у каждого региона она своя
+func NewOrderDispatcherWithStrategy(router routing.Router, profiles *courier.Profiles,
+	strategy Strategy) (*Dispatcher, error) {
+	if router == nil {
+		router = routing.Direct{}
+	}
+	switch strategy {
+	case StrategyFastest, StrategyFair:
+	default:
+		return nil, errs.NewValueIsInvalidError("strategy")
+	}
+	return &Dispatcher{router: router, profiles: profiles, strategy: strategy, now: time.Now}, nil
 }
 
+// Dispatch - назначить заказ курьеру по стратегии диспетчера. Число ходов считается с учётом профиля
+// транспорта и времени суток. Курьеры, которым до заказа не доехать, не рассматриваются.
+// Экспресс-заказ всегда получает самый быстрый курьер, даже при честной стратегии
 func (p *Dispatcher) Dispatch(order *order.Order, couriers []*courier.Courier) (*courier.Courier, error) {
 	if order == nil {
 		return nil, errs.NewValueIsRequiredError("order")
@@ -21,24 +74,37 @@ func (p *Dispatcher) Dispatch(order *order.Order, couriers []*courier.Courier) (
 		return nil, errs.NewValueIsRequiredError("couriers")
 	}
 
-	bestCourier := couriers[0]
-	minSteps, err := couriers[0].StepsToOrder(order.Location())
-	if err != nil {
-		return nil, err
-	}
-	for idx := range len(couriers) - 1 {
-		stepsToOrder, err := couriers[idx+1].StepsToOrder(order.Location())
+	now := p.now()
+	fair := p.strategy == StrategyFair && !order.Tier().IsExpress()
+	var bestCourier *courier.Courier
+	minSteps := 0
+	for _, candidate := range couriers {
+		vehicle := p.profiles.Vehicle(candidate.Transport(), now)
+		path, err := p.router.Route(candidate.Location(), order.Location(), vehicle)
+		if errors.Is(err, routing.ErrNoRoute) {
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		if stepsToOrder < minSteps {
+		stepsToOrder, err := candidate.StepsAlong(path, vehicle)
+		if errors.Is(err, routing.ErrNoRoute) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if bestCourier == nil || better(fair, candidate, stepsToOrder, bestCourier, minSteps) {
 			minSteps = stepsToOrder
-			bestCourier = couriers[idx+1]
+			bestCourier = candidate
 		}
 	}
+	if bestCourier == nil {
+		return nil, fmt.Errorf("%w to order %s from any courier", routing.ErrNoRoute, order.ID())
+	}
 
-	err = order.AssignToCourier(bestCourier.ID())
+	err := order.AssignToCourier(bestCourier.ID())
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +116,18 @@ func (p *Dispatcher) Dispatch(order *order.Order, couriers []*courier.Courier) (
 
 	return bestCourier, nil
 }
+
+func better(fair bool, candidate *courier.Courier, candidateSteps int, best *courier.Courier, bestSteps int) bool {
+	if fair {
+		candidateWait, bestWait := candidate.LastAssignedAt(), best.LastAssignedAt()
+		switch {
+		case candidateWait == nil && bestWait != nil:
+			return true
+		case candidateWait != nil && bestWait == nil:
+			return false
+		case candidateWait != nil && !candidateWait.Equal(*bestWait):
+			return candidateWait.Before(*bestWait)
+		}
+	}
+	return candidateSteps < bestSteps
+}
@@ -3,19 +3,24 @@ package services
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	model "github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
 	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
 )
 
+var orderAddress = kernel.MustNewAddress("", "", "Бажная", "", "")
+
 func TestDispatch_NilOrder(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 	courierLocation := kernel.MustNewLocation(10, 10)
 	couriers := []*model.Courier{
 		model.MustNewCourier("courier1", "bike", 3, courierLocation),
@@ -33,10 +38,10 @@ func TestDispatch_NilOrder(t *testing.T) {
 
 func TestDispatch_EmptyCouriers(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 	orderLocation := kernel.MustNewLocation(5, 5)
 	orderID := uuid.New()
-	order := order.MustNewOrder(orderID, orderLocation)
+	order := order.MustNewOrder(orderID, orderAddress, orderLocation)
 	var emptyCouriers []*model.Courier
 
 	// Act
@@ -51,7 +56,7 @@ func TestDispatch_EmptyCouriers(t *testing.T) {
 
 func TestDispatch_SuccessWithSingleCourier(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 
 	// Create locations
 	orderLocation := kernel.MustNewLocation(5, 5)
@@ -59,7 +64,7 @@ func TestDispatch_SuccessWithSingleCourier(t *testing.T) {
 
 	// Create order and courier
 	orderID := uuid.New()
-	order := order.MustNewOrder(orderID, orderLocation)
+	order := order.MustNewOrder(orderID, orderAddress, orderLocation)
 	courier := model.MustNewCourier("courier1", "bike", 3, courierLocation)
 
 	couriers := []*model.Courier{courier}
@@ -76,14 +81,14 @@ func TestDispatch_SuccessWithSingleCourier(t *testing.T) {
 
 func TestDispatch_SuccessSelectsBestCourier(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 
 	// Create order location
 	orderLocation := kernel.MustNewLocation(5, 5)
 
 	// Create order
 	orderID := uuid.New()
-	order := order.MustNewOrder(orderID, orderLocation)
+	order := order.MustNewOrder(orderID, orderAddress, orderLocation)
 
 	// Create couriers at different distances
 	courier1Location := kernel.MustNewLocation(10, 10)
@@ -110,7 +115,7 @@ func TestDispatch_SuccessSelectsBestCourier(t *testing.T) {
 
 func TestDispatch_SuccessWithEqualDistances(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 
 	// Create locations
 	orderLocation := kernel.MustNewLocation(5, 5)
@@ -120,7 +125,7 @@ func TestDispatch_SuccessWithEqualDistances(t *testing.T) {
 
 	// Create order and couriers
 	orderID := uuid.New()
-	order := order.MustNewOrder(orderID, orderLocation)
+	order := order.MustNewOrder(orderID, orderAddress, orderLocation)
 	courier1 := model.MustNewCourier("courier1", "bike", 3, courier1Location)
 	courier2 := model.MustNewCourier("courier2", "bike", 3, courier2Location)
 
@@ -139,7 +144,7 @@ func TestDispatch_SuccessWithEqualDistances(t *testing.T) {
 
 func TestDispatch_CourierWithFasterTransport(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 
 	// Create locations
 	orderLocation := kernel.MustNewLocation(5, 5)
@@ -148,7 +153,7 @@ func TestDispatch_CourierWithFasterTransport(t *testing.T) {
 
 	// Create order and couriers
 	orderID := uuid.New()
-	order := order.MustNewOrder(orderID, orderLocation)
+	order := order.MustNewOrder(orderID, orderAddress, orderLocation)
 	courier1 := model.MustNewCourier("courier1", "bike", 1, courier1Location) // Slower
 	courier2 := model.MustNewCourier("courier2", "car", 3, courier2Location)  // Faster
 
@@ -170,14 +175,14 @@ func TestDispatch_CourierWithFasterTransport(t *testing.T) {
 
 func TestDispatch_IndexBoundsInLoop(t *testing.T) {
 	// Arrange
-	dispatcher := NewOrderDispatcher()
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
 
 	// Create locations
 	orderLocation := kernel.MustNewLocation(3, 3)
 
 	// Create order
 	orderID := uuid.New()
-	order := order.MustNewOrder(orderID, orderLocation)
+	order := order.MustNewOrder(orderID, orderAddress, orderLocation)
 
 	// Create 5 couriers to ensure loop bounds are handled correctly
 	var couriers []*model.Courier
@@ -199,3 +204,133 @@ func TestDispatch_IndexBoundsInLoop(t *testing.T) {
 	assert.Equal(t, couriers[0].ID(), *order.AssignedCourier())
 	assert.True(t, couriers[0].IsBusy())
 }
+
+func TestDispatch_GeoLocationsSelectClosestByTime(t *testing.T) {
+	// Arrange
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewGeoLocation(55.7539, 37.6208))
+
+	// Пешком 300 м - 3 хода, на машине 1,5 км - 5 ходов
+	walker := model.MustNewCourier("walker", "walk", 1, kernel.MustNewGeoLocation(55.7566, 37.6208))
+	driver := model.MustNewCourier("driver", "car", 3, kernel.MustNewGeoLocation(55.7674, 37.6208))
+
+	// Act
+	result, err := dispatcher.Dispatch(order, []*model.Courier{driver, walker})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, walker, result)
+}
+
+func TestDispatch_RoadGraphSelectsClosestByRoad(t *testing.T) {
+	// Arrange
+	graph := routing.NewGraph()
+	require.NoError(t, graph.AddRoad(kernel.MustNewLocation(1, 1), kernel.MustNewLocation(10, 1), routing.RoadStreet, false))
+	require.NoError(t, graph.AddRoad(kernel.MustNewLocation(10, 1), kernel.MustNewLocation(10, 5), routing.RoadStreet, false))
+	require.NoError(t, graph.AddRoad(kernel.MustNewLocation(1, 5), kernel.MustNewLocation(5, 5), routing.RoadStreet, true))
+	dispatcher := NewOrderDispatcher(graph, nil)
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(10, 1))
+
+	// По прямой ближе второй, но с его улицы нет выезда
+	first := model.MustNewCourier("first", "bike", 1, kernel.MustNewLocation(1, 1))
+	second := model.MustNewCourier("second", "bike", 1, kernel.MustNewLocation(5, 5))
+
+	// Act
+	result, err := dispatcher.Dispatch(order, []*model.Courier{second, first})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, first, result)
+
+	_, err = dispatcher.Dispatch(order, []*model.Courier{second})
+	assert.ErrorIs(t, err, routing.ErrNoRoute)
+}
+
+func TestDispatch_TransportProfilesRestrictZones(t *testing.T) {
+	// Arrange
+	car, err := model.NewProfile(model.ProfileCar, 3, map[routing.RoadClass]float64{routing.RoadStreet: 1},
+		[]model.ZoneRule{{Name: "old_town", Bounds: kernel.MustNewArea(1, 8, 3, 10), Factor: 0}}, nil)
+	require.NoError(t, err)
+	profiles, err := model.NewProfiles(car)
+	require.NoError(t, err)
+	dispatcher := NewOrderDispatcher(routing.Direct{}, profiles)
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(2, 9))
+
+	// Машина ближе, но в пешеходный старый город ей нельзя
+	transport, err := car.NewTransport("Машина")
+	require.NoError(t, err)
+	driver, err := model.NewCourierWithTransport("driver", transport, kernel.MustNewLocation(4, 9))
+	require.NoError(t, err)
+	walker := model.MustNewCourier("walker", "walk", 1, kernel.MustNewLocation(2, 4))
+
+	// Act
+	result, err := dispatcher.Dispatch(order, []*model.Courier{driver, walker})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, walker, result)
+}
+
+func TestDispatch_FairStrategySelectsLongestWaitingCourier(t *testing.T) {
+	// Arrange
+	dispatcher, err := NewOrderDispatcherWithStrategy(routing.Direct{}, nil, StrategyFair)
+	require.NoError(t, err)
+	first := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(5, 5))
+	second := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(5, 5))
+
+	now := time.Now().UTC()
+	waitingCourier := func(name string, x, y int, lastAssignedAt *time.Time) *model.Courier {
+		transport := model.RestoreTransport(uuid.New(), "bike", 1, "")
+		return model.RestoreCourier(uuid.New(), kernel.DefaultRegion(), name, transport, kernel.MustNewLocation(x, y),
+			model.StatusFree, now.Add(-time.Hour), lastAssignedAt, nil, nil)
+	}
+	minuteAgo, hourAgo := now.Add(-time.Minute), now.Add(-time.Hour)
+	closest := waitingCourier("closest", 5, 6, &minuteAgo)
+	longestWaiting := waitingCourier("longest", 9, 9, &hourAgo)
+
+	// Act
+	result, err := dispatcher.Dispatch(first, []*model.Courier{closest, longestWaiting})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, longestWaiting, result)
+
+	// Курьер без заказов ждёт дольше всех
+	newcomer := waitingCourier("newcomer", 10, 10, nil)
+	result, err = dispatcher.Dispatch(second, []*model.Courier{closest, newcomer})
+	require.NoError(t, err)
+	assert.Equal(t, newcomer, result)
+}
+
+func TestDispatch_ExpressOrderIgnoresFairStrategy(t *testing.T) {
+	// Arrange
+	dispatcher, err := NewOrderDispatcherWithStrategy(routing.Direct{}, nil, StrategyFair)
+	require.NoError(t, err)
+	express, err := order.NewOrderInRegion(uuid.New(), kernel.DefaultRegion(), order.TierExpress, order.DeliveryWindow{},
+		orderAddress,
+		kernel.MustNewLocation(5, 5))
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	minuteAgo, hourAgo := now.Add(-time.Minute), now.Add(-time.Hour)
+	transport := model.RestoreTransport(uuid.New(), "bike", 1, "")
+	closest := model.RestoreCourier(uuid.New(), kernel.DefaultRegion(), "closest", transport,
+		kernel.MustNewLocation(5, 6), model.StatusFree, now.Add(-time.Hour), &minuteAgo, nil, nil)
+	longestWaiting := model.RestoreCourier(uuid.New(), kernel.DefaultRegion(), "longest", transport,
+		kernel.MustNewLocation(9, 9), model.StatusFree, now.Add(-time.Hour), &hourAgo, nil, nil)
+
+	// Act
+	result, err := dispatcher.Dispatch(express, []*model.Courier{longestWaiting, closest})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, closest, result)
+}
+
+func TestNewOrderDispatcherWithStrategy_UnknownStrategy(t *testing.T) {
+	_, err := NewOrderDispatcherWithStrategy(routing.Direct{}, nil, "nearest")
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	_, err = ParseStrategy("nearest")
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+}
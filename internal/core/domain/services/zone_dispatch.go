@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+var ErrNoCouriersInZone = errors.New("no free couriers for order zone yet")
+
+// DispatchInZones - назначить заказ с учётом зон. Заказ из зоны сначала предлагается курьерам этой зоны,
+// затем курьерам без зоны, а курьерам других зон - только когда он прождёт crossZoneWait с момента создания.
+// Экспресс-заказ не ждёт и сразу предлагается курьерам других зон.
+// Заказы вне зон и курьеры, чья зона удалена, не ограничены зонами
+func (p *Dispatcher) DispatchInZones(order *order.Order, couriers []*courier.Courier, zones []*zone.Zone,
+	crossZoneWait time.Duration) (*courier.Courier, error) {
+	if order == nil {
+		return nil, errs.NewValueIsRequiredError("order")
+	}
+
+	orderZone, ok := zone.Locate(zones, order.Location())
+	if !ok {
+		return p.Dispatch(order, couriers)
+	}
+
+	known := make(map[uuid.UUID]bool, len(zones))
+	for _, z := range zones {
+		known[z.ID()] = true
+	}
+	var sameZone, noZone, otherZones []*courier.Courier
+	for _, candidate := range couriers {
+		homeZoneID := candidate.HomeZoneID()
+		switch {
+		case homeZoneID == nil || !known[*homeZoneID]:
+			noZone = append(noZone, candidate)
+		case *homeZoneID == orderZone.ID():
+			sameZone = append(sameZone, candidate)
+		default:
+			otherZones = append(otherZones, candidate)
+		}
+	}
+
+	tiers := [][]*courier.Courier{sameZone, noZone}
+	if order.Tier().IsExpress() || p.now().Sub(order.CreatedAt()) >= crossZoneWait {
+		tiers = append(tiers, otherZones)
+	}
+	err := ErrNoCouriersInZone
+	for _, tier := range tiers {
+		if len(tier) == 0 {
+			continue
+		}
+		assigned, dispatchErr := p.Dispatch(order, tier)
+		if errors.Is(dispatchErr, routing.ErrNoRoute) {
+			err = dispatchErr
+			continue
+		}
+		return assigned, dispatchErr
+	}
+	return nil, err
+}
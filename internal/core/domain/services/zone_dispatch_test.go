@@ -0,0 +1,130 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	model "github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+)
+
+// zones - север и юг города, граница по y=5
+func zones(t *testing.T) (north, south *zone.Zone) {
+	northShape, err := zone.NewCellsShape(kernel.MustNewArea(1, 6, 10, 10))
+	require.NoError(t, err)
+	southShape, err := zone.NewCellsShape(kernel.MustNewArea(1, 1, 10, 5))
+	require.NoError(t, err)
+	return zone.MustNewZone("Север", northShape), zone.MustNewZone("Юг", southShape)
+}
+
+func zoneCourier(name string, x, y int, homeZone *zone.Zone) *model.Courier {
+	courier := model.MustNewCourier(name, "bike", 1, kernel.MustNewLocation(x, y))
+	if homeZone != nil {
+		zoneID := homeZone.ID()
+		courier.SetHomeZone(&zoneID)
+	}
+	return courier
+}
+
+func TestDispatchInZones_PrefersSameZoneCourier(t *testing.T) {
+	// Arrange
+	north, south := zones(t)
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(5, 5))
+
+	// Курьер севера ближе к заказу, но заказ в южной зоне
+	northern := zoneCourier("northern", 5, 6, north)
+	cityWide := zoneCourier("city", 5, 8, nil)
+	southern := zoneCourier("southern", 1, 1, south)
+
+	// Act
+	result, err := dispatcher.DispatchInZones(order, []*model.Courier{northern, cityWide, southern},
+		[]*zone.Zone{north, south}, time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, southern, result)
+}
+
+func TestDispatchInZones_FallsBackToCourierWithoutZone(t *testing.T) {
+	// Arrange
+	north, south := zones(t)
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(5, 5))
+
+	northern := zoneCourier("northern", 5, 6, north)
+	cityWide := zoneCourier("city", 5, 8, nil)
+
+	// Act
+	result, err := dispatcher.DispatchInZones(order, []*model.Courier{northern, cityWide},
+		[]*zone.Zone{north, south}, time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, cityWide, result)
+}
+
+func TestDispatchInZones_CrossesZoneAfterWait(t *testing.T) {
+	// Arrange
+	north, south := zones(t)
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(5, 5))
+	northern := zoneCourier("northern", 5, 6, north)
+
+	// Act: заказ ещё не прождал минуту
+	_, err := dispatcher.DispatchInZones(order, []*model.Courier{northern}, []*zone.Zone{north, south}, time.Minute)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNoCouriersInZone)
+	assert.True(t, northern.IsFree())
+
+	// Act: минута прошла
+	dispatcher.now = func() time.Time { return order.CreatedAt().Add(time.Minute) }
+	result, err := dispatcher.DispatchInZones(order, []*model.Courier{northern}, []*zone.Zone{north, south}, time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, northern, result)
+}
+
+func TestDispatchInZones_ExpressOrderCrossesZoneWithoutWait(t *testing.T) {
+	// Arrange
+	north, south := zones(t)
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
+	express, err := order.NewOrderInRegion(uuid.New(), kernel.DefaultRegion(), order.TierExpress, order.DeliveryWindow{},
+		orderAddress,
+		kernel.MustNewLocation(5, 5))
+	require.NoError(t, err)
+	northern := zoneCourier("northern", 5, 6, north)
+
+	// Act
+	result, err := dispatcher.DispatchInZones(express, []*model.Courier{northern}, []*zone.Zone{north, south}, time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, northern, result)
+}
+
+func TestDispatchInZones_IgnoresZonesOutsideOrderAndDeletedZones(t *testing.T) {
+	// Arrange
+	north, south := zones(t)
+	dispatcher := NewOrderDispatcher(routing.Direct{}, nil)
+
+	// Заказ в южной зоне, зона севера удалена: её курьер работает по всему городу
+	order := order.MustNewOrder(uuid.New(), orderAddress, kernel.MustNewLocation(5, 5))
+	northern := zoneCourier("northern", 5, 6, north)
+
+	// Act
+	result, err := dispatcher.DispatchInZones(order, []*model.Courier{northern}, []*zone.Zone{south}, time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, northern, result)
+}
@@ -2,15 +2,23 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 )
 
 type CourierRepository interface {
 	Add(ctx context.Context, aggregate *courier.Courier) error
 	Update(ctx context.Context, aggregate *courier.Courier) error
 	Get(ctx context.Context, ID uuid.UUID) (*courier.Courier, error)
-	GetAllInFreeStatus(ctx context.Context) ([]*courier.Courier, error)
+	GetAllInFreeStatus(ctx context.Context, region kernel.RegionCode) ([]*courier.Courier, error)
+	// GetAllStale - курьеры всех регионов, ещё не признанные недоступными, последний сигнал от которых
+	// пришёл раньше seenBefore. Курьеры, ни разу не выходившие на связь, не возвращаются
+	GetAllStale(ctx context.Context, seenBefore time.Time) ([]*courier.Courier, error)
+	// GetAllNeverSeen - курьеры всех регионов, ещё не признанные недоступными, ни разу не выходившие на связь
+	// и созданные раньше createdBefore
+	GetAllNeverSeen(ctx context.Context, createdBefore time.Time) ([]*courier.Courier, error)
 }
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+// DomainEventPublisher - отправляет доменные события за пределы сервиса
+type DomainEventPublisher interface {
+	Publish(ctx context.Context, events ...ddd.DomainEvent) error
+}
+
+// DomainEventOutbox - сохраняет доменные события в транзакции агрегатов, которые их подняли.
+// Откат транзакции отменяет и сохранённые события, поэтому после Commit они не теряются
+type DomainEventOutbox interface {
+	Save(ctx context.Context, events ...ddd.DomainEvent) error
+}
+
+// DomainEventSubscriber - подписка на доменные события внутри процесса.
+// В канал попадают только события, для которых filter вернул true, filter nil - все события.
+// Канал закрывается после unsubscribe или если подписчик не успевает читать отобранные события.
+type DomainEventSubscriber interface {
+	Subscribe(filter func(event ddd.DomainEvent) bool) (events <-chan ddd.DomainEvent, unsubscribe func())
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+)
+
+type GeoCache interface {
+	Invalidate(ctx context.Context, address kernel.Address) error
+	InvalidateAll(ctx context.Context) error
+	Stats() GeoCacheStats
+}
+
+type GeoCacheStats struct {
+	Hits           int64
+	NegativeHits   int64
+	PersistentHits int64
+	Misses         int64
+	StaleHits      int64
+	Evictions      int64
+	Size           int
+}
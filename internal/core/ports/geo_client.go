@@ -2,10 +2,16 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 )
 
+var (
+	ErrGeolocationNotFound   = errors.New("geolocation not found")
+	ErrGeoServiceUnavailable = errors.New("geo service unavailable")
+)
+
 type GeoClient interface {
-	GetGeolocation(ctx context.Context, street string) (kernel.Location, error)
+	GetGeolocation(ctx context.Context, address kernel.Address) (kernel.Location, error)
 }
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Message - сообщение брокера. Offset и Partition заполняет брокер.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+}
+
+type MessageConsumer interface {
+	// Subscribe - вступить в группу и читать топик. onRevoke вызывается из Poll
+	// перед отзывом партиций при ребалансе, пока их offset ещё можно зафиксировать.
+	Subscribe(topic string, onRevoke func(partitions []int32)) error
+	// Poll - следующее сообщение или nil, если за timeout ничего не пришло
+	Poll(timeout time.Duration) (*Message, error)
+	// Commit - зафиксировать offset следующих к чтению сообщений по партициям
+	Commit(topic string, offsets map[int32]int64) error
+	Close() error
+}
+
+type MessagePublisher interface {
+	Publish(ctx context.Context, message Message) error
+	Close() error
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
 	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
 )
 
@@ -12,6 +13,14 @@ type OrderRepository interface {
 	Add(ctx context.Context, aggregate *order.Order) error
 	Update(ctx context.Context, aggregate *order.Order) error
 	Get(ctx context.Context, ID uuid.UUID) (*order.Order, error)
+	// GetFirstInCreatedStatus - первый в очереди на назначение заказ, порядок как у GetAllInCreatedStatus
 	GetFirstInCreatedStatus(ctx context.Context) (*order.Order, error)
-	GetAllInAssignedStatus(ctx context.Context) ([]*order.Order, error)
+	// GetAllInCreatedStatus - ожидающие курьера заказы региона в порядке order.CompareForDispatch:
+	// сначала экспресс, внутри уровня созданные раньше первыми
+	GetAllInCreatedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error)
+	GetAllInAssignedStatus(ctx context.Context, region kernel.RegionCode) ([]*order.Order, error)
+	GetAllInPendingGeocodeStatus(ctx context.Context) ([]*order.Order, error)
+	// GetAllNotFinal - все ещё не доставленные и не отменённые заказы всех регионов
+	GetAllNotFinal(ctx context.Context) ([]*order.Order, error)
+	GetAllAssignedToCourier(ctx context.Context, courierID uuid.UUID) ([]*order.Order, error)
 }
@@ -0,0 +1,340 @@
+package portstest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/routing"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+// CourierRepositoryFactory - создать пустой репозиторий и UnitOfWork, работающий с тем же хранилищем
+type CourierRepositoryFactory func(t *testing.T) (uow.UnitOfWork, ports.CourierRepository)
+
+// RunCourierRepositoryContract - проверить, что реализация ports.CourierRepository соблюдает общий контракт
+func RunCourierRepositoryContract(t *testing.T, newRepository CourierRepositoryFactory) {
+	t.Run("Add and Get", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		courierAggregate := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		require.NoError(t, repository.Add(ctx, courierAggregate))
+
+		got, err := repository.Get(ctx, courierAggregate.ID())
+		require.NoError(t, err)
+		assertCouriersEqual(t, courierAggregate, got)
+	})
+
+	t.Run("Add and Get keeps transport profile", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		profile, err := courier.NewProfile(courier.ProfileScooter, 2, map[routing.RoadClass]float64{routing.RoadStreet: 1},
+			nil, nil)
+		require.NoError(t, err)
+		transport, err := profile.NewTransport("Самокат")
+		require.NoError(t, err)
+		courierAggregate, err := courier.NewCourierWithTransport("Самокат", transport, kernel.MustNewLocation(4, 5))
+		require.NoError(t, err)
+		require.NoError(t, repository.Add(ctx, courierAggregate))
+
+		got, err := repository.Get(ctx, courierAggregate.ID())
+		require.NoError(t, err)
+		assertCouriersEqual(t, courierAggregate, got)
+		assert.Equal(t, courier.ProfileScooter, got.Transport().Profile())
+	})
+
+	t.Run("Update keeps home zone", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		courierAggregate := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		require.NoError(t, repository.Add(ctx, courierAggregate))
+		zoneID := uuid.New()
+		courierAggregate.SetHomeZone(&zoneID)
+		require.NoError(t, repository.Update(ctx, courierAggregate))
+
+		got, err := repository.Get(ctx, courierAggregate.ID())
+		require.NoError(t, err)
+		assertCouriersEqual(t, courierAggregate, got)
+
+		courierAggregate.SetHomeZone(nil)
+		require.NoError(t, repository.Update(ctx, courierAggregate))
+		got, err = repository.Get(ctx, courierAggregate.ID())
+		require.NoError(t, err)
+		assert.Nil(t, got.HomeZoneID())
+	})
+
+	t.Run("Get unknown returns ErrObjectNotFound", func(t *testing.T) {
+		_, repository := newRepository(t)
+
+		got, err := repository.Get(context.Background(), uuid.New())
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	})
+
+	t.Run("Add duplicate returns error", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		courierAggregate := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		require.NoError(t, repository.Add(ctx, courierAggregate))
+		assert.Error(t, repository.Add(ctx, courierAggregate))
+	})
+
+	t.Run("Update persists changes", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		courierAggregate := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		require.NoError(t, repository.Add(ctx, courierAggregate))
+		require.NoError(t, courierAggregate.SetBusy())
+		require.NoError(t, courierAggregate.Move(kernel.MustNewLocation(10, 10)))
+		require.NoError(t, repository.Update(ctx, courierAggregate))
+
+		got, err := repository.Get(ctx, courierAggregate.ID())
+		require.NoError(t, err)
+		assertCouriersEqual(t, courierAggregate, got)
+	})
+
+	t.Run("GetAllInFreeStatus filters by status", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		got, err := repository.GetAllInFreeStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		busy := courier.MustNewCourier("Авто", "Машина", 3, kernel.MustNewLocation(7, 9))
+		require.NoError(t, busy.SetBusy())
+		require.NoError(t, repository.Add(ctx, busy))
+		free := courier.MustNewCourier("Пеший", "Пешком", 1, kernel.MustNewLocation(1, 3))
+		require.NoError(t, repository.Add(ctx, free))
+
+		got, err = repository.GetAllInFreeStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertCouriersEqual(t, free, got[0])
+	})
+
+	t.Run("GetAllInFreeStatus filters by region", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		kazan := kernel.MustNewRegionCode("kzn")
+		transport, err := courier.NewTransport("Велосипед", 2)
+		require.NoError(t, err)
+		kazanCourier, err := courier.NewCourierInRegion(kazan, "Вело", transport, kernel.MustNewLocation(4, 5))
+		require.NoError(t, err)
+		require.NoError(t, repository.Add(ctx, kazanCourier))
+		require.NoError(t, repository.Add(ctx, courier.MustNewCourier("Пеший", "Пешком", 1, kernel.MustNewLocation(1, 3))))
+
+		got, err := repository.GetAllInFreeStatus(ctx, kazan)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertCouriersEqual(t, kazanCourier, got[0])
+	})
+
+	t.Run("GetAllStale skips silent and offline couriers", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		now := time.Now().UTC()
+		silent := courier.MustNewCourier("Молчун", "Пешком", 1, kernel.MustNewLocation(1, 3))
+		require.NoError(t, repository.Add(ctx, silent))
+		fresh := courier.MustNewCourier("На связи", "Пешком", 1, kernel.MustNewLocation(1, 3))
+		fresh.Heartbeat(now)
+		require.NoError(t, repository.Add(ctx, fresh))
+		offline := courier.MustNewCourier("Пропал", "Пешком", 1, kernel.MustNewLocation(1, 3))
+		offline.Heartbeat(now.Add(-time.Hour))
+		require.NoError(t, offline.GoOffline())
+		require.NoError(t, repository.Add(ctx, offline))
+		stale := courier.MustNewCourier("Замолчал", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		stale.Heartbeat(now.Add(-time.Hour))
+		require.NoError(t, stale.SetBusy())
+		require.NoError(t, repository.Add(ctx, stale))
+
+		got, err := repository.GetAllStale(ctx, now.Add(-time.Minute))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertCouriersEqual(t, stale, got[0])
+	})
+
+	t.Run("GetAllNeverSeen returns only long-silent couriers without signals", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		now := time.Now().UTC()
+		transport := courier.MustNewTransport("Пешком", 1)
+		neverSeen := courier.RestoreCourier(uuid.New(), kernel.DefaultRegion(), "Молчун", transport,
+			kernel.MustNewLocation(1, 3), courier.StatusFree, now.Add(-time.Hour), nil, nil, nil)
+		require.NoError(t, repository.Add(ctx, neverSeen))
+		justAdded := courier.MustNewCourier("Новичок", "Пешком", 1, kernel.MustNewLocation(1, 3))
+		require.NoError(t, repository.Add(ctx, justAdded))
+		seenAt := now.Add(-time.Hour)
+		seen := courier.RestoreCourier(uuid.New(), kernel.DefaultRegion(), "Выходил", transport,
+			kernel.MustNewLocation(1, 3), courier.StatusFree, now.Add(-time.Hour), nil, nil, &seenAt)
+		require.NoError(t, repository.Add(ctx, seen))
+		offline := courier.RestoreCourier(uuid.New(), kernel.DefaultRegion(), "Пропал", transport,
+			kernel.MustNewLocation(1, 3), courier.StatusOffline, now.Add(-time.Hour), nil, nil, nil)
+		require.NoError(t, repository.Add(ctx, offline))
+
+		got, err := repository.GetAllNeverSeen(ctx, now.Add(-time.Minute))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertCouriersEqual(t, neverSeen, got[0])
+	})
+
+	t.Run("Rollback discards changes", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		existing := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		require.NoError(t, repository.Add(ctx, existing))
+
+		txCtx := unitOfWork.Begin(ctx)
+		added := courier.MustNewCourier("Авто", "Машина", 3, kernel.MustNewLocation(7, 9))
+		require.NoError(t, repository.Add(txCtx, added))
+		require.NoError(t, existing.SetBusy())
+		require.NoError(t, repository.Update(txCtx, existing))
+
+		got, err := repository.Get(txCtx, existing.ID())
+		require.NoError(t, err)
+		assert.True(t, got.IsBusy())
+
+		require.NoError(t, unitOfWork.Rollback(txCtx))
+
+		_, err = repository.Get(ctx, added.ID())
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+		got, err = repository.Get(ctx, existing.ID())
+		require.NoError(t, err)
+		assert.True(t, got.IsFree())
+	})
+
+	t.Run("Commit applies changes", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		txCtx := unitOfWork.Begin(ctx)
+		added := courier.MustNewCourier("Авто", "Машина", 3, kernel.MustNewLocation(7, 9))
+		require.NoError(t, repository.Add(txCtx, added))
+		require.NoError(t, unitOfWork.Commit(txCtx))
+
+		got, err := repository.Get(ctx, added.ID())
+		require.NoError(t, err)
+		assertCouriersEqual(t, added, got)
+	})
+
+	t.Run("Concurrent Update of the same courier fails", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		existing := courier.MustNewCourier("Вело", "Велосипед", 2, kernel.MustNewLocation(4, 5))
+		require.NoError(t, repository.Add(ctx, existing))
+
+		firstCtx := unitOfWork.Begin(ctx)
+		defer func() { _ = unitOfWork.Rollback(firstCtx) }()
+		inFirst, err := repository.Get(firstCtx, existing.ID())
+		require.NoError(t, err)
+
+		secondCtx := unitOfWork.Begin(ctx)
+		inSecond, err := repository.Get(secondCtx, existing.ID())
+		require.NoError(t, err)
+		require.NoError(t, inSecond.SetBusy())
+		require.NoError(t, repository.Update(secondCtx, inSecond))
+		require.NoError(t, unitOfWork.Commit(secondCtx))
+
+		// Курьера уже заняла вторая транзакция, первая не может занять его ещё раз
+		require.NoError(t, inFirst.SetBusy())
+		err = repository.Update(firstCtx, inFirst)
+		if err == nil {
+			err = unitOfWork.Commit(firstCtx)
+		}
+		assert.Error(t, err)
+	})
+
+	t.Run("Concurrent Add and Update", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		const workers = 10
+		aggregates := make([]*courier.Courier, workers)
+		for i := range aggregates {
+			aggregates[i] = courier.MustNewCourier(fmt.Sprintf("courier%d", i), "Велосипед", 2,
+				kernel.CreateRandomLocation())
+		}
+
+		var wg sync.WaitGroup
+		errors := make(chan error, workers*3)
+		for _, aggregate := range aggregates {
+			wg.Add(1)
+			go func(aggregate *courier.Courier) {
+				defer wg.Done()
+				txCtx := unitOfWork.Begin(ctx)
+				defer func() { _ = unitOfWork.Rollback(txCtx) }()
+
+				errors <- repository.Add(txCtx, aggregate)
+				errors <- aggregate.SetBusy()
+				errors <- repository.Update(txCtx, aggregate)
+				if err := unitOfWork.Commit(txCtx); err != nil {
+					errors <- err
+				}
+			}(aggregate)
+		}
+		wg.Wait()
+		close(errors)
+		for err := range errors {
+			require.NoError(t, err)
+		}
+
+		for _, aggregate := range aggregates {
+			got, err := repository.Get(ctx, aggregate.ID())
+			require.NoError(t, err)
+			assert.True(t, got.IsBusy())
+		}
+		free, err := repository.GetAllInFreeStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		assert.Empty(t, free)
+	})
+}
+
+func assertCouriersEqual(t *testing.T, expected *courier.Courier, actual *courier.Courier) {
+	t.Helper()
+	require.NotNil(t, actual)
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.Region(), actual.Region())
+	assert.Equal(t, expected.Name(), actual.Name())
+	assert.Equal(t, expected.Status(), actual.Status())
+	assert.True(t, expected.Location().Equals(actual.Location()),
+		"location: expected %v, got %v", expected.Location(), actual.Location())
+	assert.True(t, expected.Transport().Equals(*actual.Transport()))
+	assert.Equal(t, expected.Transport().Name(), actual.Transport().Name())
+	assert.Equal(t, expected.Transport().Speed(), actual.Transport().Speed())
+	assert.Equal(t, expected.Transport().Profile(), actual.Transport().Profile())
+	assert.Equal(t, expected.HomeZoneID(), actual.HomeZoneID())
+	// Postgres хранит время с точностью до микросекунд
+	assert.WithinDuration(t, expected.CreatedAt(), actual.CreatedAt(), time.Millisecond)
+	if expected.LastAssignedAt() == nil {
+		assert.Nil(t, actual.LastAssignedAt())
+	} else {
+		require.NotNil(t, actual.LastAssignedAt())
+		assert.WithinDuration(t, *expected.LastAssignedAt(), *actual.LastAssignedAt(), time.Millisecond)
+	}
+	if expected.LastSeenAt() == nil {
+		assert.Nil(t, actual.LastSeenAt())
+	} else {
+		require.NotNil(t, actual.LastSeenAt())
+		assert.WithinDuration(t, *expected.LastSeenAt(), *actual.LastSeenAt(), time.Millisecond)
+	}
+}
@@ -0,0 +1,466 @@
+package portstest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/order"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/uow"
+)
+
+var testAddress = kernel.MustNewAddress("Россия", "Москва", "Бажная", "1", "2")
+
+// OrderRepositoryFactory - создать пустой репозиторий и UnitOfWork, работающий с тем же хранилищем
+type OrderRepositoryFactory func(t *testing.T) (uow.UnitOfWork, ports.OrderRepository)
+
+// RunOrderRepositoryContract - проверить, что реализация ports.OrderRepository соблюдает общий контракт
+func RunOrderRepositoryContract(t *testing.T, newRepository OrderRepositoryFactory) {
+	t.Run("Add and Get", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		orderAggregate := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, repository.Add(ctx, orderAggregate))
+
+		got, err := repository.Get(ctx, orderAggregate.ID())
+		require.NoError(t, err)
+		assertOrdersEqual(t, orderAggregate, got)
+	})
+
+	t.Run("Add and Get keeps WGS84 location", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		orderAggregate := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewGeoLocation(55.7539, 37.6208))
+		require.NoError(t, repository.Add(ctx, orderAggregate))
+
+		got, err := repository.Get(ctx, orderAggregate.ID())
+		require.NoError(t, err)
+		assertOrdersEqual(t, orderAggregate, got)
+		assert.True(t, got.Location().IsGeo())
+	})
+
+	t.Run("Get unknown returns ErrObjectNotFound", func(t *testing.T) {
+		_, repository := newRepository(t)
+
+		got, err := repository.Get(context.Background(), uuid.New())
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	})
+
+	t.Run("Add duplicate returns error", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		orderAggregate := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, repository.Add(ctx, orderAggregate))
+		assert.Error(t, repository.Add(ctx, orderAggregate))
+	})
+
+	t.Run("Update persists changes", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		orderAggregate := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, repository.Add(ctx, orderAggregate))
+		require.NoError(t, orderAggregate.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Update(ctx, orderAggregate))
+
+		got, err := repository.Get(ctx, orderAggregate.ID())
+		require.NoError(t, err)
+		assertOrdersEqual(t, orderAggregate, got)
+	})
+
+	t.Run("GetFirstInCreatedStatus without created orders returns ErrObjectNotFound", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		_, err := repository.GetFirstInCreatedStatus(ctx)
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+
+		assigned := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, assigned.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Add(ctx, assigned))
+
+		_, err = repository.GetFirstInCreatedStatus(ctx)
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	})
+
+	t.Run("GetFirstInCreatedStatus filters by status", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		assigned := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, assigned.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Add(ctx, assigned))
+		created := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, repository.Add(ctx, created))
+
+		got, err := repository.GetFirstInCreatedStatus(ctx)
+		require.NoError(t, err)
+		assertOrdersEqual(t, created, got)
+	})
+
+	t.Run("GetAllInCreatedStatus returns oldest first", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		older := order.RestoreOrder(uuid.New(), kernel.DefaultRegion(), order.TierStandard, order.DeliveryWindow{},
+			nil, testAddress, kernel.MustNewLocation(2, 2), order.StatusCreated,
+			order.Timestamps{CreatedAt: time.Now().UTC().Add(-time.Minute)}, "")
+		newer := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 3))
+		assigned := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, assigned.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Add(ctx, older))
+		require.NoError(t, repository.Add(ctx, assigned))
+		require.NoError(t, repository.Add(ctx, newer))
+
+		got, err := repository.GetAllInCreatedStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assertOrdersEqual(t, older, got[0])
+		assertOrdersEqual(t, newer, got[1])
+	})
+
+	t.Run("GetAllInCreatedStatus returns express first and scheduled last", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		createdAt := time.Now().UTC().Add(-time.Hour)
+		newOrder := func(tier order.Tier, minutes int) *order.Order {
+			return order.RestoreOrder(uuid.New(), kernel.DefaultRegion(), tier, order.DeliveryWindow{}, nil, testAddress,
+				kernel.MustNewLocation(2, 2), order.StatusCreated,
+				order.Timestamps{CreatedAt: createdAt.Add(time.Duration(minutes) * time.Minute)}, "")
+		}
+		scheduled := newOrder(order.TierScheduled, 0)
+		standard := newOrder(order.TierStandard, 1)
+		laterExpress := newOrder(order.TierExpress, 3)
+		express := newOrder(order.TierExpress, 2)
+		for _, aggregate := range []*order.Order{scheduled, standard, laterExpress, express} {
+			require.NoError(t, repository.Add(ctx, aggregate))
+		}
+
+		got, err := repository.GetAllInCreatedStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		require.Len(t, got, 4)
+		assertOrdersEqual(t, express, got[0])
+		assertOrdersEqual(t, laterExpress, got[1])
+		assertOrdersEqual(t, standard, got[2])
+		assertOrdersEqual(t, scheduled, got[3])
+
+		first, err := repository.GetFirstInCreatedStatus(ctx)
+		require.NoError(t, err)
+		assertOrdersEqual(t, express, first)
+	})
+
+	t.Run("GetAllInCreatedStatus orders tiers by priority", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		// Уровни с меньшим приоритетом созданы раньше, порядок задаёт только Tier.Priority
+		createdAt := time.Now().UTC().Add(-time.Hour)
+		tiers := order.Tiers()
+		slices.SortFunc(tiers, func(a, b order.Tier) int { return b.Priority() - a.Priority() })
+		var expected []*order.Order
+		for i, tier := range tiers {
+			aggregate := order.RestoreOrder(uuid.New(), kernel.DefaultRegion(), tier, order.DeliveryWindow{}, nil,
+				testAddress, kernel.MustNewLocation(2, 2), order.StatusCreated,
+				order.Timestamps{CreatedAt: createdAt.Add(time.Duration(i) * time.Minute)}, "")
+			require.NoError(t, repository.Add(ctx, aggregate))
+			expected = append(expected, aggregate)
+		}
+		slices.SortFunc(expected, order.CompareForDispatch)
+
+		got, err := repository.GetAllInCreatedStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		require.Len(t, got, len(expected))
+		for i := range expected {
+			assert.Equal(t, expected[i].Tier(), got[i].Tier())
+		}
+	})
+
+	t.Run("GetAllInCreatedStatus filters by region", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		kazan := kernel.MustNewRegionCode("kzn")
+		kazanOrder, err := order.NewOrderInRegion(uuid.New(), kazan, order.TierStandard, order.DeliveryWindow{},
+			testAddress,
+			kernel.MustNewLocation(2, 2))
+		require.NoError(t, err)
+		require.NoError(t, repository.Add(ctx, kazanOrder))
+		require.NoError(t, repository.Add(ctx, order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 3))))
+
+		got, err := repository.GetAllInCreatedStatus(ctx, kazan)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertOrdersEqual(t, kazanOrder, got[0])
+	})
+
+	t.Run("GetAllInAssignedStatus filters by status", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		got, err := repository.GetAllInAssignedStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		created := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, repository.Add(ctx, created))
+		completed := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(4, 4))
+		require.NoError(t, completed.AssignToCourier(uuid.New()))
+		require.NoError(t, completed.Complete())
+		require.NoError(t, repository.Add(ctx, completed))
+		assigned := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, assigned.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Add(ctx, assigned))
+
+		got, err = repository.GetAllInAssignedStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertOrdersEqual(t, assigned, got[0])
+	})
+
+	t.Run("GetAllAssignedToCourier filters by courier and status", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+		courierID := uuid.New()
+
+		got, err := repository.GetAllAssignedToCourier(ctx, courierID)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		assigned := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, assigned.AssignToCourier(courierID))
+		require.NoError(t, repository.Add(ctx, assigned))
+		other := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, other.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Add(ctx, other))
+		completed := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(4, 4))
+		require.NoError(t, completed.AssignToCourier(courierID))
+		require.NoError(t, completed.Complete())
+		require.NoError(t, repository.Add(ctx, completed))
+
+		got, err = repository.GetAllAssignedToCourier(ctx, courierID)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertOrdersEqual(t, assigned, got[0])
+	})
+
+	t.Run("GetAllInPendingGeocodeStatus filters by status", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		got, err := repository.GetAllInPendingGeocodeStatus(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		created := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, repository.Add(ctx, created))
+		pending, err := order.NewPendingGeocodeOrder(uuid.New(), testAddress)
+		require.NoError(t, err)
+		require.NoError(t, repository.Add(ctx, pending))
+
+		got, err = repository.GetAllInPendingGeocodeStatus(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertOrdersEqual(t, pending, got[0])
+	})
+
+	t.Run("GetAllNotFinal skips completed and cancelled orders", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		pending, err := order.NewPendingGeocodeOrder(uuid.New(), testAddress)
+		require.NoError(t, err)
+		created := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		assigned := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 3))
+		require.NoError(t, assigned.AssignToCourier(uuid.New()))
+		completed := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(4, 4))
+		require.NoError(t, completed.AssignToCourier(uuid.New()))
+		require.NoError(t, completed.Complete())
+		cancelled := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(5, 5))
+		require.NoError(t, cancelled.Cancel())
+		for _, aggregate := range []*order.Order{pending, created, assigned, completed, cancelled} {
+			require.NoError(t, repository.Add(ctx, aggregate))
+		}
+
+		got, err := repository.GetAllNotFinal(ctx)
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(got))
+		for _, aggregate := range got {
+			ids = append(ids, aggregate.ID())
+		}
+		assert.ElementsMatch(t, []uuid.UUID{pending.ID(), created.ID(), assigned.ID()}, ids)
+	})
+
+	t.Run("Update keeps timestamps", func(t *testing.T) {
+		ctx := context.Background()
+		_, repository := newRepository(t)
+
+		aggregate := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, repository.Add(ctx, aggregate))
+		require.NoError(t, aggregate.AssignToCourier(uuid.New()))
+		require.NoError(t, aggregate.PickUp())
+		require.NoError(t, aggregate.Complete())
+		require.NoError(t, repository.Update(ctx, aggregate))
+
+		got, err := repository.Get(ctx, aggregate.ID())
+		require.NoError(t, err)
+		assertOrdersEqual(t, aggregate, got)
+		require.NotNil(t, got.AssignedAt())
+		require.NotNil(t, got.PickedUpAt())
+		require.NotNil(t, got.CompletedAt())
+	})
+
+	t.Run("Rollback discards changes", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		existing := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, repository.Add(ctx, existing))
+
+		txCtx := unitOfWork.Begin(ctx)
+		added := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, repository.Add(txCtx, added))
+		require.NoError(t, existing.AssignToCourier(uuid.New()))
+		require.NoError(t, repository.Update(txCtx, existing))
+
+		// Внутри транзакции изменения видны
+		got, err := repository.Get(txCtx, existing.ID())
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusAssigned, got.Status())
+
+		require.NoError(t, unitOfWork.Rollback(txCtx))
+
+		_, err = repository.Get(ctx, added.ID())
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+		got, err = repository.Get(ctx, existing.ID())
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCreated, got.Status())
+	})
+
+	t.Run("Commit applies changes", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		txCtx := unitOfWork.Begin(ctx)
+		added := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(2, 2))
+		require.NoError(t, repository.Add(txCtx, added))
+		require.NoError(t, unitOfWork.Commit(txCtx))
+
+		got, err := repository.Get(ctx, added.ID())
+		require.NoError(t, err)
+		assertOrdersEqual(t, added, got)
+	})
+
+	t.Run("Concurrent Update of the same order fails", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		existing := order.MustNewOrder(uuid.New(), testAddress, kernel.MustNewLocation(3, 7))
+		require.NoError(t, repository.Add(ctx, existing))
+
+		firstCtx := unitOfWork.Begin(ctx)
+		defer func() { _ = unitOfWork.Rollback(firstCtx) }()
+		inFirst, err := repository.Get(firstCtx, existing.ID())
+		require.NoError(t, err)
+
+		secondCtx := unitOfWork.Begin(ctx)
+		inSecond, err := repository.Get(secondCtx, existing.ID())
+		require.NoError(t, err)
+		require.NoError(t, inSecond.Cancel())
+		require.NoError(t, repository.Update(secondCtx, inSecond))
+		require.NoError(t, unitOfWork.Commit(secondCtx))
+
+		// Первая транзакция читала заказ до отмены и не должна её затереть
+		require.NoError(t, inFirst.AssignToCourier(uuid.New()))
+		err = repository.Update(firstCtx, inFirst)
+		if err == nil {
+			err = unitOfWork.Commit(firstCtx)
+		}
+		assert.Error(t, err)
+
+		got, err := repository.Get(ctx, existing.ID())
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, got.Status())
+		assert.Nil(t, got.AssignedCourier())
+	})
+
+	t.Run("Concurrent Add and Update", func(t *testing.T) {
+		ctx := context.Background()
+		unitOfWork, repository := newRepository(t)
+
+		const workers = 10
+		aggregates := make([]*order.Order, workers)
+		for i := range aggregates {
+			aggregates[i] = order.MustNewOrder(uuid.New(), testAddress, kernel.CreateRandomLocation())
+		}
+
+		var wg sync.WaitGroup
+		errors := make(chan error, workers*3)
+		for _, aggregate := range aggregates {
+			wg.Add(1)
+			go func(aggregate *order.Order) {
+				defer wg.Done()
+				txCtx := unitOfWork.Begin(ctx)
+				defer func() { _ = unitOfWork.Rollback(txCtx) }()
+
+				errors <- repository.Add(txCtx, aggregate)
+				errors <- aggregate.AssignToCourier(uuid.New())
+				errors <- repository.Update(txCtx, aggregate)
+				if err := unitOfWork.Commit(txCtx); err != nil {
+					errors <- err
+				}
+			}(aggregate)
+		}
+		wg.Wait()
+		close(errors)
+		for err := range errors {
+			require.NoError(t, err)
+		}
+
+		got, err := repository.GetAllInAssignedStatus(ctx, kernel.DefaultRegion())
+		require.NoError(t, err)
+		assert.Len(t, got, workers)
+	})
+}
+
+func assertOrdersEqual(t *testing.T, expected *order.Order, actual *order.Order) {
+	t.Helper()
+	require.NotNil(t, actual)
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.Region(), actual.Region())
+	assert.Equal(t, expected.Tier(), actual.Tier())
+	assert.Equal(t, expected.Status(), actual.Status())
+	assert.True(t, expected.Address().Equals(actual.Address()),
+		"address: expected %v, got %v", expected.Address(), actual.Address())
+	assert.True(t, expected.Location().Equals(actual.Location()),
+		"location: expected %v, got %v", expected.Location(), actual.Location())
+	assert.Equal(t, expected.AssignedCourier(), actual.AssignedCourier())
+	// Postgres хранит время с точностью до микросекунд
+	assert.WithinDuration(t, expected.CreatedAt(), actual.CreatedAt(), time.Millisecond)
+	assertTimesEqual(t, "assignedAt", expected.AssignedAt(), actual.AssignedAt())
+	assertTimesEqual(t, "pickedUpAt", expected.PickedUpAt(), actual.PickedUpAt())
+	assertTimesEqual(t, "completedAt", expected.CompletedAt(), actual.CompletedAt())
+}
+
+func assertTimesEqual(t *testing.T, name string, expected *time.Time, actual *time.Time) {
+	t.Helper()
+	if expected == nil {
+		assert.Nil(t, actual, name)
+		return
+	}
+	require.NotNil(t, actual, name)
+	assert.WithinDuration(t, *expected, *actual, time.Millisecond, name)
+}
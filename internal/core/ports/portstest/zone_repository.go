@@ -0,0 +1,115 @@
+package portstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+	"github.com/IgorAleksandroff/delivery/internal/core/ports"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+// ZoneRepositoryFactory - создать пустой репозиторий зон
+type ZoneRepositoryFactory func(t *testing.T) ports.ZoneRepository
+
+// RunZoneRepositoryContract - проверить, что реализация ports.ZoneRepository соблюдает общий контракт
+func RunZoneRepositoryContract(t *testing.T, newRepository ZoneRepositoryFactory) {
+	cells, err := zone.NewCellsShape(kernel.MustNewArea(4, 4, 7, 7))
+	require.NoError(t, err)
+	polygon, err := zone.NewPolygonShape([]kernel.Location{
+		kernel.MustNewGeoLocation(55.70, 37.50),
+		kernel.MustNewGeoLocation(55.70, 37.60),
+		kernel.MustNewGeoLocation(55.80, 37.55),
+	})
+	require.NoError(t, err)
+
+	t.Run("Add and Get keeps shape", func(t *testing.T) {
+		ctx := context.Background()
+		repository := newRepository(t)
+
+		for _, shape := range []zone.Shape{cells, polygon} {
+			aggregate := zone.MustNewZone("Центр", shape)
+			require.NoError(t, repository.Add(ctx, aggregate))
+
+			got, err := repository.Get(ctx, aggregate.ID())
+			require.NoError(t, err)
+			assertZonesEqual(t, aggregate, got)
+		}
+	})
+
+	t.Run("Get unknown returns ErrObjectNotFound", func(t *testing.T) {
+		repository := newRepository(t)
+
+		_, err := repository.Get(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	})
+
+	t.Run("Update replaces name and shape", func(t *testing.T) {
+		ctx := context.Background()
+		repository := newRepository(t)
+
+		aggregate := zone.MustNewZone("Центр", polygon)
+		require.NoError(t, repository.Add(ctx, aggregate))
+		require.NoError(t, aggregate.Update("Центр и окрестности", cells))
+		require.NoError(t, repository.Update(ctx, aggregate))
+
+		got, err := repository.Get(ctx, aggregate.ID())
+		require.NoError(t, err)
+		assertZonesEqual(t, aggregate, got)
+
+		assert.ErrorIs(t, repository.Update(ctx, zone.MustNewZone("Север", cells)), errs.ErrObjectNotFound)
+	})
+
+	t.Run("Delete removes zone", func(t *testing.T) {
+		ctx := context.Background()
+		repository := newRepository(t)
+
+		aggregate := zone.MustNewZone("Центр", cells)
+		require.NoError(t, repository.Add(ctx, aggregate))
+		require.NoError(t, repository.Delete(ctx, aggregate.ID()))
+
+		_, err := repository.Get(ctx, aggregate.ID())
+		assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+		assert.ErrorIs(t, repository.Delete(ctx, aggregate.ID()), errs.ErrObjectNotFound)
+	})
+
+	t.Run("GetAll returns oldest first", func(t *testing.T) {
+		ctx := context.Background()
+		repository := newRepository(t)
+
+		newer := zone.MustNewZone("Север", cells)
+		older, err := zone.NewZone(uuid.New(), kernel.MustNewRegionCode("kzn"), "Юг", polygon, time.Now().UTC().Add(-time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, repository.Add(ctx, newer))
+		require.NoError(t, repository.Add(ctx, older))
+
+		got, err := repository.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assertZonesEqual(t, older, got[0])
+		assertZonesEqual(t, newer, got[1])
+	})
+}
+
+func assertZonesEqual(t *testing.T, expected *zone.Zone, actual *zone.Zone) {
+	t.Helper()
+	require.NotNil(t, actual)
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.Region(), actual.Region())
+	assert.Equal(t, expected.Name(), actual.Name())
+	assert.True(t, expected.Shape().Cells().Equals(actual.Shape().Cells()))
+	expectedPolygon, actualPolygon := expected.Shape().Polygon(), actual.Shape().Polygon()
+	require.Len(t, actualPolygon, len(expectedPolygon))
+	for i := range expectedPolygon {
+		assert.True(t, expectedPolygon[i].Equals(actualPolygon[i]),
+			"vertex %d: expected %v, got %v", i, expectedPolygon[i], actualPolygon[i])
+	}
+	// Postgres хранит время с точностью до микросекунд
+	assert.WithinDuration(t, expected.CreatedAt(), actual.CreatedAt(), time.Millisecond)
+}
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/webhook"
+)
+
+type WebhookSubscriptionRepository interface {
+	Add(ctx context.Context, subscription *webhook.Subscription) error
+	Delete(ctx context.Context, ID uuid.UUID) error
+	Get(ctx context.Context, ID uuid.UUID) (*webhook.Subscription, error)
+	GetAll(ctx context.Context) ([]*webhook.Subscription, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Add(ctx context.Context, deliveries ...*webhook.Delivery) error
+	Update(ctx context.Context, delivery *webhook.Delivery) error
+	// ClaimDue - атомарно взять ожидающие доставки, время попытки которых наступило, самые старые первыми,
+	// и отложить их следующую попытку до claimUntil. Параллельные запуски и другие экземпляры их не получат
+	ClaimDue(ctx context.Context, now time.Time, claimUntil time.Time, limit int) ([]*webhook.Delivery, error)
+	// GetAllBySubscription - журнал доставок подписки, новые первыми
+	GetAllBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*webhook.Delivery, error)
+}
+
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID uuid.UUID
+	EventType  webhook.EventType
+	Payload    []byte
+}
+
+// WebhookSender - отправляет подписанное событие получателю.
+// Ошибка означает, что ответа 2xx не получено; statusCode равен 0, если получатель не ответил
+type WebhookSender interface {
+	Send(ctx context.Context, request WebhookRequest) (statusCode int, err error)
+}
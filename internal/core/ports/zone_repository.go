@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/zone"
+)
+
+type ZoneRepository interface {
+	Add(ctx context.Context, aggregate *zone.Zone) error
+	Update(ctx context.Context, aggregate *zone.Zone) error
+	Delete(ctx context.Context, ID uuid.UUID) error
+	Get(ctx context.Context, ID uuid.UUID) (*zone.Zone, error)
+	// GetAll - все зоны, созданные раньше первыми
+	GetAll(ctx context.Context) ([]*zone.Zone, error)
+}
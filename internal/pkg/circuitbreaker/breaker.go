@@ -0,0 +1,106 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+// Breaker - размыкает цепь после failureThreshold ошибок подряд и через openTimeout
+// пропускает одну пробную попытку: успех замыкает цепь, ошибка снова размыкает.
+type Breaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openTimeout      time.Duration
+	now              func() time.Time
+
+	state     State
+	failures  int
+	openedAt  time.Time
+	probeSent bool
+}
+
+func New(failureThreshold int, openTimeout time.Duration) *Breaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	return &Breaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		now:              time.Now,
+		state:            StateClosed,
+	}
+}
+
+// Allow - можно ли выполнить вызов; при разомкнутой цепи возвращает ErrOpen
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrOpen
+		}
+		b.state = StateHalfOpen
+		b.probeSent = true
+		return nil
+	case StateHalfOpen:
+		if b.probeSent {
+			return ErrOpen
+		}
+		b.probeSent = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.probeSent = false
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.failureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+		b.probeSent = false
+	}
+}
+
+// Release - вызов прерван вызывающим, о состоянии сервиса он ничего не говорит.
+// Счётчик не меняется, пробная попытка полуоткрытой цепи достаётся следующему вызову
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.probeSent = false
+	}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
@@ -0,0 +1,53 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := New(3, 10*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	// Ошибки ниже порога не размыкают цепь
+	breaker.Failure()
+	breaker.Failure()
+	assert.NoError(t, breaker.Allow())
+	assert.Equal(t, StateClosed, breaker.State())
+
+	// Успех сбрасывает счётчик
+	breaker.Success()
+	breaker.Failure()
+	breaker.Failure()
+	assert.Equal(t, StateClosed, breaker.State())
+
+	breaker.Failure()
+	assert.Equal(t, StateOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrOpen)
+
+	// После таймаута пропускается ровно одна пробная попытка
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, breaker.Allow())
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrOpen)
+
+	// Неудачная проба снова размыкает цепь
+	breaker.Failure()
+	assert.Equal(t, StateOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrOpen)
+
+	// Прерванная проба не меняет состояние и освобождает место следующей
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, breaker.Allow())
+	breaker.Release()
+	assert.Equal(t, StateHalfOpen, breaker.State())
+
+	// Удачная проба замыкает цепь
+	assert.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, StateClosed, breaker.State())
+	assert.NoError(t, breaker.Allow())
+}
@@ -0,0 +1,89 @@
+package codec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// HeaderContentType - заголовок сообщения, по которому выбирается декодер
+const HeaderContentType = "content-type"
+
+const (
+	ContentTypeProtobuf       = "application/x-protobuf"
+	ContentTypeProtoJSON      = "application/json"
+	ContentTypeSchemaRegistry = "application/vnd.confluent.protobuf"
+)
+
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// Decoder - декодирует значение Kafka сообщения в protobuf сообщение.
+// ctx ограничивает обращения во внешние сервисы, например в Schema Registry
+type Decoder interface {
+	Decode(ctx context.Context, value []byte, message proto.Message) error
+}
+
+// ProtobufDecoder - бинарный protobuf wire format
+type ProtobufDecoder struct{}
+
+func (ProtobufDecoder) Decode(_ context.Context, value []byte, message proto.Message) error {
+	return proto.Unmarshal(value, message)
+}
+
+// ProtoJSONDecoder - каноническое JSON представление protobuf, понимает и имена полей из .proto, и json_name
+type ProtoJSONDecoder struct{}
+
+func (ProtoJSONDecoder) Decode(_ context.Context, value []byte, message proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(value, message)
+}
+
+// Selector - выбирает декодер по content-type, а без заголовка - по содержимому сообщения
+type Selector struct {
+	decoders map[string]Decoder
+}
+
+func NewSelector(schemaRegistry *SchemaRegistryDecoder) *Selector {
+	return &Selector{decoders: map[string]Decoder{
+		ContentTypeProtobuf:       ProtobufDecoder{},
+		ContentTypeProtoJSON:      ProtoJSONDecoder{},
+		ContentTypeSchemaRegistry: schemaRegistry,
+	}}
+}
+
+func (s *Selector) Decode(ctx context.Context, contentType string, value []byte, message proto.Message) error {
+	decoder, err := s.decoder(contentType, value)
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(ctx, value, message)
+}
+
+func (s *Selector) decoder(contentType string, value []byte) (Decoder, error) {
+	if contentType == "" {
+		contentType = detectContentType(value)
+	}
+
+	// Отбрасываем параметры вида "; charset=utf-8"
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	decoder, ok := s.decoders[strings.ToLower(strings.TrimSpace(mediaType))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+	return decoder, nil
+}
+
+func detectContentType(value []byte) string {
+	switch {
+	case isSchemaRegistryFramed(value):
+		return ContentTypeSchemaRegistry
+	case bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")):
+		return ContentTypeProtoJSON
+	default:
+		return ContentTypeProtobuf
+	}
+}
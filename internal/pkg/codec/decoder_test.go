@@ -0,0 +1,152 @@
+package codec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+	"github.com/IgorAleksandroff/delivery/pkg/clients/queues/queues/basketconfirmedpb"
+)
+
+const basketConfirmedSchema = `syntax = "proto3";
+message BasketConfirmedIntegrationEvent { string basketId = 1; }`
+
+func newEvent() *basketconfirmedpb.BasketConfirmedIntegrationEvent {
+	return &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: "0f3ae8b0-8b4d-4b1c-8f3a-1a2b3c4d5e6f",
+		Address:  &basketconfirmedpb.Address{City: "Москва", Street: "Бажная", House: "1"},
+	}
+}
+
+func Test_SelectorShouldDecodeByContentType(t *testing.T) {
+	event := newEvent()
+	binary, err := proto.Marshal(event)
+	require.NoError(t, err)
+	json, err := protojson.Marshal(event)
+	require.NoError(t, err)
+
+	registry := NewInMemorySchemaRegistry()
+	schemaID := registry.Register("basket.confirmed-value", SchemaTypeProto, basketConfirmedSchema)
+	framed := EncodeFrame(schemaID, binary)
+
+	selector := NewSelector(NewSchemaRegistryDecoder(registry, "basket.confirmed-value"))
+	testCases := []struct {
+		name        string
+		contentType string
+		value       []byte
+	}{
+		{name: "protobuf", contentType: ContentTypeProtobuf, value: binary},
+		{name: "protojson", contentType: ContentTypeProtoJSON + "; charset=utf-8", value: json},
+		{name: "schema registry", contentType: ContentTypeSchemaRegistry, value: framed},
+		{name: "protobuf detected", value: binary},
+		{name: "protojson detected", value: json},
+		{name: "schema registry detected", value: framed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got basketconfirmedpb.BasketConfirmedIntegrationEvent
+			require.NoError(t, selector.Decode(context.Background(), tc.contentType, tc.value, &got))
+			assert.True(t, proto.Equal(event, &got))
+		})
+	}
+}
+
+func Test_ProtoJSONShouldAcceptProtoFieldNames(t *testing.T) {
+	var got basketconfirmedpb.BasketConfirmedIntegrationEvent
+	err := ProtoJSONDecoder{}.Decode(context.Background(), []byte(`{"basketId": "id", "address": {"street": "Бажная"}, "unknown": 1}`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, "id", got.GetBasketId())
+	assert.Equal(t, "Бажная", got.GetAddress().GetStreet())
+}
+
+func Test_SelectorShouldRejectUnknownContentType(t *testing.T) {
+	selector := NewSelector(NewSchemaRegistryDecoder(nil, ""))
+	var got basketconfirmedpb.BasketConfirmedIntegrationEvent
+	err := selector.Decode(context.Background(), "application/avro", []byte("{}"), &got)
+	assert.ErrorIs(t, err, ErrUnsupportedContentType)
+}
+
+func Test_SchemaRegistryDecoderShouldCheckSchema(t *testing.T) {
+	payload, err := proto.Marshal(newEvent())
+	require.NoError(t, err)
+
+	registry := NewInMemorySchemaRegistry()
+	otherSubject := registry.Register("other-value", SchemaTypeProto, basketConfirmedSchema)
+	avro := registry.Register("basket.confirmed-value", "AVRO", "{}")
+	otherMessage := registry.Register("basket.confirmed-value", SchemaTypeProto, "message Other {}")
+	decoder := NewSchemaRegistryDecoder(registry, "basket.confirmed-value")
+
+	ctx := context.Background()
+	var got basketconfirmedpb.BasketConfirmedIntegrationEvent
+	assert.ErrorIs(t, decoder.Decode(ctx, EncodeFrame(100, payload), &got), ErrSchemaNotFound)
+	assert.ErrorIs(t, decoder.Decode(ctx, EncodeFrame(otherSubject, payload), &got), ErrSchemaMismatch)
+	assert.ErrorIs(t, decoder.Decode(ctx, EncodeFrame(avro, payload), &got), ErrSchemaMismatch)
+	assert.ErrorIs(t, decoder.Decode(ctx, EncodeFrame(otherMessage, payload), &got), ErrSchemaMismatch)
+	assert.ErrorIs(t, decoder.Decode(ctx, []byte{1, 2, 3}, &got), ErrInvalidFrame)
+}
+
+func Test_HTTPSchemaRegistryShouldCacheSchemas(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/schemas/ids/7" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"schemaType": "PROTOBUF", "schema": "message BasketConfirmedIntegrationEvent {}"}`))
+	}))
+	defer server.Close()
+
+	client := server.Client()
+	client.Timeout = time.Second
+	registry, err := NewHTTPSchemaRegistry(server.URL+"/", client)
+	require.NoError(t, err)
+	for range 2 {
+		schema, err := registry.GetSchema(context.Background(), 7)
+		require.NoError(t, err)
+		assert.Equal(t, SchemaTypeProto, schema.SchemaType)
+	}
+	assert.Equal(t, 1, requests)
+
+	_, err = registry.GetSchema(context.Background(), 8)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	_, err = NewHTTPSchemaRegistry(server.URL, http.DefaultClient)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func Test_SchemaRegistryDecoderShouldStopOnHungRegistry(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := server.Client()
+	client.Timeout = time.Minute
+	registry, err := NewHTTPSchemaRegistry(server.URL, client)
+	require.NoError(t, err)
+	decoder := NewSchemaRegistryDecoder(registry, "")
+	payload, err := proto.Marshal(newEvent())
+	require.NoError(t, err)
+
+	// Чтение ограничено контекстом потребителя, а не только таймаутом клиента
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var got basketconfirmedpb.BasketConfirmedIntegrationEvent
+	err = decoder.Decode(ctx, EncodeFrame(7, payload), &got)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
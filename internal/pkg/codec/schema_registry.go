@@ -0,0 +1,230 @@
+package codec
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/errs"
+)
+
+const (
+	magicByte       = 0x0
+	frameHeaderSize = 5
+	SchemaTypeProto = "PROTOBUF"
+)
+
+var (
+	ErrInvalidFrame   = errors.New("invalid schema registry frame")
+	ErrSchemaNotFound = errors.New("schema not found")
+	ErrSchemaMismatch = errors.New("schema does not match expected message")
+)
+
+type Schema struct {
+	ID         int
+	Subject    string
+	SchemaType string
+	Definition string
+}
+
+// SchemaRegistry - источник схем по идентификатору из заголовка кадра
+type SchemaRegistry interface {
+	GetSchema(ctx context.Context, id int) (Schema, error)
+}
+
+// SchemaRegistryDecoder - кадр Confluent: магический байт 0, 4 байта id схемы,
+// индексы сообщения в .proto файле и protobuf payload
+type SchemaRegistryDecoder struct {
+	registry SchemaRegistry
+	subject  string
+}
+
+// NewSchemaRegistryDecoder - registry может быть nil, тогда схема не проверяется.
+// Непустой subject требует, чтобы схема была зарегистрирована под ним.
+func NewSchemaRegistryDecoder(registry SchemaRegistry, subject string) *SchemaRegistryDecoder {
+	return &SchemaRegistryDecoder{registry: registry, subject: subject}
+}
+
+func (d *SchemaRegistryDecoder) Decode(ctx context.Context, value []byte, message proto.Message) error {
+	schemaID, payload, err := parseFrame(value)
+	if err != nil {
+		return err
+	}
+
+	if d.registry != nil {
+		schema, err := d.registry.GetSchema(ctx, schemaID)
+		if err != nil {
+			return fmt.Errorf("schema %d: %w", schemaID, err)
+		}
+		if err := d.checkSchema(schema, message); err != nil {
+			return err
+		}
+	}
+
+	return proto.Unmarshal(payload, message)
+}
+
+func (d *SchemaRegistryDecoder) checkSchema(schema Schema, message proto.Message) error {
+	if schema.SchemaType != SchemaTypeProto {
+		return fmt.Errorf("%w: schema %d has type %q", ErrSchemaMismatch, schema.ID, schema.SchemaType)
+	}
+	if d.subject != "" && schema.Subject != "" && schema.Subject != d.subject {
+		return fmt.Errorf("%w: schema %d belongs to subject %q", ErrSchemaMismatch, schema.ID, schema.Subject)
+	}
+	name := string(message.ProtoReflect().Descriptor().Name())
+	if schema.Definition != "" && !strings.Contains(schema.Definition, "message "+name) {
+		return fmt.Errorf("%w: schema %d does not define %s", ErrSchemaMismatch, schema.ID, name)
+	}
+	return nil
+}
+
+func isSchemaRegistryFramed(value []byte) bool {
+	return len(value) > frameHeaderSize && value[0] == magicByte
+}
+
+func parseFrame(value []byte) (int, []byte, error) {
+	if !isSchemaRegistryFramed(value) {
+		return 0, nil, ErrInvalidFrame
+	}
+	schemaID := int(binary.BigEndian.Uint32(value[1:frameHeaderSize]))
+	rest := value[frameHeaderSize:]
+
+	// Индексы сообщения: zigzag varint количество, затем сами индексы.
+	// Одиночный 0 - сокращение для первого сообщения файла.
+	count, n := binary.Varint(rest)
+	if n <= 0 || count < 0 {
+		return 0, nil, fmt.Errorf("%w: bad message indexes", ErrInvalidFrame)
+	}
+	rest = rest[n:]
+	for i := int64(0); i < count; i++ {
+		index, n := binary.Varint(rest)
+		if n <= 0 {
+			return 0, nil, fmt.Errorf("%w: bad message indexes", ErrInvalidFrame)
+		}
+		// Ожидаем событие верхнего уровня первым сообщением файла
+		if index != 0 {
+			return 0, nil, fmt.Errorf("%w: nested message index %d", ErrSchemaMismatch, index)
+		}
+		rest = rest[n:]
+	}
+
+	return schemaID, rest, nil
+}
+
+// EncodeFrame - обернуть protobuf payload в кадр Confluent для первого сообщения файла
+func EncodeFrame(schemaID int, payload []byte) []byte {
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+1+len(payload))
+	frame[0] = magicByte
+	binary.BigEndian.PutUint32(frame[1:], uint32(schemaID))
+	frame = append(frame, 0)
+	return append(frame, payload...)
+}
+
+// InMemorySchemaRegistry - локальная замена Schema Registry для тестов и запуска без инфраструктуры
+type InMemorySchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[int]Schema
+	nextID  int
+}
+
+func NewInMemorySchemaRegistry() *InMemorySchemaRegistry {
+	return &InMemorySchemaRegistry{schemas: make(map[int]Schema), nextID: 1}
+}
+
+// Register - зарегистрировать схему и вернуть её id
+func (r *InMemorySchemaRegistry) Register(subject string, schemaType string, definition string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.nextID
+	r.nextID++
+	r.schemas[id] = Schema{ID: id, Subject: subject, SchemaType: schemaType, Definition: definition}
+	return id
+}
+
+func (r *InMemorySchemaRegistry) GetSchema(ctx context.Context, id int) (Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[id]
+	if !ok {
+		return Schema{}, ErrSchemaNotFound
+	}
+	return schema, nil
+}
+
+// HTTPSchemaRegistry - клиент Confluent Schema Registry, схемы неизменяемы и кэшируются навсегда
+type HTTPSchemaRegistry struct {
+	url    string
+	client *http.Client
+
+	mu      sync.RWMutex
+	schemas map[int]Schema
+}
+
+// NewHTTPSchemaRegistry - у client должен быть задан Timeout, иначе зависший Registry остановит чтение топика
+func NewHTTPSchemaRegistry(url string, client *http.Client) (*HTTPSchemaRegistry, error) {
+	if url == "" {
+		return nil, errs.NewValueIsRequiredError("url")
+	}
+	if client == nil {
+		return nil, errs.NewValueIsRequiredError("client")
+	}
+	if client.Timeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("client timeout")
+	}
+	return &HTTPSchemaRegistry{
+		url:     strings.TrimRight(url, "/"),
+		client:  client,
+		schemas: make(map[int]Schema),
+	}, nil
+}
+
+func (r *HTTPSchemaRegistry) GetSchema(ctx context.Context, id int) (Schema, error) {
+	r.mu.RLock()
+	schema, ok := r.schemas[id]
+	r.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/schemas/ids/%d", r.url, id), nil)
+	if err != nil {
+		return Schema{}, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return Schema{}, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Schema{}, ErrSchemaNotFound
+	case resp.StatusCode != http.StatusOK:
+		return Schema{}, fmt.Errorf("schema registry responded %s", resp.Status)
+	}
+
+	var body struct {
+		Schema     string `json:"schema"`
+		SchemaType string `json:"schemaType"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Schema{}, err
+	}
+	// Registry не присылает тип для Avro схем
+	if body.SchemaType == "" {
+		body.SchemaType = "AVRO"
+	}
+
+	schema = Schema{ID: id, SchemaType: body.SchemaType, Definition: body.Schema}
+	r.mu.Lock()
+	r.schemas[id] = schema
+	r.mu.Unlock()
+	return schema, nil
+}
@@ -0,0 +1,34 @@
+package connlimit
+
+import "sync/atomic"
+
+// Limiter - ограничение числа одновременно открытых долгих соединений, общее для нескольких адаптеров
+type Limiter struct {
+	max  int64
+	open atomic.Int64
+}
+
+func New(max int) *Limiter {
+	if max < 1 {
+		max = 1
+	}
+	return &Limiter{max: int64(max)}
+}
+
+// Acquire - занять место, false - лимит исчерпан. Каждый успешный Acquire освобождается через Release
+func (l *Limiter) Acquire() bool {
+	if l.open.Add(1) > l.max {
+		l.open.Add(-1)
+		return false
+	}
+	return true
+}
+
+func (l *Limiter) Release() {
+	l.open.Add(-1)
+}
+
+// Open - сколько мест занято
+func (l *Limiter) Open() int {
+	return int(l.open.Load())
+}
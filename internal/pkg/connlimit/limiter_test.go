@@ -0,0 +1,21 @@
+package connlimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := New(2)
+
+	assert.True(t, limiter.Acquire())
+	assert.True(t, limiter.Acquire())
+	assert.False(t, limiter.Acquire())
+	assert.Equal(t, 2, limiter.Open())
+
+	// Освобождённое место снова можно занять
+	limiter.Release()
+	assert.Equal(t, 1, limiter.Open())
+	assert.True(t, limiter.Acquire())
+}
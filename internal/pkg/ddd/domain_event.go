@@ -0,0 +1,31 @@
+package ddd
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DomainEvent - факт, произошедший с агрегатом
+type DomainEvent interface {
+	EventID() uuid.UUID
+	EventName() string
+	OccurredAt() time.Time
+}
+
+// AggregateRoot - накапливает доменные события агрегата до их публикации
+type AggregateRoot struct {
+	domainEvents []DomainEvent
+}
+
+func (a *AggregateRoot) RaiseDomainEvent(event DomainEvent) {
+	a.domainEvents = append(a.domainEvents, event)
+}
+
+func (a *AggregateRoot) DomainEvents() []DomainEvent {
+	return a.domainEvents
+}
+
+func (a *AggregateRoot) ClearDomainEvents() {
+	a.domainEvents = nil
+}
@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+// Bus - шина доменных событий внутри процесса. Публикация не блокируется:
+// подписчик, у которого переполнился буфер, отключается и должен подписаться заново.
+// Буфер расходуют только события, прошедшие фильтр подписчика, поэтому поток чужих событий его не отключает.
+type Bus struct {
+	bufferSize int
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	filter func(event ddd.DomainEvent) bool
+	events chan ddd.DomainEvent
+}
+
+func New(bufferSize int) *Bus {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &Bus{
+		bufferSize:  bufferSize,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, events ...ddd.DomainEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range events {
+		for s := range b.subscribers {
+			if s.filter != nil && !s.filter(event) {
+				continue
+			}
+			select {
+			case s.events <- event:
+			default:
+				// Пропуск события исказит картину подписчика, лучше отключить его
+				b.remove(s)
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe - filter отбирает нужные подписчику события, nil - все. Он вызывается при публикации
+// под блокировкой шины, по одному событию в порядке публикации, и может по ним менять своё состояние
+func (b *Bus) Subscribe(filter func(event ddd.DomainEvent) bool) (<-chan ddd.DomainEvent, func()) {
+	s := &subscriber{filter: filter, events: make(chan ddd.DomainEvent, b.bufferSize)}
+
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+
+	return s.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(s)
+	}
+}
+
+// Subscribers - количество активных подписчиков
+func (b *Bus) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+func (b *Bus) remove(s *subscriber) {
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	close(s.events)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, events ...ddd.DomainEvent) error
+}
+
+// MultiPublisher - отправляет события во все publishers, например в шину и в Kafka
+type MultiPublisher []Publisher
+
+func (m MultiPublisher) Publish(ctx context.Context, events ...ddd.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var errList []error
+	for _, publisher := range m {
+		err := publisher.Publish(ctx, events...)
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return errors.Join(errList...)
+}
@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/courier"
+	"github.com/IgorAleksandroff/delivery/internal/core/domain/model/kernel"
+	"github.com/IgorAleksandroff/delivery/internal/pkg/ddd"
+)
+
+func locationChanged(x int) ddd.DomainEvent {
+	return courier.NewLocationChangedDomainEvent(uuid.New(), kernel.MustNewLocation(x, 1))
+}
+
+func Test_BusShouldDeliverEventsToEverySubscriber(t *testing.T) {
+	bus := New(4)
+	first, unsubscribeFirst := bus.Subscribe(nil)
+	second, unsubscribeSecond := bus.Subscribe(nil)
+	defer unsubscribeSecond()
+
+	events := []ddd.DomainEvent{locationChanged(1), locationChanged(2)}
+	require.NoError(t, bus.Publish(context.Background(), events...))
+
+	for _, ch := range []<-chan ddd.DomainEvent{first, second} {
+		assert.Equal(t, events[0], <-ch)
+		assert.Equal(t, events[1], <-ch)
+	}
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+	assert.Equal(t, 1, bus.Subscribers())
+}
+
+func Test_BusShouldDisconnectSlowSubscriber(t *testing.T) {
+	bus := New(1)
+	slow, unsubscribe := bus.Subscribe(nil)
+	defer unsubscribe()
+
+	require.NoError(t, bus.Publish(context.Background(), locationChanged(1), locationChanged(2)))
+
+	// Первое событие уже в буфере, после него канал закрыт
+	_, ok := <-slow
+	assert.True(t, ok)
+	_, ok = <-slow
+	assert.False(t, ok)
+	assert.Equal(t, 0, bus.Subscribers())
+}
+
+func Test_BusShouldSkipFilteredEventsWithoutDisconnecting(t *testing.T) {
+	bus := New(2)
+	courierID := uuid.New()
+	own, unsubscribe := bus.Subscribe(func(event ddd.DomainEvent) bool {
+		e, ok := event.(courier.LocationChangedDomainEvent)
+		return ok && e.CourierID() == courierID
+	})
+	defer unsubscribe()
+	all, unsubscribeAll := bus.Subscribe(nil)
+	defer unsubscribeAll()
+
+	// Чужих событий больше, чем вмещает буфер
+	for x := 1; x <= 10; x++ {
+		require.NoError(t, bus.Publish(context.Background(), locationChanged(x)))
+	}
+	expected := courier.NewLocationChangedDomainEvent(courierID, kernel.MustNewLocation(3, 3))
+	require.NoError(t, bus.Publish(context.Background(), expected))
+
+	assert.Equal(t, expected, <-own)
+	assert.Len(t, own, 0)
+	// Подписчик без фильтра переполнился и отключён
+	assert.Equal(t, 1, bus.Subscribers())
+	for range all {
+	}
+}
+
+type failingPublisher struct {
+	calls int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, events ...ddd.DomainEvent) error {
+	p.calls++
+	return errors.New("broker is down")
+}
+
+func Test_MultiPublisherShouldPublishToAllAndJoinErrors(t *testing.T) {
+	bus := New(4)
+	events, unsubscribe := bus.Subscribe(nil)
+	defer unsubscribe()
+	failing := &failingPublisher{}
+
+	err := MultiPublisher{failing, bus}.Publish(context.Background(), locationChanged(1))
+	assert.ErrorContains(t, err, "broker is down")
+	assert.Equal(t, 1, failing.calls)
+	assert.Len(t, events, 1)
+
+	// Пустой список событий никуда не отправляется
+	require.NoError(t, MultiPublisher{failing, bus}.Publish(context.Background()))
+	assert.Equal(t, 1, failing.calls)
+}
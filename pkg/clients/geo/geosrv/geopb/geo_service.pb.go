@@ -23,8 +23,11 @@ const (
 
 // Request
 type GetGeolocationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Street        string                 `protobuf:"bytes,1,opt,name=Street,proto3" json:"Street,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Устарело: только улица, оставлено для Geo, не знающих Address
+	Street string `protobuf:"bytes,1,opt,name=Street,proto3" json:"Street,omitempty"`
+	// Полный адрес, с версии 2 контракта
+	Address       *Address `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +69,90 @@ func (x *GetGeolocationRequest) GetStreet() string {
 	return ""
 }
 
+func (x *GetGeolocationRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+// Address
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	House         string                 `protobuf:"bytes,4,opt,name=house,proto3" json:"house,omitempty"`
+	Apartment     string                 `protobuf:"bytes,5,opt,name=apartment,proto3" json:"apartment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_api_proto_geo_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_geo_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetHouse() string {
+	if x != nil {
+		return x.House
+	}
+	return ""
+}
+
+func (x *Address) GetApartment() string {
+	if x != nil {
+		return x.Apartment
+	}
+	return ""
+}
+
 // Response
 type GetGeolocationReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -76,7 +163,7 @@ type GetGeolocationReply struct {
 
 func (x *GetGeolocationReply) Reset() {
 	*x = GetGeolocationReply{}
-	mi := &file_api_proto_geo_service_proto_msgTypes[1]
+	mi := &file_api_proto_geo_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -88,7 +175,7 @@ func (x *GetGeolocationReply) String() string {
 func (*GetGeolocationReply) ProtoMessage() {}
 
 func (x *GetGeolocationReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_geo_service_proto_msgTypes[1]
+	mi := &file_api_proto_geo_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,7 +188,7 @@ func (x *GetGeolocationReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGeolocationReply.ProtoReflect.Descriptor instead.
 func (*GetGeolocationReply) Descriptor() ([]byte, []int) {
-	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetGeolocationReply) GetLocation() *Location {
@@ -113,16 +200,18 @@ func (x *GetGeolocationReply) GetLocation() *Location {
 
 // Geolocation
 type Location struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
-	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	// Координаты WGS84, если Geo работает с реальной картой. Тогда x и y не заполняются
+	Wgs84         *Wgs84 `protobuf:"bytes,3,opt,name=wgs84,proto3" json:"wgs84,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Location) Reset() {
 	*x = Location{}
-	mi := &file_api_proto_geo_service_proto_msgTypes[2]
+	mi := &file_api_proto_geo_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -134,7 +223,7 @@ func (x *Location) String() string {
 func (*Location) ProtoMessage() {}
 
 func (x *Location) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_geo_service_proto_msgTypes[2]
+	mi := &file_api_proto_geo_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,7 +236,7 @@ func (x *Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Location.ProtoReflect.Descriptor instead.
 func (*Location) Descriptor() ([]byte, []int) {
-	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Location) GetX() int32 {
@@ -164,6 +253,66 @@ func (x *Location) GetY() int32 {
 	return 0
 }
 
+func (x *Location) GetWgs84() *Wgs84 {
+	if x != nil {
+		return x.Wgs84
+	}
+	return nil
+}
+
+// Wgs84 - широта и долгота в градусах
+type Wgs84 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wgs84) Reset() {
+	*x = Wgs84{}
+	mi := &file_api_proto_geo_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wgs84) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wgs84) ProtoMessage() {}
+
+func (x *Wgs84) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_geo_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wgs84.ProtoReflect.Descriptor instead.
+func (*Wgs84) Descriptor() ([]byte, []int) {
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Wgs84) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Wgs84) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
 type ErrorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
@@ -173,7 +322,7 @@ type ErrorResponse struct {
 
 func (x *ErrorResponse) Reset() {
 	*x = ErrorResponse{}
-	mi := &file_api_proto_geo_service_proto_msgTypes[3]
+	mi := &file_api_proto_geo_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -185,7 +334,7 @@ func (x *ErrorResponse) String() string {
 func (*ErrorResponse) ProtoMessage() {}
 
 func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_geo_service_proto_msgTypes[3]
+	mi := &file_api_proto_geo_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +347,7 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ErrorResponse) GetText() string {
@@ -212,14 +361,26 @@ var File_api_proto_geo_service_proto protoreflect.FileDescriptor
 
 const file_api_proto_geo_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1bapi/proto/geo_service.proto\x12\x03geo\"/\n" +
+	"\x1bapi/proto/geo_service.proto\x12\x03geo\"W\n" +
 	"\x15GetGeolocationRequest\x12\x16\n" +
-	"\x06Street\x18\x01 \x01(\tR\x06Street\"@\n" +
+	"\x06Street\x18\x01 \x01(\tR\x06Street\x12&\n" +
+	"\aAddress\x18\x02 \x01(\v2\f.geo.AddressR\aAddress\"\x83\x01\n" +
+	"\aAddress\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x14\n" +
+	"\x05house\x18\x04 \x01(\tR\x05house\x12\x1c\n" +
+	"\tapartment\x18\x05 \x01(\tR\tapartment\"@\n" +
 	"\x13GetGeolocationReply\x12)\n" +
-	"\bLocation\x18\x01 \x01(\v2\r.geo.LocationR\bLocation\"&\n" +
+	"\bLocation\x18\x01 \x01(\v2\r.geo.LocationR\bLocation\"H\n" +
 	"\bLocation\x12\f\n" +
 	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
-	"\x01y\x18\x02 \x01(\x05R\x01y\"#\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12 \n" +
+	"\x05wgs84\x18\x03 \x01(\v2\n" +
+	".geo.Wgs84R\x05wgs84\"A\n" +
+	"\x05Wgs84\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"#\n" +
 	"\rErrorResponse\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text2M\n" +
 	"\x03Geo\x12F\n" +
@@ -238,22 +399,26 @@ func file_api_proto_geo_service_proto_rawDescGZIP() []byte {
 	return file_api_proto_geo_service_proto_rawDescData
 }
 
-var file_api_proto_geo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_proto_geo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_api_proto_geo_service_proto_goTypes = []any{
 	(*GetGeolocationRequest)(nil), // 0: geo.GetGeolocationRequest
-	(*GetGeolocationReply)(nil),   // 1: geo.GetGeolocationReply
-	(*Location)(nil),              // 2: geo.Location
-	(*ErrorResponse)(nil),         // 3: geo.ErrorResponse
+	(*Address)(nil),               // 1: geo.Address
+	(*GetGeolocationReply)(nil),   // 2: geo.GetGeolocationReply
+	(*Location)(nil),              // 3: geo.Location
+	(*Wgs84)(nil),                 // 4: geo.Wgs84
+	(*ErrorResponse)(nil),         // 5: geo.ErrorResponse
 }
 var file_api_proto_geo_service_proto_depIdxs = []int32{
-	2, // 0: geo.GetGeolocationReply.Location:type_name -> geo.Location
-	0, // 1: geo.Geo.GetGeolocation:input_type -> geo.GetGeolocationRequest
-	1, // 2: geo.Geo.GetGeolocation:output_type -> geo.GetGeolocationReply
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 0: geo.GetGeolocationRequest.Address:type_name -> geo.Address
+	3, // 1: geo.GetGeolocationReply.Location:type_name -> geo.Location
+	4, // 2: geo.Location.wgs84:type_name -> geo.Wgs84
+	0, // 3: geo.Geo.GetGeolocation:input_type -> geo.GetGeolocationRequest
+	2, // 4: geo.Geo.GetGeolocation:output_type -> geo.GetGeolocationReply
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_geo_service_proto_init() }
@@ -267,7 +432,7 @@ func file_api_proto_geo_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_geo_service_proto_rawDesc), len(file_api_proto_geo_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,8 +27,10 @@ type BasketConfirmedIntegrationEvent struct {
 	Address        *Address               `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	Items          []*Item                `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
 	DeliveryPeriod *DeliveryPeriod        `protobuf:"bytes,4,opt,name=deliveryPeriod,proto3" json:"deliveryPeriod,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// express, standard или scheduled. Пусто - scheduled при заданном deliveryPeriod, иначе standard
+	DeliveryTier  string `protobuf:"bytes,5,opt,name=deliveryTier,proto3" json:"deliveryTier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BasketConfirmedIntegrationEvent) Reset() {
@@ -89,6 +91,13 @@ func (x *BasketConfirmedIntegrationEvent) GetDeliveryPeriod() *DeliveryPeriod {
 	return nil
 }
 
+func (x *BasketConfirmedIntegrationEvent) GetDeliveryTier() string {
+	if x != nil {
+		return x.DeliveryTier
+	}
+	return ""
+}
+
 type Address struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
@@ -297,12 +306,13 @@ var File_api_proto_basket_confirmed_proto protoreflect.FileDescriptor
 
 const file_api_proto_basket_confirmed_proto_rawDesc = "" +
 	"\n" +
-	" api/proto/basket_confirmed.proto\x12\x0fBasketConfirmed\"\xe7\x01\n" +
+	" api/proto/basket_confirmed.proto\x12\x0fBasketConfirmed\"\x8b\x02\n" +
 	"\x1fBasketConfirmedIntegrationEvent\x12\x1a\n" +
 	"\bbasketId\x18\x01 \x01(\tR\bbasketId\x122\n" +
 	"\aaddress\x18\x02 \x01(\v2\x18.BasketConfirmed.AddressR\aaddress\x12+\n" +
 	"\x05items\x18\x03 \x03(\v2\x15.BasketConfirmed.ItemR\x05items\x12G\n" +
-	"\x0edeliveryPeriod\x18\x04 \x01(\v2\x1f.BasketConfirmed.DeliveryPeriodR\x0edeliveryPeriod\"\x83\x01\n" +
+	"\x0edeliveryPeriod\x18\x04 \x01(\v2\x1f.BasketConfirmed.DeliveryPeriodR\x0edeliveryPeriod\x12\"\n" +
+	"\fdeliveryTier\x18\x05 \x01(\tR\fdeliveryTier\"\x83\x01\n" +
 	"\aAddress\x12\x18\n" +
 	"\acountry\x18\x01 \x01(\tR\acountry\x12\x12\n" +
 	"\x04city\x18\x02 \x01(\tR\x04city\x12\x16\n" +
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/proto/courier_location_changed.proto
+
+package courierlocationchangedpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CourierLocationChangedIntegrationEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=eventId,proto3" json:"eventId,omitempty"`
+	CourierId     string                 `protobuf:"bytes,2,opt,name=courierId,proto3" json:"courierId,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourierLocationChangedIntegrationEvent) Reset() {
+	*x = CourierLocationChangedIntegrationEvent{}
+	mi := &file_api_proto_courier_location_changed_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourierLocationChangedIntegrationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierLocationChangedIntegrationEvent) ProtoMessage() {}
+
+func (x *CourierLocationChangedIntegrationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_courier_location_changed_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierLocationChangedIntegrationEvent.ProtoReflect.Descriptor instead.
+func (*CourierLocationChangedIntegrationEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_courier_location_changed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CourierLocationChangedIntegrationEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *CourierLocationChangedIntegrationEvent) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *CourierLocationChangedIntegrationEvent) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *CourierLocationChangedIntegrationEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type Location struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	// Координаты WGS84, если сервис работает с реальной картой. Тогда x и y не заполняются
+	Wgs84         *Wgs84 `protobuf:"bytes,3,opt,name=wgs84,proto3" json:"wgs84,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_api_proto_courier_location_changed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_courier_location_changed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_api_proto_courier_location_changed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Location) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Location) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Location) GetWgs84() *Wgs84 {
+	if x != nil {
+		return x.Wgs84
+	}
+	return nil
+}
+
+// Wgs84 - широта и долгота в градусах
+type Wgs84 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wgs84) Reset() {
+	*x = Wgs84{}
+	mi := &file_api_proto_courier_location_changed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wgs84) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wgs84) ProtoMessage() {}
+
+func (x *Wgs84) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_courier_location_changed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wgs84.ProtoReflect.Descriptor instead.
+func (*Wgs84) Descriptor() ([]byte, []int) {
+	return file_api_proto_courier_location_changed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Wgs84) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Wgs84) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+var File_api_proto_courier_location_changed_proto protoreflect.FileDescriptor
+
+const file_api_proto_courier_location_changed_proto_rawDesc = "" +
+	"\n" +
+	"(api/proto/courier_location_changed.proto\x12\x16CourierLocationChanged\x1a\x1fgoogle/protobuf/timestamp.proto\"\xda\x01\n" +
+	"&CourierLocationChangedIntegrationEvent\x12\x18\n" +
+	"\aeventId\x18\x01 \x01(\tR\aeventId\x12\x1c\n" +
+	"\tcourierId\x18\x02 \x01(\tR\tcourierId\x12<\n" +
+	"\blocation\x18\x03 \x01(\v2 .CourierLocationChanged.LocationR\blocation\x12:\n" +
+	"\n" +
+	"occurredAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"[\n" +
+	"\bLocation\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x123\n" +
+	"\x05wgs84\x18\x03 \x01(\v2\x1d.CourierLocationChanged.Wgs84R\x05wgs84\"A\n" +
+	"\x05Wgs84\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitudeB!Z\x1fqueues/courierlocationchangedpbb\x06proto3"
+
+var (
+	file_api_proto_courier_location_changed_proto_rawDescOnce sync.Once
+	file_api_proto_courier_location_changed_proto_rawDescData []byte
+)
+
+func file_api_proto_courier_location_changed_proto_rawDescGZIP() []byte {
+	file_api_proto_courier_location_changed_proto_rawDescOnce.Do(func() {
+		file_api_proto_courier_location_changed_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_courier_location_changed_proto_rawDesc), len(file_api_proto_courier_location_changed_proto_rawDesc)))
+	})
+	return file_api_proto_courier_location_changed_proto_rawDescData
+}
+
+var file_api_proto_courier_location_changed_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_proto_courier_location_changed_proto_goTypes = []any{
+	(*CourierLocationChangedIntegrationEvent)(nil), // 0: CourierLocationChanged.CourierLocationChangedIntegrationEvent
+	(*Location)(nil),              // 1: CourierLocationChanged.Location
+	(*Wgs84)(nil),                 // 2: CourierLocationChanged.Wgs84
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_api_proto_courier_location_changed_proto_depIdxs = []int32{
+	1, // 0: CourierLocationChanged.CourierLocationChangedIntegrationEvent.location:type_name -> CourierLocationChanged.Location
+	3, // 1: CourierLocationChanged.CourierLocationChangedIntegrationEvent.occurredAt:type_name -> google.protobuf.Timestamp
+	2, // 2: CourierLocationChanged.Location.wgs84:type_name -> CourierLocationChanged.Wgs84
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_courier_location_changed_proto_init() }
+func file_api_proto_courier_location_changed_proto_init() {
+	if File_api_proto_courier_location_changed_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_courier_location_changed_proto_rawDesc), len(file_api_proto_courier_location_changed_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proto_courier_location_changed_proto_goTypes,
+		DependencyIndexes: file_api_proto_courier_location_changed_proto_depIdxs,
+		MessageInfos:      file_api_proto_courier_location_changed_proto_msgTypes,
+	}.Build()
+	File_api_proto_courier_location_changed_proto = out.File
+	file_api_proto_courier_location_changed_proto_goTypes = nil
+	file_api_proto_courier_location_changed_proto_depIdxs = nil
+}
@@ -0,0 +1,1897 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/proto/delivery.proto
+
+package deliverypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED     OrderStatus = 0
+	OrderStatus_ORDER_STATUS_CREATED         OrderStatus = 1
+	OrderStatus_ORDER_STATUS_ASSIGNED        OrderStatus = 2
+	OrderStatus_ORDER_STATUS_COMPLETED       OrderStatus = 3
+	OrderStatus_ORDER_STATUS_CANCELLED       OrderStatus = 4
+	OrderStatus_ORDER_STATUS_PENDING_GEOCODE OrderStatus = 5
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_CREATED",
+		2: "ORDER_STATUS_ASSIGNED",
+		3: "ORDER_STATUS_COMPLETED",
+		4: "ORDER_STATUS_CANCELLED",
+		5: "ORDER_STATUS_PENDING_GEOCODE",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":     0,
+		"ORDER_STATUS_CREATED":         1,
+		"ORDER_STATUS_ASSIGNED":        2,
+		"ORDER_STATUS_COMPLETED":       3,
+		"ORDER_STATUS_CANCELLED":       4,
+		"ORDER_STATUS_PENDING_GEOCODE": 5,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_delivery_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_delivery_proto_enumTypes[0]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderTier int32
+
+const (
+	OrderTier_ORDER_TIER_UNSPECIFIED OrderTier = 0
+	OrderTier_ORDER_TIER_STANDARD    OrderTier = 1
+	OrderTier_ORDER_TIER_EXPRESS     OrderTier = 2
+	OrderTier_ORDER_TIER_SCHEDULED   OrderTier = 3
+)
+
+// Enum value maps for OrderTier.
+var (
+	OrderTier_name = map[int32]string{
+		0: "ORDER_TIER_UNSPECIFIED",
+		1: "ORDER_TIER_STANDARD",
+		2: "ORDER_TIER_EXPRESS",
+		3: "ORDER_TIER_SCHEDULED",
+	}
+	OrderTier_value = map[string]int32{
+		"ORDER_TIER_UNSPECIFIED": 0,
+		"ORDER_TIER_STANDARD":    1,
+		"ORDER_TIER_EXPRESS":     2,
+		"ORDER_TIER_SCHEDULED":   3,
+	}
+)
+
+func (x OrderTier) Enum() *OrderTier {
+	p := new(OrderTier)
+	*p = x
+	return p
+}
+
+func (x OrderTier) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderTier) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_delivery_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderTier) Type() protoreflect.EnumType {
+	return &file_api_proto_delivery_proto_enumTypes[1]
+}
+
+func (x OrderTier) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderTier.Descriptor instead.
+func (OrderTier) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{1}
+}
+
+type CourierStatus int32
+
+const (
+	CourierStatus_COURIER_STATUS_UNSPECIFIED CourierStatus = 0
+	CourierStatus_COURIER_STATUS_FREE        CourierStatus = 1
+	CourierStatus_COURIER_STATUS_BUSY        CourierStatus = 2
+	// Курьер перестал выходить на связь
+	CourierStatus_COURIER_STATUS_OFFLINE CourierStatus = 3
+)
+
+// Enum value maps for CourierStatus.
+var (
+	CourierStatus_name = map[int32]string{
+		0: "COURIER_STATUS_UNSPECIFIED",
+		1: "COURIER_STATUS_FREE",
+		2: "COURIER_STATUS_BUSY",
+		3: "COURIER_STATUS_OFFLINE",
+	}
+	CourierStatus_value = map[string]int32{
+		"COURIER_STATUS_UNSPECIFIED": 0,
+		"COURIER_STATUS_FREE":        1,
+		"COURIER_STATUS_BUSY":        2,
+		"COURIER_STATUS_OFFLINE":     3,
+	}
+)
+
+func (x CourierStatus) Enum() *CourierStatus {
+	p := new(CourierStatus)
+	*p = x
+	return p
+}
+
+func (x CourierStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CourierStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_delivery_proto_enumTypes[2].Descriptor()
+}
+
+func (CourierStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_delivery_proto_enumTypes[2]
+}
+
+func (x CourierStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CourierStatus.Descriptor instead.
+func (CourierStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{2}
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	House         string                 `protobuf:"bytes,4,opt,name=house,proto3" json:"house,omitempty"`
+	Apartment     string                 `protobuf:"bytes,5,opt,name=apartment,proto3" json:"apartment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_api_proto_delivery_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetHouse() string {
+	if x != nil {
+		return x.House
+	}
+	return ""
+}
+
+func (x *Address) GetApartment() string {
+	if x != nil {
+		return x.Apartment
+	}
+	return ""
+}
+
+type Location struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	// Координаты WGS84, если сервис работает с реальной картой. Тогда x и y не заполняются
+	Wgs84         *Wgs84 `protobuf:"bytes,3,opt,name=wgs84,proto3" json:"wgs84,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_api_proto_delivery_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Location) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Location) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Location) GetWgs84() *Wgs84 {
+	if x != nil {
+		return x.Wgs84
+	}
+	return nil
+}
+
+// Wgs84 - широта и долгота в градусах
+type Wgs84 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wgs84) Reset() {
+	*x = Wgs84{}
+	mi := &file_api_proto_delivery_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wgs84) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wgs84) ProtoMessage() {}
+
+func (x *Wgs84) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wgs84.ProtoReflect.Descriptor instead.
+func (*Wgs84) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Wgs84) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Wgs84) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Order struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=delivery.OrderStatus" json:"status,omitempty"`
+	// Пусто, пока курьер не назначен
+	CourierId string   `protobuf:"bytes,3,opt,name=courierId,proto3" json:"courierId,omitempty"`
+	Address   *Address `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	// Пусто, пока адрес не геокодирован
+	Location *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// Код региона (города) заказа
+	Region string    `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	Tier   OrderTier `protobuf:"varint,7,opt,name=tier,proto3,enum=delivery.OrderTier" json:"tier,omitempty"`
+	// Срок доставки по уровню заказа, заполняется только в GetOrder
+	SlaDeadline *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=slaDeadline,proto3" json:"slaDeadline,omitempty"`
+	// Только у заказов к интервалу
+	DeliveryWindow *DeliveryWindow `protobuf:"bytes,9,opt,name=deliveryWindow,proto3" json:"deliveryWindow,omitempty"`
+	// Моменты жизни заказа, заполняются только в GetOrder. Пусто - этап ещё не пройден
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=assignedAt,proto3" json:"assignedAt,omitempty"`
+	PickedUpAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=pickedUpAt,proto3" json:"pickedUpAt,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_api_proto_delivery_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *Order) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Order) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Order) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Order) GetTier() OrderTier {
+	if x != nil {
+		return x.Tier
+	}
+	return OrderTier_ORDER_TIER_UNSPECIFIED
+}
+
+func (x *Order) GetSlaDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlaDeadline
+	}
+	return nil
+}
+
+func (x *Order) GetDeliveryWindow() *DeliveryWindow {
+	if x != nil {
+		return x.DeliveryWindow
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *Order) GetPickedUpAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PickedUpAt
+	}
+	return nil
+}
+
+func (x *Order) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// DeliveryWindow - согласованный интервал доставки [from, to)
+type DeliveryWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryWindow) Reset() {
+	*x = DeliveryWindow{}
+	mi := &file_api_proto_delivery_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryWindow) ProtoMessage() {}
+
+func (x *DeliveryWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryWindow.ProtoReflect.Descriptor instead.
+func (*DeliveryWindow) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeliveryWindow) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *DeliveryWindow) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type Transport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Speed         int32                  `protobuf:"varint,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transport) Reset() {
+	*x = Transport{}
+	mi := &file_api_proto_delivery_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transport) ProtoMessage() {}
+
+func (x *Transport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transport.ProtoReflect.Descriptor instead.
+func (*Transport) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Transport) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Transport) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type AssignedOrder struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Location *Location              `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// Сколько клеток осталось курьеру до заказа
+	Distance      int32 `protobuf:"varint,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignedOrder) Reset() {
+	*x = AssignedOrder{}
+	mi := &file_api_proto_delivery_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignedOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignedOrder) ProtoMessage() {}
+
+func (x *AssignedOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignedOrder.ProtoReflect.Descriptor instead.
+func (*AssignedOrder) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssignedOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AssignedOrder) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *AssignedOrder) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type Courier struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location  *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Status    CourierStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=delivery.CourierStatus" json:"status,omitempty"`
+	Transport *Transport             `protobuf:"bytes,5,opt,name=transport,proto3" json:"transport,omitempty"`
+	Orders    []*AssignedOrder       `protobuf:"bytes,6,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Нет, пока курьер не получал заказов
+	LastAssignedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lastAssignedAt,proto3" json:"lastAssignedAt,omitempty"`
+	// Код региона (города) курьера
+	Region string `protobuf:"bytes,8,opt,name=region,proto3" json:"region,omitempty"`
+	// Последний сигнал от курьера, нет - курьер не выходил на связь
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=lastSeenAt,proto3" json:"lastSeenAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Courier) Reset() {
+	*x = Courier{}
+	mi := &file_api_proto_delivery_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Courier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Courier) ProtoMessage() {}
+
+func (x *Courier) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Courier.ProtoReflect.Descriptor instead.
+func (*Courier) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Courier) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Courier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Courier) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Courier) GetStatus() CourierStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CourierStatus_COURIER_STATUS_UNSPECIFIED
+}
+
+func (x *Courier) GetTransport() *Transport {
+	if x != nil {
+		return x.Transport
+	}
+	return nil
+}
+
+func (x *Courier) GetOrders() []*AssignedOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *Courier) GetLastAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAssignedAt
+	}
+	return nil
+}
+
+func (x *Courier) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Courier) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+type CreateOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Address *Address               `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Без уровня - обычная доставка
+	Tier OrderTier `protobuf:"varint,3,opt,name=tier,proto3,enum=delivery.OrderTier" json:"tier,omitempty"`
+	// Обязателен для ORDER_TIER_SCHEDULED, другим уровням не задаётся
+	DeliveryWindow *DeliveryWindow `protobuf:"bytes,4,opt,name=deliveryWindow,proto3" json:"deliveryWindow,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetTier() OrderTier {
+	if x != nil {
+		return x.Tier
+	}
+	return OrderTier_ORDER_TIER_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetDeliveryWindow() *DeliveryWindow {
+	if x != nil {
+		return x.DeliveryWindow
+	}
+	return nil
+}
+
+type CreateOrderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderReply) Reset() {
+	*x = CreateOrderReply{}
+	mi := &file_api_proto_delivery_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderReply) ProtoMessage() {}
+
+func (x *CreateOrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderReply.ProtoReflect.Descriptor instead.
+func (*CreateOrderReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateOrderReply) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Страница списка: pageSize 0 - размер по умолчанию, pageToken - nextPageToken предыдущего ответа,
+// sort - поле сортировки, "-" в начале означает убывание
+type ListActiveOrdersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	Sort      string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Пусто - заказы всех регионов
+	Region        string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveOrdersRequest) Reset() {
+	*x = ListActiveOrdersRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveOrdersRequest) ProtoMessage() {}
+
+func (x *ListActiveOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListActiveOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListActiveOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListActiveOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListActiveOrdersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListActiveOrdersRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type ListActiveOrdersReply struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Пусто на последней странице
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveOrdersReply) Reset() {
+	*x = ListActiveOrdersReply{}
+	mi := &file_api_proto_delivery_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveOrdersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveOrdersReply) ProtoMessage() {}
+
+func (x *ListActiveOrdersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveOrdersReply.ProtoReflect.Descriptor instead.
+func (*ListActiveOrdersReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListActiveOrdersReply) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListActiveOrdersReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListCouriersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	Sort      string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Пусто - курьеры всех регионов
+	Region        string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouriersRequest) Reset() {
+	*x = ListCouriersRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouriersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersRequest) ProtoMessage() {}
+
+func (x *ListCouriersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersRequest.ProtoReflect.Descriptor instead.
+func (*ListCouriersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListCouriersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCouriersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCouriersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListCouriersRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type ListCouriersReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Couriers      []*Courier             `protobuf:"bytes,1,rep,name=couriers,proto3" json:"couriers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouriersReply) Reset() {
+	*x = ListCouriersReply{}
+	mi := &file_api_proto_delivery_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouriersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersReply) ProtoMessage() {}
+
+func (x *ListCouriersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersReply.ProtoReflect.Descriptor instead.
+func (*ListCouriersReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListCouriersReply) GetCouriers() []*Courier {
+	if x != nil {
+		return x.Couriers
+	}
+	return nil
+}
+
+func (x *ListCouriersReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourierId     string                 `protobuf:"bytes,1,opt,name=courierId,proto3" json:"courierId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourierRequest) Reset() {
+	*x = GetCourierRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierRequest) ProtoMessage() {}
+
+func (x *GetCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierRequest.ProtoReflect.Descriptor instead.
+func (*GetCourierRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetCourierRequest) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type CancelOrderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderReply) Reset() {
+	*x = CancelOrderReply{}
+	mi := &file_api_proto_delivery_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderReply) ProtoMessage() {}
+
+func (x *CancelOrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderReply.ProtoReflect.Descriptor instead.
+func (*CancelOrderReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{17}
+}
+
+type WatchOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_api_proto_delivery_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type OrderUpdate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Status          OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=delivery.OrderStatus" json:"status,omitempty"`
+	CourierId       string                 `protobuf:"bytes,3,opt,name=courierId,proto3" json:"courierId,omitempty"`
+	CourierLocation *Location              `protobuf:"bytes,4,opt,name=courierLocation,proto3" json:"courierLocation,omitempty"`
+	// Оценка времени до доставки, есть только у назначенного заказа
+	EtaSeconds    *int32                 `protobuf:"varint,5,opt,name=etaSeconds,proto3,oneof" json:"etaSeconds,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderUpdate) Reset() {
+	*x = OrderUpdate{}
+	mi := &file_api_proto_delivery_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderUpdate) ProtoMessage() {}
+
+func (x *OrderUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderUpdate.ProtoReflect.Descriptor instead.
+func (*OrderUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrderUpdate) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderUpdate) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetCourierLocation() *Location {
+	if x != nil {
+		return x.CourierLocation
+	}
+	return nil
+}
+
+func (x *OrderUpdate) GetEtaSeconds() int32 {
+	if x != nil && x.EtaSeconds != nil {
+		return *x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *OrderUpdate) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type CourierLocationReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourierId     string                 `protobuf:"bytes,1,opt,name=courierId,proto3" json:"courierId,omitempty"`
+	Location      *Location              `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	ReportedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=reportedAt,proto3" json:"reportedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourierLocationReport) Reset() {
+	*x = CourierLocationReport{}
+	mi := &file_api_proto_delivery_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourierLocationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourierLocationReport) ProtoMessage() {}
+
+func (x *CourierLocationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourierLocationReport.ProtoReflect.Descriptor instead.
+func (*CourierLocationReport) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CourierLocationReport) GetCourierId() string {
+	if x != nil {
+		return x.CourierId
+	}
+	return ""
+}
+
+func (x *CourierLocationReport) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *CourierLocationReport) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+// RejectedLocation - номер сообщения в потоке и почему позицию не приняли
+type RejectedLocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectedLocation) Reset() {
+	*x = RejectedLocation{}
+	mi := &file_api_proto_delivery_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedLocation) ProtoMessage() {}
+
+func (x *RejectedLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedLocation.ProtoReflect.Descriptor instead.
+func (*RejectedLocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RejectedLocation) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RejectedLocation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReportLocationsReply struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Accepted          int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected          []*RejectedLocation    `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	CompletedOrderIds []string               `protobuf:"bytes,3,rep,name=completedOrderIds,proto3" json:"completedOrderIds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReportLocationsReply) Reset() {
+	*x = ReportLocationsReply{}
+	mi := &file_api_proto_delivery_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportLocationsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportLocationsReply) ProtoMessage() {}
+
+func (x *ReportLocationsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_delivery_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportLocationsReply.ProtoReflect.Descriptor instead.
+func (*ReportLocationsReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_delivery_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReportLocationsReply) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *ReportLocationsReply) GetRejected() []*RejectedLocation {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+func (x *ReportLocationsReply) GetCompletedOrderIds() []string {
+	if x != nil {
+		return x.CompletedOrderIds
+	}
+	return nil
+}
+
+var File_api_proto_delivery_proto protoreflect.FileDescriptor
+
+const file_api_proto_delivery_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/delivery.proto\x12\bdelivery\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x01\n" +
+	"\aAddress\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x14\n" +
+	"\x05house\x18\x04 \x01(\tR\x05house\x12\x1c\n" +
+	"\tapartment\x18\x05 \x01(\tR\tapartment\"M\n" +
+	"\bLocation\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12%\n" +
+	"\x05wgs84\x18\x03 \x01(\v2\x0f.delivery.Wgs84R\x05wgs84\"A\n" +
+	"\x05Wgs84\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xf2\x04\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.delivery.OrderStatusR\x06status\x12\x1c\n" +
+	"\tcourierId\x18\x03 \x01(\tR\tcourierId\x12+\n" +
+	"\aaddress\x18\x04 \x01(\v2\x11.delivery.AddressR\aaddress\x12.\n" +
+	"\blocation\x18\x05 \x01(\v2\x12.delivery.LocationR\blocation\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12'\n" +
+	"\x04tier\x18\a \x01(\x0e2\x13.delivery.OrderTierR\x04tier\x12<\n" +
+	"\vslaDeadline\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vslaDeadline\x12@\n" +
+	"\x0edeliveryWindow\x18\t \x01(\v2\x18.delivery.DeliveryWindowR\x0edeliveryWindow\x128\n" +
+	"\tcreatedAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12:\n" +
+	"\n" +
+	"assignedAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12:\n" +
+	"\n" +
+	"pickedUpAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"pickedUpAt\x12<\n" +
+	"\vcompletedAt\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"l\n" +
+	"\x0eDeliveryWindow\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"5\n" +
+	"\tTransport\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05speed\x18\x02 \x01(\x05R\x05speed\"k\n" +
+	"\rAssignedOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\blocation\x18\x02 \x01(\v2\x12.delivery.LocationR\blocation\x12\x1a\n" +
+	"\bdistance\x18\x03 \x01(\x05R\bdistance\"\x8a\x03\n" +
+	"\aCourier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12.\n" +
+	"\blocation\x18\x03 \x01(\v2\x12.delivery.LocationR\blocation\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.delivery.CourierStatusR\x06status\x121\n" +
+	"\ttransport\x18\x05 \x01(\v2\x13.delivery.TransportR\ttransport\x12/\n" +
+	"\x06orders\x18\x06 \x03(\v2\x17.delivery.AssignedOrderR\x06orders\x12B\n" +
+	"\x0elastAssignedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0elastAssignedAt\x12\x16\n" +
+	"\x06region\x18\b \x01(\tR\x06region\x12:\n" +
+	"\n" +
+	"lastSeenAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"\xc6\x01\n" +
+	"\x12CreateOrderRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12+\n" +
+	"\aaddress\x18\x02 \x01(\v2\x11.delivery.AddressR\aaddress\x12'\n" +
+	"\x04tier\x18\x03 \x01(\x0e2\x13.delivery.OrderTierR\x04tier\x12@\n" +
+	"\x0edeliveryWindow\x18\x04 \x01(\v2\x18.delivery.DeliveryWindowR\x0edeliveryWindow\",\n" +
+	"\x10CreateOrderReply\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"+\n" +
+	"\x0fGetOrderRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"\x7f\n" +
+	"\x17ListActiveOrdersRequest\x12\x1a\n" +
+	"\bpageSize\x18\x01 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\"f\n" +
+	"\x15ListActiveOrdersReply\x12'\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.delivery.OrderR\x06orders\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"{\n" +
+	"\x13ListCouriersRequest\x12\x1a\n" +
+	"\bpageSize\x18\x01 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\"h\n" +
+	"\x11ListCouriersReply\x12-\n" +
+	"\bcouriers\x18\x01 \x03(\v2\x11.delivery.CourierR\bcouriers\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"1\n" +
+	"\x11GetCourierRequest\x12\x1c\n" +
+	"\tcourierId\x18\x01 \x01(\tR\tcourierId\".\n" +
+	"\x12CancelOrderRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"\x12\n" +
+	"\x10CancelOrderReply\"-\n" +
+	"\x11WatchOrderRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"\xa2\x02\n" +
+	"\vOrderUpdate\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.delivery.OrderStatusR\x06status\x12\x1c\n" +
+	"\tcourierId\x18\x03 \x01(\tR\tcourierId\x12<\n" +
+	"\x0fcourierLocation\x18\x04 \x01(\v2\x12.delivery.LocationR\x0fcourierLocation\x12#\n" +
+	"\n" +
+	"etaSeconds\x18\x05 \x01(\x05H\x00R\n" +
+	"etaSeconds\x88\x01\x01\x12:\n" +
+	"\n" +
+	"occurredAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAtB\r\n" +
+	"\v_etaSeconds\"\xa1\x01\n" +
+	"\x15CourierLocationReport\x12\x1c\n" +
+	"\tcourierId\x18\x01 \x01(\tR\tcourierId\x12.\n" +
+	"\blocation\x18\x02 \x01(\v2\x12.delivery.LocationR\blocation\x12:\n" +
+	"\n" +
+	"reportedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reportedAt\"@\n" +
+	"\x10RejectedLocation\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x98\x01\n" +
+	"\x14ReportLocationsReply\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\x126\n" +
+	"\brejected\x18\x02 \x03(\v2\x1a.delivery.RejectedLocationR\brejected\x12,\n" +
+	"\x11completedOrderIds\x18\x03 \x03(\tR\x11completedOrderIds*\xba\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_CREATED\x10\x01\x12\x19\n" +
+	"\x15ORDER_STATUS_ASSIGNED\x10\x02\x12\x1a\n" +
+	"\x16ORDER_STATUS_COMPLETED\x10\x03\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x04\x12 \n" +
+	"\x1cORDER_STATUS_PENDING_GEOCODE\x10\x05*r\n" +
+	"\tOrderTier\x12\x1a\n" +
+	"\x16ORDER_TIER_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ORDER_TIER_STANDARD\x10\x01\x12\x16\n" +
+	"\x12ORDER_TIER_EXPRESS\x10\x02\x12\x18\n" +
+	"\x14ORDER_TIER_SCHEDULED\x10\x03*}\n" +
+	"\rCourierStatus\x12\x1e\n" +
+	"\x1aCOURIER_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13COURIER_STATUS_FREE\x10\x01\x12\x17\n" +
+	"\x13COURIER_STATUS_BUSY\x10\x02\x12\x1a\n" +
+	"\x16COURIER_STATUS_OFFLINE\x10\x032\xd0\x04\n" +
+	"\bDelivery\x12G\n" +
+	"\vCreateOrder\x12\x1c.delivery.CreateOrderRequest\x1a\x1a.delivery.CreateOrderReply\x126\n" +
+	"\bGetOrder\x12\x19.delivery.GetOrderRequest\x1a\x0f.delivery.Order\x12V\n" +
+	"\x10ListActiveOrders\x12!.delivery.ListActiveOrdersRequest\x1a\x1f.delivery.ListActiveOrdersReply\x12J\n" +
+	"\fListCouriers\x12\x1d.delivery.ListCouriersRequest\x1a\x1b.delivery.ListCouriersReply\x12<\n" +
+	"\n" +
+	"GetCourier\x12\x1b.delivery.GetCourierRequest\x1a\x11.delivery.Courier\x12G\n" +
+	"\vCancelOrder\x12\x1c.delivery.CancelOrderRequest\x1a\x1a.delivery.CancelOrderReply\x12B\n" +
+	"\n" +
+	"WatchOrder\x12\x1b.delivery.WatchOrderRequest\x1a\x15.delivery.OrderUpdate0\x01\x12T\n" +
+	"\x0fReportLocations\x12\x1f.delivery.CourierLocationReport\x1a\x1e.delivery.ReportLocationsReply(\x01B\x18Z\x16deliverysrv/deliverypbb\x06proto3"
+
+var (
+	file_api_proto_delivery_proto_rawDescOnce sync.Once
+	file_api_proto_delivery_proto_rawDescData []byte
+)
+
+func file_api_proto_delivery_proto_rawDescGZIP() []byte {
+	file_api_proto_delivery_proto_rawDescOnce.Do(func() {
+		file_api_proto_delivery_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_delivery_proto_rawDesc), len(file_api_proto_delivery_proto_rawDesc)))
+	})
+	return file_api_proto_delivery_proto_rawDescData
+}
+
+var file_api_proto_delivery_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_proto_delivery_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_api_proto_delivery_proto_goTypes = []any{
+	(OrderStatus)(0),                // 0: delivery.OrderStatus
+	(OrderTier)(0),                  // 1: delivery.OrderTier
+	(CourierStatus)(0),              // 2: delivery.CourierStatus
+	(*Address)(nil),                 // 3: delivery.Address
+	(*Location)(nil),                // 4: delivery.Location
+	(*Wgs84)(nil),                   // 5: delivery.Wgs84
+	(*Order)(nil),                   // 6: delivery.Order
+	(*DeliveryWindow)(nil),          // 7: delivery.DeliveryWindow
+	(*Transport)(nil),               // 8: delivery.Transport
+	(*AssignedOrder)(nil),           // 9: delivery.AssignedOrder
+	(*Courier)(nil),                 // 10: delivery.Courier
+	(*CreateOrderRequest)(nil),      // 11: delivery.CreateOrderRequest
+	(*CreateOrderReply)(nil),        // 12: delivery.CreateOrderReply
+	(*GetOrderRequest)(nil),         // 13: delivery.GetOrderRequest
+	(*ListActiveOrdersRequest)(nil), // 14: delivery.ListActiveOrdersRequest
+	(*ListActiveOrdersReply)(nil),   // 15: delivery.ListActiveOrdersReply
+	(*ListCouriersRequest)(nil),     // 16: delivery.ListCouriersRequest
+	(*ListCouriersReply)(nil),       // 17: delivery.ListCouriersReply
+	(*GetCourierRequest)(nil),       // 18: delivery.GetCourierRequest
+	(*CancelOrderRequest)(nil),      // 19: delivery.CancelOrderRequest
+	(*CancelOrderReply)(nil),        // 20: delivery.CancelOrderReply
+	(*WatchOrderRequest)(nil),       // 21: delivery.WatchOrderRequest
+	(*OrderUpdate)(nil),             // 22: delivery.OrderUpdate
+	(*CourierLocationReport)(nil),   // 23: delivery.CourierLocationReport
+	(*RejectedLocation)(nil),        // 24: delivery.RejectedLocation
+	(*ReportLocationsReply)(nil),    // 25: delivery.ReportLocationsReply
+	(*timestamppb.Timestamp)(nil),   // 26: google.protobuf.Timestamp
+}
+var file_api_proto_delivery_proto_depIdxs = []int32{
+	5,  // 0: delivery.Location.wgs84:type_name -> delivery.Wgs84
+	0,  // 1: delivery.Order.status:type_name -> delivery.OrderStatus
+	3,  // 2: delivery.Order.address:type_name -> delivery.Address
+	4,  // 3: delivery.Order.location:type_name -> delivery.Location
+	1,  // 4: delivery.Order.tier:type_name -> delivery.OrderTier
+	26, // 5: delivery.Order.slaDeadline:type_name -> google.protobuf.Timestamp
+	7,  // 6: delivery.Order.deliveryWindow:type_name -> delivery.DeliveryWindow
+	26, // 7: delivery.Order.createdAt:type_name -> google.protobuf.Timestamp
+	26, // 8: delivery.Order.assignedAt:type_name -> google.protobuf.Timestamp
+	26, // 9: delivery.Order.pickedUpAt:type_name -> google.protobuf.Timestamp
+	26, // 10: delivery.Order.completedAt:type_name -> google.protobuf.Timestamp
+	26, // 11: delivery.DeliveryWindow.from:type_name -> google.protobuf.Timestamp
+	26, // 12: delivery.DeliveryWindow.to:type_name -> google.protobuf.Timestamp
+	4,  // 13: delivery.AssignedOrder.location:type_name -> delivery.Location
+	4,  // 14: delivery.Courier.location:type_name -> delivery.Location
+	2,  // 15: delivery.Courier.status:type_name -> delivery.CourierStatus
+	8,  // 16: delivery.Courier.transport:type_name -> delivery.Transport
+	9,  // 17: delivery.Courier.orders:type_name -> delivery.AssignedOrder
+	26, // 18: delivery.Courier.lastAssignedAt:type_name -> google.protobuf.Timestamp
+	26, // 19: delivery.Courier.lastSeenAt:type_name -> google.protobuf.Timestamp
+	3,  // 20: delivery.CreateOrderRequest.address:type_name -> delivery.Address
+	1,  // 21: delivery.CreateOrderRequest.tier:type_name -> delivery.OrderTier
+	7,  // 22: delivery.CreateOrderRequest.deliveryWindow:type_name -> delivery.DeliveryWindow
+	6,  // 23: delivery.ListActiveOrdersReply.orders:type_name -> delivery.Order
+	10, // 24: delivery.ListCouriersReply.couriers:type_name -> delivery.Courier
+	0,  // 25: delivery.OrderUpdate.status:type_name -> delivery.OrderStatus
+	4,  // 26: delivery.OrderUpdate.courierLocation:type_name -> delivery.Location
+	26, // 27: delivery.OrderUpdate.occurredAt:type_name -> google.protobuf.Timestamp
+	4,  // 28: delivery.CourierLocationReport.location:type_name -> delivery.Location
+	26, // 29: delivery.CourierLocationReport.reportedAt:type_name -> google.protobuf.Timestamp
+	24, // 30: delivery.ReportLocationsReply.rejected:type_name -> delivery.RejectedLocation
+	11, // 31: delivery.Delivery.CreateOrder:input_type -> delivery.CreateOrderRequest
+	13, // 32: delivery.Delivery.GetOrder:input_type -> delivery.GetOrderRequest
+	14, // 33: delivery.Delivery.ListActiveOrders:input_type -> delivery.ListActiveOrdersRequest
+	16, // 34: delivery.Delivery.ListCouriers:input_type -> delivery.ListCouriersRequest
+	18, // 35: delivery.Delivery.GetCourier:input_type -> delivery.GetCourierRequest
+	19, // 36: delivery.Delivery.CancelOrder:input_type -> delivery.CancelOrderRequest
+	21, // 37: delivery.Delivery.WatchOrder:input_type -> delivery.WatchOrderRequest
+	23, // 38: delivery.Delivery.ReportLocations:input_type -> delivery.CourierLocationReport
+	12, // 39: delivery.Delivery.CreateOrder:output_type -> delivery.CreateOrderReply
+	6,  // 40: delivery.Delivery.GetOrder:output_type -> delivery.Order
+	15, // 41: delivery.Delivery.ListActiveOrders:output_type -> delivery.ListActiveOrdersReply
+	17, // 42: delivery.Delivery.ListCouriers:output_type -> delivery.ListCouriersReply
+	10, // 43: delivery.Delivery.GetCourier:output_type -> delivery.Courier
+	20, // 44: delivery.Delivery.CancelOrder:output_type -> delivery.CancelOrderReply
+	22, // 45: delivery.Delivery.WatchOrder:output_type -> delivery.OrderUpdate
+	25, // 46: delivery.Delivery.ReportLocations:output_type -> delivery.ReportLocationsReply
+	39, // [39:47] is the sub-list for method output_type
+	31, // [31:39] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_delivery_proto_init() }
+func file_api_proto_delivery_proto_init() {
+	if File_api_proto_delivery_proto != nil {
+		return
+	}
+	file_api_proto_delivery_proto_msgTypes[19].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_delivery_proto_rawDesc), len(file_api_proto_delivery_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_delivery_proto_goTypes,
+		DependencyIndexes: file_api_proto_delivery_proto_depIdxs,
+		EnumInfos:         file_api_proto_delivery_proto_enumTypes,
+		MessageInfos:      file_api_proto_delivery_proto_msgTypes,
+	}.Build()
+	File_api_proto_delivery_proto = out.File
+	file_api_proto_delivery_proto_goTypes = nil
+	file_api_proto_delivery_proto_depIdxs = nil
+}
@@ -0,0 +1,398 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: api/proto/delivery.proto
+
+package deliverypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Delivery_CreateOrder_FullMethodName      = "/delivery.Delivery/CreateOrder"
+	Delivery_GetOrder_FullMethodName         = "/delivery.Delivery/GetOrder"
+	Delivery_ListActiveOrders_FullMethodName = "/delivery.Delivery/ListActiveOrders"
+	Delivery_ListCouriers_FullMethodName     = "/delivery.Delivery/ListCouriers"
+	Delivery_GetCourier_FullMethodName       = "/delivery.Delivery/GetCourier"
+	Delivery_CancelOrder_FullMethodName      = "/delivery.Delivery/CancelOrder"
+	Delivery_WatchOrder_FullMethodName       = "/delivery.Delivery/WatchOrder"
+	Delivery_ReportLocations_FullMethodName  = "/delivery.Delivery/ReportLocations"
+)
+
+// DeliveryClient is the client API for Delivery service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Delivery - тот же API, что и HTTP, для внутренних сервисов
+type DeliveryClient interface {
+	// Создать заказ по адресу, id задаёт вызывающий, повтор с тем же id - AlreadyExists
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderReply, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// Заказы, ожидающие курьера или в пути
+	ListActiveOrders(ctx context.Context, in *ListActiveOrdersRequest, opts ...grpc.CallOption) (*ListActiveOrdersReply, error)
+	ListCouriers(ctx context.Context, in *ListCouriersRequest, opts ...grpc.CallOption) (*ListCouriersReply, error)
+	GetCourier(ctx context.Context, in *GetCourierRequest, opts ...grpc.CallOption) (*Courier, error)
+	// Отменить заказ, доставленный или уже отменённый - FailedPrecondition
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderReply, error)
+	// Поток изменений заказа: первым текущее состояние, поток завершается после доставки или отмены
+	WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderUpdate], error)
+	// Поток позиций из приложения курьера, доступен при COURIER_MOVEMENT=real. Итог приходит после закрытия потока
+	ReportLocations(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CourierLocationReport, ReportLocationsReply], error)
+}
+
+type deliveryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeliveryClient(cc grpc.ClientConnInterface) DeliveryClient {
+	return &deliveryClient{cc}
+}
+
+func (c *deliveryClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrderReply)
+	err := c.cc.Invoke(ctx, Delivery_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, Delivery_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryClient) ListActiveOrders(ctx context.Context, in *ListActiveOrdersRequest, opts ...grpc.CallOption) (*ListActiveOrdersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActiveOrdersReply)
+	err := c.cc.Invoke(ctx, Delivery_ListActiveOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryClient) ListCouriers(ctx context.Context, in *ListCouriersRequest, opts ...grpc.CallOption) (*ListCouriersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCouriersReply)
+	err := c.cc.Invoke(ctx, Delivery_ListCouriers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryClient) GetCourier(ctx context.Context, in *GetCourierRequest, opts ...grpc.CallOption) (*Courier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Courier)
+	err := c.cc.Invoke(ctx, Delivery_GetCourier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelOrderReply)
+	err := c.cc.Invoke(ctx, Delivery_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deliveryClient) WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Delivery_ServiceDesc.Streams[0], Delivery_WatchOrder_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrderRequest, OrderUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Delivery_WatchOrderClient = grpc.ServerStreamingClient[OrderUpdate]
+
+func (c *deliveryClient) ReportLocations(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CourierLocationReport, ReportLocationsReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Delivery_ServiceDesc.Streams[1], Delivery_ReportLocations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CourierLocationReport, ReportLocationsReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Delivery_ReportLocationsClient = grpc.ClientStreamingClient[CourierLocationReport, ReportLocationsReply]
+
+// DeliveryServer is the server API for Delivery service.
+// All implementations must embed UnimplementedDeliveryServer
+// for forward compatibility.
+//
+// Delivery - тот же API, что и HTTP, для внутренних сервисов
+type DeliveryServer interface {
+	// Создать заказ по адресу, id задаёт вызывающий, повтор с тем же id - AlreadyExists
+	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderReply, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	// Заказы, ожидающие курьера или в пути
+	ListActiveOrders(context.Context, *ListActiveOrdersRequest) (*ListActiveOrdersReply, error)
+	ListCouriers(context.Context, *ListCouriersRequest) (*ListCouriersReply, error)
+	GetCourier(context.Context, *GetCourierRequest) (*Courier, error)
+	// Отменить заказ, доставленный или уже отменённый - FailedPrecondition
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderReply, error)
+	// Поток изменений заказа: первым текущее состояние, поток завершается после доставки или отмены
+	WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderUpdate]) error
+	// Поток позиций из приложения курьера, доступен при COURIER_MOVEMENT=real. Итог приходит после закрытия потока
+	ReportLocations(grpc.ClientStreamingServer[CourierLocationReport, ReportLocationsReply]) error
+	mustEmbedUnimplementedDeliveryServer()
+}
+
+// UnimplementedDeliveryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeliveryServer struct{}
+
+func (UnimplementedDeliveryServer) CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedDeliveryServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedDeliveryServer) ListActiveOrders(context.Context, *ListActiveOrdersRequest) (*ListActiveOrdersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActiveOrders not implemented")
+}
+func (UnimplementedDeliveryServer) ListCouriers(context.Context, *ListCouriersRequest) (*ListCouriersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCouriers not implemented")
+}
+func (UnimplementedDeliveryServer) GetCourier(context.Context, *GetCourierRequest) (*Courier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourier not implemented")
+}
+func (UnimplementedDeliveryServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedDeliveryServer) WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
+func (UnimplementedDeliveryServer) ReportLocations(grpc.ClientStreamingServer[CourierLocationReport, ReportLocationsReply]) error {
+	return status.Errorf(codes.Unimplemented, "method ReportLocations not implemented")
+}
+func (UnimplementedDeliveryServer) mustEmbedUnimplementedDeliveryServer() {}
+func (UnimplementedDeliveryServer) testEmbeddedByValue()                  {}
+
+// UnsafeDeliveryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeliveryServer will
+// result in compilation errors.
+type UnsafeDeliveryServer interface {
+	mustEmbedUnimplementedDeliveryServer()
+}
+
+func RegisterDeliveryServer(s grpc.ServiceRegistrar, srv DeliveryServer) {
+	// If the following call pancis, it indicates UnimplementedDeliveryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Delivery_ServiceDesc, srv)
+}
+
+func _Delivery_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Delivery_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Delivery_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Delivery_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Delivery_ListActiveOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActiveOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServer).ListActiveOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Delivery_ListActiveOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServer).ListActiveOrders(ctx, req.(*ListActiveOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Delivery_ListCouriers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCouriersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServer).ListCouriers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Delivery_ListCouriers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServer).ListCouriers(ctx, req.(*ListCouriersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Delivery_GetCourier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServer).GetCourier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Delivery_GetCourier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServer).GetCourier(ctx, req.(*GetCourierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Delivery_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliveryServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Delivery_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliveryServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Delivery_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DeliveryServer).WatchOrder(m, &grpc.GenericServerStream[WatchOrderRequest, OrderUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Delivery_WatchOrderServer = grpc.ServerStreamingServer[OrderUpdate]
+
+func _Delivery_ReportLocations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliveryServer).ReportLocations(&grpc.GenericServerStream[CourierLocationReport, ReportLocationsReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Delivery_ReportLocationsServer = grpc.ClientStreamingServer[CourierLocationReport, ReportLocationsReply]
+
+// Delivery_ServiceDesc is the grpc.ServiceDesc for Delivery service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Delivery_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "delivery.Delivery",
+	HandlerType: (*DeliveryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _Delivery_CreateOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Delivery_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListActiveOrders",
+			Handler:    _Delivery_ListActiveOrders_Handler,
+		},
+		{
+			MethodName: "ListCouriers",
+			Handler:    _Delivery_ListCouriers_Handler,
+		},
+		{
+			MethodName: "GetCourier",
+			Handler:    _Delivery_GetCourier_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _Delivery_CancelOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _Delivery_WatchOrder_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReportLocations",
+			Handler:       _Delivery_ReportLocations_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "api/proto/delivery.proto",
+}
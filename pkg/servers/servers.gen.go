@@ -8,23 +8,124 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// AtRiskOrder defines model for AtRiskOrder.
+type AtRiskOrder struct {
+	// CourierId Курьер заказа, отсутствует, пока заказ не назначен
+	CourierId *openapi_types.UUID `json:"courierId,omitempty"`
+	Deadline  time.Time           `json:"deadline"`
+	Id        openapi_types.UUID  `json:"id"`
+	Region    string              `json:"region"`
+
+	// RemainingSeconds Сколько секунд осталось до срока, отрицательное, если срок уже нарушен
+	RemainingSeconds int64 `json:"remainingSeconds"`
+
+	// Stage Ближайший непройденный этап - assign или deliver
+	Stage  string `json:"stage"`
+	Status string `json:"status"`
+	Tier   string `json:"tier"`
+}
+
 // Courier defines model for Courier.
 type Courier struct {
+	// HomeZoneId Домашняя зона, отсутствует, если курьер работает по всему городу
+	HomeZoneId *openapi_types.UUID `json:"homeZoneId,omitempty"`
+
 	// Id Идентификатор
-	Id       openapi_types.UUID `json:"id"`
-	Location Location           `json:"location"`
+	Id openapi_types.UUID `json:"id"`
+
+	// LastAssignedAt Последнее назначение, отсутствует, пока курьер не получал заказов
+	LastAssignedAt *time.Time `json:"lastAssignedAt,omitempty"`
+
+	// LastSeenAt Последний сигнал от курьера, отсутствует, пока курьер не выходил на связь
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	Location   Location   `json:"location"`
 
 	// Name Имя
 	Name string `json:"name"`
+
+	// Orders Заказы, которые курьер везёт сейчас
+	Orders []CourierOrder `json:"orders"`
+
+	// Region Код региона
+	Region                     string `json:"region"`
+	SecondsSinceLastAssignment *int64 `json:"secondsSinceLastAssignment,omitempty"`
+
+	// Status free, busy или offline
+	Status    string           `json:"status"`
+	Transport CourierTransport `json:"transport"`
+}
+
+// CourierLocationReport defines model for CourierLocationReport.
+type CourierLocationReport struct {
+	// Lat Широта, только в координатах WGS84
+	Lat *float64 `json:"lat,omitempty"`
+
+	// Lon Долгота, только в координатах WGS84
+	Lon *float64 `json:"lon,omitempty"`
+
+	// ReportedAt Когда курьер был в этой точке
+	ReportedAt time.Time `json:"reportedAt"`
+
+	// X X
+	X int `json:"x"`
+
+	// Y Y
+	Y int `json:"y"`
+}
+
+// CourierLocationsRequest defines model for CourierLocationsRequest.
+type CourierLocationsRequest struct {
+	Locations []CourierLocationReport `json:"locations"`
+}
+
+// CourierLocationsResult defines model for CourierLocationsResult.
+type CourierLocationsResult struct {
+	// Accepted Сколько точек принято
+	Accepted int `json:"accepted"`
+
+	// CompletedOrders Заказы, доставленные по принятым точкам
+	CompletedOrders []openapi_types.UUID      `json:"completedOrders"`
+	Rejected        []RejectedCourierLocation `json:"rejected"`
+}
+
+// CourierOrder defines model for CourierOrder.
+type CourierOrder struct {
+	// Distance Сколько клеток или, в координатах WGS84, метров осталось курьеру до заказа
+	Distance int                `json:"distance"`
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+}
+
+// CourierTransport defines model for CourierTransport.
+type CourierTransport struct {
+	Name  string `json:"name"`
+	Speed int    `json:"speed"`
+}
+
+// CouriersPage defines model for CouriersPage.
+type CouriersPage struct {
+	Couriers []Courier `json:"couriers"`
+
+	// NextCursor Курсор следующей страницы, отсутствует на последней
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// DeliveryWindow defines model for DeliveryWindow.
+type DeliveryWindow struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
 }
 
 // Error defines model for Error.
@@ -36,8 +137,37 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// GeoCacheStats defines model for GeoCacheStats.
+type GeoCacheStats struct {
+	Evictions int64 `json:"evictions"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+
+	// NegativeHits Попадания в запомненные ненайденные адреса
+	NegativeHits int64 `json:"negativeHits"`
+
+	// PersistentHits Попадания в постоянное хранилище кэша
+	PersistentHits int64 `json:"persistentHits"`
+	Size           int   `json:"size"`
+
+	// StaleHits Устаревшие записи, отданные при недоступном геокодере
+	StaleHits int64 `json:"staleHits"`
+}
+
+// HomeZoneRequest defines model for HomeZoneRequest.
+type HomeZoneRequest struct {
+	// ZoneId Домашняя зона, null - курьер работает по всему городу
+	ZoneId *openapi_types.UUID `json:"zoneId"`
+}
+
 // Location defines model for Location.
 type Location struct {
+	// Lat Широта, только в координатах WGS84
+	Lat *float64 `json:"lat,omitempty"`
+
+	// Lon Долгота, только в координатах WGS84
+	Lon *float64 `json:"lon,omitempty"`
+
 	// X X
 	X int `json:"x"`
 
@@ -47,22 +177,341 @@ type Location struct {
 
 // Order defines model for Order.
 type Order struct {
+	DeliveryWindow *DeliveryWindow `json:"deliveryWindow,omitempty"`
+
 	// Id Идентификатор
 	Id       openapi_types.UUID `json:"id"`
 	Location Location           `json:"location"`
+
+	// Region Код региона
+	Region string `json:"region"`
+
+	// Tier express, standard или scheduled
+	Tier string `json:"tier"`
+}
+
+// OrderTrackingUpdate defines model for OrderTrackingUpdate.
+type OrderTrackingUpdate struct {
+	CourierId       *openapi_types.UUID `json:"courierId,omitempty"`
+	CourierLocation *Location           `json:"courierLocation,omitempty"`
+
+	// EtaSeconds Через сколько секунд курьер будет у заказа
+	EtaSeconds *int               `json:"etaSeconds,omitempty"`
+	OccurredAt time.Time          `json:"occurredAt"`
+	OrderId    openapi_types.UUID `json:"orderId"`
+	Status     string             `json:"status"`
+
+	// Type Всегда tracking
+	Type string `json:"type"`
+}
+
+// OrdersPage defines model for OrdersPage.
+type OrdersPage struct {
+	// NextCursor Курсор следующей страницы, отсутствует на последней
+	NextCursor *string `json:"nextCursor,omitempty"`
+	Orders     []Order `json:"orders"`
+}
+
+// Problem Ошибка в формате RFC 7807
+type Problem struct {
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+	Title  string `json:"title"`
+	Type   string `json:"type"`
+}
+
+// ReassignOrderRequest defines model for ReassignOrderRequest.
+type ReassignOrderRequest struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+// RejectedCourierLocation defines model for RejectedCourierLocation.
+type RejectedCourierLocation struct {
+	// Index Номер точки в пакете
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// TrackingHeartbeat defines model for TrackingHeartbeat.
+type TrackingHeartbeat struct {
+	At time.Time `json:"at"`
+
+	// Type Всегда heartbeat
+	Type string `json:"type"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts  int                `json:"attempts"`
+	CreatedAt time.Time          `json:"createdAt"`
+	EventId   openapi_types.UUID `json:"eventId"`
+	EventType string             `json:"eventType"`
+	Id        openapi_types.UUID `json:"id"`
+	LastError *string            `json:"lastError,omitempty"`
+
+	// NextAttemptAt Время следующей попытки, отсутствует у завершённых доставок
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// ResponseCode Код ответа последней попытки, отсутствует, если ответа не было
+	ResponseCode *int `json:"responseCode,omitempty"`
+
+	// Status pending, succeeded или failed
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt  time.Time          `json:"createdAt"`
+	EventTypes []string           `json:"eventTypes"`
+	Id         openapi_types.UUID `json:"id"`
+	Url        string             `json:"url"`
+}
+
+// WebhookSubscriptionCreated defines model for WebhookSubscriptionCreated.
+type WebhookSubscriptionCreated struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+// WebhookSubscriptionRequest defines model for WebhookSubscriptionRequest.
+type WebhookSubscriptionRequest struct {
+	// EventTypes order.created, order.assigned, order.completed, order.cancelled, order.sla_breached или order.unassigned
+	EventTypes []string `json:"eventTypes"`
+
+	// Secret Секрет подписи HMAC-SHA256
+	Secret string `json:"secret"`
+
+	// Url Абсолютный http или https адрес партнёра
+	Url string `json:"url"`
+}
+
+// Zone defines model for Zone.
+type Zone struct {
+	Cells     *ZoneCells         `json:"cells,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+	Polygon   *[]Location        `json:"polygon,omitempty"`
+
+	// QueueDepth Сколько заказов в зоне ждут курьера
+	QueueDepth int    `json:"queueDepth"`
+	Region     string `json:"region"`
+}
+
+// ZoneCells defines model for ZoneCells.
+type ZoneCells struct {
+	MaxX int `json:"maxX"`
+	MaxY int `json:"maxY"`
+	MinX int `json:"minX"`
+	MinY int `json:"minY"`
+}
+
+// ZoneCreated defines model for ZoneCreated.
+type ZoneCreated struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+// ZoneRequest Граница зоны - ровно одно из cells и polygon
+type ZoneRequest struct {
+	Cells *ZoneCells `json:"cells,omitempty"`
+	Name  string     `json:"name"`
+
+	// Polygon Вершины многоугольника - клетки или точки WGS84
+	Polygon *[]Location `json:"polygon,omitempty"`
+
+	// Region Код региона, задаётся только при создании, по умолчанию - регион по умолчанию
+	Region *string `json:"region,omitempty"`
+}
+
+// InvalidateGeoCacheAddressParams defines parameters for InvalidateGeoCacheAddress.
+type InvalidateGeoCacheAddressParams struct {
+	Country *string `form:"country,omitempty" json:"country,omitempty"`
+	City    *string `form:"city,omitempty" json:"city,omitempty"`
+	House   *string `form:"house,omitempty" json:"house,omitempty"`
+}
+
+// GetCouriersParams defines parameters for GetCouriers.
+type GetCouriersParams struct {
+	// Limit Размер страницы, по умолчанию 50, не больше 500
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы из nextCursor, действителен только для той же сортировки
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort created_at (по умолчанию) или name, "-" в начале - по убыванию
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Status free, busy или offline
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
+
+	// Region Код региона
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
+
+	// Transport Название транспорта без учёта регистра
+	Transport *string `form:"transport,omitempty" json:"transport,omitempty"`
+
+	// Bbox minX,minY,maxX,maxY в клетках или south,west,north,east в градусах при координатах WGS84
+	Bbox *string `form:"bbox,omitempty" json:"bbox,omitempty"`
+
+	// CreatedFrom Создан не раньше
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Создан раньше
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+}
+
+// CreateOrderParams defines parameters for CreateOrder.
+type CreateOrderParams struct {
+	// City Город адреса, выбирает регион заказа, когда регионы заданы файлом
+	City *string `form:"city,omitempty" json:"city,omitempty"`
+
+	// Tier express, standard (по умолчанию) или scheduled
+	Tier *string `form:"tier,omitempty" json:"tier,omitempty"`
+
+	// WindowFrom Начало интервала доставки, задаётся вместе с window_to
+	WindowFrom *time.Time `form:"window_from,omitempty" json:"window_from,omitempty"`
+
+	// WindowTo Конец интервала доставки
+	WindowTo *time.Time `form:"window_to,omitempty" json:"window_to,omitempty"`
+}
+
+// GetOrdersParams defines parameters for GetOrders.
+type GetOrdersParams struct {
+	// Limit Размер страницы, по умолчанию 50, не больше 500
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы из nextCursor, действителен только для той же сортировки
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort created_at (по умолчанию), "-" в начале - по убыванию
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Status created или assigned
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
+
+	// Region Код региона
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
+
+	// Bbox minX,minY,maxX,maxY в клетках или south,west,north,east в градусах при координатах WGS84
+	Bbox *string `form:"bbox,omitempty" json:"bbox,omitempty"`
+
+	// CreatedFrom Создан не раньше
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Создан раньше
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+}
+
+// GetAtRiskOrdersParams defines parameters for GetAtRiskOrders.
+type GetAtRiskOrdersParams struct {
+	// Within Запас до срока, например 15m, по умолчанию 10m
+	Within *string `form:"within,omitempty" json:"within,omitempty"`
+
+	// Region Код региона, без него - заказы всех регионов
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
+}
+
+// GetZonesParams defines parameters for GetZones.
+type GetZonesParams struct {
+	// Region Код региона, без него - зоны всех регионов
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
 }
 
+// SetCourierHomeZoneJSONRequestBody defines body for SetCourierHomeZone for application/json ContentType.
+type SetCourierHomeZoneJSONRequestBody = HomeZoneRequest
+
+// ReportCourierLocationsJSONRequestBody defines body for ReportCourierLocations for application/json ContentType.
+type ReportCourierLocationsJSONRequestBody = CourierLocationsRequest
+
+// ReassignOrderJSONRequestBody defines body for ReassignOrder for application/json ContentType.
+type ReassignOrderJSONRequestBody = ReassignOrderRequest
+
+// CreateWebhookSubscriptionJSONRequestBody defines body for CreateWebhookSubscription for application/json ContentType.
+type CreateWebhookSubscriptionJSONRequestBody = WebhookSubscriptionRequest
+
+// CreateZoneJSONRequestBody defines body for CreateZone for application/json ContentType.
+type CreateZoneJSONRequestBody = ZoneRequest
+
+// UpdateZoneJSONRequestBody defines body for UpdateZone for application/json ContentType.
+type UpdateZoneJSONRequestBody = ZoneRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Очистить кэш геокодирования
+	// (DELETE /api/v1/admin/geocache)
+	InvalidateGeoCache(ctx echo.Context) error
+	// Получить статистику кэша геокодирования
+	// (GET /api/v1/admin/geocache/stats)
+	GetGeoCacheStats(ctx echo.Context) error
+	// Удалить адрес из кэша геокодирования
+	// (DELETE /api/v1/admin/geocache/{street})
+	InvalidateGeoCacheAddress(ctx echo.Context, street string, params InvalidateGeoCacheAddressParams) error
 	// Получить всех курьеров
 	// (GET /api/v1/couriers)
-	GetCouriers(ctx echo.Context) error
+	GetCouriers(ctx echo.Context, params GetCouriersParams) error
+	// Получить курьера
+	// (GET /api/v1/couriers/{id})
+	GetCourier(ctx echo.Context, id openapi_types.UUID) error
+	// Сообщить, что курьер на связи
+	// (POST /api/v1/couriers/{id}/heartbeat)
+	CourierHeartbeat(ctx echo.Context, id openapi_types.UUID) error
+	// Назначить курьеру домашнюю зону
+	// (PUT /api/v1/couriers/{id}/home-zone)
+	SetCourierHomeZone(ctx echo.Context, id openapi_types.UUID) error
+	// Принять позиции курьера
+	// (POST /api/v1/couriers/{id}/locations)
+	ReportCourierLocations(ctx echo.Context, id openapi_types.UUID) error
 	// Создать заказ
 	// (POST /api/v1/orders)
-	CreateOrder(ctx echo.Context) error
+	CreateOrder(ctx echo.Context, params CreateOrderParams) error
 	// Получить все незавершенные заказы
 	// (GET /api/v1/orders/active)
-	GetOrders(ctx echo.Context) error
+	GetOrders(ctx echo.Context, params GetOrdersParams) error
+	// Получить заказы под угрозой нарушения сроков
+	// (GET /api/v1/orders/at-risk)
+	GetAtRiskOrders(ctx echo.Context, params GetAtRiskOrdersParams) error
+	// Отменить заказ
+	// (POST /api/v1/orders/{id}/cancel)
+	CancelOrder(ctx echo.Context, id openapi_types.UUID) error
+	// Передать заказ другому курьеру
+	// (POST /api/v1/orders/{id}/reassign)
+	ReassignOrder(ctx echo.Context, id openapi_types.UUID) error
+	// Отслеживать заказ
+	// (GET /api/v1/orders/{id}/track)
+	TrackOrder(ctx echo.Context, id openapi_types.UUID) error
+	// Отслеживать заказ по WebSocket
+	// (GET /api/v1/orders/{id}/track/ws)
+	TrackOrderWebSocket(ctx echo.Context, id openapi_types.UUID) error
+	// Получить подписки на вебхуки
+	// (GET /api/v1/webhooks)
+	GetWebhookSubscriptions(ctx echo.Context) error
+	// Подписаться на вебхуки
+	// (POST /api/v1/webhooks)
+	CreateWebhookSubscription(ctx echo.Context) error
+	// Удалить подписку на вебхуки
+	// (DELETE /api/v1/webhooks/{id})
+	DeleteWebhookSubscription(ctx echo.Context, id openapi_types.UUID) error
+	// Получить журнал доставок
+	// (GET /api/v1/webhooks/{id}/deliveries)
+	GetWebhookDeliveries(ctx echo.Context, id openapi_types.UUID) error
+	// Отправить проверочный вебхук
+	// (POST /api/v1/webhooks/{id}/test)
+	SendTestWebhook(ctx echo.Context, id openapi_types.UUID) error
+	// Получить зоны
+	// (GET /api/v1/zones)
+	GetZones(ctx echo.Context, params GetZonesParams) error
+	// Создать зону
+	// (POST /api/v1/zones)
+	CreateZone(ctx echo.Context) error
+	// Удалить зону
+	// (DELETE /api/v1/zones/{id})
+	DeleteZone(ctx echo.Context, id openapi_types.UUID) error
+	// Получить зону
+	// (GET /api/v1/zones/{id})
+	GetZone(ctx echo.Context, id openapi_types.UUID) error
+	// Изменить зону
+	// (PUT /api/v1/zones/{id})
+	UpdateZone(ctx echo.Context, id openapi_types.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -70,12 +519,198 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// InvalidateGeoCache converts echo context to params.
+func (w *ServerInterfaceWrapper) InvalidateGeoCache(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.InvalidateGeoCache(ctx)
+	return err
+}
+
+// GetGeoCacheStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGeoCacheStats(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetGeoCacheStats(ctx)
+	return err
+}
+
+// InvalidateGeoCacheAddress converts echo context to params.
+func (w *ServerInterfaceWrapper) InvalidateGeoCacheAddress(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "street" -------------
+	var street string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "street", ctx.Param("street"), &street, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter street: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params InvalidateGeoCacheAddressParams
+	// ------------- Optional query parameter "country" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "country", ctx.QueryParams(), &params.Country)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter country: %s", err))
+	}
+
+	// ------------- Optional query parameter "city" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city", ctx.QueryParams(), &params.City)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter city: %s", err))
+	}
+
+	// ------------- Optional query parameter "house" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "house", ctx.QueryParams(), &params.House)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter house: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.InvalidateGeoCacheAddress(ctx, street, params)
+	return err
+}
+
 // GetCouriers converts echo context to params.
 func (w *ServerInterfaceWrapper) GetCouriers(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCouriersParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", ctx.QueryParams(), &params.Region)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter region: %s", err))
+	}
+
+	// ------------- Optional query parameter "transport" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "transport", ctx.QueryParams(), &params.Transport)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter transport: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCouriers(ctx, params)
+	return err
+}
+
+// GetCourier converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCourier(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetCouriers(ctx)
+	err = w.Handler.GetCourier(ctx, id)
+	return err
+}
+
+// CourierHeartbeat converts echo context to params.
+func (w *ServerInterfaceWrapper) CourierHeartbeat(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CourierHeartbeat(ctx, id)
+	return err
+}
+
+// SetCourierHomeZone converts echo context to params.
+func (w *ServerInterfaceWrapper) SetCourierHomeZone(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SetCourierHomeZone(ctx, id)
+	return err
+}
+
+// ReportCourierLocations converts echo context to params.
+func (w *ServerInterfaceWrapper) ReportCourierLocations(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ReportCourierLocations(ctx, id)
 	return err
 }
 
@@ -83,8 +718,38 @@ func (w *ServerInterfaceWrapper) GetCouriers(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateOrderParams
+	// ------------- Optional query parameter "city" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city", ctx.QueryParams(), &params.City)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter city: %s", err))
+	}
+
+	// ------------- Optional query parameter "tier" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tier", ctx.QueryParams(), &params.Tier)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tier: %s", err))
+	}
+
+	// ------------- Optional query parameter "window_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "window_from", ctx.QueryParams(), &params.WindowFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter window_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "window_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "window_to", ctx.QueryParams(), &params.WindowTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter window_to: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateOrder(ctx)
+	err = w.Handler.CreateOrder(ctx, params)
 	return err
 }
 
@@ -92,8 +757,296 @@ func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrdersParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", ctx.QueryParams(), &params.Region)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter region: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrders(ctx, params)
+	return err
+}
+
+// GetAtRiskOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAtRiskOrders(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAtRiskOrdersParams
+	// ------------- Optional query parameter "within" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "within", ctx.QueryParams(), &params.Within)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter within: %s", err))
+	}
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", ctx.QueryParams(), &params.Region)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter region: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAtRiskOrders(ctx, params)
+	return err
+}
+
+// CancelOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CancelOrder(ctx, id)
+	return err
+}
+
+// ReassignOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) ReassignOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ReassignOrder(ctx, id)
+	return err
+}
+
+// TrackOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) TrackOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.TrackOrder(ctx, id)
+	return err
+}
+
+// TrackOrderWebSocket converts echo context to params.
+func (w *ServerInterfaceWrapper) TrackOrderWebSocket(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.TrackOrderWebSocket(ctx, id)
+	return err
+}
+
+// GetWebhookSubscriptions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhookSubscriptions(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhookSubscriptions(ctx)
+	return err
+}
+
+// CreateWebhookSubscription converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateWebhookSubscription(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateWebhookSubscription(ctx)
+	return err
+}
+
+// DeleteWebhookSubscription converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteWebhookSubscription(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteWebhookSubscription(ctx, id)
+	return err
+}
+
+// GetWebhookDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhookDeliveries(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhookDeliveries(ctx, id)
+	return err
+}
+
+// SendTestWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) SendTestWebhook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SendTestWebhook(ctx, id)
+	return err
+}
+
+// GetZones converts echo context to params.
+func (w *ServerInterfaceWrapper) GetZones(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetZonesParams
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", ctx.QueryParams(), &params.Region)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter region: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetZones(ctx, params)
+	return err
+}
+
+// CreateZone converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateZone(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateZone(ctx)
+	return err
+}
+
+// DeleteZone converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteZone(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteZone(ctx, id)
+	return err
+}
+
+// GetZone converts echo context to params.
+func (w *ServerInterfaceWrapper) GetZone(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetZone(ctx, id)
+	return err
+}
+
+// UpdateZone converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateZone(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetOrders(ctx)
+	err = w.Handler.UpdateZone(ctx, id)
 	return err
 }
 
@@ -125,28 +1078,128 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.DELETE(baseURL+"/api/v1/admin/geocache", wrapper.InvalidateGeoCache)
+	router.GET(baseURL+"/api/v1/admin/geocache/stats", wrapper.GetGeoCacheStats)
+	router.DELETE(baseURL+"/api/v1/admin/geocache/:street", wrapper.InvalidateGeoCacheAddress)
 	router.GET(baseURL+"/api/v1/couriers", wrapper.GetCouriers)
+	router.GET(baseURL+"/api/v1/couriers/:id", wrapper.GetCourier)
+	router.POST(baseURL+"/api/v1/couriers/:id/heartbeat", wrapper.CourierHeartbeat)
+	router.PUT(baseURL+"/api/v1/couriers/:id/home-zone", wrapper.SetCourierHomeZone)
+	router.POST(baseURL+"/api/v1/couriers/:id/locations", wrapper.ReportCourierLocations)
 	router.POST(baseURL+"/api/v1/orders", wrapper.CreateOrder)
 	router.GET(baseURL+"/api/v1/orders/active", wrapper.GetOrders)
+	router.GET(baseURL+"/api/v1/orders/at-risk", wrapper.GetAtRiskOrders)
+	router.POST(baseURL+"/api/v1/orders/:id/cancel", wrapper.CancelOrder)
+	router.POST(baseURL+"/api/v1/orders/:id/reassign", wrapper.ReassignOrder)
+	router.GET(baseURL+"/api/v1/orders/:id/track", wrapper.TrackOrder)
+	router.GET(baseURL+"/api/v1/orders/:id/track/ws", wrapper.TrackOrderWebSocket)
+	router.GET(baseURL+"/api/v1/webhooks", wrapper.GetWebhookSubscriptions)
+	router.POST(baseURL+"/api/v1/webhooks", wrapper.CreateWebhookSubscription)
+	router.DELETE(baseURL+"/api/v1/webhooks/:id", wrapper.DeleteWebhookSubscription)
+	router.GET(baseURL+"/api/v1/webhooks/:id/deliveries", wrapper.GetWebhookDeliveries)
+	router.POST(baseURL+"/api/v1/webhooks/:id/test", wrapper.SendTestWebhook)
+	router.GET(baseURL+"/api/v1/zones", wrapper.GetZones)
+	router.POST(baseURL+"/api/v1/zones", wrapper.CreateZone)
+	router.DELETE(baseURL+"/api/v1/zones/:id", wrapper.DeleteZone)
+	router.GET(baseURL+"/api/v1/zones/:id", wrapper.GetZone)
+	router.PUT(baseURL+"/api/v1/zones/:id", wrapper.UpdateZone)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xU3UobTxR/leX8/5eLG/Vub20pBcGL3rSIF0MyiVOyH52dpIYQSGKrgqK3pRf26wXS",
-	"kCXb2KzQJzjnjcrMGI1mtRZKaXsTdiZnzvl9nHPaUI6COAp5qBLw25CUt3nAzOda1JCCS/3J6vWNKvib",
-	"bYhlFHOpBDchoqJ/KzwpSxErEYXgA77BEaY4pT5m9AoznOCA+phTF1yoRjJgCnxoNEQFXFCtmIMPiZIi",
-	"rEHHhXpUZjZRG/6XvAo+/OddQfQu8Hnrs7iOCyELeCGOr3SyWKPjguQvGkLyCvibYGCYDHPFtzpbHRce",
-	"ShkZ+tdJl6NKUbm3mOPIwZwOMMPPOMFsnq8I1erKFRgRKl7jUqMPeJKwWlHGj5jihHrUv5n1bkYG31Ve",
-	"zWR9TtXbvNxZRPC0EHBrMfBZQeANVDugX1phN2Tlz++roj651iA6QoTVqADqKfVxiCnt4wBT7d8YBw7t",
-	"0r49TWiXunSEKXUxx6H77b2mRj081/+bqC5m+hHtYUbHDo4w142AAxzq1+bVwq3mLVRdE3/yktVqXDoP",
-	"eF00uWyBC00uEwtveam0VNKiRDEPWSzAh1Vz5ULM1LZxwGOx8JrLXtkuAXNX46qA6zvMcYxDzPGMTiy/",
-	"c3PQdDPq05GDQ+phSq8XmIPBII2mjyvgwyOu1mYVtf5JHIWJ7YmVUslOX6h4aICwOK4La4j3PLHeWh9N",
-	"DykeJD+ye7bjOpctw6RkLTDm3iD66cKgA5zSIX7RQ2ld7oMJrrJGXf0UxLuQ2eVThOP0chcMTJcmjSBg",
-	"sjXz4n7C64czjyM9jkaqOEruZzH1zNVIj6CuM8aBxoNjh3oO7WGKZ3RExw71dV+bkTV1cYBTzMxavm78",
-	"muRMcbsYFoxfLsD0F7jx4RaNCsT3WFmJJv8FU+bgFFNTS4vRpQO9M41GadG0bVjvf8esWXP/6Um7Q/w5",
-	"/+lQV+t8DwAA//8lgDf/ewkAAA==",
+	"H4sIAAAAAAAC/+xdW2/cRpb+KwR3H3YBypITe2ZWwD54HW8cIFkPLM8mmYwRUM2SxHE32SGrfYkhQOqO",
+	"YwdyrPXMAh4E6/Ek87D72JLVVuvSrb9Q9Y8W51QVWSSLTbYsy/JMXhx1N1mXU+f6nXMq9+1G2GqHAQlo",
+	"bM/ft+PGCmm5+Oclet2Pb12LPBLBx3YUtklEfYI/NsJO5JPoIw8+eCRuRH6b+mFgz9vsB97ja/wxG/A1",
+	"i+2yPttnffivY7Ex7/J13sN/u2yb99iAdx2LHbExPKU9brERG8A/8OqI9flDNmAj27GXwqjlUnve7nR8",
+	"z3Zseq9N7Hk7ppEfLNurju0R12v6AYGVJQ97LiUz1G8R0xu+l3m2bOCILOMO75t+arl+4AfLC6QRBl5s",
+	"oMqPbJ+N2QF/DP+1+DobsH3eYyO2A2QBcvTZAf712GI7+AhfE2SRhFtjQ/4t6/MuG+A4IzZmA8diA77O",
+	"DtgwecHiPfZKEo+v8R5/lCedH9BfXEi36AeULJMINhJTd5kYVv8UZmCvWJ/t8UdsyPbwfNgRzrjHdmAG",
+	"NuIbbM/i3+NejqwZy41jfzmw2BDX55Gmf5tEJtLG1KWd2Eha6pPI8AMS/auOHxHPnv/CxhOTByTfSUZV",
+	"u9JYw3BiN1cd+7LgapjObTavLdnzX+QZfyVskd+GATFy/n+zMTtkff6Ijfgm3wR2HrNRcoAmzleHx/Y1",
+	"qeFrrM+24B3Wh+dQQCy2jVxzyHsWe8nGSPod3qsjE75ptX8Sx8a7bMi/YUPgNN6FceuM2HRjegmPl3iX",
+	"qGH0F8DL7IAN2A6ySlGY2ZANJlAm0Qk6ZYRWOEJJ6vGHIDO6jhmzbdupKfWwgwVCghqrB37n62zIXuIe",
+	"DnDRmYXV027FnWzzDf4AzhFkBCkEE23zTbbLH9ffSthwqdRN/xiRJXve/ofZVLHPSq0++7F6btWxA7dF",
+	"jExxyDdNc4RgCEyK7ZmiPt9wLFBugon4BhvkdrzNBmyXP+Vdof724Pz4uu3YPiWtuGrxUjiFQVpNVuhG",
+	"kXsvq57z9gjoCzI1YC/ZUEikUQkJTbDgBw3yccLeLRLQjHmYqDylFsuuYCkixLEWO/E9pQrDpSWph4r6",
+	"LnKDuB1GtCY9biTPG3UiHrPGIZqWTNRjOmVyzDdXNX2o2OY6UetKtWNNdsur0QiHKlEdcGAv2U5BYrb4",
+	"BgjJtjAxYxBKYLWHbJ8NaspKjkTaMow7jq+TrzokpkUHSBEUP0zDvzliFhg5t8J0HvPy4k7TsDq30SBt",
+	"SrxqP0QQcMD2LTTmQ7RcXTY2Mjjsqkko8a7VUwY7iWezzQ4SF2EgzZk2H99gh+lh9tmhrhQqbVFRE/ye",
+	"NOTuax3NdflCjryVh5OQWZuzSCXt4Eq8ac+PqRs0SOVxsX2gIzL/vtQlDggEPgQeAdiREdrxPn9gffrh",
+	"wq8uOBY7hHfQX9g2OJuajPGe8D11t93ICTVd5ukNk0mHacoroZRG1Bu6xswSVhm5oq5vE+JpvyRby80v",
+	"1ad4XJsz/rX0k41h0dRKwcTFAblLL3eiOIxKY6x1OHNLOSq8x5/w78CwQijQRSdyBDED3yhzToTHAeKY",
+	"8dX2KhVnslGgyQfCsb/3qR944Z0iVZaisFU/GqNh3Wdza8Jp8H1Y1JUoCo2Bq0dKfQQ2xuBmi+2zYS5e",
+	"ev89oxy0SBybI6afMMBb5938qFWE9Yidjgs7+ZCEl93GClmgLo2LOyK3/UZiiWp4KSs+rftoy49jUvfh",
+	"gCy71L9Nrsrxix41O2J9tiO5chP1FuiZI4yaRrp9EB/6mchyYMHb4MbxddRKNdbUJlHsx5QEdKpVHUkl",
+	"OeabbCTibIs/UAIFWhfkDDTn9/xR3bXE/tfEpHHQEWuWke2vQlvDttk28tFAUW0IIYmQbLl+ZV3BsAoi",
+	"SgvMe+wI93EIoeMA4xEIOiByGdRZfo5LkYdyR16gdsI/+g4djV8lTYDHr8qwutTf+nr6mDvoNJvWzMlG",
+	"1jCmu9gk9jyNOqRKmOWiYYMfa5awDFtouiZv+P/YkK+JFTvoI6XewGTDn3GJww6sOllw0GktCuZrhkEJ",
+	"VQ/Yyzc37d3ipJ8ZxeZe8cHPqxn0rg1vCp8+cbrK6O4V7Nckk52zdm8GXTlGQP/a8a9C2rKvk7vtiMSx",
+	"Y4Hj5bmRp2JYWIPXaRKv0qblnbgsTqcd0o3Ibdzyg+XftMHqV6DOlURs5Lz5KWhJqFuO5P6vUJtsFzyv",
+	"clA3F7r2UN12Ld4T+nuSdx02Gp0oUtFxPbcJA/ealJkEt+IXhT3/AfcmgnIqT6ny3PHXdGUa4qBtMDn8",
+	"Epf6bLnBOg5Wy8EvgatylArTSPHXUbjYJC3DZv+cuJF9BEG+gW2j7euygXX93y9bv/zV3C9tp6DdqOs3",
+	"zVFQng80HqQ+bZKJLFLr8MUw2tHL5cBWrxORIEAalVr+aYTeHKVIE1wW5Rdm9AOPGOwTe46uBroRCqoY",
+	"Sn8R5Bni8oFRniPixsbMUV5R4sTJ87BopRKvEjeii8Q1gT1TaIka4r2SzFRTvl0hxJ+SxZUwvKXMo2md",
+	"lLTatITfGhFx6XQqj9wmAa2p8vDZG2bOrQ9nuDFN4srCr6CpLok9GmHNP6DVOOSbJlWFUdAR3+Bd4KpS",
+	"RaWsxzaiNY/4U+H18wdZsG3M9msnDyISt8MgJpcrIuMuTto1Ksqay9fzXZkBMReC8G4J8lgGrbdJ4PnB",
+	"smPFnUaDEI8k7smS6xt9E8futL3pOM3kzije0zlL03IJr+uMrU+tScxCZ1HbUkH9HVMuYEVZM1WJm9aU",
+	"gk7UrJmQhSczy9GpUUKBy+IBg1Y+hgHwvbJpSs1NlnhZbkMzfU5uwbHER1dmQdXnBP5NvgDAstlMv4ib",
+	"7peLEQFMJ2FX8UsnUKPpAHjlwcWkERFqRI/BIV1LIl22o3AD6+only7PLFy99N7FX0w45Nx4/8W2wNti",
+	"B/wJ78p8/wqlbbUL+DvWcBo0jXwNn30KnljliQmWkRvK8A4cJEAEBhEhzWalIwavXsYHj2dsagpHKeLc",
+	"Dpv3loWA13Icy5MQjv1Vh3TIB6RNV6ozBpnkuMTcxkLhvkILlE9kl3gwJbUvE+swJHquLTevANJTKZxq",
+	"y737mdlVaLl3Py/5xQ8+K/3l8xp4Pw4gn3bEEuR8yWrfgH7KIV+5A/1jGsKIEqkxSJ41Y4mMDuB6Foo2",
+	"/jFkuxYKhMWGlmI657Ulpg5f5/0d4aNgjm/DQoh3jJBSj72UHDoSwAiidDKzhT610Caal62QpdcWnalQ",
+	"EkeIzw7rQ9UCX+ebOSRMIK2oEncVhozuD6CKvMcO8eGH8vieWDOZCcoeq2QbPIybq/C1HyyFxlhReFcP",
+	"Fcy5C5UlUDEzyAv8mG1jqhY8syP4GR+C1Owu6/Nvcd0Z5xIQDvVS5tsk3Ju3F+64y8skspJowLFvAzqM",
+	"qzt/bu7cHMbSbRK4bd+et9/Hrxy77dIVPN9Zt+3P3j4/63otP5hdJmEDzKVE64gAh4Cj8aAhBrA/Cm67",
+	"TR90uEqY2Klzi2O+N3fBiCF8zx9ZyGkI67MR0jvutFpudE8QE35aRyivKzKm+IqOpA+lMMo0Ag5h3sNs",
+	"rPI4y4QWt/EhodmET2ETcyIqDqisTHHb7aYvOH729zLOFKJQJSjZiZCfiukHZIpH0tinbnueSC+SmixB",
+	"JJm36CrKAYMkGZNj0+5+TCNC6GqWEQpeinI/wLzhyY74JhtIERY+CVQZyLQ4/DW0GmEnoNE9x2r4FAp1",
+	"rJWwEyN+VcFmlzwPEFLk3shtEYr40BeAI9jzyNHKFKJAE3RsUnEWiYT0wAqiLwf6qiPkSI4kl2sf51Wf",
+	"Hus9RZDyF2/Wkjj9fFBrHkAcK0yX4hBQDxdOkNUVrGZi8ueQW0OdJ5k88V/zTP5XsVylBzQ/V197fe7W",
+	"6wWWSUkhItvFiP5AsLBe/SiXgdkr/qCg1gu8+yGhqn6hyK25if+CPqOEu4o4aomBuzjnqGhe2EkoPrYu",
+	"zoFuN7FU02/51MRSmoP2uqivOJwURxbWju0JZIINRUE1KP6cdd8Bmluy1AxLqsWkqNTEoYqUvlHIcK4q",
+	"McvuTPrGX7rU+qcSCv+zco5gGsf6nT3zOxu9elFWC8w5AHdKvg2YyrbmV5hWGovKvynWOamg0ThDUohd",
+	"f46ytJVp/LSkcYrxnyODS+LA2UqmQYsnTrkPbIyJnh5/CC4g6ycLUlxWsiS9pnKKVUH84UD04UDsAf98",
+	"LvOtyjuGPKvKwIUduuLcITF1gjCiKw5xY4qPv8StgFCsixdkScDktK1pH4uL4d0pCftj6g0LXSDpirqg",
+	"TFok58sannS6evjcxDVMMT0Np5/85ht00DLVZtP6Z/DwkivrQ09kOQL7Nq1Dy01VOYbl5spkGWfv+97q",
+	"65lHfRoor7dQgMeJh4pSMmaHjkkFYMnMMJOyBW+xWOmO8VxS4T7B9NbyE31voo9YhTKcAk8egx0vzF04",
+	"sVVM8ud+KLRZaLVkZ1cuMnxaLgyzK5lUYGjEjJ7na7/EcWRKodYRkNmWCZ2koUbBBaqOu8DKkgGuannC",
+	"t8DQF4zQp9aio5WXnyHmy/IAmKkx2+LfCR5wLP4QdEqhUyjtCmLDSZwRtsjM1woj79CK5kxQhTtp+ZxI",
+	"5Al0eKxyemVcIdhHuZx9GQCN2IA/dSxR9yYK8PC5ITtUYxS1sQQ0C3y2kKhMVR14apyGUOy/hd69E2OZ",
+	"fIHj6upqfpWrtXj8mfCEi910bzdkxpJUrFNcf2vSJrxitptSKCt9fCMvf881Gha1sOyGSMpLn/AncnTe",
+	"myCFmdagEv38QlWM4AwQIGdacVCYhAI7YGMIPVV1cq7n0ARHX772m+sfXbn+5SfX/vPKJ1f+48a/RsRt",
+	"nrPYT2nNyhhDiQPcyiZ/kgJjaSaB7alI/ggxjjURSYrH5OILIiuamvJdSu+w2Jb1g9US37k3uAzs+zJJ",
+	"xF/QBe0h9oLurWQL/hT4+O9eR+R1QqI1jjCSgBzbUBbPJy6EMF9KTAT4JUID3kss9g5ASUpSc62vmHrF",
+	"BuJDOBlAj0RBDIxX8Au1trjHhXWVO4lpYWKpzsnHSmniSsyV3r4A2Oy34p4B/gS0zEDmP7JYZsE1xDha",
+	"1DxWAYx/VIX2mb4OB3ui2RZOJVyGbMose6HEftIqqj/FN8RzGP+Dr/INHjjou0PbOTYmXlUYXYnZ6SXT",
+	"pkWoewumQ7GkD4a53xEe1RrbVm5ZJkWHcWs+o8m2EeWFhwDgtO5gXbuAQkyLlL+fFFLzg6hG4N/WWf7k",
+	"JZ0MenPe3IVz1mGWH0tE2aAjZt0GdO2cQNIB9aRekJh2a5kQENkQ+3Pq4d1MPbytdINcm9KieoncO5Js",
+	"+BnW/xnWzzeF/A2C+hPMQRZSNNkkOhP58a1yo/Q8OzR/ahw6B8wfiviabWXur0rw/zV1M0/hUoRDNlDM",
+	"Zd3x6YofOKpXFHgQmE++IqxP4T4pg/nTblSrNoLPsMe3LzG67G1gIxlIDaWRPH+xVW4Xz8+1St0m2NcJ",
+	"aEpH5UnZSNJ2JgsWJjmfzHuiROGYOvZ15bNWJaF2YoYerunzH2ch8K4Q5MyxHYnD7qHlGSP2tZe7Vw5h",
+	"qYQ782k8KdyIi4k6+AkBatnFgSieKHdbCIbtiMBQBuMmnbCXlJDKq/DgHIRIa4+k4W76uyLDSAS/bNcU",
+	"5eJGSqLct5X7eGbYDGz2NJGWZ4bbG7W024W5fzntZeSYYyJbFMs+czwxMaZCFo9kR+MEJtcWdyQbiTP1",
+	"xSmjCxj2UJRN6qA0hgWgZUVgkFfFiNeyV/LeumwqqVSQDFCu1pz5DiO4xibT18i+mE6Pjc6Oen8r0j4s",
+	"3mFpzL6cng4o2BIEVJ3irSg6Boxplx2QMRAv4SSaGgLkrgvXmyUDTdQrLzSxz8Gu2uRFuS/VOtiQX+o4",
+	"L5DoNolmFkhArSvQThVbMwL53cJ+TQCrVUs/4H+GmxhkfzysEZQGXIcDtBJb1K7yPJLg3RhBAQQ3YAff",
+	"4fWffF2SS9yqI94Aj+FQkkpccwM6K6lwUN3VvAdE4g/OWenNnAVwMOczKJB+X36vMlilGg/3/Batel57",
+	"UHKXzmID3ExMI+K2spKRH7AoAy8SCmSOe+8MuQQX594/lWWALUcOwGSN6EHmjwT7aa1JGteoy/K6gtvY",
+	"KzbMFXFnXYXsY3UdBhS82TsTCsB/Ep16WGG2JSt2DjTHO6kgkV9hL4NBhh1NqmYK70FjulW8a6BcRD4l",
+	"iwth4xZ5K9U/540gPYBDA4mBJVk4ZaX3sWdVVEqMhT5Lt/CzPJyyPOC4+gFo4nFHNGtPEAmtq5lvZPua",
+	"Ud2Lm5VFDgOBmu/S7K0JlzG0h8f2acALholfG2aoCO91Yu2LRHdf1K1u8Qe8B9+Jrs7S4EV6mkD5/Vx/",
+	"N+9lJpAVI6q8ROs4x/zyenqMgu0Ka5MXBGK3qMx5DKzPZlRT4cyCvxy4tBORkkS0ib5vJsqYcMNArVjj",
+	"/JtciWpZLvERNJr3s72sZ6QLy8nwitYdPIQrRwrOTVEC1AZRDSEvGtneoISS8vOyrtMP8Hszo50RZKhw",
+	"xLLpTq8aPB3jl19JwQSyfkXHXUZF8N505zgrL/rzyQTjkr/5f2Cdn5sr3K5TUFYT7MoH6azvQOn/NIZL",
+	"KeITwcbPLAsWbOgriMpVmXmOMSZwH1X3zZht64+Y0NzlPUGZI/y4na3VSiN2OfQ5GBQbVHL+Tlo5lS8G",
+	"zF3UBLU3qBCgS1rUd2bjakWjsX79nAY5sX6B9xdI4N0gsRKAd73jpcDtNcsudUqfcR7/s8ZxStXKOhOs",
+	"X+QPldRqujbD61Dz/5p9zOpmkwKwA0nQsbyWAgpuHvMnWjCRXGyT4lN7JnX8W1xhVd51ivymXO07mtsE",
+	"cvz9JDXHCoAujWxE08CMhQgC5t7Fa9n/6YK6WkfgvKV32qibd9TFANoI8CbkX/SCf1WqYwpiZOPLm4ha",
+	"pm5IOX+iU0+KS9R5nIl4RJjFl/o9TI6F1dybSTSiCX9laWaxgwS1pyHYKM9q8A010oaqxhPFX094N3PN",
+	"eeI4T7jm3BTTJHxXuyXp7QUWz8pafioDiuQwnNLreMyEmDtRUTjDHvMUtC3Ru0jd0/AAzS2P/wOLVU6H",
+	"EtqMOPOeWuqGk2s3wE0fpiMYMUSBsJ8lRT1JTHOpuzOkVLVELPxf6M4mk/8poV6eyVdXV/9/AKQ/EJ1Q",
+	"dQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file